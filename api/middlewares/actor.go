@@ -0,0 +1,18 @@
+package middlewares
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/viniosilva/where-are-my-fruits/internal/infra"
+)
+
+// ActorMiddleware keeps the X-Actor header in the context, so the changes made
+// by the request can be traced back to whoever made them
+func ActorMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if actor := c.GetHeader("X-Actor"); actor != "" {
+			c.Set(infra.ActorKey, actor)
+		}
+
+		c.Next()
+	}
+}
@@ -21,13 +21,103 @@ type BucketController interface {
 	Create(ctx *gin.Context)
 	List(ctx *gin.Context)
 	Delete(ctx *gin.Context)
+	Consolidate(ctx *gin.Context)
+	ListViolations(ctx *gin.Context)
 }
 
 type FruitController interface {
 	Create(ctx *gin.Context)
+	CreateBatch(ctx *gin.Context)
+	GetByBarcode(ctx *gin.Context)
+	ListExpiring(ctx *gin.Context)
 	AddOnBucket(ctx *gin.Context)
+	Transfer(ctx *gin.Context)
 	RemoveFromBucket(ctx *gin.Context)
 	Delete(ctx *gin.Context)
+	MoveMany(ctx *gin.Context)
+	UnassignMany(ctx *gin.Context)
+	DeleteMany(ctx *gin.Context)
+	Recall(ctx *gin.Context)
+}
+
+type FruitStateController interface {
+	Change(ctx *gin.Context)
+	ListTransitions(ctx *gin.Context)
+}
+
+type FruitPriceController interface {
+	Change(ctx *gin.Context)
+	List(ctx *gin.Context)
+	Stats(ctx *gin.Context)
+}
+
+type PhotoController interface {
+	UploadFruitPhoto(ctx *gin.Context)
+	ListFruitPhotos(ctx *gin.Context)
+	UploadBucketPhoto(ctx *gin.Context)
+	ListBucketPhotos(ctx *gin.Context)
+	Download(ctx *gin.Context)
+	DownloadThumbnail(ctx *gin.Context)
+}
+
+type NoteController interface {
+	CreateFruitNote(ctx *gin.Context)
+	ListFruitNotes(ctx *gin.Context)
+	CreateBucketNote(ctx *gin.Context)
+	ListBucketNotes(ctx *gin.Context)
+}
+
+type PickController interface {
+	Create(ctx *gin.Context)
+}
+
+type OrderController interface {
+	Create(ctx *gin.Context)
+	Get(ctx *gin.Context)
+	Checkout(ctx *gin.Context)
+	Sales(ctx *gin.Context)
+}
+
+type ReservationController interface {
+	Create(ctx *gin.Context)
+	Get(ctx *gin.Context)
+	Confirm(ctx *gin.Context)
+	Release(ctx *gin.Context)
+}
+
+type PurchaseOrderController interface {
+	Create(ctx *gin.Context)
+	Get(ctx *gin.Context)
+	Receive(ctx *gin.Context)
+}
+
+type CycleCountController interface {
+	Open(ctx *gin.Context)
+	Get(ctx *gin.Context)
+	Submit(ctx *gin.Context)
+	Approve(ctx *gin.Context)
+}
+
+type StockController interface {
+	SetReorderPoint(ctx *gin.Context)
+	ListReorderPoints(ctx *gin.Context)
+	Low(ctx *gin.Context)
+	ListAlerts(ctx *gin.Context)
+	Waste(ctx *gin.Context)
+	Valuation(ctx *gin.Context)
+}
+
+type MovementController interface {
+	List(ctx *gin.Context)
+}
+
+type SupplierController interface {
+	Create(ctx *gin.Context)
+	List(ctx *gin.Context)
+	Get(ctx *gin.Context)
+	Update(ctx *gin.Context)
+	Delete(ctx *gin.Context)
+	Report(ctx *gin.Context)
 }
 
 // @title			Where are my fruits API
@@ -35,10 +125,12 @@ type FruitController interface {
 // @description		Gerenciamento de frutas em baldes
 // @contact.name	API Support
 // @contact.email	support@wherearemyfruits.com.br
-func ConfigGin(host, port string, logger *zap.SugaredLogger, health HealthController, bucket BucketController, fruit FruitController) *gin.Engine {
+func ConfigGin(host, port string, logger *zap.SugaredLogger, health HealthController, bucket BucketController, fruit FruitController,
+	fruitState FruitStateController, fruitPrice FruitPriceController, photo PhotoController, note NoteController, pick PickController, order OrderController, reservation ReservationController, purchaseOrder PurchaseOrderController, cycleCount CycleCountController, stock StockController, movement MovementController, supplier SupplierController) *gin.Engine {
 	r := gin.New()
 	r.Use(middlewares.JSONLogMiddleware(logger))
 	r.Use(middlewares.CORSMiddleware())
+	r.Use(middlewares.ActorMiddleware())
 	r.Use(gin.Recovery())
 
 	docs.SwaggerInfo.BasePath = "/api"
@@ -52,17 +144,84 @@ func ConfigGin(host, port string, logger *zap.SugaredLogger, health HealthContro
 	r.POST("/api/v1/buckets", bucket.Create)
 	r.GET("/api/v1/buckets", bucket.List)
 	r.DELETE("/api/v1/buckets/:bucketID", bucket.Delete)
+	r.POST("/api/v1/buckets/consolidation", bucket.Consolidate)
+	r.GET("/api/v1/reports/storage-violations", bucket.ListViolations)
 
 	r.POST("/api/v1/fruits", fruit.Create)
+	r.POST("/api/v1/fruits/batch", fruit.CreateBatch)
+	r.POST("/api/v1/fruits/bulk/move", fruit.MoveMany)
+	r.POST("/api/v1/fruits/bulk/unassign", fruit.UnassignMany)
+	r.POST("/api/v1/fruits/bulk/delete", fruit.DeleteMany)
+	r.POST("/api/v1/fruits/recall", fruit.Recall)
+	r.GET("/api/v1/fruits/by-barcode/:code", fruit.GetByBarcode)
+	r.GET("/api/v1/fruits/expiring", fruit.ListExpiring)
 	r.POST("/api/v1/fruits/:fruitID/buckets/:bucketID", fruit.AddOnBucket)
 	r.DELETE("/api/v1/fruits/:fruitID/buckets/:bucketID", fruit.RemoveFromBucket)
+	r.POST("/api/v1/fruits/:fruitID/transfer", fruit.Transfer)
 	r.DELETE("/api/v1/fruits/:fruitID", fruit.Delete)
 
+	r.POST("/api/v1/fruits/:fruitID/states", fruitState.Change)
+	r.GET("/api/v1/fruits/:fruitID/states", fruitState.ListTransitions)
+
+	r.GET("/api/v1/fruits/prices/stats", fruitPrice.Stats)
+	r.POST("/api/v1/fruits/:fruitID/prices", fruitPrice.Change)
+	r.GET("/api/v1/fruits/:fruitID/prices", fruitPrice.List)
+
+	r.POST("/api/v1/fruits/:fruitID/photos", photo.UploadFruitPhoto)
+	r.GET("/api/v1/fruits/:fruitID/photos", photo.ListFruitPhotos)
+	r.POST("/api/v1/buckets/:bucketID/photos", photo.UploadBucketPhoto)
+	r.GET("/api/v1/buckets/:bucketID/photos", photo.ListBucketPhotos)
+	r.GET("/api/v1/photos/:photoID", photo.Download)
+	r.GET("/api/v1/photos/:photoID/thumbnail", photo.DownloadThumbnail)
+
+	r.POST("/api/v1/fruits/:fruitID/notes", note.CreateFruitNote)
+	r.GET("/api/v1/fruits/:fruitID/notes", note.ListFruitNotes)
+	r.POST("/api/v1/buckets/:bucketID/notes", note.CreateBucketNote)
+	r.GET("/api/v1/buckets/:bucketID/notes", note.ListBucketNotes)
+
+	r.POST("/api/v1/picks", pick.Create)
+
+	r.POST("/api/v1/orders", order.Create)
+	r.GET("/api/v1/orders/:orderID", order.Get)
+	r.POST("/api/v1/orders/:orderID/checkout", order.Checkout)
+	r.GET("/api/v1/reports/sales", order.Sales)
+
+	r.POST("/api/v1/reservations", reservation.Create)
+	r.GET("/api/v1/reservations/:reservationID", reservation.Get)
+	r.POST("/api/v1/reservations/:reservationID/confirm", reservation.Confirm)
+	r.DELETE("/api/v1/reservations/:reservationID", reservation.Release)
+
+	r.POST("/api/v1/purchase-orders", purchaseOrder.Create)
+	r.GET("/api/v1/purchase-orders/:purchaseOrderID", purchaseOrder.Get)
+	r.POST("/api/v1/purchase-orders/:purchaseOrderID/receive", purchaseOrder.Receive)
+
+	r.POST("/api/v1/buckets/:bucketID/cycle-counts", cycleCount.Open)
+	r.GET("/api/v1/cycle-counts/:cycleCountID", cycleCount.Get)
+	r.POST("/api/v1/cycle-counts/:cycleCountID/submit", cycleCount.Submit)
+	r.POST("/api/v1/cycle-counts/:cycleCountID/approve", cycleCount.Approve)
+
+	r.PUT("/api/v1/stock/reorder-points", stock.SetReorderPoint)
+	r.GET("/api/v1/stock/reorder-points", stock.ListReorderPoints)
+	r.GET("/api/v1/stock/low", stock.Low)
+	r.GET("/api/v1/stock/alerts", stock.ListAlerts)
+	r.GET("/api/v1/reports/waste", stock.Waste)
+	r.GET("/api/v1/reports/valuation", stock.Valuation)
+
+	r.GET("/api/v1/movements", movement.List)
+
+	r.POST("/api/v1/suppliers", supplier.Create)
+	r.GET("/api/v1/suppliers", supplier.List)
+	r.GET("/api/v1/suppliers/:supplierID", supplier.Get)
+	r.PUT("/api/v1/suppliers/:supplierID", supplier.Update)
+	r.DELETE("/api/v1/suppliers/:supplierID", supplier.Delete)
+	r.GET("/api/v1/suppliers/:supplierID/report", supplier.Report)
+
 	return r
 }
 
-func ConfigServer(host, port string, logger *zap.SugaredLogger, health HealthController, bucket BucketController, fruit FruitController) *http.Server {
-	r := ConfigGin(host, port, logger, health, bucket, fruit)
+func ConfigServer(host, port string, logger *zap.SugaredLogger, health HealthController, bucket BucketController, fruit FruitController,
+	fruitState FruitStateController, fruitPrice FruitPriceController, photo PhotoController, note NoteController, pick PickController, order OrderController, reservation ReservationController, purchaseOrder PurchaseOrderController, cycleCount CycleCountController, stock StockController, movement MovementController, supplier SupplierController) *http.Server {
+	r := ConfigGin(host, port, logger, health, bucket, fruit, fruitState, fruitPrice, photo, note, pick, order, reservation, purchaseOrder, cycleCount, stock, movement, supplier)
 	server := &http.Server{
 		Addr:    fmt.Sprintf("%s:%s", host, port),
 		Handler: r,
@@ -31,9 +31,22 @@ func TestServer_ConfigServer(t *testing.T) {
 			healthControllerMock := mocks.NewMockHealthController(ctrl)
 			bucketControllerMock := mocks.NewMockBucketController(ctrl)
 			fruitControllerMock := mocks.NewMockFruitController(ctrl)
+			fruitStateControllerMock := mocks.NewMockFruitStateController(ctrl)
+			fruitPriceControllerMock := mocks.NewMockFruitPriceController(ctrl)
+			photoControllerMock := mocks.NewMockPhotoController(ctrl)
+			noteControllerMock := mocks.NewMockNoteController(ctrl)
+			pickControllerMock := mocks.NewMockPickController(ctrl)
+			orderControllerMock := mocks.NewMockOrderController(ctrl)
+			reservationControllerMock := mocks.NewMockReservationController(ctrl)
+			purchaseOrderControllerMock := mocks.NewMockPurchaseOrderController(ctrl)
+			cycleCountControllerMock := mocks.NewMockCycleCountController(ctrl)
+			stockControllerMock := mocks.NewMockStockController(ctrl)
+			movementControllerMock := mocks.NewMockMovementController(ctrl)
+			supplierControllerMock := mocks.NewMockSupplierController(ctrl)
 
 			// when
-			got := ConfigServer(tt.args.host, tt.args.port, nil, healthControllerMock, bucketControllerMock, fruitControllerMock)
+			got := ConfigServer(tt.args.host, tt.args.port, nil, healthControllerMock, bucketControllerMock, fruitControllerMock,
+				fruitStateControllerMock, fruitPriceControllerMock, photoControllerMock, noteControllerMock, pickControllerMock, orderControllerMock, reservationControllerMock, purchaseOrderControllerMock, cycleCountControllerMock, stockControllerMock, movementControllerMock, supplierControllerMock)
 
 			// then
 			assert.NotNil(t, got)
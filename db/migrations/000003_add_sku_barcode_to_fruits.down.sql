@@ -0,0 +1,5 @@
+ALTER TABLE fruits
+    DROP INDEX fruits_barcode_unique,
+    DROP INDEX fruits_sku_unique,
+    DROP COLUMN barcode,
+    DROP COLUMN sku;
@@ -0,0 +1,5 @@
+ALTER TABLE fruits
+    ADD COLUMN sku varchar(64) AFTER bucket_fk,
+    ADD COLUMN barcode varchar(14) AFTER sku,
+    ADD CONSTRAINT fruits_sku_unique UNIQUE (sku),
+    ADD CONSTRAINT fruits_barcode_unique UNIQUE (barcode);
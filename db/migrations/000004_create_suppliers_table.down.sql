@@ -0,0 +1 @@
+DROP TABLE suppliers;
\ No newline at end of file
@@ -0,0 +1,11 @@
+CREATE TABLE suppliers (
+    id bigint NOT NULL AUTO_INCREMENT,
+    created_at datetime NOT NULL,
+    deleted_at datetime,
+
+    name varchar(128) NOT NULL,
+    country char(2) NOT NULL,
+    contact varchar(256),
+
+    PRIMARY KEY (ID)
+);
@@ -0,0 +1,5 @@
+ALTER TABLE fruits
+    DROP FOREIGN KEY fruits_ibfk_2,
+    DROP COLUMN harvested_at,
+    DROP COLUMN origin_country,
+    DROP COLUMN supplier_fk;
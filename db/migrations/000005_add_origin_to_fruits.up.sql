@@ -0,0 +1,5 @@
+ALTER TABLE fruits
+    ADD COLUMN supplier_fk bigint AFTER bucket_fk,
+    ADD COLUMN origin_country char(2) AFTER expires_at,
+    ADD COLUMN harvested_at datetime AFTER origin_country,
+    ADD FOREIGN KEY (supplier_fk) REFERENCES suppliers(id);
@@ -0,0 +1,2 @@
+ALTER TABLE fruits
+    DROP COLUMN state;
@@ -0,0 +1,2 @@
+ALTER TABLE fruits
+    ADD COLUMN state varchar(16) NOT NULL DEFAULT 'unripe' AFTER expires_at;
@@ -0,0 +1 @@
+DROP TABLE fruit_state_transitions;
\ No newline at end of file
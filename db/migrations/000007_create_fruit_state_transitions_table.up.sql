@@ -0,0 +1,12 @@
+CREATE TABLE fruit_state_transitions (
+    id bigint NOT NULL AUTO_INCREMENT,
+    created_at datetime NOT NULL,
+
+    fruit_fk bigint NOT NULL,
+
+    from_state varchar(16) NOT NULL,
+    to_state varchar(16) NOT NULL,
+
+    PRIMARY KEY (ID),
+    FOREIGN KEY (fruit_fk) REFERENCES fruits(id)
+);
@@ -0,0 +1 @@
+DROP TABLE fruit_prices;
\ No newline at end of file
@@ -0,0 +1,16 @@
+CREATE TABLE fruit_prices (
+    id bigint NOT NULL AUTO_INCREMENT,
+    created_at datetime NOT NULL,
+
+    fruit_fk bigint NOT NULL,
+
+    price decimal(8,2) NOT NULL,
+    reason varchar(128) NOT NULL,
+
+    PRIMARY KEY (ID),
+    FOREIGN KEY (fruit_fk) REFERENCES fruits(id),
+    INDEX (created_at)
+);
+
+INSERT INTO fruit_prices (created_at, fruit_fk, price, reason)
+    SELECT created_at, id, price, 'created' FROM fruits;
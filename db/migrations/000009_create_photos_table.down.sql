@@ -0,0 +1 @@
+DROP TABLE photos;
\ No newline at end of file
@@ -0,0 +1,19 @@
+CREATE TABLE photos (
+    id bigint NOT NULL AUTO_INCREMENT,
+    created_at datetime NOT NULL,
+
+    fruit_fk bigint,
+    bucket_fk bigint,
+
+    filename varchar(255) NOT NULL,
+    content_type varchar(64) NOT NULL,
+    size bigint NOT NULL,
+    width int NOT NULL,
+    height int NOT NULL,
+    blob_key varchar(255) NOT NULL,
+    thumbnail_key varchar(255) NOT NULL,
+
+    PRIMARY KEY (ID),
+    FOREIGN KEY (fruit_fk) REFERENCES fruits(id),
+    FOREIGN KEY (bucket_fk) REFERENCES buckets(id)
+);
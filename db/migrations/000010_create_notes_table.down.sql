@@ -0,0 +1 @@
+DROP TABLE notes;
\ No newline at end of file
@@ -0,0 +1,15 @@
+CREATE TABLE notes (
+    id bigint NOT NULL AUTO_INCREMENT,
+    created_at datetime NOT NULL,
+
+    fruit_fk bigint,
+    bucket_fk bigint,
+
+    author varchar(128) NOT NULL,
+    text varchar(1024) NOT NULL,
+
+    PRIMARY KEY (ID),
+    FOREIGN KEY (fruit_fk) REFERENCES fruits(id),
+    FOREIGN KEY (bucket_fk) REFERENCES buckets(id),
+    INDEX (created_at)
+);
@@ -0,0 +1,10 @@
+CREATE TABLE orders (
+    id bigint NOT NULL AUTO_INCREMENT,
+    created_at datetime NOT NULL,
+    checked_out_at datetime,
+
+    total decimal(10,2) NOT NULL,
+
+    PRIMARY KEY (ID),
+    INDEX (checked_out_at)
+);
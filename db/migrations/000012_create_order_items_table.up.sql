@@ -0,0 +1,13 @@
+CREATE TABLE order_items (
+    id bigint NOT NULL AUTO_INCREMENT,
+
+    order_fk bigint NOT NULL,
+    fruit_fk bigint NOT NULL,
+
+    name varchar(128) NOT NULL,
+    price decimal(8,2) NOT NULL,
+
+    PRIMARY KEY (ID),
+    FOREIGN KEY (order_fk) REFERENCES orders(id),
+    FOREIGN KEY (fruit_fk) REFERENCES fruits(id)
+);
@@ -0,0 +1,14 @@
+CREATE TABLE reservations (
+    id bigint NOT NULL AUTO_INCREMENT,
+    created_at datetime NOT NULL,
+    expires_at datetime NOT NULL,
+    confirmed_at datetime,
+    released_at datetime,
+
+    holder varchar(128) NOT NULL,
+    order_fk bigint,
+
+    PRIMARY KEY (ID),
+    FOREIGN KEY (order_fk) REFERENCES orders(id),
+    INDEX (expires_at)
+);
@@ -0,0 +1,4 @@
+ALTER TABLE fruits
+    DROP FOREIGN KEY fruits_ibfk_3,
+    DROP COLUMN reservation_fk,
+    DROP COLUMN reserved_until;
\ No newline at end of file
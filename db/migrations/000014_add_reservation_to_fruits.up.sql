@@ -0,0 +1,4 @@
+ALTER TABLE fruits
+    ADD COLUMN reservation_fk bigint AFTER supplier_fk,
+    ADD COLUMN reserved_until datetime AFTER state,
+    ADD FOREIGN KEY (reservation_fk) REFERENCES reservations(id);
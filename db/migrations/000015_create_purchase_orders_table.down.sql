@@ -0,0 +1 @@
+DROP TABLE purchase_orders;
\ No newline at end of file
@@ -0,0 +1,10 @@
+CREATE TABLE purchase_orders (
+    id bigint NOT NULL AUTO_INCREMENT,
+    created_at datetime NOT NULL,
+    received_at datetime,
+
+    supplier_fk bigint NOT NULL,
+
+    PRIMARY KEY (ID),
+    FOREIGN KEY (supplier_fk) REFERENCES suppliers(id)
+);
@@ -0,0 +1 @@
+DROP TABLE purchase_order_lines;
\ No newline at end of file
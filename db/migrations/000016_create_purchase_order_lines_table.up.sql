@@ -0,0 +1,13 @@
+CREATE TABLE purchase_order_lines (
+    id bigint NOT NULL AUTO_INCREMENT,
+
+    purchase_order_fk bigint NOT NULL,
+
+    name varchar(128) NOT NULL,
+    price decimal(8,2) NOT NULL,
+    quantity int NOT NULL,
+    received_quantity int,
+
+    PRIMARY KEY (ID),
+    FOREIGN KEY (purchase_order_fk) REFERENCES purchase_orders(id)
+);
@@ -0,0 +1,3 @@
+ALTER TABLE fruits
+    DROP FOREIGN KEY fruits_ibfk_4,
+    DROP COLUMN purchase_order_line_fk;
\ No newline at end of file
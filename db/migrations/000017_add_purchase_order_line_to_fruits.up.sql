@@ -0,0 +1,3 @@
+ALTER TABLE fruits
+    ADD COLUMN purchase_order_line_fk bigint AFTER reservation_fk,
+    ADD FOREIGN KEY (purchase_order_line_fk) REFERENCES purchase_order_lines(id);
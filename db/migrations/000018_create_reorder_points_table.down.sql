@@ -0,0 +1 @@
+DROP TABLE reorder_points;
\ No newline at end of file
@@ -0,0 +1,11 @@
+CREATE TABLE reorder_points (
+    id bigint NOT NULL AUTO_INCREMENT,
+    created_at datetime NOT NULL,
+    updated_at datetime NOT NULL,
+
+    name varchar(128) NOT NULL,
+    min_quantity int NOT NULL,
+
+    PRIMARY KEY (ID),
+    UNIQUE (name)
+);
@@ -0,0 +1 @@
+DROP TABLE stock_alerts;
\ No newline at end of file
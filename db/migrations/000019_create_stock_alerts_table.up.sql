@@ -0,0 +1,12 @@
+CREATE TABLE stock_alerts (
+    id bigint NOT NULL AUTO_INCREMENT,
+    created_at datetime NOT NULL,
+
+    name varchar(128) NOT NULL,
+    quantity int NOT NULL,
+    min_quantity int NOT NULL,
+    reason varchar(16) NOT NULL,
+
+    PRIMARY KEY (ID),
+    INDEX (created_at)
+);
@@ -0,0 +1 @@
+DROP TABLE cycle_counts;
\ No newline at end of file
@@ -0,0 +1,11 @@
+CREATE TABLE cycle_counts (
+    id bigint NOT NULL AUTO_INCREMENT,
+    created_at datetime NOT NULL,
+    submitted_at datetime,
+    approved_at datetime,
+
+    bucket_fk bigint NOT NULL,
+
+    PRIMARY KEY (ID),
+    FOREIGN KEY (bucket_fk) REFERENCES buckets(id)
+);
@@ -0,0 +1 @@
+DROP TABLE cycle_count_items;
\ No newline at end of file
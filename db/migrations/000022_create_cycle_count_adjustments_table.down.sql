@@ -0,0 +1 @@
+DROP TABLE cycle_count_adjustments;
\ No newline at end of file
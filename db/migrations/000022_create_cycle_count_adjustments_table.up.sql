@@ -0,0 +1,15 @@
+CREATE TABLE cycle_count_adjustments (
+    id bigint NOT NULL AUTO_INCREMENT,
+    created_at datetime NOT NULL,
+
+    cycle_count_fk bigint NOT NULL,
+    fruit_fk bigint,
+
+    name varchar(128) NOT NULL,
+    quantity int NOT NULL,
+    action varchar(16) NOT NULL,
+
+    PRIMARY KEY (ID),
+    FOREIGN KEY (cycle_count_fk) REFERENCES cycle_counts(id),
+    FOREIGN KEY (fruit_fk) REFERENCES fruits(id)
+);
@@ -0,0 +1 @@
+DROP TABLE movements;
\ No newline at end of file
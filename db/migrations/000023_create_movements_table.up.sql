@@ -0,0 +1,17 @@
+CREATE TABLE movements (
+    id bigint NOT NULL AUTO_INCREMENT,
+    created_at datetime NOT NULL,
+
+    fruit_fk bigint NOT NULL,
+    from_bucket_fk bigint,
+    to_bucket_fk bigint,
+
+    type varchar(16) NOT NULL,
+    actor varchar(128),
+
+    PRIMARY KEY (ID),
+    INDEX (created_at),
+    FOREIGN KEY (fruit_fk) REFERENCES fruits(id),
+    FOREIGN KEY (from_bucket_fk) REFERENCES buckets(id),
+    FOREIGN KEY (to_bucket_fk) REFERENCES buckets(id)
+);
@@ -0,0 +1,2 @@
+ALTER TABLE buckets
+    ADD COLUMN quarantine boolean NOT NULL DEFAULT false AFTER capacity;
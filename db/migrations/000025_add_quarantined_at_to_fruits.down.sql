@@ -0,0 +1,3 @@
+ALTER TABLE fruits
+    DROP INDEX quarantined_at,
+    DROP COLUMN quarantined_at;
@@ -0,0 +1,3 @@
+ALTER TABLE fruits
+    ADD COLUMN quarantined_at datetime AFTER reserved_until,
+    ADD INDEX (quarantined_at);
@@ -75,7 +75,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/presenters.BucketsRes"
+                            "$ref": "#/definitions/presenters.BucketsFruitsRes"
                         }
                     },
                     "500": {
@@ -130,6 +130,50 @@ const docTemplate = `{
                 }
             }
         },
+        "/v1/buckets/consolidation": {
+            "post": {
+                "description": "Plans moving fruits so that as many buckets as possible end up empty. It is a dry run unless apply is true",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "bucket"
+                ],
+                "summary": "consolidate partially full buckets",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "default": false,
+                        "description": "apply the moves",
+                        "name": "apply",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/presenters.BucketConsolidationRes"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/presenters.ErrorRes"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/presenters.ErrorRes"
+                        }
+                    }
+                }
+            }
+        },
         "/v1/buckets/{bucketID}": {
             "delete": {
                 "consumes": [
@@ -170,7 +214,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/fruits": {
+        "/v1/buckets/{bucketID}/cycle-counts": {
             "post": {
                 "consumes": [
                     "application/json"
@@ -179,25 +223,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "fruit"
+                    "cycle-count"
                 ],
-                "summary": "create fruit",
+                "summary": "open a cycle count of a bucket",
                 "parameters": [
                     {
-                        "description": "Fruit",
-                        "name": "fruit",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/presenters.CreateFruitReq"
-                        }
+                        "type": "integer",
+                        "description": "Bucket ID",
+                        "name": "bucketID",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/presenters.FruitRes"
+                            "$ref": "#/definitions/presenters.CycleCountRes"
                         }
                     },
                     "400": {
@@ -206,6 +248,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/presenters.ErrorRes"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/presenters.ErrorRes"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/presenters.ErrorRes"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -215,8 +269,8 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/fruits/{fruitID}": {
-            "delete": {
+        "/v1/buckets/{bucketID}/notes": {
+            "get": {
                 "consumes": [
                     "application/json"
                 ],
@@ -224,21 +278,38 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "fruit"
+                    "note"
                 ],
-                "summary": "delete fruit",
+                "summary": "list bucket notes",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Fruit ID",
-                        "name": "fruitID",
+                        "description": "Bucket ID",
+                        "name": "bucketID",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "page",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "pageSize",
+                        "name": "pageSize",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK"
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/presenters.NotesRes"
+                        }
                     },
                     "400": {
                         "description": "Bad Request",
@@ -253,10 +324,8 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/v1/fruits/{fruitID}/buckets": {
-            "delete": {
+            },
+            "post": {
                 "consumes": [
                     "application/json"
                 ],
@@ -264,21 +333,33 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "fruit"
+                    "note"
                 ],
-                "summary": "remove fruit from bucket",
+                "summary": "create bucket note",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Fruit ID",
-                        "name": "fruitID",
+                        "description": "Bucket ID",
+                        "name": "bucketID",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Note",
+                        "name": "note",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/presenters.CreateNoteReq"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK"
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/presenters.NoteRes"
+                        }
                     },
                     "400": {
                         "description": "Bad Request",
@@ -286,6 +367,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/presenters.ErrorRes"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/presenters.ErrorRes"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -295,8 +382,8 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/fruits/{fruitID}/buckets/{bucketID}": {
-            "post": {
+        "/v1/buckets/{bucketID}/photos": {
+            "get": {
                 "consumes": [
                     "application/json"
                 ],
@@ -304,28 +391,72 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "fruit"
+                    "photo"
                 ],
-                "summary": "add fruit on bucket",
+                "summary": "list bucket photos",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Fruit ID",
-                        "name": "fruitID",
+                        "description": "Bucket ID",
+                        "name": "bucketID",
                         "in": "path",
                         "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/presenters.PhotosRes"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/presenters.ErrorRes"
+                        }
                     },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/presenters.ErrorRes"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "photo"
+                ],
+                "summary": "upload bucket photo",
+                "parameters": [
                     {
                         "type": "integer",
                         "description": "Bucket ID",
                         "name": "bucketID",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "jpeg, png or gif image",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK"
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/presenters.PhotoRes"
+                        }
                     },
                     "400": {
                         "description": "Bad Request",
@@ -333,6 +464,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/presenters.ErrorRes"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/presenters.ErrorRes"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -341,138 +478,4199 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "presenters.BucketDtoRes": {
-            "type": "object",
-            "properties": {
-                "capacity": {
-                    "type": "integer",
-                    "example": 10
-                },
-                "id": {
-                    "type": "integer",
-                    "example": 1
-                },
-                "name": {
-                    "type": "string",
-                    "example": "A"
-                },
-                "percent": {
-                    "type": "string",
-                    "example": "50%"
-                },
-                "total_fruit": {
-                    "type": "integer",
-                    "example": 5
-                },
-                "total_price": {
-                    "type": "number",
-                    "example": 23.54
+        },
+        "/v1/cycle-counts/{cycleCountID}": {
+            "get": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "cycle-count"
+                ],
+                "summary": "get cycle count, with its variance while it waits for approval",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Cycle count ID",
+                        "name": "cycleCountID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/presenters.CycleCountRes"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/presenters.ErrorRes"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/presenters.ErrorRes"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/presenters.ErrorRes"
+                        }
+                    }
                 }
             }
         },
-        "presenters.BucketRes": {
-            "type": "object",
-            "properties": {
-                "capacity": {
-                    "type": "integer",
-                    "example": 10
-                },
-                "created_at": {
-                    "type": "string",
-                    "example": "2000-12-31 23:59:59"
-                },
-                "deleted_at": {
-                    "type": "string",
-                    "example": "2000-12-31 23:59:59"
-                },
-                "id": {
-                    "type": "integer",
-                    "example": 1
-                },
-                "name": {
-                    "type": "string",
-                    "example": "A"
+        "/v1/cycle-counts/{cycleCountID}/approve": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "cycle-count"
+                ],
+                "summary": "approve cycle count, unassigning missing fruits and flagging unknown ones",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Cycle count ID",
+                        "name": "cycleCountID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/presenters.CycleCountRes"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/presenters.ErrorRes"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/presenters.ErrorRes"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/presenters.ErrorRes"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/cycle-counts/{cycleCountID}/submit": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "cycle-count"
+                ],
+                "summary": "submit the fruit ids, or the quantities by name, found in the bucket",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Cycle count ID",
+                        "name": "cycleCountID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Count",
+                        "name": "count",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/presenters.SubmitCycleCountReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/presenters.CycleCountRes"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/presenters.ErrorRes"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/presenters.ErrorRes"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/presenters.ErrorRes"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/fruits": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "fruit"
+                ],
+                "summary": "create fruit",
+                "parameters": [
+                    {
+                        "description": "Fruit",
+                        "name": "fruit",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/presenters.CreateFruitReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/presenters.FruitRes"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/presenters.ErrorRes"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/presenters.ErrorRes"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/fruits/batch": {
+            "post": {
+                "description": "mode all_or_nothing (default) creates every item or none of them, best_effort creates the valid items",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "fruit"
+                ],
+                "summary": "create many fruits",
+                "parameters": [
+                    {
+                        "description": "Fruits",
+                        "name": "fruits",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/presenters.CreateFruitsBatchReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/presenters.FruitsBatchRes"
+                        }
+                    },
+                    "207": {
+                        "description": "Multi-Status",
+                        "schema": {
+                            "$ref": "#/definitions/presenters.FruitsBatchRes"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/presenters.FruitsBatchRes"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/presenters.ErrorRes"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/fruits/bulk/delete": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "fruit"
+                ],
+                "summary": "delete many fruits",
+                "parameters": [
+                    {
+                        "description": "Fruits selected by ids or filter",
+                        "name": "fruits",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/presenters.SelectFruitsReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/presenters.FruitsBulkRes"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/presenters.ErrorRes"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/presenters.ErrorRes"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/presenters.ErrorRes"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/fruits/bulk/move": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "fruit"
+                ],
+                "summary": "move many fruits to a bucket",
+                "parameters": [
+                    {
+                        "description": "Fruits selected by ids or filter",
+                        "name": "fruits",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/presenters.MoveFruitsReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/presenters.FruitsBulkRes"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/presenters.ErrorRes"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/presenters.ErrorRes"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/presenters.ErrorRes"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/fruits/bulk/unassign": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "fruit"
+                ],
+                "summary": "remove many fruits from their buckets",
+                "parameters": [
+                    {
+                        "description": "Fruits selected by ids or filter",
+                        "name": "fruits",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/presenters.SelectFruitsReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/presenters.FruitsBulkRes"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/presenters.ErrorRes"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/presenters.ErrorRes"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/presenters.ErrorRes"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/fruits/by-barcode/{code}": {
+            "get": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "fruit"
+                ],
+                "summary": "get fruit by barcode",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "GTIN/EAN barcode",
+                        "name": "code",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/presenters.FruitRes"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/presenters.ErrorRes"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/presenters.ErrorRes"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/fruits/expiring": {
+            "get": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "fruit"
+                ],
+                "summary": "list fruits expiring soon grouped by bucket",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "48h",
+                        "description": "window",
+                        "name": "within",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/presenters.ExpiringFruitsRes"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/presenters.ErrorRes"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/presenters.ErrorRes"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/fruits/prices/stats": {
+            "get": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "fruit"
+                ],
+                "summary": "fruit price statistics by name",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Fruit name",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "2000-12-01",
+                        "description": "First day of the period (default 30 days before to)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "2000-12-31",
+                        "description": "Last day of the period (default today)",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/presenters.FruitPricesStatsRes"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/presenters.ErrorRes"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/presenters.ErrorRes"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/fruits/recall": {
+            "post": {
+                "description": "Quarantines the fruits from a supplier, lot (purchase order line) or created-at window and lists where each one is",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "fruit"
+                ],
+                "summary": "recall fruits",
+                "parameters": [
+                    {
+                        "description": "Recall",
+                        "name": "recall",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/presenters.RecallFruitsReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/presenters.RecallRes"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/presenters.ErrorRes"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/presenters.ErrorRes"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/fruits/{fruitID}": {
+            "delete": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "fruit"
+                ],
+                "summary": "delete fruit",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Fruit ID",
+                        "name": "fruitID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/presenters.ErrorRes"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/presenters.ErrorRes"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/fruits/{fruitID}/buckets": {
+            "delete": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "fruit"
+                ],
+                "summary": "remove fruit from bucket",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Fruit ID",
+                        "name": "fruitID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/presenters.ErrorRes"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/presenters.ErrorRes"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/fruits/{fruitID}/buckets/{bucketID}": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "fruit"
+                ],
+                "summary": "add fruit on bucket",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Fruit ID",
+                        "name": "fruitID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Bucket ID",
+                        "name": "bucketID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/presenters.ErrorRes"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/presenters.ErrorRes"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/fruits/{fruitID}/notes": {
+            "get": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "note"
+                ],
+                "summary": "list fruit notes",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Fruit ID",
+                        "name": "fruitID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "page",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "pageSize",
+                        "name": "pageSize",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/presenters.NotesRes"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/presenters.ErrorRes"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/presenters.ErrorRes"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "note"
+                ],
+                "summary": "create fruit note",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Fruit ID",
+                        "name": "fruitID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Note",
+                        "name": "note",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/presenters.CreateNoteReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/presenters.NoteRes"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/presenters.ErrorRes"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/presenters.ErrorRes"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/presenters.ErrorRes"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/fruits/{fruitID}/photos": {
+            "get": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "photo"
+                ],
+                "summary": "list fruit photos",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Fruit ID",
+                        "name": "fruitID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/presenters.PhotosRes"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/presenters.ErrorRes"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/presenters.ErrorRes"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "photo"
+                ],
+                "summary": "upload fruit photo",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Fruit ID",
+                        "name": "fruitID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "jpeg, png or gif image",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/presenters.PhotoRes"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/presenters.ErrorRes"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/presenters.ErrorRes"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/presenters.ErrorRes"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/fruits/{fruitID}/prices": {
+            "get": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "fruit"
+                ],
+                "summary": "list fruit price history",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Fruit ID",
+                        "name": "fruitID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/presenters.FruitPricesRes"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/presenters.ErrorRes"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/presenters.ErrorRes"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "fruit"
+                ],
+                "summary": "change fruit price",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Fruit ID",
+                        "name": "fruitID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Price",
+                        "name": "price",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/presenters.ChangeFruitPriceReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/presenters.FruitPriceRes"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/presenters.ErrorRes"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/presenters.ErrorRes"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/presenters.ErrorRes"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/fruits/{fruitID}/states": {
+            "get": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "fruit"
+                ],
+                "summary": "list fruit ripeness state transitions",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Fruit ID",
+                        "name": "fruitID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/presenters.FruitStateTransitionsRes"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/presenters.ErrorRes"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/presenters.ErrorRes"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "fruit"
+                ],
+                "summary": "change fruit ripeness state",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Fruit ID",
+                        "name": "fruitID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "State",
+                        "name": "state",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/presenters.ChangeFruitStateReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/presenters.FruitStateTransitionRes"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/presenters.ErrorRes"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/presenters.ErrorRes"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/presenters.ErrorRes"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/presenters.ErrorRes"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/fruits/{fruitID}/transfer": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "fruit"
+                ],
+                "summary": "transfer fruit between buckets",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Fruit ID",
+                        "name": "fruitID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Transfer",
+                        "name": "transfer",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/presenters.TransferFruitReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/presenters.FruitTransferRes"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/presenters.ErrorRes"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/presenters.ErrorRes"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/presenters.ErrorRes"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/presenters.ErrorRes"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/movements": {
+            "get": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "movement"
+                ],
+                "summary": "list fruit movements, newest first",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Fruit ID",
+                        "name": "fruit_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Bucket ID the fruit left or entered",
+                        "name": "bucket_id",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "created",
+                            "moved",
+                            "unassigned",
+                            "deleted",
+                            "picked"
+                        ],
+                        "type": "string",
+                        "description": "Movement type",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Actor",
+                        "name": "actor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "2000-12-01",
+                        "description": "First day of the period",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "2000-12-31",
+                        "description": "Last day of the period",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "page",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "pageSize",
+                        "name": "pageSize",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/presenters.MovementsRes"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/presenters.ErrorRes"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/presenters.ErrorRes"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/orders": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "order"
+                ],
+                "summary": "create order",
+                "parameters": [
+                    {
+                        "description": "Order",
+                        "name": "order",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/presenters.CreateOrderReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/presenters.OrderRes"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/presenters.ErrorRes"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/presenters.ErrorRes"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/orders/{orderID}": {
+            "get": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "order"
+                ],
+                "summary": "get order",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Order ID",
+                        "name": "orderID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/presenters.OrderRes"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/presenters.ErrorRes"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/presenters.ErrorRes"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/presenters.ErrorRes"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/orders/{orderID}/checkout": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "order"
+                ],
+                "summary": "checkout order",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Order ID",
+                        "name": "orderID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/presenters.OrderRes"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/presenters.ErrorRes"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/presenters.ErrorRes"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/presenters.ErrorRes"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/photos/{photoID}": {
+            "get": {
+                "produces": [
+                    "image/jpeg",
+                    "image/png",
+                    "image/gif"
+                ],
+                "tags": [
+                    "photo"
+                ],
+                "summary": "download photo",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Photo ID",
+                        "name": "photoID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/presenters.ErrorRes"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/presenters.ErrorRes"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/presenters.ErrorRes"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/photos/{photoID}/thumbnail": {
+            "get": {
+                "produces": [
+                    "image/jpeg"
+                ],
+                "tags": [
+                    "photo"
+                ],
+                "summary": "download photo thumbnail",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Photo ID",
+                        "name": "photoID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/presenters.ErrorRes"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/presenters.ErrorRes"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/presenters.ErrorRes"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/picks": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "pick"
+                ],
+                "summary": "pick fruits first-expired-first-out",
+                "parameters": [
+                    {
+                        "description": "Pick",
+                        "name": "pick",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/presenters.CreatePickReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/presenters.PickRes"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/presenters.ErrorRes"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/presenters.ErrorRes"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/purchase-orders": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "purchase order"
+                ],
+                "summary": "create purchase order",
+                "parameters": [
+                    {
+                        "description": "Purchase order",
+                        "name": "purchaseOrder",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/presenters.CreatePurchaseOrderReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/presenters.PurchaseOrderRes"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/presenters.ErrorRes"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/presenters.ErrorRes"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/purchase-orders/{purchaseOrderID}": {
+            "get": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "purchase order"
+                ],
+                "summary": "get purchase order",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Purchase order ID",
+                        "name": "purchaseOrderID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/presenters.PurchaseOrderRes"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/presenters.ErrorRes"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/presenters.ErrorRes"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/presenters.ErrorRes"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/purchase-orders/{purchaseOrderID}/receive": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "purchase order"
+                ],
+                "summary": "receive purchase order, turning the received lines into fruits",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Purchase order ID",
+                        "name": "purchaseOrderID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Received lines",
+                        "name": "receipt",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/presenters.ReceivePurchaseOrderReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/presenters.PurchaseOrderRes"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/presenters.ErrorRes"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/presenters.ErrorRes"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/presenters.ErrorRes"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/reports/sales": {
+            "get": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "order"
+                ],
+                "summary": "sales report by day and fruit name",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Fruit name",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "2000-12-01",
+                        "description": "First day of the period (default 30 days before to)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "2000-12-31",
+                        "description": "Last day of the period (default today)",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/presenters.SalesReportRes"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/presenters.ErrorRes"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/presenters.ErrorRes"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/reports/storage-violations": {
+            "get": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "bucket"
+                ],
+                "summary": "list buckets violating the storage compatibility rules",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/presenters.BucketsViolationsRes"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/presenters.ErrorRes"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/reports/valuation": {
+            "get": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stock"
+                ],
+                "summary": "value of the stock at a given moment by bucket and fruit name",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "2000-12-31 23:59:59",
+                        "description": "Moment of the valuation (default now)",
+                        "name": "at",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/presenters.ValuationReportRes"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/presenters.ErrorRes"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/presenters.ErrorRes"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/reports/waste": {
+            "get": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stock"
+                ],
+                "summary": "waste report of expired and discarded fruits by fruit name, bucket and supplier",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "2000-12-01",
+                        "description": "First day of the period (default 30 days before to)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "2000-12-31",
+                        "description": "Last day of the period (default today)",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/presenters.WasteReportRes"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/presenters.ErrorRes"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/presenters.ErrorRes"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/reservations": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reservation"
+                ],
+                "summary": "hold specific fruits, or a quantity of fruits by name, for a while",
+                "parameters": [
+                    {
+                        "description": "Reservation",
+                        "name": "reservation",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/presenters.CreateReservationReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/presenters.ReservationRes"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/presenters.ErrorRes"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/presenters.ErrorRes"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/reservations/{reservationID}": {
+            "get": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reservation"
+                ],
+                "summary": "get reservation",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Reservation ID",
+                        "name": "reservationID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/presenters.ReservationRes"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/presenters.ErrorRes"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/presenters.ErrorRes"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/presenters.ErrorRes"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reservation"
+                ],
+                "summary": "release reservation before it lapses",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Reservation ID",
+                        "name": "reservationID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/presenters.ErrorRes"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/presenters.ErrorRes"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/presenters.ErrorRes"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/reservations/{reservationID}/confirm": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reservation"
+                ],
+                "summary": "confirm reservation, selling the held fruits",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Reservation ID",
+                        "name": "reservationID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/presenters.ReservationRes"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/presenters.ErrorRes"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/presenters.ErrorRes"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/presenters.ErrorRes"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/stock/alerts": {
+            "get": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stock"
+                ],
+                "summary": "list low-stock alerts, newest first",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "page",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "pageSize",
+                        "name": "pageSize",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/presenters.StockAlertsRes"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/presenters.ErrorRes"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/stock/low": {
+            "get": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stock"
+                ],
+                "summary": "list fruit names whose stock is below their reorder point",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/presenters.LowStockRes"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/presenters.ErrorRes"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/stock/reorder-points": {
+            "get": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stock"
+                ],
+                "summary": "list reorder points",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "page",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "pageSize",
+                        "name": "pageSize",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/presenters.ReorderPointsRes"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/presenters.ErrorRes"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stock"
+                ],
+                "summary": "set the minimum stock wanted of a fruit name",
+                "parameters": [
+                    {
+                        "description": "Reorder point",
+                        "name": "reorderPoint",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/presenters.SetReorderPointReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/presenters.ReorderPointRes"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/presenters.ErrorRes"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/presenters.ErrorRes"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/suppliers": {
+            "get": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "supplier"
+                ],
+                "summary": "list suppliers",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "page",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "pageSize",
+                        "name": "pageSize",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/presenters.SuppliersRes"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/presenters.ErrorRes"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "supplier"
+                ],
+                "summary": "create supplier",
+                "parameters": [
+                    {
+                        "description": "Supplier",
+                        "name": "supplier",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/presenters.CreateSupplierReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/presenters.SupplierRes"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/presenters.ErrorRes"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/presenters.ErrorRes"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/suppliers/{supplierID}": {
+            "get": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "supplier"
+                ],
+                "summary": "get supplier",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Supplier ID",
+                        "name": "supplierID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/presenters.SupplierRes"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/presenters.ErrorRes"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/presenters.ErrorRes"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/presenters.ErrorRes"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "supplier"
+                ],
+                "summary": "update supplier",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Supplier ID",
+                        "name": "supplierID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Supplier",
+                        "name": "supplier",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/presenters.UpdateSupplierReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/presenters.SupplierRes"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/presenters.ErrorRes"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/presenters.ErrorRes"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/presenters.ErrorRes"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "supplier"
+                ],
+                "summary": "delete supplier",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Supplier ID",
+                        "name": "supplierID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/presenters.ErrorRes"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/presenters.ErrorRes"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/suppliers/{supplierID}/report": {
+            "get": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "supplier"
+                ],
+                "summary": "supplier fruits report",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Supplier ID",
+                        "name": "supplierID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/presenters.SupplierFruitsRes"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/presenters.ErrorRes"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/presenters.ErrorRes"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/presenters.ErrorRes"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "presenters.BucketConsolidationMoveRes": {
+            "type": "object",
+            "properties": {
+                "from_bucket_id": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "fruit_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "to_bucket_id": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "presenters.BucketConsolidationRes": {
+            "type": "object",
+            "properties": {
+                "applied": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "emptied_bucket_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        2
+                    ]
+                },
+                "moves": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/presenters.BucketConsolidationMoveRes"
+                    }
+                }
+            }
+        },
+        "presenters.BucketFruitsRes": {
+            "type": "object",
+            "properties": {
+                "capacity": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "name": {
+                    "type": "string",
+                    "example": "A"
+                },
+                "percent": {
+                    "type": "string",
+                    "example": "50%"
+                },
+                "states": {
+                    "$ref": "#/definitions/presenters.BucketFruitsStatesRes"
+                },
+                "total_effective_price": {
+                    "type": "number",
+                    "example": 18.83
+                },
+                "total_fruit": {
+                    "type": "integer",
+                    "example": 5
+                },
+                "total_price": {
+                    "type": "number",
+                    "example": 23.54
+                },
+                "total_reserved": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "presenters.BucketFruitsStatesRes": {
+            "type": "object",
+            "properties": {
+                "overripe": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "ripe": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "unripe": {
+                    "type": "integer",
+                    "example": 2
+                }
+            }
+        },
+        "presenters.BucketRes": {
+            "type": "object",
+            "properties": {
+                "capacity": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2000-12-31 23:59:59"
+                },
+                "deleted_at": {
+                    "type": "string",
+                    "example": "2000-12-31 23:59:59"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "name": {
+                    "type": "string",
+                    "example": "A"
+                },
+                "quarantine": {
+                    "type": "boolean",
+                    "example": false
+                }
+            }
+        },
+        "presenters.BucketViolationsRes": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "name": {
+                    "type": "string",
+                    "example": "A"
+                },
+                "violations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/presenters.CompatibilityViolationRes"
+                    }
+                }
+            }
+        },
+        "presenters.BucketsFruitsRes": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/presenters.BucketFruitsRes"
+                    }
+                }
+            }
+        },
+        "presenters.BucketsViolationsRes": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/presenters.BucketViolationsRes"
+                    }
+                }
+            }
+        },
+        "presenters.ChangeFruitPriceReq": {
+            "type": "object",
+            "properties": {
+                "price": {
+                    "type": "number",
+                    "example": 2.49
+                },
+                "reason": {
+                    "type": "string",
+                    "example": "supplier cost increase"
+                }
+            }
+        },
+        "presenters.ChangeFruitStateReq": {
+            "type": "object",
+            "properties": {
+                "state": {
+                    "type": "string",
+                    "example": "ripe"
+                }
+            }
+        },
+        "presenters.CompatibilityViolationRes": {
+            "type": "object",
+            "properties": {
+                "fruit": {
+                    "type": "string",
+                    "example": "apple"
+                },
+                "other": {
+                    "type": "string",
+                    "example": "kiwi"
+                },
+                "rule": {
+                    "type": "string",
+                    "example": "ethylene_producers/ethylene_sensitive"
+                }
+            }
+        },
+        "presenters.CreateBucketReq": {
+            "type": "object",
+            "properties": {
+                "capacity": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "name": {
+                    "type": "string",
+                    "example": "A"
+                },
+                "quarantine": {
+                    "type": "boolean",
+                    "example": false
+                }
+            }
+        },
+        "presenters.CreateFruitReq": {
+            "type": "object",
+            "properties": {
+                "allocation": {
+                    "type": "string",
+                    "example": "least_full"
+                },
+                "barcode": {
+                    "type": "string",
+                    "example": "7891234567895"
+                },
+                "bucket_id": {
+                    "type": "string",
+                    "example": "1"
+                },
+                "expires_in": {
+                    "type": "string",
+                    "example": "1m"
+                },
+                "harvested_at": {
+                    "type": "string",
+                    "example": "2000-12-31"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Orange"
+                },
+                "origin_country": {
+                    "type": "string",
+                    "example": "BR"
+                },
+                "price": {
+                    "type": "number",
+                    "example": 1.99
+                },
+                "sku": {
+                    "type": "string",
+                    "example": "ORG-001"
+                },
+                "supplier_id": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "presenters.CreateFruitsBatchReq": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/presenters.CreateFruitReq"
+                    }
+                },
+                "mode": {
+                    "type": "string",
+                    "example": "all_or_nothing"
+                }
+            }
+        },
+        "presenters.CreateNoteReq": {
+            "type": "object",
+            "properties": {
+                "author": {
+                    "type": "string",
+                    "example": "John"
+                },
+                "text": {
+                    "type": "string",
+                    "example": "lid cracked"
+                }
+            }
+        },
+        "presenters.CreateOrderItemReq": {
+            "type": "object",
+            "properties": {
+                "fruit_id": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "presenters.CreateOrderReq": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/presenters.CreateOrderItemReq"
+                    }
+                }
+            }
+        },
+        "presenters.CreatePickReq": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "example": "Orange"
+                },
+                "quantity": {
+                    "type": "integer",
+                    "example": 2
+                }
+            }
+        },
+        "presenters.CreatePurchaseOrderLineReq": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "example": "Orange"
+                },
+                "price": {
+                    "type": "number",
+                    "example": 1.99
+                },
+                "quantity": {
+                    "type": "integer",
+                    "example": 10
+                }
+            }
+        },
+        "presenters.CreatePurchaseOrderReq": {
+            "type": "object",
+            "properties": {
+                "lines": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/presenters.CreatePurchaseOrderLineReq"
+                    }
+                },
+                "supplier_id": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "presenters.CreateReservationReq": {
+            "type": "object",
+            "properties": {
+                "fruit_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        1,
+                        2
+                    ]
+                },
+                "hold_for": {
+                    "type": "string",
+                    "example": "15m"
+                },
+                "holder": {
+                    "type": "string",
+                    "example": "Store 1"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Orange"
+                },
+                "quantity": {
+                    "type": "integer",
+                    "example": 2
+                }
+            }
+        },
+        "presenters.CreateSupplierReq": {
+            "type": "object",
+            "properties": {
+                "contact": {
+                    "type": "string",
+                    "example": "contato@boavista.com.br"
+                },
+                "country": {
+                    "type": "string",
+                    "example": "BR"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Fazenda Boa Vista"
+                }
+            }
+        },
+        "presenters.CycleCountAdjustmentRes": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string",
+                    "example": "unassigned"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2000-12-31 23:59:59"
+                },
+                "fruit_id": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Orange"
+                },
+                "quantity": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "presenters.CycleCountItemReq": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "example": "Orange"
+                },
+                "quantity": {
+                    "type": "integer",
+                    "example": 10
+                }
+            }
+        },
+        "presenters.CycleCountItemRes": {
+            "type": "object",
+            "properties": {
+                "fruit_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Orange"
+                },
+                "quantity": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "presenters.CycleCountNameVarianceRes": {
+            "type": "object",
+            "properties": {
+                "counted": {
+                    "type": "integer",
+                    "example": 9
+                },
+                "difference": {
+                    "type": "integer",
+                    "example": -1
+                },
+                "expected": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Orange"
+                }
+            }
+        },
+        "presenters.CycleCountRes": {
+            "type": "object",
+            "properties": {
+                "adjustments": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/presenters.CycleCountAdjustmentRes"
+                    }
+                },
+                "approved_at": {
+                    "type": "string",
+                    "example": "2000-12-31 23:59:59"
+                },
+                "bucket_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2000-12-31 23:00:00"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/presenters.CycleCountItemRes"
+                    }
+                },
+                "status": {
+                    "type": "string",
+                    "example": "submitted"
+                },
+                "submitted_at": {
+                    "type": "string",
+                    "example": "2000-12-31 23:30:00"
+                },
+                "variance": {
+                    "$ref": "#/definitions/presenters.CycleCountVarianceRes"
+                }
+            }
+        },
+        "presenters.CycleCountVarianceRes": {
+            "type": "object",
+            "properties": {
+                "missing_fruit_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        3
+                    ]
+                },
+                "names": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/presenters.CycleCountNameVarianceRes"
+                    }
+                },
+                "unknown_fruit_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        7
+                    ]
+                }
+            }
+        },
+        "presenters.ErrorRes": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": "Not Found"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "fruit not found"
+                },
+                "messages": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "invalid field",
+                        "invalid value"
+                    ]
+                }
+            }
+        },
+        "presenters.ExpiringBucketFruitsRes": {
+            "type": "object",
+            "properties": {
+                "bucket_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "bucket_name": {
+                    "type": "string",
+                    "example": "A"
+                },
+                "fruits": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/presenters.FruitRes"
+                    }
+                },
+                "total_effective_price": {
+                    "type": "number",
+                    "example": 1.99
+                },
+                "total_price": {
+                    "type": "number",
+                    "example": 3.98
+                }
+            }
+        },
+        "presenters.ExpiringFruitsRes": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/presenters.ExpiringBucketFruitsRes"
+                    }
+                },
+                "total_effective_price": {
+                    "type": "number",
+                    "example": 1.99
+                },
+                "total_price": {
+                    "type": "number",
+                    "example": 3.98
+                }
+            }
+        },
+        "presenters.FilterFruitsReq": {
+            "type": "object",
+            "properties": {
+                "bucket_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Orange"
+                },
+                "state": {
+                    "type": "string",
+                    "example": "ripe"
+                },
+                "supplier_id": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "presenters.FruitBatchItemRes": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": "validation"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "index": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Bucket is full"
+                },
+                "messages": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "invalid field",
+                        "invalid value"
+                    ]
+                }
+            }
+        },
+        "presenters.FruitPriceRes": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string",
+                    "example": "2000-12-31 23:59:59"
+                },
+                "fruit_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "price": {
+                    "type": "number",
+                    "example": 2.49
+                },
+                "reason": {
+                    "type": "string",
+                    "example": "supplier cost increase"
+                }
+            }
+        },
+        "presenters.FruitPriceStatsRes": {
+            "type": "object",
+            "properties": {
+                "avg": {
+                    "type": "number",
+                    "example": 2.24
+                },
+                "changes": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "max": {
+                    "type": "number",
+                    "example": 2.49
+                },
+                "min": {
+                    "type": "number",
+                    "example": 1.99
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Mango"
+                }
+            }
+        },
+        "presenters.FruitPricesRes": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/presenters.FruitPriceRes"
+                    }
+                }
+            }
+        },
+        "presenters.FruitPricesStatsRes": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/presenters.FruitPriceStatsRes"
+                    }
+                },
+                "from": {
+                    "type": "string",
+                    "example": "2000-12-01"
+                },
+                "to": {
+                    "type": "string",
+                    "example": "2000-12-31"
+                }
+            }
+        },
+        "presenters.FruitRes": {
+            "type": "object",
+            "properties": {
+                "barcode": {
+                    "type": "string",
+                    "example": "7891234567895"
+                },
+                "bucket_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2000-12-31 23:59:59"
+                },
+                "deleted_at": {
+                    "type": "string",
+                    "example": "2000-12-31 23:59:59"
+                },
+                "effective_price": {
+                    "type": "number",
+                    "example": 1.59
+                },
+                "expires_at": {
+                    "type": "string",
+                    "example": "1m"
+                },
+                "harvested_at": {
+                    "type": "string",
+                    "example": "2000-12-31"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Orange"
+                },
+                "origin_country": {
+                    "type": "string",
+                    "example": "BR"
+                },
+                "price": {
+                    "type": "number",
+                    "example": 1.99
+                },
+                "purchase_order_line_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "sku": {
+                    "type": "string",
+                    "example": "ORG-001"
+                },
+                "state": {
+                    "type": "string",
+                    "example": "ripe"
+                },
+                "supplier_id": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "presenters.FruitStateTransitionRes": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string",
+                    "example": "2000-12-31 23:59:59"
+                },
+                "from_state": {
+                    "type": "string",
+                    "example": "unripe"
+                },
+                "fruit_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "to_state": {
+                    "type": "string",
+                    "example": "ripe"
+                }
+            }
+        },
+        "presenters.FruitStateTransitionsRes": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/presenters.FruitStateTransitionRes"
+                    }
+                }
+            }
+        },
+        "presenters.FruitTransferRes": {
+            "type": "object",
+            "properties": {
+                "from": {
+                    "$ref": "#/definitions/presenters.BucketRes"
+                },
+                "fruit_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "moved": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "to": {
+                    "$ref": "#/definitions/presenters.BucketRes"
+                }
+            }
+        },
+        "presenters.FruitsBatchRes": {
+            "type": "object",
+            "properties": {
+                "created": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/presenters.FruitBatchItemRes"
+                    }
+                },
+                "failed": {
+                    "type": "integer",
+                    "example": 0
+                }
+            }
+        },
+        "presenters.FruitsBulkRes": {
+            "type": "object",
+            "properties": {
+                "affected": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "fruit_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        1,
+                        2
+                    ]
+                }
+            }
+        },
+        "presenters.HealthCheckRes": {
+            "type": "object",
+            "properties": {
+                "status": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/presenters.HealthCheckStatus"
+                        }
+                    ],
+                    "example": "down"
+                }
+            }
+        },
+        "presenters.HealthCheckStatus": {
+            "type": "string",
+            "enum": [
+                "up",
+                "down"
+            ],
+            "x-enum-varnames": [
+                "HealthCheckStatusUp",
+                "HealthCheckStatusDown"
+            ]
+        },
+        "presenters.LowStockRes": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/presenters.StockRes"
+                    }
+                }
+            }
+        },
+        "presenters.MoveFruitsReq": {
+            "type": "object",
+            "properties": {
+                "filter": {
+                    "$ref": "#/definitions/presenters.FilterFruitsReq"
+                },
+                "ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        1,
+                        2
+                    ]
+                },
+                "to_bucket_id": {
+                    "type": "integer",
+                    "example": 2
+                }
+            }
+        },
+        "presenters.MovementRes": {
+            "type": "object",
+            "properties": {
+                "actor": {
+                    "type": "string",
+                    "example": "jane"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2000-12-31 23:59:59"
+                },
+                "from_bucket_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "fruit_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "to_bucket_id": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "type": {
+                    "type": "string",
+                    "example": "moved"
+                }
+            }
+        },
+        "presenters.MovementsRes": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/presenters.MovementRes"
+                    }
+                }
+            }
+        },
+        "presenters.NoteRes": {
+            "type": "object",
+            "properties": {
+                "author": {
+                    "type": "string",
+                    "example": "John"
+                },
+                "bucket_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2000-12-31 23:59:59"
+                },
+                "fruit_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "text": {
+                    "type": "string",
+                    "example": "lid cracked"
+                }
+            }
+        },
+        "presenters.NotesRes": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/presenters.NoteRes"
+                    }
+                }
+            }
+        },
+        "presenters.OrderItemRes": {
+            "type": "object",
+            "properties": {
+                "fruit_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Orange"
+                },
+                "price": {
+                    "type": "number",
+                    "example": 1.99
+                }
+            }
+        },
+        "presenters.OrderRes": {
+            "type": "object",
+            "properties": {
+                "checked_out_at": {
+                    "type": "string",
+                    "example": "2000-12-31 23:59:59"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2000-12-31 23:59:59"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/presenters.OrderItemRes"
+                    }
+                },
+                "total": {
+                    "type": "number",
+                    "example": 1.99
+                }
+            }
+        },
+        "presenters.PhotoRes": {
+            "type": "object",
+            "properties": {
+                "bucket_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "content_type": {
+                    "type": "string",
+                    "example": "image/jpeg"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2000-12-31 23:59:59"
+                },
+                "filename": {
+                    "type": "string",
+                    "example": "bruised-mango.jpg"
+                },
+                "fruit_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "height": {
+                    "type": "integer",
+                    "example": 768
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "size": {
+                    "type": "integer",
+                    "example": 204800
+                },
+                "thumbnail_url": {
+                    "type": "string",
+                    "example": "/api/v1/photos/1/thumbnail"
+                },
+                "url": {
+                    "type": "string",
+                    "example": "/api/v1/photos/1"
+                },
+                "width": {
+                    "type": "integer",
+                    "example": 1024
+                }
+            }
+        },
+        "presenters.PhotosRes": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/presenters.PhotoRes"
+                    }
+                }
+            }
+        },
+        "presenters.PickItemRes": {
+            "type": "object",
+            "properties": {
+                "bucket_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "bucket_name": {
+                    "type": "string",
+                    "example": "A"
+                },
+                "expires_at": {
+                    "type": "string",
+                    "example": "2000-12-31 23:59:59"
+                },
+                "fruit_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "price": {
+                    "type": "number",
+                    "example": 1.99
+                }
+            }
+        },
+        "presenters.PickRes": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/presenters.PickItemRes"
+                    }
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Orange"
+                },
+                "quantity": {
+                    "type": "integer",
+                    "example": 2
+                }
+            }
+        },
+        "presenters.PurchaseOrderLineRes": {
+            "type": "object",
+            "properties": {
+                "discrepancy": {
+                    "type": "integer",
+                    "example": -1
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Orange"
+                },
+                "price": {
+                    "type": "number",
+                    "example": 1.99
+                },
+                "quantity": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "received_quantity": {
+                    "type": "integer",
+                    "example": 9
+                }
+            }
+        },
+        "presenters.PurchaseOrderRes": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string",
+                    "example": "2000-12-31 23:59:59"
+                },
+                "discrepancies": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "lines": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/presenters.PurchaseOrderLineRes"
+                    }
+                },
+                "received_at": {
+                    "type": "string",
+                    "example": "2000-12-31 23:59:59"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "received"
+                },
+                "supplier_id": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "presenters.RecallFruitRes": {
+            "type": "object",
+            "properties": {
+                "bucket_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "bucket_name": {
+                    "type": "string",
+                    "example": "A"
+                },
+                "fruit_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Apple"
+                }
+            }
+        },
+        "presenters.RecallFruitsReq": {
+            "type": "object",
+            "properties": {
+                "created_from": {
+                    "type": "string",
+                    "example": "2000-12-01 00:00:00"
+                },
+                "created_to": {
+                    "type": "string",
+                    "example": "2000-12-31 23:59:59"
+                },
+                "lot_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "supplier_id": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "presenters.RecallRes": {
+            "type": "object",
+            "properties": {
+                "affected": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/presenters.RecallFruitRes"
+                    }
+                },
+                "quarantined_at": {
+                    "type": "string",
+                    "example": "2000-12-31 23:59:59"
+                }
+            }
+        },
+        "presenters.ReceivePurchaseOrderLineReq": {
+            "type": "object",
+            "properties": {
+                "bucket_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "expires_in": {
+                    "type": "string",
+                    "example": "168h"
+                },
+                "line_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "quantity": {
+                    "type": "integer",
+                    "example": 9
+                }
+            }
+        },
+        "presenters.ReceivePurchaseOrderReq": {
+            "type": "object",
+            "properties": {
+                "lines": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/presenters.ReceivePurchaseOrderLineReq"
+                    }
+                }
+            }
+        },
+        "presenters.ReorderPointRes": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string",
+                    "example": "2000-12-31 23:59:59"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "min_quantity": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Orange"
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2000-12-31 23:59:59"
+                }
+            }
+        },
+        "presenters.ReorderPointsRes": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/presenters.ReorderPointRes"
+                    }
+                }
+            }
+        },
+        "presenters.ReservationRes": {
+            "type": "object",
+            "properties": {
+                "confirmed_at": {
+                    "type": "string",
+                    "example": "2000-12-31 23:50:59"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2000-12-31 23:44:59"
+                },
+                "expires_at": {
+                    "type": "string",
+                    "example": "2000-12-31 23:59:59"
+                },
+                "fruit_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        1,
+                        2
+                    ]
+                },
+                "holder": {
+                    "type": "string",
+                    "example": "Store 1"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "order_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "released_at": {
+                    "type": "string",
+                    "example": "2000-12-31 23:50:59"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "active"
                 }
             }
         },
-        "presenters.BucketsRes": {
+        "presenters.SalesReportRes": {
             "type": "object",
             "properties": {
                 "data": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/presenters.BucketDtoRes"
+                        "$ref": "#/definitions/presenters.SalesRes"
                     }
+                },
+                "from": {
+                    "type": "string",
+                    "example": "2000-12-01"
+                },
+                "to": {
+                    "type": "string",
+                    "example": "2000-12-31"
+                },
+                "total_quantity": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "total_revenue": {
+                    "type": "number",
+                    "example": 3.98
                 }
             }
         },
-        "presenters.CreateBucketReq": {
+        "presenters.SalesRes": {
             "type": "object",
             "properties": {
-                "capacity": {
+                "day": {
+                    "type": "string",
+                    "example": "2000-12-31"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Orange"
+                },
+                "quantity": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "revenue": {
+                    "type": "number",
+                    "example": 3.98
+                }
+            }
+        },
+        "presenters.SelectFruitsReq": {
+            "type": "object",
+            "properties": {
+                "filter": {
+                    "$ref": "#/definitions/presenters.FilterFruitsReq"
+                },
+                "ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        1,
+                        2
+                    ]
+                }
+            }
+        },
+        "presenters.SetReorderPointReq": {
+            "type": "object",
+            "properties": {
+                "min_quantity": {
                     "type": "integer",
                     "example": 10
                 },
                 "name": {
                     "type": "string",
-                    "example": "A"
+                    "example": "Orange"
                 }
             }
         },
-        "presenters.CreateFruitReq": {
+        "presenters.StockAlertRes": {
             "type": "object",
             "properties": {
-                "bucket_id": {
+                "created_at": {
+                    "type": "string",
+                    "example": "2000-12-31 23:59:59"
+                },
+                "id": {
                     "type": "integer",
                     "example": 1
                 },
-                "expires_in": {
-                    "type": "string",
-                    "example": "1m"
+                "min_quantity": {
+                    "type": "integer",
+                    "example": 10
                 },
                 "name": {
                     "type": "string",
                     "example": "Orange"
                 },
-                "price": {
-                    "type": "number",
-                    "example": 1.99
+                "quantity": {
+                    "type": "integer",
+                    "example": 9
+                },
+                "reason": {
+                    "type": "string",
+                    "example": "expired"
                 }
             }
         },
-        "presenters.ErrorRes": {
+        "presenters.StockAlertsRes": {
             "type": "object",
             "properties": {
-                "error": {
-                    "type": "string",
-                    "example": "Not Found"
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/presenters.StockAlertRes"
+                    }
+                }
+            }
+        },
+        "presenters.StockRes": {
+            "type": "object",
+            "properties": {
+                "min_quantity": {
+                    "type": "integer",
+                    "example": 10
                 },
-                "message": {
+                "name": {
                     "type": "string",
-                    "example": "fruit not found"
+                    "example": "Orange"
                 },
-                "messages": {
+                "quantity": {
+                    "type": "integer",
+                    "example": 4
+                },
+                "shortage": {
+                    "type": "integer",
+                    "example": 6
+                }
+            }
+        },
+        "presenters.SubmitCycleCountReq": {
+            "type": "object",
+            "properties": {
+                "fruit_ids": {
                     "type": "array",
                     "items": {
-                        "type": "string"
+                        "type": "integer"
                     },
                     "example": [
-                        "invalid field",
-                        "invalid value"
+                        1,
+                        2
                     ]
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/presenters.CycleCountItemReq"
+                    }
                 }
             }
         },
-        "presenters.FruitRes": {
+        "presenters.SupplierFruitsRes": {
             "type": "object",
             "properties": {
-                "bucket_id": {
+                "current_fruits": {
+                    "type": "integer",
+                    "example": 5
+                },
+                "current_price": {
+                    "type": "number",
+                    "example": 9.95
+                },
+                "expired_fruits": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "expired_price": {
+                    "type": "number",
+                    "example": 3.98
+                },
+                "id": {
                     "type": "integer",
                     "example": 1
                 },
+                "name": {
+                    "type": "string",
+                    "example": "Fazenda Boa Vista"
+                },
+                "received_fruits": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "received_price": {
+                    "type": "number",
+                    "example": 19.9
+                }
+            }
+        },
+        "presenters.SupplierRes": {
+            "type": "object",
+            "properties": {
+                "contact": {
+                    "type": "string",
+                    "example": "contato@boavista.com.br"
+                },
+                "country": {
+                    "type": "string",
+                    "example": "BR"
+                },
                 "created_at": {
                     "type": "string",
                     "example": "2000-12-31 23:59:59"
@@ -481,47 +4679,188 @@ const docTemplate = `{
                     "type": "string",
                     "example": "2000-12-31 23:59:59"
                 },
-                "expires_at": {
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "name": {
                     "type": "string",
-                    "example": "1m"
+                    "example": "Fazenda Boa Vista"
+                }
+            }
+        },
+        "presenters.SuppliersRes": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/presenters.SupplierRes"
+                    }
+                }
+            }
+        },
+        "presenters.TransferFruitReq": {
+            "type": "object",
+            "properties": {
+                "from_bucket_id": {
+                    "type": "integer",
+                    "example": 1
                 },
-                "id": {
+                "to_bucket_id": {
+                    "type": "integer",
+                    "example": 2
+                }
+            }
+        },
+        "presenters.UpdateSupplierReq": {
+            "type": "object",
+            "properties": {
+                "contact": {
+                    "type": "string",
+                    "example": "contato@boavista.com.br"
+                },
+                "country": {
+                    "type": "string",
+                    "example": "BR"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Fazenda Boa Vista"
+                }
+            }
+        },
+        "presenters.ValuationReportRes": {
+            "type": "object",
+            "properties": {
+                "at": {
+                    "type": "string",
+                    "example": "2000-12-31 23:59:59"
+                },
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/presenters.ValuationRes"
+                    }
+                },
+                "total_quantity": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "total_value": {
+                    "type": "number",
+                    "example": 3.98
+                }
+            }
+        },
+        "presenters.ValuationRes": {
+            "type": "object",
+            "properties": {
+                "bucket_id": {
                     "type": "integer",
                     "example": 1
                 },
+                "bucket_name": {
+                    "type": "string",
+                    "example": "A"
+                },
                 "name": {
                     "type": "string",
                     "example": "Orange"
                 },
-                "price": {
+                "quantity": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "value": {
                     "type": "number",
-                    "example": 1.99
+                    "example": 3.98
                 }
             }
         },
-        "presenters.HealthCheckRes": {
+        "presenters.WasteReportRes": {
             "type": "object",
             "properties": {
-                "status": {
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/presenters.HealthCheckStatus"
-                        }
-                    ],
-                    "example": "down"
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/presenters.WasteRes"
+                    }
+                },
+                "from": {
+                    "type": "string",
+                    "example": "2000-12-01"
+                },
+                "to": {
+                    "type": "string",
+                    "example": "2000-12-31"
+                },
+                "total_received_fruits": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "total_waste_rate": {
+                    "type": "number",
+                    "example": 0.3
+                },
+                "total_wasted_fruits": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "total_wasted_price": {
+                    "type": "number",
+                    "example": 5.97
                 }
             }
         },
-        "presenters.HealthCheckStatus": {
-            "type": "string",
-            "enum": [
-                "up",
-                "down"
-            ],
-            "x-enum-varnames": [
-                "HealthCheckStatusUp",
-                "HealthCheckStatusDown"
-            ]
+        "presenters.WasteRes": {
+            "type": "object",
+            "properties": {
+                "bucket_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "discarded_fruits": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "discarded_price": {
+                    "type": "number",
+                    "example": 1.99
+                },
+                "expired_fruits": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "expired_price": {
+                    "type": "number",
+                    "example": 3.98
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Orange"
+                },
+                "received_fruits": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "supplier_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "waste_rate": {
+                    "type": "number",
+                    "example": 0.3
+                },
+                "wasted_fruits": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "wasted_price": {
+                    "type": "number",
+                    "example": 5.97
+                }
+            }
         }
     }
 }`
@@ -38,8 +38,9 @@ func (impl *BucketController) Create(ctx *gin.Context) {
 	ctx.BindJSON(&req)
 
 	data := dtos.CreateBucketDto{
-		Name:     req.Name,
-		Capacity: req.Capacity,
+		Name:       req.Name,
+		Capacity:   req.Capacity,
+		Quarantine: req.Quarantine,
 	}
 
 	res, err := impl.service.Create(ctx, data)
@@ -64,7 +65,7 @@ func (impl *BucketController) Create(ctx *gin.Context) {
 // @Produce json
 // @Param page query int false "page" default(1)
 // @Param pageSize query int false "pageSize" default(10)
-// @Success 200 {object} presenters.BucketsRes
+// @Success 200 {object} presenters.BucketsFruitsRes
 // @Failure 500 {object} presenters.ErrorRes
 // @Router /v1/buckets [get]
 func (impl *BucketController) List(ctx *gin.Context) {
@@ -124,12 +125,96 @@ func (impl *BucketController) Delete(ctx *gin.Context) {
 	ctx.Status(http.StatusOK)
 }
 
+// Bucket godoc
+// @Summary consolidate partially full buckets
+// @Description Plans moving fruits so that as many buckets as possible end up empty. It is a dry run unless apply is true
+// @Schemes
+// @Tags bucket
+// @Accept json
+// @Produce json
+// @Param apply query bool false "apply the moves" default(false)
+// @Success 200 {object} presenters.BucketConsolidationRes
+// @Failure 400 {object} presenters.ErrorRes
+// @Failure 500 {object} presenters.ErrorRes
+// @Router /v1/buckets/consolidation [post]
+func (impl *BucketController) Consolidate(ctx *gin.Context) {
+	apply := false
+	if v := ctx.Query("apply"); v != "" {
+		var err error
+		apply, err = strconv.ParseBool(v)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, presenters.ErrorRes{Error: exceptions.ValidationExceptionName, Message: "invalid apply"})
+			return
+		}
+	}
+
+	res, err := impl.service.Consolidate(ctx, apply)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, presenters.ErrorRes{Error: http.StatusText(http.StatusInternalServerError)})
+		return
+	}
+
+	resp := presenters.BucketConsolidationRes{
+		Applied:          res.Applied,
+		Moves:            []presenters.BucketConsolidationMoveRes{},
+		EmptiedBucketIDs: res.EmptiedBucketIDs,
+	}
+	for _, move := range res.Moves {
+		resp.Moves = append(resp.Moves, presenters.BucketConsolidationMoveRes{
+			FruitID:      move.FruitID,
+			FromBucketID: move.FromBucketID,
+			ToBucketID:   move.ToBucketID,
+		})
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// Bucket godoc
+// @Summary list buckets violating the storage compatibility rules
+// @Schemes
+// @Tags bucket
+// @Accept json
+// @Produce json
+// @Success 200 {object} presenters.BucketsViolationsRes
+// @Failure 500 {object} presenters.ErrorRes
+// @Router /v1/reports/storage-violations [get]
+func (impl *BucketController) ListViolations(ctx *gin.Context) {
+	res, err := impl.service.ListViolations(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, presenters.ErrorRes{Error: http.StatusText(http.StatusInternalServerError)})
+		return
+	}
+
+	resp := presenters.BucketsViolationsRes{Data: []presenters.BucketViolationsRes{}}
+	for _, bucket := range res {
+		violations := []presenters.CompatibilityViolationRes{}
+		for _, violation := range bucket.Violations {
+			violations = append(violations, presenters.CompatibilityViolationRes{
+				Rule:  violation.Rule,
+				Fruit: violation.Fruit,
+				Other: violation.Other,
+			})
+		}
+
+		resp.Data = append(resp.Data, presenters.BucketViolationsRes{
+			ID:         bucket.ID,
+			Name:       bucket.Name,
+			Violations: violations,
+		})
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
 func (impl *BucketController) parseModel(bucket *models.Bucket) presenters.BucketRes {
 	return presenters.BucketRes{
 		ID:        bucket.ID,
 		CreatedAt: bucket.CreatedAt.Format(time.DateTime),
 		Name:      bucket.Name,
 		Capacity:  bucket.Capacity,
+
+		Quarantine: bucket.Quarantine,
 	}
 }
 
@@ -141,5 +226,14 @@ func (impl *BucketController) parseDTO(bucket *models.BucketFruits) presenters.B
 		TotalFruits: bucket.TotalFruits,
 		TotalPrice:  bucket.TotalPrice,
 		Percent:     bucket.Percent.StringFixed(2) + "%",
+
+		TotalEffectivePrice: bucket.TotalEffectivePrice,
+		TotalReserved:       bucket.TotalReserved,
+
+		States: presenters.BucketFruitsStatesRes{
+			Unripe:   bucket.TotalUnripe,
+			Ripe:     bucket.TotalRipe,
+			Overripe: bucket.TotalOverripe,
+		},
 	}
 }
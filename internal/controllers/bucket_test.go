@@ -140,6 +140,11 @@ func TestBucketController_List(t *testing.T) {
 						TotalFruits: 1,
 						TotalPrice:  decimal.NewFromFloat32(4.55),
 						Percent:     decimal.NewFromInt32(100),
+
+						TotalEffectivePrice: decimal.NewFromFloat32(3.64),
+						TotalReserved:       1,
+
+						TotalRipe: 1,
 					},
 				}, nil)
 			},
@@ -155,6 +160,10 @@ func TestBucketController_List(t *testing.T) {
 						TotalFruits: 1,
 						TotalPrice:  decimal.NewFromFloat32(4.55),
 						Percent:     "100.00%",
+						States:      presenters.BucketFruitsStatesRes{Ripe: 1},
+
+						TotalEffectivePrice: decimal.NewFromFloat32(3.64),
+						TotalReserved:       1,
 					},
 				},
 			},
@@ -285,3 +294,182 @@ func TestBucketController_Delete(t *testing.T) {
 		})
 	}
 }
+
+func TestBucketController_Consolidate(t *testing.T) {
+	tests := map[string]struct {
+		mock        func(service *mocks.MockBucketService)
+		query       string
+		wantCode    int
+		wantBody    presenters.BucketConsolidationRes
+		wantBodyErr presenters.ErrorRes
+	}{
+		"should be success as a dry run by default": {
+			mock: func(service *mocks.MockBucketService) {
+				service.EXPECT().Consolidate(gomock.Any(), false).Return(&models.BucketConsolidation{
+					Moves:            []models.BucketConsolidationMove{{FruitID: 4, FromBucketID: 2, ToBucketID: 1}},
+					EmptiedBucketIDs: []int64{2},
+				}, nil)
+			},
+			wantCode: http.StatusOK,
+			wantBody: presenters.BucketConsolidationRes{
+				Moves:            []presenters.BucketConsolidationMoveRes{{FruitID: 4, FromBucketID: 2, ToBucketID: 1}},
+				EmptiedBucketIDs: []int64{2},
+			},
+		},
+		"should be success when applying": {
+			mock: func(service *mocks.MockBucketService) {
+				service.EXPECT().Consolidate(gomock.Any(), true).Return(&models.BucketConsolidation{
+					Moves:            []models.BucketConsolidationMove{},
+					EmptiedBucketIDs: []int64{},
+					Applied:          true,
+				}, nil)
+			},
+			query:    "?apply=true",
+			wantCode: http.StatusOK,
+			wantBody: presenters.BucketConsolidationRes{
+				Applied:          true,
+				Moves:            []presenters.BucketConsolidationMoveRes{},
+				EmptiedBucketIDs: []int64{},
+			},
+		},
+		"should throw validation exception when apply is invalid": {
+			mock:     func(service *mocks.MockBucketService) {},
+			query:    "?apply=maybe",
+			wantCode: http.StatusBadRequest,
+			wantBodyErr: presenters.ErrorRes{
+				Error:   exceptions.ValidationExceptionName,
+				Message: "invalid apply",
+			},
+		},
+		"should throw internal server error": {
+			mock: func(service *mocks.MockBucketService) {
+				service.EXPECT().Consolidate(gomock.Any(), gomock.Any()).Return(nil, fmt.Errorf("error"))
+			},
+			wantCode:    http.StatusInternalServerError,
+			wantBodyErr: presenters.ErrorRes{Error: http.StatusText(http.StatusInternalServerError)},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			// setup
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			serviceMock := mocks.NewMockBucketService(ctrl)
+			tt.mock(serviceMock)
+
+			r := gin.Default()
+			controller := NewBucket(serviceMock)
+
+			r.POST("/api/v1/buckets/consolidation", controller.Consolidate)
+
+			var gotErr presenters.ErrorRes
+			var got presenters.BucketConsolidationRes
+
+			// given
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("POST", "/api/v1/buckets/consolidation"+tt.query, nil)
+
+			// when
+			r.ServeHTTP(w, req)
+
+			json.Unmarshal(w.Body.Bytes(), &gotErr)
+			json.Unmarshal(w.Body.Bytes(), &got)
+
+			// then
+			assert.Equal(t, tt.wantCode, w.Code)
+
+			if gotErr.Error != "" {
+				assert.Equal(t, tt.wantBodyErr, gotErr)
+				return
+			}
+
+			assert.Equal(t, tt.wantBody, got)
+		})
+	}
+}
+
+func TestBucketController_ListViolations(t *testing.T) {
+	tests := map[string]struct {
+		mock        func(service *mocks.MockBucketService)
+		wantCode    int
+		wantBody    presenters.BucketsViolationsRes
+		wantBodyErr presenters.ErrorRes
+	}{
+		"should be success": {
+			mock: func(service *mocks.MockBucketService) {
+				service.EXPECT().ListViolations(gomock.Any()).Return([]models.BucketViolations{
+					{
+						ID:   1,
+						Name: "A",
+						Violations: []models.CompatibilityViolation{
+							{Rule: "ethylene_producers/ethylene_sensitive", Fruit: "apple", Other: "kiwi"},
+						},
+					},
+				}, nil)
+			},
+			wantCode: http.StatusOK,
+			wantBody: presenters.BucketsViolationsRes{
+				Data: []presenters.BucketViolationsRes{
+					{
+						ID:   1,
+						Name: "A",
+						Violations: []presenters.CompatibilityViolationRes{
+							{Rule: "ethylene_producers/ethylene_sensitive", Fruit: "apple", Other: "kiwi"},
+						},
+					},
+				},
+			},
+		},
+		"should be success when there are no violations": {
+			mock: func(service *mocks.MockBucketService) {
+				service.EXPECT().ListViolations(gomock.Any()).Return([]models.BucketViolations{}, nil)
+			},
+			wantCode: http.StatusOK,
+			wantBody: presenters.BucketsViolationsRes{Data: []presenters.BucketViolationsRes{}},
+		},
+		"should throw internal server error": {
+			mock: func(service *mocks.MockBucketService) {
+				service.EXPECT().ListViolations(gomock.Any()).Return(nil, fmt.Errorf("error"))
+			},
+			wantCode:    http.StatusInternalServerError,
+			wantBodyErr: presenters.ErrorRes{Error: http.StatusText(http.StatusInternalServerError)},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			// setup
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			serviceMock := mocks.NewMockBucketService(ctrl)
+			tt.mock(serviceMock)
+
+			r := gin.Default()
+			controller := NewBucket(serviceMock)
+
+			r.GET("/api/v1/reports/storage-violations", controller.ListViolations)
+
+			var gotErr presenters.ErrorRes
+			var got presenters.BucketsViolationsRes
+
+			// given
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("GET", "/api/v1/reports/storage-violations", nil)
+
+			// when
+			r.ServeHTTP(w, req)
+
+			json.Unmarshal(w.Body.Bytes(), &gotErr)
+			json.Unmarshal(w.Body.Bytes(), &got)
+
+			// then
+			assert.Equal(t, tt.wantCode, w.Code)
+
+			if gotErr.Error != "" {
+				assert.Equal(t, tt.wantBodyErr, gotErr)
+				return
+			}
+
+			assert.Equal(t, tt.wantBody, got)
+		})
+	}
+}
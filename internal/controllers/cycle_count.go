@@ -0,0 +1,242 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/viniosilva/where-are-my-fruits/internal/controllers/presenters"
+	"github.com/viniosilva/where-are-my-fruits/internal/dtos"
+	"github.com/viniosilva/where-are-my-fruits/internal/exceptions"
+	"github.com/viniosilva/where-are-my-fruits/internal/models"
+)
+
+type CycleCountController struct {
+	service CycleCountService
+}
+
+func NewCycleCount(service CycleCountService) *CycleCountController {
+	return &CycleCountController{
+		service: service,
+	}
+}
+
+// CycleCount godoc
+// @Summary open a cycle count of a bucket
+// @Schemes
+// @Tags cycle-count
+// @Accept json
+// @Produce json
+// @Param bucketID path int64 true "Bucket ID"
+// @Success 201 {object} presenters.CycleCountRes
+// @Failure 400 {object} presenters.ErrorRes
+// @Failure 404 {object} presenters.ErrorRes
+// @Failure 409 {object} presenters.ErrorRes
+// @Failure 500 {object} presenters.ErrorRes
+// @Router /v1/buckets/{bucketID}/cycle-counts [post]
+func (impl *CycleCountController) Open(ctx *gin.Context) {
+	bucketID, err := strconv.ParseInt(ctx.Param("bucketID"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, presenters.ErrorRes{Error: exceptions.ValidationExceptionName, Message: "invalid bucketID"})
+		return
+	}
+
+	res, err := impl.service.Open(ctx, bucketID)
+	if err != nil {
+		if e, ok := err.(*exceptions.NotFoundException); ok {
+			ctx.JSON(http.StatusNotFound, presenters.ErrorRes{Error: e.Name, Message: e.Error()})
+			return
+		}
+		if e, ok := err.(*exceptions.ConflictException); ok {
+			ctx.JSON(http.StatusConflict, presenters.ErrorRes{Error: e.Name, Message: e.Error()})
+			return
+		}
+
+		ctx.JSON(http.StatusInternalServerError, presenters.ErrorRes{Error: http.StatusText(http.StatusInternalServerError)})
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, impl.parse(res))
+}
+
+// CycleCount godoc
+// @Summary get cycle count, with its variance while it waits for approval
+// @Schemes
+// @Tags cycle-count
+// @Accept json
+// @Produce json
+// @Param cycleCountID path int64 true "Cycle count ID"
+// @Success 200 {object} presenters.CycleCountRes
+// @Failure 400 {object} presenters.ErrorRes
+// @Failure 404 {object} presenters.ErrorRes
+// @Failure 500 {object} presenters.ErrorRes
+// @Router /v1/cycle-counts/{cycleCountID} [get]
+func (impl *CycleCountController) Get(ctx *gin.Context) {
+	cycleCountID, err := strconv.ParseInt(ctx.Param("cycleCountID"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, presenters.ErrorRes{Error: exceptions.ValidationExceptionName, Message: "invalid cycleCountID"})
+		return
+	}
+
+	res, err := impl.service.Get(ctx, cycleCountID)
+	if err != nil {
+		if e, ok := err.(*exceptions.NotFoundException); ok {
+			ctx.JSON(http.StatusNotFound, presenters.ErrorRes{Error: e.Name, Message: e.Error()})
+			return
+		}
+
+		ctx.JSON(http.StatusInternalServerError, presenters.ErrorRes{Error: http.StatusText(http.StatusInternalServerError)})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, impl.parse(res))
+}
+
+// CycleCount godoc
+// @Summary submit the fruit ids, or the quantities by name, found in the bucket
+// @Schemes
+// @Tags cycle-count
+// @Accept json
+// @Produce json
+// @Param cycleCountID path int64 true "Cycle count ID"
+// @Param count body presenters.SubmitCycleCountReq true "Count"
+// @Success 200 {object} presenters.CycleCountRes
+// @Failure 400 {object} presenters.ErrorRes
+// @Failure 404 {object} presenters.ErrorRes
+// @Failure 500 {object} presenters.ErrorRes
+// @Router /v1/cycle-counts/{cycleCountID}/submit [post]
+func (impl *CycleCountController) Submit(ctx *gin.Context) {
+	cycleCountID, err := strconv.ParseInt(ctx.Param("cycleCountID"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, presenters.ErrorRes{Error: exceptions.ValidationExceptionName, Message: "invalid cycleCountID"})
+		return
+	}
+
+	var req presenters.SubmitCycleCountReq
+	ctx.BindJSON(&req)
+
+	data := dtos.SubmitCycleCountDto{FruitIDs: req.FruitIDs}
+	if req.Items != nil {
+		data.Items = []dtos.CycleCountItemDto{}
+		for _, item := range req.Items {
+			data.Items = append(data.Items, dtos.CycleCountItemDto{Name: item.Name, Quantity: item.Quantity})
+		}
+	}
+
+	res, err := impl.service.Submit(ctx, cycleCountID, data)
+	if err != nil {
+		if e, ok := err.(*exceptions.ValidationException); ok {
+			ctx.JSON(http.StatusBadRequest, presenters.ErrorRes{Error: e.Name, Messages: e.Errors})
+			return
+		}
+		if e, ok := err.(*exceptions.NotFoundException); ok {
+			ctx.JSON(http.StatusNotFound, presenters.ErrorRes{Error: e.Name, Message: e.Error()})
+			return
+		}
+		if e, ok := err.(*exceptions.ForeignNotFoundException); ok {
+			ctx.JSON(http.StatusBadRequest, presenters.ErrorRes{Error: e.Name, Message: e.Error()})
+			return
+		}
+		if e, ok := err.(*exceptions.ForbiddenException); ok {
+			ctx.JSON(http.StatusBadRequest, presenters.ErrorRes{Error: e.Name, Message: e.Error()})
+			return
+		}
+
+		ctx.JSON(http.StatusInternalServerError, presenters.ErrorRes{Error: http.StatusText(http.StatusInternalServerError)})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, impl.parse(res))
+}
+
+// CycleCount godoc
+// @Summary approve cycle count, unassigning missing fruits and flagging unknown ones
+// @Schemes
+// @Tags cycle-count
+// @Accept json
+// @Produce json
+// @Param cycleCountID path int64 true "Cycle count ID"
+// @Success 200 {object} presenters.CycleCountRes
+// @Failure 400 {object} presenters.ErrorRes
+// @Failure 404 {object} presenters.ErrorRes
+// @Failure 500 {object} presenters.ErrorRes
+// @Router /v1/cycle-counts/{cycleCountID}/approve [post]
+func (impl *CycleCountController) Approve(ctx *gin.Context) {
+	cycleCountID, err := strconv.ParseInt(ctx.Param("cycleCountID"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, presenters.ErrorRes{Error: exceptions.ValidationExceptionName, Message: "invalid cycleCountID"})
+		return
+	}
+
+	res, err := impl.service.Approve(ctx, cycleCountID)
+	if err != nil {
+		if e, ok := err.(*exceptions.NotFoundException); ok {
+			ctx.JSON(http.StatusNotFound, presenters.ErrorRes{Error: e.Name, Message: e.Error()})
+			return
+		}
+		if e, ok := err.(*exceptions.ForbiddenException); ok {
+			ctx.JSON(http.StatusBadRequest, presenters.ErrorRes{Error: e.Name, Message: e.Error()})
+			return
+		}
+
+		ctx.JSON(http.StatusInternalServerError, presenters.ErrorRes{Error: http.StatusText(http.StatusInternalServerError)})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, impl.parse(res))
+}
+
+func (impl *CycleCountController) parse(count *models.CycleCount) presenters.CycleCountRes {
+	res := presenters.CycleCountRes{
+		ID:          count.ID,
+		CreatedAt:   count.CreatedAt.Format(time.DateTime),
+		BucketID:    count.BucketID,
+		Status:      count.Status(),
+		Items:       []presenters.CycleCountItemRes{},
+		Adjustments: []presenters.CycleCountAdjustmentRes{},
+	}
+	if count.SubmittedAt != nil {
+		res.SubmittedAt = count.SubmittedAt.Format(time.DateTime)
+	}
+	if count.ApprovedAt != nil {
+		res.ApprovedAt = count.ApprovedAt.Format(time.DateTime)
+	}
+
+	for _, item := range count.Items {
+		res.Items = append(res.Items, presenters.CycleCountItemRes{
+			FruitID:  item.FruitID,
+			Name:     item.Name,
+			Quantity: item.Quantity,
+		})
+	}
+
+	for _, adjustment := range count.Adjustments {
+		res.Adjustments = append(res.Adjustments, presenters.CycleCountAdjustmentRes{
+			ID:        adjustment.ID,
+			CreatedAt: adjustment.CreatedAt.Format(time.DateTime),
+			FruitID:   adjustment.FruitID,
+			Name:      adjustment.Name,
+			Quantity:  adjustment.Quantity,
+			Action:    adjustment.Action,
+		})
+	}
+
+	if count.Variance != nil {
+		res.Variance = &presenters.CycleCountVarianceRes{
+			Names:           []presenters.CycleCountNameVarianceRes{},
+			MissingFruitIDs: count.Variance.MissingFruitIDs,
+			UnknownFruitIDs: count.Variance.UnknownFruitIDs,
+		}
+		for _, name := range count.Variance.Names {
+			res.Variance.Names = append(res.Variance.Names, presenters.CycleCountNameVarianceRes{
+				Name:       name.Name,
+				Expected:   name.Expected,
+				Counted:    name.Counted,
+				Difference: name.Difference(),
+			})
+		}
+	}
+
+	return res
+}
@@ -0,0 +1,507 @@
+package controllers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/viniosilva/where-are-my-fruits/internal/controllers/presenters"
+	"github.com/viniosilva/where-are-my-fruits/internal/dtos"
+	"github.com/viniosilva/where-are-my-fruits/internal/exceptions"
+	"github.com/viniosilva/where-are-my-fruits/internal/models"
+	"github.com/viniosilva/where-are-my-fruits/mocks"
+)
+
+func TestCycleCountController_Open(t *testing.T) {
+	now := time.Date(2000, 12, 31, 23, 59, 59, 0, time.Local)
+
+	tests := map[string]struct {
+		mock        func(service *mocks.MockCycleCountService)
+		bucketID    string
+		wantCode    int
+		wantBody    presenters.CycleCountRes
+		wantBodyErr presenters.ErrorRes
+	}{
+		"should be success": {
+			mock: func(service *mocks.MockCycleCountService) {
+				service.EXPECT().Open(gomock.Any(), int64(1)).Return(&models.CycleCount{
+					ID: 1, CreatedAt: now, BucketID: 1,
+				}, nil)
+			},
+			bucketID: "1",
+			wantCode: http.StatusCreated,
+			wantBody: presenters.CycleCountRes{
+				ID:          1,
+				CreatedAt:   "2000-12-31 23:59:59",
+				BucketID:    1,
+				Status:      models.CycleCountStatusOpen,
+				Items:       []presenters.CycleCountItemRes{},
+				Adjustments: []presenters.CycleCountAdjustmentRes{},
+			},
+		},
+		"should throw validation exception when bucketID is invalid": {
+			mock:     func(service *mocks.MockCycleCountService) {},
+			bucketID: "a",
+			wantCode: http.StatusBadRequest,
+			wantBodyErr: presenters.ErrorRes{
+				Error:   exceptions.ValidationExceptionName,
+				Message: "invalid bucketID",
+			},
+		},
+		"should throw not found": {
+			mock: func(service *mocks.MockCycleCountService) {
+				service.EXPECT().Open(gomock.Any(), int64(1)).Return(nil, exceptions.NewNotFoundException("Bucket not found"))
+			},
+			bucketID: "1",
+			wantCode: http.StatusNotFound,
+			wantBodyErr: presenters.ErrorRes{
+				Error:   exceptions.NotFoundExceptionName,
+				Message: "Bucket not found",
+			},
+		},
+		"should throw conflict when bucket has a count in progress": {
+			mock: func(service *mocks.MockCycleCountService) {
+				service.EXPECT().Open(gomock.Any(), int64(1)).Return(nil, exceptions.NewConflictException("Bucket 1 already has a cycle count in progress"))
+			},
+			bucketID: "1",
+			wantCode: http.StatusConflict,
+			wantBodyErr: presenters.ErrorRes{
+				Error:   exceptions.ConflictExceptionName,
+				Message: "Bucket 1 already has a cycle count in progress",
+			},
+		},
+		"should throw internal server error": {
+			mock: func(service *mocks.MockCycleCountService) {
+				service.EXPECT().Open(gomock.Any(), int64(1)).Return(nil, fmt.Errorf("error"))
+			},
+			bucketID:    "1",
+			wantCode:    http.StatusInternalServerError,
+			wantBodyErr: presenters.ErrorRes{Error: http.StatusText(http.StatusInternalServerError)},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			// setup
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			serviceMock := mocks.NewMockCycleCountService(ctrl)
+			tt.mock(serviceMock)
+
+			r := gin.Default()
+			controller := NewCycleCount(serviceMock)
+
+			r.POST("/api/v1/buckets/:bucketID/cycle-counts", controller.Open)
+
+			var got presenters.CycleCountRes
+			var gotErr presenters.ErrorRes
+
+			// given
+			path := fmt.Sprintf("/api/v1/buckets/%s/cycle-counts", tt.bucketID)
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("POST", path, nil)
+
+			// when
+			r.ServeHTTP(w, req)
+
+			json.Unmarshal(w.Body.Bytes(), &gotErr)
+			json.Unmarshal(w.Body.Bytes(), &got)
+
+			// then
+			assert.Equal(t, tt.wantCode, w.Code)
+
+			if gotErr.Error != "" {
+				assert.Equal(t, tt.wantBodyErr, gotErr)
+				return
+			}
+			assert.Equal(t, tt.wantBody, got)
+		})
+	}
+}
+
+func TestCycleCountController_Get(t *testing.T) {
+	now := time.Date(2000, 12, 31, 23, 59, 59, 0, time.Local)
+	fruitID := int64(1)
+
+	tests := map[string]struct {
+		mock         func(service *mocks.MockCycleCountService)
+		cycleCountID string
+		wantCode     int
+		wantBody     presenters.CycleCountRes
+		wantBodyErr  presenters.ErrorRes
+	}{
+		"should be success": {
+			mock: func(service *mocks.MockCycleCountService) {
+				service.EXPECT().Get(gomock.Any(), int64(1)).Return(&models.CycleCount{
+					ID:          1,
+					CreatedAt:   now,
+					SubmittedAt: &now,
+					BucketID:    1,
+					Items:       []models.CycleCountItem{{ID: 1, CycleCountID: 1, FruitID: &fruitID, Name: "Orange", Quantity: 1}},
+					Adjustments: []models.CycleCountAdjustment{},
+					Variance: &models.CycleCountVariance{
+						Names:           []models.CycleCountNameVariance{{Name: "Orange", Expected: 2, Counted: 1}},
+						MissingFruitIDs: []int64{2},
+						UnknownFruitIDs: []int64{},
+					},
+				}, nil)
+			},
+			cycleCountID: "1",
+			wantCode:     http.StatusOK,
+			wantBody: presenters.CycleCountRes{
+				ID:          1,
+				CreatedAt:   "2000-12-31 23:59:59",
+				SubmittedAt: "2000-12-31 23:59:59",
+				BucketID:    1,
+				Status:      models.CycleCountStatusSubmitted,
+				Items:       []presenters.CycleCountItemRes{{FruitID: &fruitID, Name: "Orange", Quantity: 1}},
+				Variance: &presenters.CycleCountVarianceRes{
+					Names:           []presenters.CycleCountNameVarianceRes{{Name: "Orange", Expected: 2, Counted: 1, Difference: -1}},
+					MissingFruitIDs: []int64{2},
+					UnknownFruitIDs: []int64{},
+				},
+				Adjustments: []presenters.CycleCountAdjustmentRes{},
+			},
+		},
+		"should throw validation exception when cycleCountID is invalid": {
+			mock:         func(service *mocks.MockCycleCountService) {},
+			cycleCountID: "a",
+			wantCode:     http.StatusBadRequest,
+			wantBodyErr: presenters.ErrorRes{
+				Error:   exceptions.ValidationExceptionName,
+				Message: "invalid cycleCountID",
+			},
+		},
+		"should throw not found": {
+			mock: func(service *mocks.MockCycleCountService) {
+				service.EXPECT().Get(gomock.Any(), int64(1)).Return(nil, exceptions.NewNotFoundException("Cycle count not found"))
+			},
+			cycleCountID: "1",
+			wantCode:     http.StatusNotFound,
+			wantBodyErr: presenters.ErrorRes{
+				Error:   exceptions.NotFoundExceptionName,
+				Message: "Cycle count not found",
+			},
+		},
+		"should throw internal server error": {
+			mock: func(service *mocks.MockCycleCountService) {
+				service.EXPECT().Get(gomock.Any(), int64(1)).Return(nil, fmt.Errorf("error"))
+			},
+			cycleCountID: "1",
+			wantCode:     http.StatusInternalServerError,
+			wantBodyErr:  presenters.ErrorRes{Error: http.StatusText(http.StatusInternalServerError)},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			// setup
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			serviceMock := mocks.NewMockCycleCountService(ctrl)
+			tt.mock(serviceMock)
+
+			r := gin.Default()
+			controller := NewCycleCount(serviceMock)
+
+			r.GET("/api/v1/cycle-counts/:cycleCountID", controller.Get)
+
+			var got presenters.CycleCountRes
+			var gotErr presenters.ErrorRes
+
+			// given
+			path := fmt.Sprintf("/api/v1/cycle-counts/%s", tt.cycleCountID)
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("GET", path, nil)
+
+			// when
+			r.ServeHTTP(w, req)
+
+			json.Unmarshal(w.Body.Bytes(), &gotErr)
+			json.Unmarshal(w.Body.Bytes(), &got)
+
+			// then
+			assert.Equal(t, tt.wantCode, w.Code)
+
+			if gotErr.Error != "" {
+				assert.Equal(t, tt.wantBodyErr, gotErr)
+				return
+			}
+			assert.Equal(t, tt.wantBody, got)
+		})
+	}
+}
+
+func TestCycleCountController_Submit(t *testing.T) {
+	now := time.Date(2000, 12, 31, 23, 59, 59, 0, time.Local)
+
+	tests := map[string]struct {
+		mock         func(service *mocks.MockCycleCountService)
+		cycleCountID string
+		body         presenters.SubmitCycleCountReq
+		wantCode     int
+		wantBody     presenters.CycleCountRes
+		wantBodyErr  presenters.ErrorRes
+	}{
+		"should be success": {
+			mock: func(service *mocks.MockCycleCountService) {
+				data := dtos.SubmitCycleCountDto{Items: []dtos.CycleCountItemDto{{Name: "Orange", Quantity: 3}}}
+				service.EXPECT().Submit(gomock.Any(), int64(1), data).Return(&models.CycleCount{
+					ID:          1,
+					CreatedAt:   now,
+					SubmittedAt: &now,
+					BucketID:    1,
+					Items:       []models.CycleCountItem{{ID: 1, CycleCountID: 1, Name: "Orange", Quantity: 3}},
+					Variance: &models.CycleCountVariance{
+						Names:           []models.CycleCountNameVariance{{Name: "Orange", Expected: 1, Counted: 3}},
+						MissingFruitIDs: []int64{},
+						UnknownFruitIDs: []int64{},
+					},
+				}, nil)
+			},
+			cycleCountID: "1",
+			body:         presenters.SubmitCycleCountReq{Items: []presenters.CycleCountItemReq{{Name: "Orange", Quantity: 3}}},
+			wantCode:     http.StatusOK,
+			wantBody: presenters.CycleCountRes{
+				ID:          1,
+				CreatedAt:   "2000-12-31 23:59:59",
+				SubmittedAt: "2000-12-31 23:59:59",
+				BucketID:    1,
+				Status:      models.CycleCountStatusSubmitted,
+				Items:       []presenters.CycleCountItemRes{{Name: "Orange", Quantity: 3}},
+				Variance: &presenters.CycleCountVarianceRes{
+					Names:           []presenters.CycleCountNameVarianceRes{{Name: "Orange", Expected: 1, Counted: 3, Difference: 2}},
+					MissingFruitIDs: []int64{},
+					UnknownFruitIDs: []int64{},
+				},
+				Adjustments: []presenters.CycleCountAdjustmentRes{},
+			},
+		},
+		"should throw validation exception when cycleCountID is invalid": {
+			mock:         func(service *mocks.MockCycleCountService) {},
+			cycleCountID: "a",
+			wantCode:     http.StatusBadRequest,
+			wantBodyErr: presenters.ErrorRes{
+				Error:   exceptions.ValidationExceptionName,
+				Message: "invalid cycleCountID",
+			},
+		},
+		"should throw validation exception": {
+			mock: func(service *mocks.MockCycleCountService) {
+				service.EXPECT().Submit(gomock.Any(), int64(1), gomock.Any()).Return(nil, exceptions.NewValidationException(validator.ValidationErrors{
+					&mocks.FieldError{Itag: "error 1", Ins: "error 1"},
+				}))
+			},
+			cycleCountID: "1",
+			wantCode:     http.StatusBadRequest,
+			wantBodyErr: presenters.ErrorRes{
+				Error:    exceptions.ValidationExceptionName,
+				Messages: []string{"Key: 'error 1' Error:Field validation for '' failed on the 'error 1' tag"},
+			},
+		},
+		"should throw not found": {
+			mock: func(service *mocks.MockCycleCountService) {
+				service.EXPECT().Submit(gomock.Any(), int64(1), gomock.Any()).Return(nil, exceptions.NewNotFoundException("Cycle count not found"))
+			},
+			cycleCountID: "1",
+			wantCode:     http.StatusNotFound,
+			wantBodyErr: presenters.ErrorRes{
+				Error:   exceptions.NotFoundExceptionName,
+				Message: "Cycle count not found",
+			},
+		},
+		"should throw foreign not found when counted fruits are unknown": {
+			mock: func(service *mocks.MockCycleCountService) {
+				service.EXPECT().Submit(gomock.Any(), int64(1), gomock.Any()).Return(nil, exceptions.NewForeignNotFoundException("Fruits not found: 2"))
+			},
+			cycleCountID: "1",
+			wantCode:     http.StatusBadRequest,
+			wantBodyErr: presenters.ErrorRes{
+				Error:   exceptions.ForeignNotFoundExceptionName,
+				Message: "Fruits not found: 2",
+			},
+		},
+		"should throw forbidden when cycle count is submitted": {
+			mock: func(service *mocks.MockCycleCountService) {
+				service.EXPECT().Submit(gomock.Any(), int64(1), gomock.Any()).Return(nil, exceptions.NewForbiddenException("Cycle count is submitted"))
+			},
+			cycleCountID: "1",
+			wantCode:     http.StatusBadRequest,
+			wantBodyErr: presenters.ErrorRes{
+				Error:   exceptions.ForbiddenExceptionName,
+				Message: "Cycle count is submitted",
+			},
+		},
+		"should throw internal server error": {
+			mock: func(service *mocks.MockCycleCountService) {
+				service.EXPECT().Submit(gomock.Any(), int64(1), gomock.Any()).Return(nil, fmt.Errorf("error"))
+			},
+			cycleCountID: "1",
+			wantCode:     http.StatusInternalServerError,
+			wantBodyErr:  presenters.ErrorRes{Error: http.StatusText(http.StatusInternalServerError)},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			// setup
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			serviceMock := mocks.NewMockCycleCountService(ctrl)
+			tt.mock(serviceMock)
+
+			r := gin.Default()
+			controller := NewCycleCount(serviceMock)
+
+			r.POST("/api/v1/cycle-counts/:cycleCountID/submit", controller.Submit)
+
+			var got presenters.CycleCountRes
+			var gotErr presenters.ErrorRes
+
+			// given
+			path := fmt.Sprintf("/api/v1/cycle-counts/%s/submit", tt.cycleCountID)
+			body, _ := json.Marshal(tt.body)
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("POST", path, bytes.NewReader(body))
+
+			// when
+			r.ServeHTTP(w, req)
+
+			json.Unmarshal(w.Body.Bytes(), &gotErr)
+			json.Unmarshal(w.Body.Bytes(), &got)
+
+			// then
+			assert.Equal(t, tt.wantCode, w.Code)
+
+			if gotErr.Error != "" {
+				assert.Equal(t, tt.wantBodyErr, gotErr)
+				return
+			}
+			assert.Equal(t, tt.wantBody, got)
+		})
+	}
+}
+
+func TestCycleCountController_Approve(t *testing.T) {
+	now := time.Date(2000, 12, 31, 23, 59, 59, 0, time.Local)
+	fruitID := int64(2)
+
+	tests := map[string]struct {
+		mock         func(service *mocks.MockCycleCountService)
+		cycleCountID string
+		wantCode     int
+		wantBody     presenters.CycleCountRes
+		wantBodyErr  presenters.ErrorRes
+	}{
+		"should be success": {
+			mock: func(service *mocks.MockCycleCountService) {
+				service.EXPECT().Approve(gomock.Any(), int64(1)).Return(&models.CycleCount{
+					ID:          1,
+					CreatedAt:   now,
+					SubmittedAt: &now,
+					ApprovedAt:  &now,
+					BucketID:    1,
+					Items:       []models.CycleCountItem{{ID: 1, CycleCountID: 1, Name: "Orange", Quantity: 1}},
+					Adjustments: []models.CycleCountAdjustment{
+						{ID: 1, CreatedAt: now, CycleCountID: 1, FruitID: &fruitID, Name: "Orange", Quantity: 1, Action: models.CycleCountActionUnassigned},
+					},
+				}, nil)
+			},
+			cycleCountID: "1",
+			wantCode:     http.StatusOK,
+			wantBody: presenters.CycleCountRes{
+				ID:          1,
+				CreatedAt:   "2000-12-31 23:59:59",
+				SubmittedAt: "2000-12-31 23:59:59",
+				ApprovedAt:  "2000-12-31 23:59:59",
+				BucketID:    1,
+				Status:      models.CycleCountStatusApproved,
+				Items:       []presenters.CycleCountItemRes{{Name: "Orange", Quantity: 1}},
+				Adjustments: []presenters.CycleCountAdjustmentRes{
+					{ID: 1, CreatedAt: "2000-12-31 23:59:59", FruitID: &fruitID, Name: "Orange", Quantity: 1, Action: "unassigned"},
+				},
+			},
+		},
+		"should throw validation exception when cycleCountID is invalid": {
+			mock:         func(service *mocks.MockCycleCountService) {},
+			cycleCountID: "a",
+			wantCode:     http.StatusBadRequest,
+			wantBodyErr: presenters.ErrorRes{
+				Error:   exceptions.ValidationExceptionName,
+				Message: "invalid cycleCountID",
+			},
+		},
+		"should throw not found": {
+			mock: func(service *mocks.MockCycleCountService) {
+				service.EXPECT().Approve(gomock.Any(), int64(1)).Return(nil, exceptions.NewNotFoundException("Cycle count not found"))
+			},
+			cycleCountID: "1",
+			wantCode:     http.StatusNotFound,
+			wantBodyErr: presenters.ErrorRes{
+				Error:   exceptions.NotFoundExceptionName,
+				Message: "Cycle count not found",
+			},
+		},
+		"should throw forbidden when cycle count is open": {
+			mock: func(service *mocks.MockCycleCountService) {
+				service.EXPECT().Approve(gomock.Any(), int64(1)).Return(nil, exceptions.NewForbiddenException("Cycle count is open"))
+			},
+			cycleCountID: "1",
+			wantCode:     http.StatusBadRequest,
+			wantBodyErr: presenters.ErrorRes{
+				Error:   exceptions.ForbiddenExceptionName,
+				Message: "Cycle count is open",
+			},
+		},
+		"should throw internal server error": {
+			mock: func(service *mocks.MockCycleCountService) {
+				service.EXPECT().Approve(gomock.Any(), int64(1)).Return(nil, fmt.Errorf("error"))
+			},
+			cycleCountID: "1",
+			wantCode:     http.StatusInternalServerError,
+			wantBodyErr:  presenters.ErrorRes{Error: http.StatusText(http.StatusInternalServerError)},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			// setup
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			serviceMock := mocks.NewMockCycleCountService(ctrl)
+			tt.mock(serviceMock)
+
+			r := gin.Default()
+			controller := NewCycleCount(serviceMock)
+
+			r.POST("/api/v1/cycle-counts/:cycleCountID/approve", controller.Approve)
+
+			var got presenters.CycleCountRes
+			var gotErr presenters.ErrorRes
+
+			// given
+			path := fmt.Sprintf("/api/v1/cycle-counts/%s/approve", tt.cycleCountID)
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("POST", path, nil)
+
+			// when
+			r.ServeHTTP(w, req)
+
+			json.Unmarshal(w.Body.Bytes(), &gotErr)
+			json.Unmarshal(w.Body.Bytes(), &got)
+
+			// then
+			assert.Equal(t, tt.wantCode, w.Code)
+
+			if gotErr.Error != "" {
+				assert.Equal(t, tt.wantBodyErr, gotErr)
+				return
+			}
+			assert.Equal(t, tt.wantBody, got)
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package controllers
 
 import (
+	"fmt"
 	"net/http"
 	"strconv"
 	"time"
@@ -37,19 +38,13 @@ func (impl *FruitController) Create(ctx *gin.Context) {
 	var req presenters.CreateFruitReq
 	ctx.BindJSON(&req)
 
-	var expiresIn *time.Duration
-	if v, err := time.ParseDuration(req.ExpiresIn); err == nil {
-		expiresIn = &v
-	}
-
-	data := dtos.CreateFruitDto{
-		Name:      req.Name,
-		Price:     req.Price,
-		ExpiresIn: expiresIn,
-		BucketID:  req.BucketID,
+	data, err := impl.parseCreateReq(req)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, presenters.ErrorRes{Error: exceptions.ValidationExceptionName, Message: err.Error()})
+		return
 	}
 
-	res, err := impl.service.Create(ctx, data)
+	res, err := impl.service.Create(ctx, *data)
 	if err != nil {
 		if e, ok := err.(*exceptions.ValidationException); ok {
 			ctx.JSON(http.StatusBadRequest, presenters.ErrorRes{Error: e.Name, Messages: e.Errors})
@@ -63,6 +58,10 @@ func (impl *FruitController) Create(ctx *gin.Context) {
 			ctx.JSON(http.StatusBadRequest, presenters.ErrorRes{Error: e.Name, Message: e.Error()})
 			return
 		}
+		if e, ok := err.(*exceptions.ConflictException); ok {
+			ctx.JSON(http.StatusConflict, presenters.ErrorRes{Error: e.Name, Message: e.Error()})
+			return
+		}
 
 		ctx.JSON(http.StatusInternalServerError, presenters.ErrorRes{Error: http.StatusText(http.StatusInternalServerError)})
 		return
@@ -72,6 +71,170 @@ func (impl *FruitController) Create(ctx *gin.Context) {
 
 }
 
+// Fruit godoc
+// @Summary create many fruits
+// @Description mode all_or_nothing (default) creates every item or none of them, best_effort creates the valid items
+// @Schemes
+// @Tags fruit
+// @Accept json
+// @Produce json
+// @Param fruits body presenters.CreateFruitsBatchReq true "Fruits"
+// @Success 201 {object} presenters.FruitsBatchRes
+// @Success 207 {object} presenters.FruitsBatchRes
+// @Failure 400 {object} presenters.FruitsBatchRes
+// @Failure 500 {object} presenters.ErrorRes
+// @Router /v1/fruits/batch [post]
+func (impl *FruitController) CreateBatch(ctx *gin.Context) {
+	var req presenters.CreateFruitsBatchReq
+	ctx.BindJSON(&req)
+
+	data := dtos.CreateFruitsBatchDto{
+		Mode:  req.Mode,
+		Items: make([]dtos.CreateFruitDto, 0, len(req.Items)),
+	}
+	if data.Mode == "" {
+		data.Mode = models.FruitBatchModeAllOrNothing
+	}
+
+	for i, item := range req.Items {
+		v, err := impl.parseCreateReq(item)
+		if err == nil && v.Allocation != nil {
+			err = fmt.Errorf("bucket_id auto is not supported in batches")
+		}
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, presenters.ErrorRes{Error: exceptions.ValidationExceptionName, Message: fmt.Sprintf("items[%d]: %s", i, err)})
+			return
+		}
+		data.Items = append(data.Items, *v)
+	}
+
+	res, err := impl.service.CreateBatch(ctx, data)
+	if err != nil {
+		if e, ok := err.(*exceptions.ValidationException); ok {
+			ctx.JSON(http.StatusBadRequest, presenters.ErrorRes{Error: e.Name, Messages: e.Errors})
+			return
+		}
+
+		ctx.JSON(http.StatusInternalServerError, presenters.ErrorRes{Error: http.StatusText(http.StatusInternalServerError)})
+		return
+	}
+
+	resp := presenters.FruitsBatchRes{
+		Data:    make([]presenters.FruitBatchItemRes, 0, len(res.Results)),
+		Created: res.Created,
+		Failed:  res.Failed,
+	}
+	for _, result := range res.Results {
+		item := presenters.FruitBatchItemRes{Index: result.Index}
+		if result.Fruit != nil {
+			item.ID = &result.Fruit.ID
+		}
+
+		switch e := result.Err.(type) {
+		case nil:
+		case *exceptions.ValidationException:
+			item.Error, item.Messages = e.Name, e.Errors
+		case *exceptions.ForeignNotFoundException:
+			item.Error, item.Message = e.Name, e.Error()
+		case *exceptions.ForbiddenException:
+			item.Error, item.Message = e.Name, e.Error()
+		case *exceptions.ConflictException:
+			item.Error, item.Message = e.Name, e.Error()
+		default:
+			item.Error = http.StatusText(http.StatusInternalServerError)
+		}
+
+		resp.Data = append(resp.Data, item)
+	}
+
+	status := http.StatusCreated
+	if res.Failed > 0 && res.Created > 0 {
+		status = http.StatusMultiStatus
+	} else if res.Failed > 0 {
+		status = http.StatusBadRequest
+	}
+
+	ctx.JSON(status, resp)
+}
+
+// Fruit godoc
+// @Summary get fruit by barcode
+// @Schemes
+// @Tags fruit
+// @Accept json
+// @Produce json
+// @Param code path string true "GTIN/EAN barcode"
+// @Success 200 {object} presenters.FruitRes
+// @Failure 404 {object} presenters.ErrorRes
+// @Failure 500 {object} presenters.ErrorRes
+// @Router /v1/fruits/by-barcode/{code} [get]
+func (impl *FruitController) GetByBarcode(ctx *gin.Context) {
+	res, err := impl.service.GetByBarcode(ctx, ctx.Param("code"))
+	if err != nil {
+		if e, ok := err.(*exceptions.NotFoundException); ok {
+			ctx.JSON(http.StatusNotFound, presenters.ErrorRes{Error: e.Name, Message: e.Error()})
+			return
+		}
+
+		ctx.JSON(http.StatusInternalServerError, presenters.ErrorRes{Error: http.StatusText(http.StatusInternalServerError)})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, impl.parse(res))
+}
+
+// Fruit godoc
+// @Summary list fruits expiring soon grouped by bucket
+// @Schemes
+// @Tags fruit
+// @Accept json
+// @Produce json
+// @Param within query string false "window" default(48h)
+// @Success 200 {object} presenters.ExpiringFruitsRes
+// @Failure 400 {object} presenters.ErrorRes
+// @Failure 500 {object} presenters.ErrorRes
+// @Router /v1/fruits/expiring [get]
+func (impl *FruitController) ListExpiring(ctx *gin.Context) {
+	within := 48 * time.Hour
+	if v := ctx.Query("within"); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil || d <= 0 {
+			ctx.JSON(http.StatusBadRequest, presenters.ErrorRes{Error: exceptions.ValidationExceptionName, Message: "invalid within"})
+			return
+		}
+		within = d
+	}
+
+	res, err := impl.service.ListExpiring(ctx, within)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, presenters.ErrorRes{Error: http.StatusText(http.StatusInternalServerError)})
+		return
+	}
+
+	resp := presenters.ExpiringFruitsRes{
+		Data:                []presenters.ExpiringBucketFruitsRes{},
+		TotalPrice:          res.TotalPrice,
+		TotalEffectivePrice: res.TotalEffectivePrice,
+	}
+	for _, bucket := range res.Buckets {
+		fruits := []presenters.FruitRes{}
+		for _, fruit := range bucket.Fruits {
+			fruits = append(fruits, impl.parse(&fruit))
+		}
+
+		resp.Data = append(resp.Data, presenters.ExpiringBucketFruitsRes{
+			BucketID:   bucket.BucketID,
+			BucketName: bucket.BucketName,
+			Fruits:     fruits,
+			TotalPrice: bucket.TotalPrice,
+
+			TotalEffectivePrice: bucket.TotalEffectivePrice,
+		})
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
 // Fruit godoc
 // @Summary add fruit on bucket
 // @Schemes
@@ -99,6 +262,10 @@ func (impl *FruitController) AddOnBucket(ctx *gin.Context) {
 
 	err = impl.service.AddOnBucket(ctx, fruitID, bucketID)
 	if err != nil {
+		if e, ok := err.(*exceptions.ValidationException); ok {
+			ctx.JSON(http.StatusBadRequest, presenters.ErrorRes{Error: e.Name, Messages: e.Errors})
+			return
+		}
 		if e, ok := err.(*exceptions.ForeignNotFoundException); ok {
 			ctx.JSON(http.StatusBadRequest, presenters.ErrorRes{Error: e.Name, Message: e.Error()})
 			return
@@ -119,6 +286,75 @@ func (impl *FruitController) AddOnBucket(ctx *gin.Context) {
 	ctx.Status(http.StatusOK)
 }
 
+// Fruit godoc
+// @Summary transfer fruit between buckets
+// @Schemes
+// @Tags fruit
+// @Accept json
+// @Produce json
+// @Param fruitID path int64 true "Fruit ID"
+// @Param transfer body presenters.TransferFruitReq true "Transfer"
+// @Success 200 {object} presenters.FruitTransferRes
+// @Failure 400 {object} presenters.ErrorRes
+// @Failure 404 {object} presenters.ErrorRes
+// @Failure 409 {object} presenters.ErrorRes
+// @Failure 500 {object} presenters.ErrorRes
+// @Router /v1/fruits/{fruitID}/transfer [post]
+func (impl *FruitController) Transfer(ctx *gin.Context) {
+	fruitID, err := strconv.ParseInt(ctx.Param("fruitID"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, presenters.ErrorRes{Error: exceptions.ValidationExceptionName, Message: "invalid fruitID"})
+		return
+	}
+
+	var req presenters.TransferFruitReq
+	ctx.BindJSON(&req)
+
+	data := dtos.TransferFruitDto{
+		ToBucketID:   req.ToBucketID,
+		FromBucketID: req.FromBucketID,
+	}
+
+	res, err := impl.service.Transfer(ctx, fruitID, data)
+	if err != nil {
+		if e, ok := err.(*exceptions.ValidationException); ok {
+			ctx.JSON(http.StatusBadRequest, presenters.ErrorRes{Error: e.Name, Messages: e.Errors})
+			return
+		}
+		if e, ok := err.(*exceptions.ForeignNotFoundException); ok {
+			ctx.JSON(http.StatusBadRequest, presenters.ErrorRes{Error: e.Name, Message: e.Error()})
+			return
+		}
+		if e, ok := err.(*exceptions.ForbiddenException); ok {
+			ctx.JSON(http.StatusBadRequest, presenters.ErrorRes{Error: e.Name, Message: e.Error()})
+			return
+		}
+		if e, ok := err.(*exceptions.NotFoundException); ok {
+			ctx.JSON(http.StatusNotFound, presenters.ErrorRes{Error: e.Name, Message: e.Error()})
+			return
+		}
+		if e, ok := err.(*exceptions.ConflictException); ok {
+			ctx.JSON(http.StatusConflict, presenters.ErrorRes{Error: e.Name, Message: e.Error()})
+			return
+		}
+
+		ctx.JSON(http.StatusInternalServerError, presenters.ErrorRes{Error: http.StatusText(http.StatusInternalServerError)})
+		return
+	}
+
+	resp := presenters.FruitTransferRes{
+		FruitID: res.FruitID,
+		To:      impl.parseBucket(&res.ToBucket),
+		Moved:   res.Moved,
+	}
+	if res.FromBucket != nil {
+		from := impl.parseBucket(res.FromBucket)
+		resp.From = &from
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
 // Fruit godoc
 // @Summary remove fruit from bucket
 // @Schemes
@@ -173,6 +409,216 @@ func (impl *FruitController) Delete(ctx *gin.Context) {
 	ctx.Status(http.StatusOK)
 }
 
+// Fruit godoc
+// @Summary move many fruits to a bucket
+// @Schemes
+// @Tags fruit
+// @Accept json
+// @Produce json
+// @Param fruits body presenters.MoveFruitsReq true "Fruits selected by ids or filter"
+// @Success 200 {object} presenters.FruitsBulkRes
+// @Failure 400 {object} presenters.ErrorRes
+// @Failure 404 {object} presenters.ErrorRes
+// @Failure 500 {object} presenters.ErrorRes
+// @Router /v1/fruits/bulk/move [post]
+func (impl *FruitController) MoveMany(ctx *gin.Context) {
+	var req presenters.MoveFruitsReq
+	ctx.BindJSON(&req)
+
+	data := dtos.MoveFruitsDto{
+		SelectFruitsDto: impl.parseSelectReq(req.SelectFruitsReq),
+		ToBucketID:      req.ToBucketID,
+	}
+
+	res, err := impl.service.MoveMany(ctx, data)
+	impl.bulkResponse(ctx, res, err)
+}
+
+// Fruit godoc
+// @Summary remove many fruits from their buckets
+// @Schemes
+// @Tags fruit
+// @Accept json
+// @Produce json
+// @Param fruits body presenters.SelectFruitsReq true "Fruits selected by ids or filter"
+// @Success 200 {object} presenters.FruitsBulkRes
+// @Failure 400 {object} presenters.ErrorRes
+// @Failure 404 {object} presenters.ErrorRes
+// @Failure 500 {object} presenters.ErrorRes
+// @Router /v1/fruits/bulk/unassign [post]
+func (impl *FruitController) UnassignMany(ctx *gin.Context) {
+	var req presenters.SelectFruitsReq
+	ctx.BindJSON(&req)
+
+	res, err := impl.service.UnassignMany(ctx, impl.parseSelectReq(req))
+	impl.bulkResponse(ctx, res, err)
+}
+
+// Fruit godoc
+// @Summary delete many fruits
+// @Schemes
+// @Tags fruit
+// @Accept json
+// @Produce json
+// @Param fruits body presenters.SelectFruitsReq true "Fruits selected by ids or filter"
+// @Success 200 {object} presenters.FruitsBulkRes
+// @Failure 400 {object} presenters.ErrorRes
+// @Failure 404 {object} presenters.ErrorRes
+// @Failure 500 {object} presenters.ErrorRes
+// @Router /v1/fruits/bulk/delete [post]
+func (impl *FruitController) DeleteMany(ctx *gin.Context) {
+	var req presenters.SelectFruitsReq
+	ctx.BindJSON(&req)
+
+	res, err := impl.service.DeleteMany(ctx, impl.parseSelectReq(req))
+	impl.bulkResponse(ctx, res, err)
+}
+
+// Fruit godoc
+// @Summary recall fruits
+// @Description Quarantines the fruits from a supplier, lot (purchase order line) or created-at window and lists where each one is
+// @Schemes
+// @Tags fruit
+// @Accept json
+// @Produce json
+// @Param recall body presenters.RecallFruitsReq true "Recall"
+// @Success 200 {object} presenters.RecallRes
+// @Failure 400 {object} presenters.ErrorRes
+// @Failure 500 {object} presenters.ErrorRes
+// @Router /v1/fruits/recall [post]
+func (impl *FruitController) Recall(ctx *gin.Context) {
+	var req presenters.RecallFruitsReq
+	ctx.BindJSON(&req)
+
+	data := dtos.RecallFruitsDto{
+		SupplierID: req.SupplierID,
+		LotID:      req.LotID,
+	}
+	if req.CreatedFrom != "" {
+		v, err := time.ParseInLocation(time.DateTime, req.CreatedFrom, time.Local)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, presenters.ErrorRes{Error: exceptions.ValidationExceptionName, Message: "invalid created_from"})
+			return
+		}
+		data.CreatedFrom = &v
+	}
+	if req.CreatedTo != "" {
+		v, err := time.ParseInLocation(time.DateTime, req.CreatedTo, time.Local)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, presenters.ErrorRes{Error: exceptions.ValidationExceptionName, Message: "invalid created_to"})
+			return
+		}
+		data.CreatedTo = &v
+	}
+
+	res, err := impl.service.Recall(ctx, data)
+	if err != nil {
+		if e, ok := err.(*exceptions.ValidationException); ok {
+			ctx.JSON(http.StatusBadRequest, presenters.ErrorRes{Error: e.Name, Messages: e.Errors})
+			return
+		}
+
+		ctx.JSON(http.StatusInternalServerError, presenters.ErrorRes{Error: http.StatusText(http.StatusInternalServerError)})
+		return
+	}
+
+	resp := presenters.RecallRes{
+		QuarantinedAt: res.QuarantinedAt.Format(time.DateTime),
+		Affected:      res.Affected,
+		Data:          []presenters.RecallFruitRes{},
+	}
+	for _, fruit := range res.Fruits {
+		resp.Data = append(resp.Data, presenters.RecallFruitRes{
+			FruitID:    fruit.FruitID,
+			Name:       fruit.Name,
+			BucketID:   fruit.BucketID,
+			BucketName: fruit.BucketName,
+		})
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+func (impl *FruitController) bulkResponse(ctx *gin.Context, res *models.FruitBulk, err error) {
+	if err != nil {
+		if e, ok := err.(*exceptions.ValidationException); ok {
+			ctx.JSON(http.StatusBadRequest, presenters.ErrorRes{Error: e.Name, Messages: e.Errors})
+			return
+		}
+		if e, ok := err.(*exceptions.ForeignNotFoundException); ok {
+			ctx.JSON(http.StatusBadRequest, presenters.ErrorRes{Error: e.Name, Message: e.Error()})
+			return
+		}
+		if e, ok := err.(*exceptions.ForbiddenException); ok {
+			ctx.JSON(http.StatusBadRequest, presenters.ErrorRes{Error: e.Name, Message: e.Error()})
+			return
+		}
+		if e, ok := err.(*exceptions.NotFoundException); ok {
+			ctx.JSON(http.StatusNotFound, presenters.ErrorRes{Error: e.Name, Message: e.Error()})
+			return
+		}
+
+		ctx.JSON(http.StatusInternalServerError, presenters.ErrorRes{Error: http.StatusText(http.StatusInternalServerError)})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, presenters.FruitsBulkRes{FruitIDs: res.FruitIDs, Affected: res.Affected})
+}
+
+func (impl *FruitController) parseCreateReq(req presenters.CreateFruitReq) (*dtos.CreateFruitDto, error) {
+	var expiresIn *time.Duration
+	if v, err := time.ParseDuration(req.ExpiresIn); err == nil {
+		expiresIn = &v
+	}
+
+	var harvestedAt *time.Time
+	if req.HarvestedAt != "" {
+		v, err := time.ParseInLocation(time.DateOnly, req.HarvestedAt, time.Local)
+		if err != nil {
+			return nil, fmt.Errorf("invalid harvested_at")
+		}
+		harvestedAt = &v
+	}
+
+	var allocation *string
+	if req.BucketID.Auto {
+		allocation = req.Allocation
+		if allocation == nil {
+			v := models.BucketAllocationDefaultStrategy
+			allocation = &v
+		}
+	} else if req.Allocation != nil {
+		return nil, fmt.Errorf("allocation requires bucket_id auto")
+	}
+
+	return &dtos.CreateFruitDto{
+		Name:          req.Name,
+		Price:         req.Price,
+		ExpiresIn:     expiresIn,
+		BucketID:      req.BucketID.ID,
+		SKU:           req.SKU,
+		Barcode:       req.Barcode,
+		SupplierID:    req.SupplierID,
+		OriginCountry: req.OriginCountry,
+		HarvestedAt:   harvestedAt,
+		Allocation:    allocation,
+	}, nil
+}
+
+func (impl *FruitController) parseSelectReq(req presenters.SelectFruitsReq) dtos.SelectFruitsDto {
+	data := dtos.SelectFruitsDto{IDs: req.IDs}
+	if req.Filter != nil {
+		data.Filter = &dtos.FilterFruitsDto{
+			BucketID:   req.Filter.BucketID,
+			SupplierID: req.Filter.SupplierID,
+			Name:       req.Filter.Name,
+			State:      req.Filter.State,
+		}
+	}
+
+	return data
+}
+
 func (impl *FruitController) parse(fruit *models.Fruit) presenters.FruitRes {
 	res := presenters.FruitRes{
 		ID:        fruit.ID,
@@ -180,11 +626,37 @@ func (impl *FruitController) parse(fruit *models.Fruit) presenters.FruitRes {
 		Name:      fruit.Name,
 		Price:     fruit.Price,
 		ExpiresAt: fruit.ExpiresAt.Format(time.DateTime),
+		State:     string(fruit.State),
+		SKU:       fruit.SKU,
+		Barcode:   fruit.Barcode,
+
+		EffectivePrice: fruit.EffectivePrice,
 	}
 
 	if fruit.BucketID != nil {
 		res.BucketID = fruit.BucketID
 	}
+	if fruit.SupplierID != nil {
+		res.SupplierID = fruit.SupplierID
+	}
+	if fruit.OriginCountry != nil {
+		res.OriginCountry = fruit.OriginCountry
+	}
+	if fruit.HarvestedAt != nil {
+		res.HarvestedAt = fruit.HarvestedAt.Format(time.DateOnly)
+	}
+	if fruit.PurchaseOrderLineID != nil {
+		res.PurchaseOrderLineID = fruit.PurchaseOrderLineID
+	}
 
 	return res
 }
+
+func (impl *FruitController) parseBucket(bucket *models.Bucket) presenters.BucketRes {
+	return presenters.BucketRes{
+		ID:        bucket.ID,
+		CreatedAt: bucket.CreatedAt.Format(time.DateTime),
+		Name:      bucket.Name,
+		Capacity:  bucket.Capacity,
+	}
+}
@@ -0,0 +1,179 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/viniosilva/where-are-my-fruits/internal/controllers/presenters"
+	"github.com/viniosilva/where-are-my-fruits/internal/dtos"
+	"github.com/viniosilva/where-are-my-fruits/internal/exceptions"
+	"github.com/viniosilva/where-are-my-fruits/internal/models"
+)
+
+// fruitPriceStatsDefaultPeriod is the period covered by the price statistics
+// when no start date is given
+const fruitPriceStatsDefaultPeriod = 30 * 24 * time.Hour
+
+type FruitPriceController struct {
+	service FruitPriceService
+}
+
+func NewFruitPrice(service FruitPriceService) *FruitPriceController {
+	return &FruitPriceController{
+		service: service,
+	}
+}
+
+// FruitPrice godoc
+// @Summary change fruit price
+// @Schemes
+// @Tags fruit
+// @Accept json
+// @Produce json
+// @Param fruitID path int64 true "Fruit ID"
+// @Param price body presenters.ChangeFruitPriceReq true "Price"
+// @Success 201 {object} presenters.FruitPriceRes
+// @Failure 400 {object} presenters.ErrorRes
+// @Failure 404 {object} presenters.ErrorRes
+// @Failure 500 {object} presenters.ErrorRes
+// @Router /v1/fruits/{fruitID}/prices [post]
+func (impl *FruitPriceController) Change(ctx *gin.Context) {
+	fruitID, err := strconv.ParseInt(ctx.Param("fruitID"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, presenters.ErrorRes{Error: exceptions.ValidationExceptionName, Message: "invalid fruitID"})
+		return
+	}
+
+	var req presenters.ChangeFruitPriceReq
+	ctx.BindJSON(&req)
+
+	res, err := impl.service.Change(ctx, fruitID, dtos.ChangeFruitPriceDto{Price: req.Price, Reason: req.Reason})
+	if err != nil {
+		if e, ok := err.(*exceptions.ValidationException); ok {
+			ctx.JSON(http.StatusBadRequest, presenters.ErrorRes{Error: e.Name, Messages: e.Errors})
+			return
+		}
+		if e, ok := err.(*exceptions.NotFoundException); ok {
+			ctx.JSON(http.StatusNotFound, presenters.ErrorRes{Error: e.Name, Message: e.Error()})
+			return
+		}
+
+		ctx.JSON(http.StatusInternalServerError, presenters.ErrorRes{Error: http.StatusText(http.StatusInternalServerError)})
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, impl.parse(res))
+}
+
+// FruitPrice godoc
+// @Summary list fruit price history
+// @Schemes
+// @Tags fruit
+// @Accept json
+// @Produce json
+// @Param fruitID path int64 true "Fruit ID"
+// @Success 200 {object} presenters.FruitPricesRes
+// @Failure 400 {object} presenters.ErrorRes
+// @Failure 500 {object} presenters.ErrorRes
+// @Router /v1/fruits/{fruitID}/prices [get]
+func (impl *FruitPriceController) List(ctx *gin.Context) {
+	fruitID, err := strconv.ParseInt(ctx.Param("fruitID"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, presenters.ErrorRes{Error: exceptions.ValidationExceptionName, Message: "invalid fruitID"})
+		return
+	}
+
+	res, err := impl.service.List(ctx, fruitID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, presenters.ErrorRes{Error: http.StatusText(http.StatusInternalServerError)})
+		return
+	}
+
+	resp := presenters.FruitPricesRes{Data: []presenters.FruitPriceRes{}}
+	for _, price := range res {
+		resp.Data = append(resp.Data, impl.parse(&price))
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// FruitPrice godoc
+// @Summary fruit price statistics by name
+// @Schemes
+// @Tags fruit
+// @Accept json
+// @Produce json
+// @Param name query string false "Fruit name"
+// @Param from query string false "First day of the period (default 30 days before to)" example(2000-12-01)
+// @Param to query string false "Last day of the period (default today)" example(2000-12-31)
+// @Success 200 {object} presenters.FruitPricesStatsRes
+// @Failure 400 {object} presenters.ErrorRes
+// @Failure 500 {object} presenters.ErrorRes
+// @Router /v1/fruits/prices/stats [get]
+func (impl *FruitPriceController) Stats(ctx *gin.Context) {
+	today := time.Now()
+	to := time.Date(today.Year(), today.Month(), today.Day(), 0, 0, 0, 0, time.Local)
+	if v := ctx.Query("to"); v != "" {
+		d, err := time.ParseInLocation(time.DateOnly, v, time.Local)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, presenters.ErrorRes{Error: exceptions.ValidationExceptionName, Message: "invalid to"})
+			return
+		}
+		to = d
+	}
+
+	from := to.Add(-fruitPriceStatsDefaultPeriod)
+	if v := ctx.Query("from"); v != "" {
+		d, err := time.ParseInLocation(time.DateOnly, v, time.Local)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, presenters.ErrorRes{Error: exceptions.ValidationExceptionName, Message: "invalid from"})
+			return
+		}
+		from = d
+	}
+
+	data := dtos.FruitPriceStatsDto{From: from, To: to.AddDate(0, 0, 1)}
+	if v := ctx.Query("name"); v != "" {
+		data.Name = &v
+	}
+
+	res, err := impl.service.Stats(ctx, data)
+	if err != nil {
+		if e, ok := err.(*exceptions.ValidationException); ok {
+			ctx.JSON(http.StatusBadRequest, presenters.ErrorRes{Error: e.Name, Messages: e.Errors})
+			return
+		}
+
+		ctx.JSON(http.StatusInternalServerError, presenters.ErrorRes{Error: http.StatusText(http.StatusInternalServerError)})
+		return
+	}
+
+	resp := presenters.FruitPricesStatsRes{
+		From: from.Format(time.DateOnly),
+		To:   to.Format(time.DateOnly),
+		Data: []presenters.FruitPriceStatsRes{},
+	}
+	for _, stat := range res {
+		resp.Data = append(resp.Data, presenters.FruitPriceStatsRes{
+			Name:    stat.Name,
+			Min:     stat.Min,
+			Avg:     stat.Avg,
+			Max:     stat.Max,
+			Changes: stat.Changes,
+		})
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+func (impl *FruitPriceController) parse(price *models.FruitPrice) presenters.FruitPriceRes {
+	return presenters.FruitPriceRes{
+		ID:        price.ID,
+		CreatedAt: price.CreatedAt.Format(time.DateTime),
+		FruitID:   price.FruitID,
+		Price:     price.Price,
+		Reason:    price.Reason,
+	}
+}
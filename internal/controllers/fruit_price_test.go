@@ -0,0 +1,354 @@
+package controllers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/golang/mock/gomock"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/viniosilva/where-are-my-fruits/internal/controllers/presenters"
+	"github.com/viniosilva/where-are-my-fruits/internal/dtos"
+	"github.com/viniosilva/where-are-my-fruits/internal/exceptions"
+	"github.com/viniosilva/where-are-my-fruits/internal/models"
+	"github.com/viniosilva/where-are-my-fruits/mocks"
+)
+
+func TestFruitPriceController_Change(t *testing.T) {
+	now := time.Date(2000, 12, 31, 23, 59, 59, 0, time.Local)
+
+	tests := map[string]struct {
+		mock        func(service *mocks.MockFruitPriceService)
+		fruitID     string
+		body        presenters.ChangeFruitPriceReq
+		wantCode    int
+		wantBody    presenters.FruitPriceRes
+		wantBodyErr presenters.ErrorRes
+	}{
+		"should be success": {
+			mock: func(service *mocks.MockFruitPriceService) {
+				data := dtos.ChangeFruitPriceDto{Price: decimal.RequireFromString("2.49"), Reason: "promotion"}
+				service.EXPECT().Change(gomock.Any(), int64(1), data).Return(&models.FruitPrice{
+					ID:        2,
+					CreatedAt: now,
+					FruitID:   1,
+					Price:     decimal.RequireFromString("2.49"),
+					Reason:    "promotion",
+				}, nil)
+			},
+			fruitID:  "1",
+			body:     presenters.ChangeFruitPriceReq{Price: decimal.RequireFromString("2.49"), Reason: "promotion"},
+			wantCode: http.StatusCreated,
+			wantBody: presenters.FruitPriceRes{
+				ID:        2,
+				CreatedAt: "2000-12-31 23:59:59",
+				FruitID:   1,
+				Price:     decimal.RequireFromString("2.49"),
+				Reason:    "promotion",
+			},
+		},
+		"should throw bad request when fruitID is invalid": {
+			mock:     func(service *mocks.MockFruitPriceService) {},
+			fruitID:  "a",
+			wantCode: http.StatusBadRequest,
+			wantBodyErr: presenters.ErrorRes{
+				Error:   exceptions.ValidationExceptionName,
+				Message: "invalid fruitID",
+			},
+		},
+		"should throw validation exception": {
+			mock: func(service *mocks.MockFruitPriceService) {
+				service.EXPECT().Change(gomock.Any(), int64(1), gomock.Any()).Return(nil, exceptions.NewValidationException(validator.ValidationErrors{
+					&mocks.FieldError{Itag: "error 1", Ins: "error 1"},
+				}))
+			},
+			fruitID:  "1",
+			wantCode: http.StatusBadRequest,
+			wantBodyErr: presenters.ErrorRes{
+				Error:    exceptions.ValidationExceptionName,
+				Messages: []string{"Key: 'error 1' Error:Field validation for '' failed on the 'error 1' tag"},
+			},
+		},
+		"should throw not found": {
+			mock: func(service *mocks.MockFruitPriceService) {
+				service.EXPECT().Change(gomock.Any(), int64(1), gomock.Any()).Return(nil, exceptions.NewNotFoundException("Fruit not found"))
+			},
+			fruitID:  "1",
+			body:     presenters.ChangeFruitPriceReq{Price: decimal.NewFromInt(1), Reason: "promotion"},
+			wantCode: http.StatusNotFound,
+			wantBodyErr: presenters.ErrorRes{
+				Error:   exceptions.NotFoundExceptionName,
+				Message: "Fruit not found",
+			},
+		},
+		"should throw internal server error": {
+			mock: func(service *mocks.MockFruitPriceService) {
+				service.EXPECT().Change(gomock.Any(), int64(1), gomock.Any()).Return(nil, fmt.Errorf("error"))
+			},
+			fruitID:     "1",
+			body:        presenters.ChangeFruitPriceReq{Price: decimal.NewFromInt(1), Reason: "promotion"},
+			wantCode:    http.StatusInternalServerError,
+			wantBodyErr: presenters.ErrorRes{Error: http.StatusText(http.StatusInternalServerError)},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			// setup
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			serviceMock := mocks.NewMockFruitPriceService(ctrl)
+			tt.mock(serviceMock)
+
+			r := gin.Default()
+			controller := NewFruitPrice(serviceMock)
+
+			r.POST("/api/v1/fruits/:fruitID/prices", controller.Change)
+
+			var got presenters.FruitPriceRes
+			var gotErr presenters.ErrorRes
+
+			// given
+			body, _ := json.Marshal(tt.body)
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("POST", fmt.Sprintf("/api/v1/fruits/%s/prices", tt.fruitID), bytes.NewReader(body))
+
+			// when
+			r.ServeHTTP(w, req)
+
+			json.Unmarshal(w.Body.Bytes(), &gotErr)
+			json.Unmarshal(w.Body.Bytes(), &got)
+
+			// then
+			assert.Equal(t, tt.wantCode, w.Code)
+
+			if gotErr.Error != "" {
+				assert.Equal(t, tt.wantBodyErr, gotErr)
+				return
+			}
+			assert.Equal(t, tt.wantBody, got)
+		})
+	}
+}
+
+func TestFruitPriceController_List(t *testing.T) {
+	now := time.Date(2000, 12, 31, 23, 59, 59, 0, time.Local)
+
+	tests := map[string]struct {
+		mock        func(service *mocks.MockFruitPriceService)
+		fruitID     string
+		wantCode    int
+		wantBody    presenters.FruitPricesRes
+		wantBodyErr presenters.ErrorRes
+	}{
+		"should be success": {
+			mock: func(service *mocks.MockFruitPriceService) {
+				service.EXPECT().List(gomock.Any(), int64(1)).Return([]models.FruitPrice{
+					{ID: 1, CreatedAt: now, FruitID: 1, Price: decimal.RequireFromString("1.99"), Reason: "created"},
+				}, nil)
+			},
+			fruitID:  "1",
+			wantCode: http.StatusOK,
+			wantBody: presenters.FruitPricesRes{
+				Data: []presenters.FruitPriceRes{
+					{ID: 1, CreatedAt: "2000-12-31 23:59:59", FruitID: 1, Price: decimal.RequireFromString("1.99"), Reason: "created"},
+				},
+			},
+		},
+		"should throw bad request when fruitID is invalid": {
+			mock:     func(service *mocks.MockFruitPriceService) {},
+			fruitID:  "a",
+			wantCode: http.StatusBadRequest,
+			wantBodyErr: presenters.ErrorRes{
+				Error:   exceptions.ValidationExceptionName,
+				Message: "invalid fruitID",
+			},
+		},
+		"should throw internal server error": {
+			mock: func(service *mocks.MockFruitPriceService) {
+				service.EXPECT().List(gomock.Any(), int64(1)).Return(nil, fmt.Errorf("error"))
+			},
+			fruitID:     "1",
+			wantCode:    http.StatusInternalServerError,
+			wantBodyErr: presenters.ErrorRes{Error: http.StatusText(http.StatusInternalServerError)},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			// setup
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			serviceMock := mocks.NewMockFruitPriceService(ctrl)
+			tt.mock(serviceMock)
+
+			r := gin.Default()
+			controller := NewFruitPrice(serviceMock)
+
+			r.GET("/api/v1/fruits/:fruitID/prices", controller.List)
+
+			var got presenters.FruitPricesRes
+			var gotErr presenters.ErrorRes
+
+			// given
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("GET", fmt.Sprintf("/api/v1/fruits/%s/prices", tt.fruitID), nil)
+
+			// when
+			r.ServeHTTP(w, req)
+
+			json.Unmarshal(w.Body.Bytes(), &gotErr)
+			json.Unmarshal(w.Body.Bytes(), &got)
+
+			// then
+			assert.Equal(t, tt.wantCode, w.Code)
+
+			if gotErr.Error != "" {
+				assert.Equal(t, tt.wantBodyErr, gotErr)
+				return
+			}
+			assert.Equal(t, tt.wantBody, got)
+		})
+	}
+}
+
+func TestFruitPriceController_Stats(t *testing.T) {
+	name := "Mango"
+
+	tests := map[string]struct {
+		mock        func(service *mocks.MockFruitPriceService)
+		query       string
+		wantCode    int
+		wantBody    presenters.FruitPricesStatsRes
+		wantBodyErr presenters.ErrorRes
+	}{
+		"should be success": {
+			mock: func(service *mocks.MockFruitPriceService) {
+				data := dtos.FruitPriceStatsDto{
+					Name: &name,
+					From: time.Date(2000, 12, 1, 0, 0, 0, 0, time.Local),
+					To:   time.Date(2001, 1, 1, 0, 0, 0, 0, time.Local),
+				}
+				service.EXPECT().Stats(gomock.Any(), data).Return([]models.FruitPriceStats{
+					{
+						Name:    "Mango",
+						Min:     decimal.RequireFromString("1.99"),
+						Avg:     decimal.RequireFromString("2.24"),
+						Max:     decimal.RequireFromString("2.49"),
+						Changes: 2,
+					},
+				}, nil)
+			},
+			query:    "?name=Mango&from=2000-12-01&to=2000-12-31",
+			wantCode: http.StatusOK,
+			wantBody: presenters.FruitPricesStatsRes{
+				From: "2000-12-01",
+				To:   "2000-12-31",
+				Data: []presenters.FruitPriceStatsRes{
+					{
+						Name:    "Mango",
+						Min:     decimal.RequireFromString("1.99"),
+						Avg:     decimal.RequireFromString("2.24"),
+						Max:     decimal.RequireFromString("2.49"),
+						Changes: 2,
+					},
+				},
+			},
+		},
+		"should default the period to the last 30 days": {
+			mock: func(service *mocks.MockFruitPriceService) {
+				data := dtos.FruitPriceStatsDto{
+					From: time.Date(2000, 12, 1, 0, 0, 0, 0, time.Local),
+					To:   time.Date(2001, 1, 1, 0, 0, 0, 0, time.Local),
+				}
+				service.EXPECT().Stats(gomock.Any(), data).Return([]models.FruitPriceStats{}, nil)
+			},
+			query:    "?to=2000-12-31",
+			wantCode: http.StatusOK,
+			wantBody: presenters.FruitPricesStatsRes{
+				From: "2000-12-01",
+				To:   "2000-12-31",
+				Data: []presenters.FruitPriceStatsRes{},
+			},
+		},
+		"should throw bad request when from is invalid": {
+			mock:     func(service *mocks.MockFruitPriceService) {},
+			query:    "?from=yesterday",
+			wantCode: http.StatusBadRequest,
+			wantBodyErr: presenters.ErrorRes{
+				Error:   exceptions.ValidationExceptionName,
+				Message: "invalid from",
+			},
+		},
+		"should throw bad request when to is invalid": {
+			mock:     func(service *mocks.MockFruitPriceService) {},
+			query:    "?to=31/12/2000",
+			wantCode: http.StatusBadRequest,
+			wantBodyErr: presenters.ErrorRes{
+				Error:   exceptions.ValidationExceptionName,
+				Message: "invalid to",
+			},
+		},
+		"should throw validation exception": {
+			mock: func(service *mocks.MockFruitPriceService) {
+				service.EXPECT().Stats(gomock.Any(), gomock.Any()).Return(nil, exceptions.NewValidationException(validator.ValidationErrors{
+					&mocks.FieldError{Itag: "error 1", Ins: "error 1"},
+				}))
+			},
+			query:    "?from=2001-01-01&to=2000-12-01",
+			wantCode: http.StatusBadRequest,
+			wantBodyErr: presenters.ErrorRes{
+				Error:    exceptions.ValidationExceptionName,
+				Messages: []string{"Key: 'error 1' Error:Field validation for '' failed on the 'error 1' tag"},
+			},
+		},
+		"should throw internal server error": {
+			mock: func(service *mocks.MockFruitPriceService) {
+				service.EXPECT().Stats(gomock.Any(), gomock.Any()).Return(nil, fmt.Errorf("error"))
+			},
+			wantCode:    http.StatusInternalServerError,
+			wantBodyErr: presenters.ErrorRes{Error: http.StatusText(http.StatusInternalServerError)},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			// setup
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			serviceMock := mocks.NewMockFruitPriceService(ctrl)
+			tt.mock(serviceMock)
+
+			r := gin.Default()
+			controller := NewFruitPrice(serviceMock)
+
+			r.GET("/api/v1/fruits/prices/stats", controller.Stats)
+
+			var got presenters.FruitPricesStatsRes
+			var gotErr presenters.ErrorRes
+
+			// given
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("GET", "/api/v1/fruits/prices/stats"+tt.query, nil)
+
+			// when
+			r.ServeHTTP(w, req)
+
+			json.Unmarshal(w.Body.Bytes(), &gotErr)
+			json.Unmarshal(w.Body.Bytes(), &got)
+
+			// then
+			assert.Equal(t, tt.wantCode, w.Code)
+
+			if gotErr.Error != "" {
+				assert.Equal(t, tt.wantBodyErr, gotErr)
+				return
+			}
+			assert.Equal(t, tt.wantBody, got)
+		})
+	}
+}
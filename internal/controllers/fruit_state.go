@@ -0,0 +1,111 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/viniosilva/where-are-my-fruits/internal/controllers/presenters"
+	"github.com/viniosilva/where-are-my-fruits/internal/dtos"
+	"github.com/viniosilva/where-are-my-fruits/internal/exceptions"
+	"github.com/viniosilva/where-are-my-fruits/internal/models"
+)
+
+type FruitStateController struct {
+	service FruitStateService
+}
+
+func NewFruitState(service FruitStateService) *FruitStateController {
+	return &FruitStateController{
+		service: service,
+	}
+}
+
+// FruitState godoc
+// @Summary change fruit ripeness state
+// @Schemes
+// @Tags fruit
+// @Accept json
+// @Produce json
+// @Param fruitID path int64 true "Fruit ID"
+// @Param state body presenters.ChangeFruitStateReq true "State"
+// @Success 201 {object} presenters.FruitStateTransitionRes
+// @Failure 400 {object} presenters.ErrorRes
+// @Failure 404 {object} presenters.ErrorRes
+// @Failure 422 {object} presenters.ErrorRes
+// @Failure 500 {object} presenters.ErrorRes
+// @Router /v1/fruits/{fruitID}/states [post]
+func (impl *FruitStateController) Change(ctx *gin.Context) {
+	fruitID, err := strconv.ParseInt(ctx.Param("fruitID"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, presenters.ErrorRes{Error: exceptions.ValidationExceptionName, Message: "invalid fruitID"})
+		return
+	}
+
+	var req presenters.ChangeFruitStateReq
+	ctx.BindJSON(&req)
+
+	res, err := impl.service.Change(ctx, fruitID, dtos.ChangeFruitStateDto{State: req.State})
+	if err != nil {
+		if e, ok := err.(*exceptions.ValidationException); ok {
+			ctx.JSON(http.StatusBadRequest, presenters.ErrorRes{Error: e.Name, Messages: e.Errors})
+			return
+		}
+		if e, ok := err.(*exceptions.NotFoundException); ok {
+			ctx.JSON(http.StatusNotFound, presenters.ErrorRes{Error: e.Name, Message: e.Error()})
+			return
+		}
+		if e, ok := err.(*exceptions.InvalidStateTransitionException); ok {
+			ctx.JSON(http.StatusUnprocessableEntity, presenters.ErrorRes{Error: e.Name, Message: e.Error()})
+			return
+		}
+
+		ctx.JSON(http.StatusInternalServerError, presenters.ErrorRes{Error: http.StatusText(http.StatusInternalServerError)})
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, impl.parse(res))
+}
+
+// FruitState godoc
+// @Summary list fruit ripeness state transitions
+// @Schemes
+// @Tags fruit
+// @Accept json
+// @Produce json
+// @Param fruitID path int64 true "Fruit ID"
+// @Success 200 {object} presenters.FruitStateTransitionsRes
+// @Failure 400 {object} presenters.ErrorRes
+// @Failure 500 {object} presenters.ErrorRes
+// @Router /v1/fruits/{fruitID}/states [get]
+func (impl *FruitStateController) ListTransitions(ctx *gin.Context) {
+	fruitID, err := strconv.ParseInt(ctx.Param("fruitID"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, presenters.ErrorRes{Error: exceptions.ValidationExceptionName, Message: "invalid fruitID"})
+		return
+	}
+
+	res, err := impl.service.ListTransitions(ctx, fruitID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, presenters.ErrorRes{Error: http.StatusText(http.StatusInternalServerError)})
+		return
+	}
+
+	resp := presenters.FruitStateTransitionsRes{Data: []presenters.FruitStateTransitionRes{}}
+	for _, transition := range res {
+		resp.Data = append(resp.Data, impl.parse(&transition))
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+func (impl *FruitStateController) parse(transition *models.FruitStateTransition) presenters.FruitStateTransitionRes {
+	return presenters.FruitStateTransitionRes{
+		ID:        transition.ID,
+		CreatedAt: transition.CreatedAt.Format(time.DateTime),
+		FruitID:   transition.FruitID,
+		FromState: string(transition.FromState),
+		ToState:   string(transition.ToState),
+	}
+}
@@ -0,0 +1,229 @@
+package controllers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/viniosilva/where-are-my-fruits/internal/controllers/presenters"
+	"github.com/viniosilva/where-are-my-fruits/internal/dtos"
+	"github.com/viniosilva/where-are-my-fruits/internal/exceptions"
+	"github.com/viniosilva/where-are-my-fruits/internal/models"
+	"github.com/viniosilva/where-are-my-fruits/mocks"
+)
+
+func TestFruitStateController_Change(t *testing.T) {
+	now := time.Date(2000, 12, 31, 23, 59, 59, 0, time.Local)
+
+	tests := map[string]struct {
+		mock        func(service *mocks.MockFruitStateService)
+		fruitID     string
+		body        presenters.ChangeFruitStateReq
+		wantCode    int
+		wantBody    presenters.FruitStateTransitionRes
+		wantBodyErr presenters.ErrorRes
+	}{
+		"should be success": {
+			mock: func(service *mocks.MockFruitStateService) {
+				data := dtos.ChangeFruitStateDto{State: "ripe"}
+				service.EXPECT().Change(gomock.Any(), int64(1), data).Return(&models.FruitStateTransition{
+					ID:        1,
+					CreatedAt: now,
+					FruitID:   1,
+					FromState: models.FruitStateUnripe,
+					ToState:   models.FruitStateRipe,
+				}, nil)
+			},
+			fruitID:  "1",
+			body:     presenters.ChangeFruitStateReq{State: "ripe"},
+			wantCode: http.StatusCreated,
+			wantBody: presenters.FruitStateTransitionRes{
+				ID:        1,
+				CreatedAt: "2000-12-31 23:59:59",
+				FruitID:   1,
+				FromState: "unripe",
+				ToState:   "ripe",
+			},
+		},
+		"should throw bad request when fruitID is invalid": {
+			mock:     func(service *mocks.MockFruitStateService) {},
+			fruitID:  "a",
+			wantCode: http.StatusBadRequest,
+			wantBodyErr: presenters.ErrorRes{
+				Error:   exceptions.ValidationExceptionName,
+				Message: "invalid fruitID",
+			},
+		},
+		"should throw validation exception": {
+			mock: func(service *mocks.MockFruitStateService) {
+				service.EXPECT().Change(gomock.Any(), int64(1), gomock.Any()).Return(nil, exceptions.NewValidationException(validator.ValidationErrors{
+					&mocks.FieldError{Itag: "error 1", Ins: "error 1"},
+				}))
+			},
+			fruitID:  "1",
+			wantCode: http.StatusBadRequest,
+			wantBodyErr: presenters.ErrorRes{
+				Error:    exceptions.ValidationExceptionName,
+				Messages: []string{"Key: 'error 1' Error:Field validation for '' failed on the 'error 1' tag"},
+			},
+		},
+		"should throw not found": {
+			mock: func(service *mocks.MockFruitStateService) {
+				service.EXPECT().Change(gomock.Any(), int64(1), gomock.Any()).Return(nil, exceptions.NewNotFoundException("Fruit not found"))
+			},
+			fruitID:  "1",
+			body:     presenters.ChangeFruitStateReq{State: "ripe"},
+			wantCode: http.StatusNotFound,
+			wantBodyErr: presenters.ErrorRes{
+				Error:   exceptions.NotFoundExceptionName,
+				Message: "Fruit not found",
+			},
+		},
+		"should throw unprocessable entity when transition is not allowed": {
+			mock: func(service *mocks.MockFruitStateService) {
+				service.EXPECT().Change(gomock.Any(), int64(1), gomock.Any()).Return(nil, exceptions.NewInvalidStateTransitionException("Fruit cannot transition from overripe to unripe"))
+			},
+			fruitID:  "1",
+			body:     presenters.ChangeFruitStateReq{State: "unripe"},
+			wantCode: http.StatusUnprocessableEntity,
+			wantBodyErr: presenters.ErrorRes{
+				Error:   exceptions.InvalidStateTransitionExceptionName,
+				Message: "Fruit cannot transition from overripe to unripe",
+			},
+		},
+		"should throw internal server error": {
+			mock: func(service *mocks.MockFruitStateService) {
+				service.EXPECT().Change(gomock.Any(), int64(1), gomock.Any()).Return(nil, fmt.Errorf("error"))
+			},
+			fruitID:     "1",
+			body:        presenters.ChangeFruitStateReq{State: "ripe"},
+			wantCode:    http.StatusInternalServerError,
+			wantBodyErr: presenters.ErrorRes{Error: http.StatusText(http.StatusInternalServerError)},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			// setup
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			serviceMock := mocks.NewMockFruitStateService(ctrl)
+			tt.mock(serviceMock)
+
+			r := gin.Default()
+			controller := NewFruitState(serviceMock)
+
+			r.POST("/api/v1/fruits/:fruitID/states", controller.Change)
+
+			var got presenters.FruitStateTransitionRes
+			var gotErr presenters.ErrorRes
+
+			// given
+			body, _ := json.Marshal(tt.body)
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("POST", fmt.Sprintf("/api/v1/fruits/%s/states", tt.fruitID), bytes.NewReader(body))
+
+			// when
+			r.ServeHTTP(w, req)
+
+			json.Unmarshal(w.Body.Bytes(), &gotErr)
+			json.Unmarshal(w.Body.Bytes(), &got)
+
+			// then
+			assert.Equal(t, tt.wantCode, w.Code)
+
+			if gotErr.Error != "" {
+				assert.Equal(t, tt.wantBodyErr, gotErr)
+				return
+			}
+			assert.Equal(t, tt.wantBody, got)
+		})
+	}
+}
+
+func TestFruitStateController_ListTransitions(t *testing.T) {
+	now := time.Date(2000, 12, 31, 23, 59, 59, 0, time.Local)
+
+	tests := map[string]struct {
+		mock        func(service *mocks.MockFruitStateService)
+		fruitID     string
+		wantCode    int
+		wantBody    presenters.FruitStateTransitionsRes
+		wantBodyErr presenters.ErrorRes
+	}{
+		"should be success": {
+			mock: func(service *mocks.MockFruitStateService) {
+				service.EXPECT().ListTransitions(gomock.Any(), int64(1)).Return([]models.FruitStateTransition{
+					{ID: 1, CreatedAt: now, FruitID: 1, FromState: models.FruitStateUnripe, ToState: models.FruitStateRipe},
+				}, nil)
+			},
+			fruitID:  "1",
+			wantCode: http.StatusOK,
+			wantBody: presenters.FruitStateTransitionsRes{
+				Data: []presenters.FruitStateTransitionRes{
+					{ID: 1, CreatedAt: "2000-12-31 23:59:59", FruitID: 1, FromState: "unripe", ToState: "ripe"},
+				},
+			},
+		},
+		"should throw bad request when fruitID is invalid": {
+			mock:     func(service *mocks.MockFruitStateService) {},
+			fruitID:  "a",
+			wantCode: http.StatusBadRequest,
+			wantBodyErr: presenters.ErrorRes{
+				Error:   exceptions.ValidationExceptionName,
+				Message: "invalid fruitID",
+			},
+		},
+		"should throw internal server error": {
+			mock: func(service *mocks.MockFruitStateService) {
+				service.EXPECT().ListTransitions(gomock.Any(), int64(1)).Return(nil, fmt.Errorf("error"))
+			},
+			fruitID:     "1",
+			wantCode:    http.StatusInternalServerError,
+			wantBodyErr: presenters.ErrorRes{Error: http.StatusText(http.StatusInternalServerError)},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			// setup
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			serviceMock := mocks.NewMockFruitStateService(ctrl)
+			tt.mock(serviceMock)
+
+			r := gin.Default()
+			controller := NewFruitState(serviceMock)
+
+			r.GET("/api/v1/fruits/:fruitID/states", controller.ListTransitions)
+
+			var got presenters.FruitStateTransitionsRes
+			var gotErr presenters.ErrorRes
+
+			// given
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("GET", fmt.Sprintf("/api/v1/fruits/%s/states", tt.fruitID), nil)
+
+			// when
+			r.ServeHTTP(w, req)
+
+			json.Unmarshal(w.Body.Bytes(), &gotErr)
+			json.Unmarshal(w.Body.Bytes(), &got)
+
+			// then
+			assert.Equal(t, tt.wantCode, w.Code)
+
+			if gotErr.Error != "" {
+				assert.Equal(t, tt.wantBodyErr, gotErr)
+				return
+			}
+			assert.Equal(t, tt.wantBody, got)
+		})
+	}
+}
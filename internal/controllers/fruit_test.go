@@ -26,6 +26,8 @@ func TestFruitController_Create(t *testing.T) {
 	price, _ := decimal.NewFromString("1.99")
 	expiresIn, _ := time.ParseDuration("1m")
 	bucketID := int64(1)
+	leastFull := models.BucketAllocationLeastFull
+	firstFit := models.BucketAllocationFirstFit
 
 	tests := map[string]struct {
 		mock        func(service *mocks.MockFruitService)
@@ -42,11 +44,12 @@ func TestFruitController_Create(t *testing.T) {
 					ExpiresIn: &expiresIn,
 				}
 				service.EXPECT().Create(gomock.Any(), data).Return(&models.Fruit{
-					ID:        1,
-					CreatedAt: now,
-					Name:      "Testing",
-					Price:     price,
-					ExpiresAt: now.Add(expiresIn),
+					ID:             1,
+					CreatedAt:      now,
+					Name:           "Testing",
+					Price:          price,
+					EffectivePrice: price,
+					ExpiresAt:      now.Add(expiresIn),
 				}, nil)
 			},
 			body: presenters.CreateFruitReq{
@@ -56,11 +59,12 @@ func TestFruitController_Create(t *testing.T) {
 			},
 			wantCode: http.StatusCreated,
 			wantBody: presenters.FruitRes{
-				ID:        1,
-				CreatedAt: "2000-12-31 23:59:59",
-				Name:      "Testing",
-				Price:     price,
-				ExpiresAt: "2001-01-01 00:00:59",
+				ID:             1,
+				CreatedAt:      "2000-12-31 23:59:59",
+				Name:           "Testing",
+				Price:          price,
+				EffectivePrice: price,
+				ExpiresAt:      "2001-01-01 00:00:59",
 			},
 		},
 		"should be success when bucketID is setted": {
@@ -72,28 +76,44 @@ func TestFruitController_Create(t *testing.T) {
 					BucketID:  &bucketID,
 				}
 				service.EXPECT().Create(gomock.Any(), data).Return(&models.Fruit{
-					ID:        1,
-					CreatedAt: now,
-					Name:      "Testing",
-					Price:     price,
-					ExpiresAt: now.Add(expiresIn),
-					BucketID:  &bucketID,
+					ID:             1,
+					CreatedAt:      now,
+					Name:           "Testing",
+					Price:          price,
+					EffectivePrice: price,
+					ExpiresAt:      now.Add(expiresIn),
+					BucketID:       &bucketID,
 				}, nil)
 			},
 			body: presenters.CreateFruitReq{
 				Name:      "Testing",
 				Price:     price,
 				ExpiresIn: "1m",
-				BucketID:  &bucketID,
+				BucketID:  presenters.BucketIDReq{ID: &bucketID},
 			},
 			wantCode: http.StatusCreated,
 			wantBody: presenters.FruitRes{
-				ID:        1,
-				CreatedAt: "2000-12-31 23:59:59",
-				Name:      "Testing",
-				Price:     price,
-				ExpiresAt: "2001-01-01 00:00:59",
-				BucketID:  &bucketID,
+				ID:             1,
+				CreatedAt:      "2000-12-31 23:59:59",
+				Name:           "Testing",
+				Price:          price,
+				EffectivePrice: price,
+				ExpiresAt:      "2001-01-01 00:00:59",
+				BucketID:       &bucketID,
+			},
+		},
+		"should throw validation exception when harvested_at is invalid": {
+			mock: func(service *mocks.MockFruitService) {},
+			body: presenters.CreateFruitReq{
+				Name:        "Testing",
+				Price:       price,
+				ExpiresIn:   "1m",
+				HarvestedAt: "31/12/2000",
+			},
+			wantCode: http.StatusBadRequest,
+			wantBodyErr: presenters.ErrorRes{
+				Error:   exceptions.ValidationExceptionName,
+				Message: "invalid harvested_at",
 			},
 		},
 		"should throw validation exception": {
@@ -113,6 +133,79 @@ func TestFruitController_Create(t *testing.T) {
 				},
 			},
 		},
+		"should be success allocating a bucket when bucket_id is auto": {
+			mock: func(service *mocks.MockFruitService) {
+				data := dtos.CreateFruitDto{
+					Name:       "Testing",
+					Price:      price,
+					ExpiresIn:  &expiresIn,
+					Allocation: &leastFull,
+				}
+				service.EXPECT().Create(gomock.Any(), data).Return(&models.Fruit{
+					ID:             1,
+					CreatedAt:      now,
+					Name:           "Testing",
+					Price:          price,
+					EffectivePrice: price,
+					ExpiresAt:      now.Add(expiresIn),
+					BucketID:       &bucketID,
+				}, nil)
+			},
+			body: presenters.CreateFruitReq{
+				Name:       "Testing",
+				Price:      price,
+				ExpiresIn:  "1m",
+				BucketID:   presenters.BucketIDReq{Auto: true},
+				Allocation: &leastFull,
+			},
+			wantCode: http.StatusCreated,
+			wantBody: presenters.FruitRes{
+				ID:             1,
+				CreatedAt:      "2000-12-31 23:59:59",
+				Name:           "Testing",
+				Price:          price,
+				EffectivePrice: price,
+				ExpiresAt:      "2001-01-01 00:00:59",
+				BucketID:       &bucketID,
+			},
+		},
+		"should allocate a bucket first fit when bucket_id is auto without allocation": {
+			mock: func(service *mocks.MockFruitService) {
+				data := dtos.CreateFruitDto{
+					Name:       "Testing",
+					Price:      price,
+					ExpiresIn:  &expiresIn,
+					Allocation: &firstFit,
+				}
+				service.EXPECT().Create(gomock.Any(), data).Return(nil, exceptions.NewForbiddenException("No bucket has free capacity"))
+			},
+			body: presenters.CreateFruitReq{
+				Name:      "Testing",
+				Price:     price,
+				ExpiresIn: "1m",
+				BucketID:  presenters.BucketIDReq{Auto: true},
+			},
+			wantCode: http.StatusBadRequest,
+			wantBodyErr: presenters.ErrorRes{
+				Error:   exceptions.ForbiddenExceptionName,
+				Message: "No bucket has free capacity",
+			},
+		},
+		"should throw validation exception when allocation is given without bucket_id auto": {
+			mock: func(service *mocks.MockFruitService) {},
+			body: presenters.CreateFruitReq{
+				Name:       "Testing",
+				Price:      price,
+				ExpiresIn:  "1m",
+				BucketID:   presenters.BucketIDReq{ID: &bucketID},
+				Allocation: &leastFull,
+			},
+			wantCode: http.StatusBadRequest,
+			wantBodyErr: presenters.ErrorRes{
+				Error:   exceptions.ValidationExceptionName,
+				Message: "allocation requires bucket_id auto",
+			},
+		},
 		"should throw foreign not found exception": {
 			mock: func(service *mocks.MockFruitService) {
 				service.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil, exceptions.NewForeignNotFoundException("Bucket not found"))
@@ -121,7 +214,7 @@ func TestFruitController_Create(t *testing.T) {
 				Name:      "Testing",
 				Price:     price,
 				ExpiresIn: "1m",
-				BucketID:  &bucketID,
+				BucketID:  presenters.BucketIDReq{ID: &bucketID},
 			},
 			wantCode: http.StatusBadRequest,
 			wantBodyErr: presenters.ErrorRes{
@@ -137,7 +230,7 @@ func TestFruitController_Create(t *testing.T) {
 				Name:      "Testing",
 				Price:     price,
 				ExpiresIn: "1m",
-				BucketID:  &bucketID,
+				BucketID:  presenters.BucketIDReq{ID: &bucketID},
 			},
 			wantCode: http.StatusBadRequest,
 			wantBodyErr: presenters.ErrorRes{
@@ -145,6 +238,21 @@ func TestFruitController_Create(t *testing.T) {
 				Message: "Bucket is full",
 			},
 		},
+		"should throw conflict exception": {
+			mock: func(service *mocks.MockFruitService) {
+				service.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil, exceptions.NewConflictException("Fruit sku or barcode already exists"))
+			},
+			body: presenters.CreateFruitReq{
+				Name:      "Testing",
+				Price:     price,
+				ExpiresIn: "1m",
+			},
+			wantCode: http.StatusConflict,
+			wantBodyErr: presenters.ErrorRes{
+				Error:   exceptions.ConflictExceptionName,
+				Message: "Fruit sku or barcode already exists",
+			},
+		},
 		"should throw internal server error": {
 			mock: func(service *mocks.MockFruitService) {
 				service.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil, fmt.Errorf("error"))
@@ -194,86 +302,112 @@ func TestFruitController_Create(t *testing.T) {
 	}
 }
 
-func TestFruitController_AddOnBucket(t *testing.T) {
+func TestFruitController_CreateBatch(t *testing.T) {
+	now := time.Date(2000, 12, 31, 23, 59, 59, 0, time.Local)
+	expiresIn, _ := time.ParseDuration("1m")
+	fruitID := int64(1)
+
+	item := presenters.CreateFruitReq{Name: "Testing", Price: decimal.NewFromInt(1), ExpiresIn: "1m"}
+	itemDto := dtos.CreateFruitDto{Name: "Testing", Price: decimal.NewFromInt(1), ExpiresIn: &expiresIn}
+
 	tests := map[string]struct {
-		mock          func(service *mocks.MockFruitService)
-		fruitIDParam  string
-		bucketIDParam string
-		wantCode      int
-		wantBodyErr   presenters.ErrorRes
+		mock        func(service *mocks.MockFruitService)
+		body        presenters.CreateFruitsBatchReq
+		wantCode    int
+		wantBody    presenters.FruitsBatchRes
+		wantBodyErr presenters.ErrorRes
 	}{
-		"should be success": {
+		"should create every item in all_or_nothing mode by default": {
 			mock: func(service *mocks.MockFruitService) {
-				service.EXPECT().AddOnBucket(gomock.Any(), int64(1), int64(1)).Return(nil)
+				data := dtos.CreateFruitsBatchDto{Mode: models.FruitBatchModeAllOrNothing, Items: []dtos.CreateFruitDto{itemDto}}
+				service.EXPECT().CreateBatch(gomock.Any(), data).Return(&models.FruitBatch{
+					Results: []models.FruitBatchResult{{Index: 0, Fruit: &models.Fruit{ID: 1, CreatedAt: now}}},
+					Created: 1,
+				}, nil)
 			},
-			fruitIDParam:  "1",
-			bucketIDParam: "1",
-			wantCode:      http.StatusOK,
-		},
-		"should throw validation exception when fruitID is invalid": {
-			mock:          func(service *mocks.MockFruitService) {},
-			fruitIDParam:  "invalid",
-			bucketIDParam: "1",
-			wantCode:      http.StatusBadRequest,
-			wantBodyErr: presenters.ErrorRes{
-				Error:   exceptions.ValidationExceptionName,
-				Message: "invalid fruitID",
+			body:     presenters.CreateFruitsBatchReq{Items: []presenters.CreateFruitReq{item}},
+			wantCode: http.StatusCreated,
+			wantBody: presenters.FruitsBatchRes{
+				Data:    []presenters.FruitBatchItemRes{{Index: 0, ID: &fruitID}},
+				Created: 1,
 			},
 		},
-		"should throw validation exception when bucketID is invalid": {
-			mock:          func(service *mocks.MockFruitService) {},
-			fruitIDParam:  "1",
-			bucketIDParam: "invalid",
-			wantCode:      http.StatusBadRequest,
-			wantBodyErr: presenters.ErrorRes{
-				Error:   exceptions.ValidationExceptionName,
-				Message: "invalid bucketID",
+		"should return multi status when some items fail in best_effort mode": {
+			mock: func(service *mocks.MockFruitService) {
+				service.EXPECT().CreateBatch(gomock.Any(), gomock.Any()).Return(&models.FruitBatch{
+					Results: []models.FruitBatchResult{
+						{Index: 0, Fruit: &models.Fruit{ID: 1, CreatedAt: now}},
+						{Index: 1, Err: exceptions.NewValidationException(validator.ValidationErrors{})},
+						{Index: 2, Err: exceptions.NewForbiddenException("Bucket is full")},
+					},
+					Created: 1,
+					Failed:  2,
+				}, nil)
+			},
+			body:     presenters.CreateFruitsBatchReq{Mode: models.FruitBatchModeBestEffort, Items: []presenters.CreateFruitReq{item, {}, item}},
+			wantCode: http.StatusMultiStatus,
+			wantBody: presenters.FruitsBatchRes{
+				Data: []presenters.FruitBatchItemRes{
+					{Index: 0, ID: &fruitID},
+					{Index: 1, Error: exceptions.ValidationExceptionName},
+					{Index: 2, Error: exceptions.ForbiddenExceptionName, Message: "Bucket is full"},
+				},
+				Created: 1,
+				Failed:  2,
 			},
 		},
-		"should throw foreign not found exception": {
+		"should return bad request when nothing was created": {
 			mock: func(service *mocks.MockFruitService) {
-				service.EXPECT().AddOnBucket(gomock.Any(), gomock.Any(), gomock.Any()).Return(exceptions.NewForeignNotFoundException("Bucket not found"))
+				service.EXPECT().CreateBatch(gomock.Any(), gomock.Any()).Return(&models.FruitBatch{
+					Results: []models.FruitBatchResult{
+						{Index: 0, Err: exceptions.NewConflictException("Fruit sku or barcode already exists")},
+					},
+					Failed: 1,
+				}, nil)
 			},
-			fruitIDParam:  "1",
-			bucketIDParam: "1",
-			wantCode:      http.StatusBadRequest,
+			body:     presenters.CreateFruitsBatchReq{Items: []presenters.CreateFruitReq{item}},
+			wantCode: http.StatusBadRequest,
+			wantBody: presenters.FruitsBatchRes{
+				Data: []presenters.FruitBatchItemRes{
+					{Index: 0, Error: exceptions.ConflictExceptionName, Message: "Fruit sku or barcode already exists"},
+				},
+				Failed: 1,
+			},
+		},
+		"should throw validation exception when bucket_id is auto": {
+			mock:     func(service *mocks.MockFruitService) {},
+			body:     presenters.CreateFruitsBatchReq{Items: []presenters.CreateFruitReq{item, {BucketID: presenters.BucketIDReq{Auto: true}}}},
+			wantCode: http.StatusBadRequest,
 			wantBodyErr: presenters.ErrorRes{
 				Error:   exceptions.ValidationExceptionName,
-				Message: "Bucket not found",
+				Message: "items[1]: bucket_id auto is not supported in batches",
 			},
 		},
-		"should throw forbidden exception": {
-			mock: func(service *mocks.MockFruitService) {
-				service.EXPECT().AddOnBucket(gomock.Any(), gomock.Any(), gomock.Any()).Return(exceptions.NewForbiddenException("Bucket is full"))
-			},
-			fruitIDParam:  "1",
-			bucketIDParam: "1",
-			wantCode:      http.StatusBadRequest,
+		"should throw validation exception when harvested_at is invalid": {
+			mock:     func(service *mocks.MockFruitService) {},
+			body:     presenters.CreateFruitsBatchReq{Items: []presenters.CreateFruitReq{item, {HarvestedAt: "invalid"}}},
+			wantCode: http.StatusBadRequest,
 			wantBodyErr: presenters.ErrorRes{
 				Error:   exceptions.ValidationExceptionName,
-				Message: "Bucket is full",
+				Message: "items[1]: invalid harvested_at",
 			},
 		},
-		"should throw not found exception": {
+		"should throw validation exception": {
 			mock: func(service *mocks.MockFruitService) {
-				service.EXPECT().AddOnBucket(gomock.Any(), gomock.Any(), gomock.Any()).Return(exceptions.NewNotFoundException("Fruit not found"))
-			},
-			fruitIDParam:  "1",
-			bucketIDParam: "1",
-			wantCode:      http.StatusBadRequest,
-			wantBodyErr: presenters.ErrorRes{
-				Error:   exceptions.NotFoundExceptionName,
-				Message: "Fruit not found",
+				service.EXPECT().CreateBatch(gomock.Any(), gomock.Any()).
+					Return(nil, exceptions.NewValidationException(validator.ValidationErrors{}))
 			},
+			body:        presenters.CreateFruitsBatchReq{},
+			wantCode:    http.StatusBadRequest,
+			wantBodyErr: presenters.ErrorRes{Error: exceptions.ValidationExceptionName},
 		},
 		"should throw internal server error": {
 			mock: func(service *mocks.MockFruitService) {
-				service.EXPECT().AddOnBucket(gomock.Any(), gomock.Any(), gomock.Any()).Return(fmt.Errorf("error"))
+				service.EXPECT().CreateBatch(gomock.Any(), gomock.Any()).Return(nil, fmt.Errorf("error"))
 			},
-			fruitIDParam:  "1",
-			bucketIDParam: "1",
-			wantCode:      http.StatusInternalServerError,
-			wantBodyErr:   presenters.ErrorRes{Error: http.StatusText(http.StatusInternalServerError)},
+			body:        presenters.CreateFruitsBatchReq{Items: []presenters.CreateFruitReq{item}},
+			wantCode:    http.StatusInternalServerError,
+			wantBodyErr: presenters.ErrorRes{Error: http.StatusText(http.StatusInternalServerError)},
 		},
 	}
 	for name, tt := range tests {
@@ -287,61 +421,90 @@ func TestFruitController_AddOnBucket(t *testing.T) {
 			r := gin.Default()
 			controller := NewFruit(serviceMock)
 
-			r.POST("/api/v1/fruits/:fruitID/buckets/:bucketID", controller.AddOnBucket)
+			r.POST("/api/v1/fruits/batch", controller.CreateBatch)
 
+			var got presenters.FruitsBatchRes
 			var gotErr presenters.ErrorRes
 
 			// given
-			path := fmt.Sprintf("/api/v1/fruits/%s/buckets/%s", tt.fruitIDParam, tt.bucketIDParam)
+			body, _ := json.Marshal(tt.body)
 			w := httptest.NewRecorder()
-			req, _ := http.NewRequest("POST", path, nil)
+			req, _ := http.NewRequest("POST", "/api/v1/fruits/batch", bytes.NewBuffer(body))
 
 			// when
 			r.ServeHTTP(w, req)
 
-			errBodyErr := json.Unmarshal(w.Body.Bytes(), &gotErr)
+			json.Unmarshal(w.Body.Bytes(), &gotErr)
 
 			// then
 			assert.Equal(t, tt.wantCode, w.Code)
 
-			if errBodyErr != nil {
+			if gotErr.Error != "" {
 				assert.Equal(t, tt.wantBodyErr, gotErr)
 				return
 			}
+
+			json.Unmarshal(w.Body.Bytes(), &got)
+			assert.Equal(t, tt.wantBody, got)
 		})
 	}
 }
 
-func TestFruitController_RemoveFromBucket(t *testing.T) {
+func TestFruitController_GetByBarcode(t *testing.T) {
+	now := time.Date(2000, 12, 31, 23, 59, 59, 0, time.Local)
+	price, _ := decimal.NewFromString("1.99")
+	effectivePrice, _ := decimal.NewFromString("1.59")
+	barcode := "7891234567895"
+
 	tests := map[string]struct {
-		mock         func(service *mocks.MockFruitService)
-		fruitIDParam string
-		wantCode     int
-		wantBodyErr  presenters.ErrorRes
+		mock        func(service *mocks.MockFruitService)
+		codeParam   string
+		wantCode    int
+		wantBody    presenters.FruitRes
+		wantBodyErr presenters.ErrorRes
 	}{
 		"should be success": {
 			mock: func(service *mocks.MockFruitService) {
-				service.EXPECT().RemoveFromBucket(gomock.Any(), int64(1)).Return(nil)
+				service.EXPECT().GetByBarcode(gomock.Any(), barcode).Return(&models.Fruit{
+					ID:             1,
+					CreatedAt:      now,
+					Name:           "Testing",
+					Price:          price,
+					EffectivePrice: effectivePrice,
+					ExpiresAt:      now,
+					Barcode:        &barcode,
+				}, nil)
+			},
+			codeParam: barcode,
+			wantCode:  http.StatusOK,
+			wantBody: presenters.FruitRes{
+				ID:             1,
+				CreatedAt:      "2000-12-31 23:59:59",
+				Name:           "Testing",
+				Price:          price,
+				EffectivePrice: effectivePrice,
+				ExpiresAt:      "2000-12-31 23:59:59",
+				Barcode:        &barcode,
 			},
-			fruitIDParam: "1",
-			wantCode:     http.StatusOK,
 		},
-		"should throw validation exception when fruitID is invalid": {
-			mock:         func(service *mocks.MockFruitService) {},
-			fruitIDParam: "invalid",
-			wantCode:     http.StatusBadRequest,
+		"should throw not found exception": {
+			mock: func(service *mocks.MockFruitService) {
+				service.EXPECT().GetByBarcode(gomock.Any(), gomock.Any()).Return(nil, exceptions.NewNotFoundException("Fruit not found"))
+			},
+			codeParam: barcode,
+			wantCode:  http.StatusNotFound,
 			wantBodyErr: presenters.ErrorRes{
-				Error:   exceptions.ValidationExceptionName,
-				Message: "invalid fruitID",
+				Error:   exceptions.NotFoundExceptionName,
+				Message: "Fruit not found",
 			},
 		},
 		"should throw internal server error": {
 			mock: func(service *mocks.MockFruitService) {
-				service.EXPECT().RemoveFromBucket(gomock.Any(), gomock.Any()).Return(fmt.Errorf("error"))
+				service.EXPECT().GetByBarcode(gomock.Any(), gomock.Any()).Return(nil, fmt.Errorf("error"))
 			},
-			fruitIDParam: "1",
-			wantCode:     http.StatusInternalServerError,
-			wantBodyErr:  presenters.ErrorRes{Error: http.StatusText(http.StatusInternalServerError)},
+			codeParam:   barcode,
+			wantCode:    http.StatusInternalServerError,
+			wantBodyErr: presenters.ErrorRes{Error: http.StatusText(http.StatusInternalServerError)},
 		},
 	}
 	for name, tt := range tests {
@@ -355,61 +518,143 @@ func TestFruitController_RemoveFromBucket(t *testing.T) {
 			r := gin.Default()
 			controller := NewFruit(serviceMock)
 
-			r.DELETE("/api/v1/fruits/:fruitID/buckets", controller.RemoveFromBucket)
+			r.GET("/api/v1/fruits/by-barcode/:code", controller.GetByBarcode)
 
+			var got presenters.FruitRes
 			var gotErr presenters.ErrorRes
 
 			// given
-			path := fmt.Sprintf("/api/v1/fruits/%s/buckets", tt.fruitIDParam)
+			path := fmt.Sprintf("/api/v1/fruits/by-barcode/%s", tt.codeParam)
 			w := httptest.NewRecorder()
-			req, _ := http.NewRequest("DELETE", path, nil)
+			req, _ := http.NewRequest("GET", path, nil)
 
 			// when
 			r.ServeHTTP(w, req)
 
-			errBodyErr := json.Unmarshal(w.Body.Bytes(), &gotErr)
+			json.Unmarshal(w.Body.Bytes(), &gotErr)
+			json.Unmarshal(w.Body.Bytes(), &got)
 
 			// then
 			assert.Equal(t, tt.wantCode, w.Code)
 
-			if errBodyErr != nil {
+			if gotErr.Error != "" {
 				assert.Equal(t, tt.wantBodyErr, gotErr)
 				return
 			}
+			assert.Equal(t, tt.wantBody, got)
 		})
 	}
 }
 
-func TestFruitController_Delete(t *testing.T) {
+func TestFruitController_ListExpiring(t *testing.T) {
+	now := time.Date(2000, 12, 31, 23, 59, 59, 0, time.Local)
+	price, _ := decimal.NewFromString("1.99")
+	bucketID := int64(1)
+
 	tests := map[string]struct {
-		mock         func(service *mocks.MockFruitService)
-		fruitIDParam string
-		wantCode     int
-		wantBodyErr  presenters.ErrorRes
+		mock        func(service *mocks.MockFruitService)
+		query       string
+		wantCode    int
+		wantBody    presenters.ExpiringFruitsRes
+		wantBodyErr presenters.ErrorRes
 	}{
 		"should be success": {
 			mock: func(service *mocks.MockFruitService) {
-				service.EXPECT().Delete(gomock.Any(), int64(1)).Return(nil)
+				service.EXPECT().ListExpiring(gomock.Any(), 12*time.Hour).Return(&models.ExpiringFruits{
+					Buckets: []models.ExpiringBucketFruits{
+						{
+							BucketID:   &bucketID,
+							BucketName: "A",
+							Fruits: []models.Fruit{
+								{ID: 1, CreatedAt: now, Name: "Testing", Price: price, EffectivePrice: price, ExpiresAt: now, State: models.FruitStateRipe, BucketID: &bucketID},
+							},
+							TotalPrice: price,
+
+							TotalEffectivePrice: price,
+						},
+						{
+							Fruits: []models.Fruit{
+								{ID: 2, CreatedAt: now, Name: "Testing", Price: price, EffectivePrice: price, ExpiresAt: now, State: models.FruitStateRipe},
+							},
+							TotalPrice: price,
+
+							TotalEffectivePrice: price,
+						},
+					},
+					TotalPrice: price.Add(price),
+
+					TotalEffectivePrice: price.Add(price),
+				}, nil)
+			},
+			query:    "?within=12h",
+			wantCode: http.StatusOK,
+			wantBody: presenters.ExpiringFruitsRes{
+				Data: []presenters.ExpiringBucketFruitsRes{
+					{
+						BucketID:   &bucketID,
+						BucketName: "A",
+						Fruits: []presenters.FruitRes{
+							{ID: 1, CreatedAt: "2000-12-31 23:59:59", BucketID: &bucketID, Name: "Testing", Price: price, EffectivePrice: price, ExpiresAt: "2000-12-31 23:59:59", State: "ripe"},
+						},
+						TotalPrice: price,
+
+						TotalEffectivePrice: price,
+					},
+					{
+						Fruits: []presenters.FruitRes{
+							{ID: 2, CreatedAt: "2000-12-31 23:59:59", Name: "Testing", Price: price, EffectivePrice: price, ExpiresAt: "2000-12-31 23:59:59", State: "ripe"},
+						},
+						TotalPrice: price,
+
+						TotalEffectivePrice: price,
+					},
+				},
+				TotalPrice: price.Add(price),
+
+				TotalEffectivePrice: price.Add(price),
 			},
-			fruitIDParam: "1",
-			wantCode:     http.StatusOK,
 		},
-		"should throw validation exception when fruitID is invalid": {
-			mock:         func(service *mocks.MockFruitService) {},
-			fruitIDParam: "invalid",
-			wantCode:     http.StatusBadRequest,
+		"should be success with default window": {
+			mock: func(service *mocks.MockFruitService) {
+				service.EXPECT().ListExpiring(gomock.Any(), 48*time.Hour).Return(&models.ExpiringFruits{
+					Buckets:    []models.ExpiringBucketFruits{},
+					TotalPrice: decimal.Zero,
+
+					TotalEffectivePrice: decimal.Zero,
+				}, nil)
+			},
+			wantCode: http.StatusOK,
+			wantBody: presenters.ExpiringFruitsRes{
+				Data:       []presenters.ExpiringBucketFruitsRes{},
+				TotalPrice: decimal.NewFromInt(0),
+
+				TotalEffectivePrice: decimal.NewFromInt(0),
+			},
+		},
+		"should throw bad request when within is invalid": {
+			mock:     func(service *mocks.MockFruitService) {},
+			query:    "?within=tomorrow",
+			wantCode: http.StatusBadRequest,
 			wantBodyErr: presenters.ErrorRes{
 				Error:   exceptions.ValidationExceptionName,
-				Message: "invalid fruitID",
+				Message: "invalid within",
+			},
+		},
+		"should throw bad request when within is not positive": {
+			mock:     func(service *mocks.MockFruitService) {},
+			query:    "?within=-1h",
+			wantCode: http.StatusBadRequest,
+			wantBodyErr: presenters.ErrorRes{
+				Error:   exceptions.ValidationExceptionName,
+				Message: "invalid within",
 			},
 		},
 		"should throw internal server error": {
 			mock: func(service *mocks.MockFruitService) {
-				service.EXPECT().Delete(gomock.Any(), gomock.Any()).Return(fmt.Errorf("error"))
+				service.EXPECT().ListExpiring(gomock.Any(), gomock.Any()).Return(nil, fmt.Errorf("error"))
 			},
-			fruitIDParam: "1",
-			wantCode:     http.StatusInternalServerError,
-			wantBodyErr:  presenters.ErrorRes{Error: http.StatusText(http.StatusInternalServerError)},
+			wantCode:    http.StatusInternalServerError,
+			wantBodyErr: presenters.ErrorRes{Error: http.StatusText(http.StatusInternalServerError)},
 		},
 	}
 	for name, tt := range tests {
@@ -423,27 +668,802 @@ func TestFruitController_Delete(t *testing.T) {
 			r := gin.Default()
 			controller := NewFruit(serviceMock)
 
-			r.DELETE("/api/v1/fruits/:fruitID", controller.Delete)
+			r.GET("/api/v1/fruits/expiring", controller.ListExpiring)
 
+			var got presenters.ExpiringFruitsRes
 			var gotErr presenters.ErrorRes
 
 			// given
-			path := fmt.Sprintf("/api/v1/fruits/%s", tt.fruitIDParam)
 			w := httptest.NewRecorder()
-			req, _ := http.NewRequest("DELETE", path, nil)
+			req, _ := http.NewRequest("GET", "/api/v1/fruits/expiring"+tt.query, nil)
 
 			// when
 			r.ServeHTTP(w, req)
 
-			errBodyErr := json.Unmarshal(w.Body.Bytes(), &gotErr)
+			json.Unmarshal(w.Body.Bytes(), &gotErr)
+			json.Unmarshal(w.Body.Bytes(), &got)
 
 			// then
 			assert.Equal(t, tt.wantCode, w.Code)
 
-			if errBodyErr != nil {
+			if gotErr.Error != "" {
 				assert.Equal(t, tt.wantBodyErr, gotErr)
 				return
 			}
+			assert.Equal(t, tt.wantBody, got)
+		})
+	}
+}
+
+func TestFruitController_AddOnBucket(t *testing.T) {
+	tests := map[string]struct {
+		mock          func(service *mocks.MockFruitService)
+		fruitIDParam  string
+		bucketIDParam string
+		wantCode      int
+		wantBodyErr   presenters.ErrorRes
+	}{
+		"should be success": {
+			mock: func(service *mocks.MockFruitService) {
+				service.EXPECT().AddOnBucket(gomock.Any(), int64(1), int64(1)).Return(nil)
+			},
+			fruitIDParam:  "1",
+			bucketIDParam: "1",
+			wantCode:      http.StatusOK,
+		},
+		"should throw validation exception when fruitID is invalid": {
+			mock:          func(service *mocks.MockFruitService) {},
+			fruitIDParam:  "invalid",
+			bucketIDParam: "1",
+			wantCode:      http.StatusBadRequest,
+			wantBodyErr: presenters.ErrorRes{
+				Error:   exceptions.ValidationExceptionName,
+				Message: "invalid fruitID",
+			},
+		},
+		"should throw validation exception when bucketID is invalid": {
+			mock:          func(service *mocks.MockFruitService) {},
+			fruitIDParam:  "1",
+			bucketIDParam: "invalid",
+			wantCode:      http.StatusBadRequest,
+			wantBodyErr: presenters.ErrorRes{
+				Error:   exceptions.ValidationExceptionName,
+				Message: "invalid bucketID",
+			},
+		},
+		"should throw foreign not found exception": {
+			mock: func(service *mocks.MockFruitService) {
+				service.EXPECT().AddOnBucket(gomock.Any(), gomock.Any(), gomock.Any()).Return(exceptions.NewForeignNotFoundException("Bucket not found"))
+			},
+			fruitIDParam:  "1",
+			bucketIDParam: "1",
+			wantCode:      http.StatusBadRequest,
+			wantBodyErr: presenters.ErrorRes{
+				Error:   exceptions.ValidationExceptionName,
+				Message: "Bucket not found",
+			},
+		},
+		"should throw forbidden exception": {
+			mock: func(service *mocks.MockFruitService) {
+				service.EXPECT().AddOnBucket(gomock.Any(), gomock.Any(), gomock.Any()).Return(exceptions.NewForbiddenException("Bucket is full"))
+			},
+			fruitIDParam:  "1",
+			bucketIDParam: "1",
+			wantCode:      http.StatusBadRequest,
+			wantBodyErr: presenters.ErrorRes{
+				Error:   exceptions.ValidationExceptionName,
+				Message: "Bucket is full",
+			},
+		},
+		"should throw not found exception": {
+			mock: func(service *mocks.MockFruitService) {
+				service.EXPECT().AddOnBucket(gomock.Any(), gomock.Any(), gomock.Any()).Return(exceptions.NewNotFoundException("Fruit not found"))
+			},
+			fruitIDParam:  "1",
+			bucketIDParam: "1",
+			wantCode:      http.StatusBadRequest,
+			wantBodyErr: presenters.ErrorRes{
+				Error:   exceptions.NotFoundExceptionName,
+				Message: "Fruit not found",
+			},
+		},
+		"should throw internal server error": {
+			mock: func(service *mocks.MockFruitService) {
+				service.EXPECT().AddOnBucket(gomock.Any(), gomock.Any(), gomock.Any()).Return(fmt.Errorf("error"))
+			},
+			fruitIDParam:  "1",
+			bucketIDParam: "1",
+			wantCode:      http.StatusInternalServerError,
+			wantBodyErr:   presenters.ErrorRes{Error: http.StatusText(http.StatusInternalServerError)},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			// setup
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			serviceMock := mocks.NewMockFruitService(ctrl)
+			tt.mock(serviceMock)
+
+			r := gin.Default()
+			controller := NewFruit(serviceMock)
+
+			r.POST("/api/v1/fruits/:fruitID/buckets/:bucketID", controller.AddOnBucket)
+
+			var gotErr presenters.ErrorRes
+
+			// given
+			path := fmt.Sprintf("/api/v1/fruits/%s/buckets/%s", tt.fruitIDParam, tt.bucketIDParam)
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("POST", path, nil)
+
+			// when
+			r.ServeHTTP(w, req)
+
+			errBodyErr := json.Unmarshal(w.Body.Bytes(), &gotErr)
+
+			// then
+			assert.Equal(t, tt.wantCode, w.Code)
+
+			if errBodyErr != nil {
+				assert.Equal(t, tt.wantBodyErr, gotErr)
+				return
+			}
+		})
+	}
+}
+
+func TestFruitController_Transfer(t *testing.T) {
+	now := time.Date(2000, 12, 31, 23, 59, 59, 0, time.Local)
+	fromBucketID := int64(1)
+	toBucketID := int64(2)
+
+	tests := map[string]struct {
+		mock         func(service *mocks.MockFruitService)
+		fruitIDParam string
+		body         presenters.TransferFruitReq
+		wantCode     int
+		wantBody     presenters.FruitTransferRes
+		wantBodyErr  presenters.ErrorRes
+	}{
+		"should be success": {
+			mock: func(service *mocks.MockFruitService) {
+				service.EXPECT().Transfer(gomock.Any(), int64(1), dtos.TransferFruitDto{ToBucketID: &toBucketID, FromBucketID: &fromBucketID}).
+					Return(&models.FruitTransfer{
+						FruitID:    1,
+						FromBucket: &models.Bucket{ID: fromBucketID, CreatedAt: now, Name: "A", Capacity: 1},
+						ToBucket:   models.Bucket{ID: toBucketID, CreatedAt: now, Name: "B", Capacity: 1},
+						Moved:      true,
+					}, nil)
+			},
+			fruitIDParam: "1",
+			body:         presenters.TransferFruitReq{ToBucketID: &toBucketID, FromBucketID: &fromBucketID},
+			wantCode:     http.StatusOK,
+			wantBody: presenters.FruitTransferRes{
+				FruitID: 1,
+				From:    &presenters.BucketRes{ID: fromBucketID, CreatedAt: "2000-12-31 23:59:59", Name: "A", Capacity: 1},
+				To:      presenters.BucketRes{ID: toBucketID, CreatedAt: "2000-12-31 23:59:59", Name: "B", Capacity: 1},
+				Moved:   true,
+			},
+		},
+		"should throw validation exception when fruitID is invalid": {
+			mock:         func(service *mocks.MockFruitService) {},
+			fruitIDParam: "invalid",
+			wantCode:     http.StatusBadRequest,
+			wantBodyErr: presenters.ErrorRes{
+				Error:   exceptions.ValidationExceptionName,
+				Message: "invalid fruitID",
+			},
+		},
+		"should throw validation exception": {
+			mock: func(service *mocks.MockFruitService) {
+				service.EXPECT().Transfer(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil, exceptions.NewValidationException(validator.ValidationErrors{}))
+			},
+			fruitIDParam: "1",
+			wantCode:     http.StatusBadRequest,
+			wantBodyErr: presenters.ErrorRes{
+				Error: exceptions.ValidationExceptionName,
+			},
+		},
+		"should throw forbidden exception": {
+			mock: func(service *mocks.MockFruitService) {
+				service.EXPECT().Transfer(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil, exceptions.NewForbiddenException("Bucket is full"))
+			},
+			fruitIDParam: "1",
+			body:         presenters.TransferFruitReq{ToBucketID: &toBucketID},
+			wantCode:     http.StatusBadRequest,
+			wantBodyErr: presenters.ErrorRes{
+				Error:   exceptions.ForbiddenExceptionName,
+				Message: "Bucket is full",
+			},
+		},
+		"should throw not found exception": {
+			mock: func(service *mocks.MockFruitService) {
+				service.EXPECT().Transfer(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil, exceptions.NewNotFoundException("Fruit not found"))
+			},
+			fruitIDParam: "1",
+			body:         presenters.TransferFruitReq{ToBucketID: &toBucketID},
+			wantCode:     http.StatusNotFound,
+			wantBodyErr: presenters.ErrorRes{
+				Error:   exceptions.NotFoundExceptionName,
+				Message: "Fruit not found",
+			},
+		},
+		"should throw conflict exception": {
+			mock: func(service *mocks.MockFruitService) {
+				service.EXPECT().Transfer(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil, exceptions.NewConflictException("Fruit is not in the expected bucket"))
+			},
+			fruitIDParam: "1",
+			body:         presenters.TransferFruitReq{ToBucketID: &toBucketID, FromBucketID: &fromBucketID},
+			wantCode:     http.StatusConflict,
+			wantBodyErr: presenters.ErrorRes{
+				Error:   exceptions.ConflictExceptionName,
+				Message: "Fruit is not in the expected bucket",
+			},
+		},
+		"should throw internal server error": {
+			mock: func(service *mocks.MockFruitService) {
+				service.EXPECT().Transfer(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil, fmt.Errorf("error"))
+			},
+			fruitIDParam: "1",
+			body:         presenters.TransferFruitReq{ToBucketID: &toBucketID},
+			wantCode:     http.StatusInternalServerError,
+			wantBodyErr:  presenters.ErrorRes{Error: http.StatusText(http.StatusInternalServerError)},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			// setup
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			serviceMock := mocks.NewMockFruitService(ctrl)
+			tt.mock(serviceMock)
+
+			r := gin.Default()
+			controller := NewFruit(serviceMock)
+
+			r.POST("/api/v1/fruits/:fruitID/transfer", controller.Transfer)
+
+			var got presenters.FruitTransferRes
+			var gotErr presenters.ErrorRes
+
+			// given
+			body, _ := json.Marshal(tt.body)
+			path := fmt.Sprintf("/api/v1/fruits/%s/transfer", tt.fruitIDParam)
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("POST", path, bytes.NewBuffer(body))
+
+			// when
+			r.ServeHTTP(w, req)
+
+			json.Unmarshal(w.Body.Bytes(), &gotErr)
+
+			// then
+			assert.Equal(t, tt.wantCode, w.Code)
+
+			if gotErr.Error != "" {
+				assert.Equal(t, tt.wantBodyErr, gotErr)
+				return
+			}
+
+			json.Unmarshal(w.Body.Bytes(), &got)
+			assert.Equal(t, tt.wantBody, got)
+		})
+	}
+}
+
+func TestFruitController_MoveMany(t *testing.T) {
+	bucketID := int64(1)
+	toBucketID := int64(2)
+
+	tests := map[string]struct {
+		mock        func(service *mocks.MockFruitService)
+		body        presenters.MoveFruitsReq
+		wantCode    int
+		wantBody    presenters.FruitsBulkRes
+		wantBodyErr presenters.ErrorRes
+	}{
+		"should be success": {
+			mock: func(service *mocks.MockFruitService) {
+				data := dtos.MoveFruitsDto{
+					SelectFruitsDto: dtos.SelectFruitsDto{Filter: &dtos.FilterFruitsDto{BucketID: &bucketID}},
+					ToBucketID:      &toBucketID,
+				}
+				service.EXPECT().MoveMany(gomock.Any(), data).
+					Return(&models.FruitBulk{FruitIDs: []int64{1, 2}, Affected: 2}, nil)
+			},
+			body: presenters.MoveFruitsReq{
+				SelectFruitsReq: presenters.SelectFruitsReq{Filter: &presenters.FilterFruitsReq{BucketID: &bucketID}},
+				ToBucketID:      &toBucketID,
+			},
+			wantCode: http.StatusOK,
+			wantBody: presenters.FruitsBulkRes{FruitIDs: []int64{1, 2}, Affected: 2},
+		},
+		"should throw validation exception": {
+			mock: func(service *mocks.MockFruitService) {
+				service.EXPECT().MoveMany(gomock.Any(), gomock.Any()).
+					Return(nil, exceptions.NewValidationException(validator.ValidationErrors{}))
+			},
+			wantCode:    http.StatusBadRequest,
+			wantBodyErr: presenters.ErrorRes{Error: exceptions.ValidationExceptionName},
+		},
+		"should throw forbidden exception": {
+			mock: func(service *mocks.MockFruitService) {
+				service.EXPECT().MoveMany(gomock.Any(), gomock.Any()).
+					Return(nil, exceptions.NewForbiddenException("Bucket is full"))
+			},
+			body: presenters.MoveFruitsReq{
+				SelectFruitsReq: presenters.SelectFruitsReq{IDs: []int64{1, 2}},
+				ToBucketID:      &toBucketID,
+			},
+			wantCode:    http.StatusBadRequest,
+			wantBodyErr: presenters.ErrorRes{Error: exceptions.ForbiddenExceptionName, Message: "Bucket is full"},
+		},
+		"should throw foreign not found exception": {
+			mock: func(service *mocks.MockFruitService) {
+				service.EXPECT().MoveMany(gomock.Any(), gomock.Any()).
+					Return(nil, exceptions.NewForeignNotFoundException("Bucket not found"))
+			},
+			body: presenters.MoveFruitsReq{
+				SelectFruitsReq: presenters.SelectFruitsReq{IDs: []int64{1}},
+				ToBucketID:      &toBucketID,
+			},
+			wantCode:    http.StatusBadRequest,
+			wantBodyErr: presenters.ErrorRes{Error: exceptions.ForeignNotFoundExceptionName, Message: "Bucket not found"},
+		},
+		"should throw not found exception": {
+			mock: func(service *mocks.MockFruitService) {
+				service.EXPECT().MoveMany(gomock.Any(), gomock.Any()).
+					Return(nil, exceptions.NewNotFoundException("Fruits not found: 3"))
+			},
+			body: presenters.MoveFruitsReq{
+				SelectFruitsReq: presenters.SelectFruitsReq{IDs: []int64{3}},
+				ToBucketID:      &toBucketID,
+			},
+			wantCode:    http.StatusNotFound,
+			wantBodyErr: presenters.ErrorRes{Error: exceptions.NotFoundExceptionName, Message: "Fruits not found: 3"},
+		},
+		"should throw internal server error": {
+			mock: func(service *mocks.MockFruitService) {
+				service.EXPECT().MoveMany(gomock.Any(), gomock.Any()).Return(nil, fmt.Errorf("error"))
+			},
+			body: presenters.MoveFruitsReq{
+				SelectFruitsReq: presenters.SelectFruitsReq{IDs: []int64{1}},
+				ToBucketID:      &toBucketID,
+			},
+			wantCode:    http.StatusInternalServerError,
+			wantBodyErr: presenters.ErrorRes{Error: http.StatusText(http.StatusInternalServerError)},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			// setup
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			serviceMock := mocks.NewMockFruitService(ctrl)
+			tt.mock(serviceMock)
+
+			r := gin.Default()
+			controller := NewFruit(serviceMock)
+
+			r.POST("/api/v1/fruits/bulk/move", controller.MoveMany)
+
+			var got presenters.FruitsBulkRes
+			var gotErr presenters.ErrorRes
+
+			// given
+			body, _ := json.Marshal(tt.body)
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("POST", "/api/v1/fruits/bulk/move", bytes.NewBuffer(body))
+
+			// when
+			r.ServeHTTP(w, req)
+
+			json.Unmarshal(w.Body.Bytes(), &gotErr)
+
+			// then
+			assert.Equal(t, tt.wantCode, w.Code)
+
+			if gotErr.Error != "" {
+				assert.Equal(t, tt.wantBodyErr, gotErr)
+				return
+			}
+
+			json.Unmarshal(w.Body.Bytes(), &got)
+			assert.Equal(t, tt.wantBody, got)
+		})
+	}
+}
+
+func TestFruitController_UnassignMany(t *testing.T) {
+	bucketID := int64(1)
+
+	tests := map[string]struct {
+		mock        func(service *mocks.MockFruitService)
+		body        presenters.SelectFruitsReq
+		wantCode    int
+		wantBody    presenters.FruitsBulkRes
+		wantBodyErr presenters.ErrorRes
+	}{
+		"should be success": {
+			mock: func(service *mocks.MockFruitService) {
+				data := dtos.SelectFruitsDto{Filter: &dtos.FilterFruitsDto{BucketID: &bucketID}}
+				service.EXPECT().UnassignMany(gomock.Any(), data).
+					Return(&models.FruitBulk{FruitIDs: []int64{1}, Affected: 1}, nil)
+			},
+			body:     presenters.SelectFruitsReq{Filter: &presenters.FilterFruitsReq{BucketID: &bucketID}},
+			wantCode: http.StatusOK,
+			wantBody: presenters.FruitsBulkRes{FruitIDs: []int64{1}, Affected: 1},
+		},
+		"should throw internal server error": {
+			mock: func(service *mocks.MockFruitService) {
+				service.EXPECT().UnassignMany(gomock.Any(), gomock.Any()).Return(nil, fmt.Errorf("error"))
+			},
+			body:        presenters.SelectFruitsReq{IDs: []int64{1}},
+			wantCode:    http.StatusInternalServerError,
+			wantBodyErr: presenters.ErrorRes{Error: http.StatusText(http.StatusInternalServerError)},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			// setup
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			serviceMock := mocks.NewMockFruitService(ctrl)
+			tt.mock(serviceMock)
+
+			r := gin.Default()
+			controller := NewFruit(serviceMock)
+
+			r.POST("/api/v1/fruits/bulk/unassign", controller.UnassignMany)
+
+			var got presenters.FruitsBulkRes
+			var gotErr presenters.ErrorRes
+
+			// given
+			body, _ := json.Marshal(tt.body)
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("POST", "/api/v1/fruits/bulk/unassign", bytes.NewBuffer(body))
+
+			// when
+			r.ServeHTTP(w, req)
+
+			json.Unmarshal(w.Body.Bytes(), &gotErr)
+
+			// then
+			assert.Equal(t, tt.wantCode, w.Code)
+
+			if gotErr.Error != "" {
+				assert.Equal(t, tt.wantBodyErr, gotErr)
+				return
+			}
+
+			json.Unmarshal(w.Body.Bytes(), &got)
+			assert.Equal(t, tt.wantBody, got)
+		})
+	}
+}
+
+func TestFruitController_DeleteMany(t *testing.T) {
+	tests := map[string]struct {
+		mock        func(service *mocks.MockFruitService)
+		body        presenters.SelectFruitsReq
+		wantCode    int
+		wantBody    presenters.FruitsBulkRes
+		wantBodyErr presenters.ErrorRes
+	}{
+		"should be success": {
+			mock: func(service *mocks.MockFruitService) {
+				service.EXPECT().DeleteMany(gomock.Any(), dtos.SelectFruitsDto{IDs: []int64{1, 2}}).
+					Return(&models.FruitBulk{FruitIDs: []int64{1, 2}, Affected: 2}, nil)
+			},
+			body:     presenters.SelectFruitsReq{IDs: []int64{1, 2}},
+			wantCode: http.StatusOK,
+			wantBody: presenters.FruitsBulkRes{FruitIDs: []int64{1, 2}, Affected: 2},
+		},
+		"should throw not found exception": {
+			mock: func(service *mocks.MockFruitService) {
+				service.EXPECT().DeleteMany(gomock.Any(), gomock.Any()).
+					Return(nil, exceptions.NewNotFoundException("Fruits not found: 2"))
+			},
+			body:        presenters.SelectFruitsReq{IDs: []int64{1, 2}},
+			wantCode:    http.StatusNotFound,
+			wantBodyErr: presenters.ErrorRes{Error: exceptions.NotFoundExceptionName, Message: "Fruits not found: 2"},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			// setup
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			serviceMock := mocks.NewMockFruitService(ctrl)
+			tt.mock(serviceMock)
+
+			r := gin.Default()
+			controller := NewFruit(serviceMock)
+
+			r.POST("/api/v1/fruits/bulk/delete", controller.DeleteMany)
+
+			var got presenters.FruitsBulkRes
+			var gotErr presenters.ErrorRes
+
+			// given
+			body, _ := json.Marshal(tt.body)
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("POST", "/api/v1/fruits/bulk/delete", bytes.NewBuffer(body))
+
+			// when
+			r.ServeHTTP(w, req)
+
+			json.Unmarshal(w.Body.Bytes(), &gotErr)
+
+			// then
+			assert.Equal(t, tt.wantCode, w.Code)
+
+			if gotErr.Error != "" {
+				assert.Equal(t, tt.wantBodyErr, gotErr)
+				return
+			}
+
+			json.Unmarshal(w.Body.Bytes(), &got)
+			assert.Equal(t, tt.wantBody, got)
+		})
+	}
+}
+
+func TestFruitController_RemoveFromBucket(t *testing.T) {
+	tests := map[string]struct {
+		mock         func(service *mocks.MockFruitService)
+		fruitIDParam string
+		wantCode     int
+		wantBodyErr  presenters.ErrorRes
+	}{
+		"should be success": {
+			mock: func(service *mocks.MockFruitService) {
+				service.EXPECT().RemoveFromBucket(gomock.Any(), int64(1)).Return(nil)
+			},
+			fruitIDParam: "1",
+			wantCode:     http.StatusOK,
+		},
+		"should throw validation exception when fruitID is invalid": {
+			mock:         func(service *mocks.MockFruitService) {},
+			fruitIDParam: "invalid",
+			wantCode:     http.StatusBadRequest,
+			wantBodyErr: presenters.ErrorRes{
+				Error:   exceptions.ValidationExceptionName,
+				Message: "invalid fruitID",
+			},
+		},
+		"should throw internal server error": {
+			mock: func(service *mocks.MockFruitService) {
+				service.EXPECT().RemoveFromBucket(gomock.Any(), gomock.Any()).Return(fmt.Errorf("error"))
+			},
+			fruitIDParam: "1",
+			wantCode:     http.StatusInternalServerError,
+			wantBodyErr:  presenters.ErrorRes{Error: http.StatusText(http.StatusInternalServerError)},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			// setup
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			serviceMock := mocks.NewMockFruitService(ctrl)
+			tt.mock(serviceMock)
+
+			r := gin.Default()
+			controller := NewFruit(serviceMock)
+
+			r.DELETE("/api/v1/fruits/:fruitID/buckets", controller.RemoveFromBucket)
+
+			var gotErr presenters.ErrorRes
+
+			// given
+			path := fmt.Sprintf("/api/v1/fruits/%s/buckets", tt.fruitIDParam)
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("DELETE", path, nil)
+
+			// when
+			r.ServeHTTP(w, req)
+
+			errBodyErr := json.Unmarshal(w.Body.Bytes(), &gotErr)
+
+			// then
+			assert.Equal(t, tt.wantCode, w.Code)
+
+			if errBodyErr != nil {
+				assert.Equal(t, tt.wantBodyErr, gotErr)
+				return
+			}
+		})
+	}
+}
+
+func TestFruitController_Delete(t *testing.T) {
+	tests := map[string]struct {
+		mock         func(service *mocks.MockFruitService)
+		fruitIDParam string
+		wantCode     int
+		wantBodyErr  presenters.ErrorRes
+	}{
+		"should be success": {
+			mock: func(service *mocks.MockFruitService) {
+				service.EXPECT().Delete(gomock.Any(), int64(1)).Return(nil)
+			},
+			fruitIDParam: "1",
+			wantCode:     http.StatusOK,
+		},
+		"should throw validation exception when fruitID is invalid": {
+			mock:         func(service *mocks.MockFruitService) {},
+			fruitIDParam: "invalid",
+			wantCode:     http.StatusBadRequest,
+			wantBodyErr: presenters.ErrorRes{
+				Error:   exceptions.ValidationExceptionName,
+				Message: "invalid fruitID",
+			},
+		},
+		"should throw internal server error": {
+			mock: func(service *mocks.MockFruitService) {
+				service.EXPECT().Delete(gomock.Any(), gomock.Any()).Return(fmt.Errorf("error"))
+			},
+			fruitIDParam: "1",
+			wantCode:     http.StatusInternalServerError,
+			wantBodyErr:  presenters.ErrorRes{Error: http.StatusText(http.StatusInternalServerError)},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			// setup
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			serviceMock := mocks.NewMockFruitService(ctrl)
+			tt.mock(serviceMock)
+
+			r := gin.Default()
+			controller := NewFruit(serviceMock)
+
+			r.DELETE("/api/v1/fruits/:fruitID", controller.Delete)
+
+			var gotErr presenters.ErrorRes
+
+			// given
+			path := fmt.Sprintf("/api/v1/fruits/%s", tt.fruitIDParam)
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("DELETE", path, nil)
+
+			// when
+			r.ServeHTTP(w, req)
+
+			errBodyErr := json.Unmarshal(w.Body.Bytes(), &gotErr)
+
+			// then
+			assert.Equal(t, tt.wantCode, w.Code)
+
+			if errBodyErr != nil {
+				assert.Equal(t, tt.wantBodyErr, gotErr)
+				return
+			}
+		})
+	}
+}
+
+func TestFruitController_Recall(t *testing.T) {
+	now := time.Date(2000, 12, 31, 23, 59, 59, 0, time.Local)
+	from := time.Date(2000, 12, 1, 0, 0, 0, 0, time.Local)
+	supplierID := int64(1)
+	bucketID := int64(2)
+	bucketName := "A"
+
+	tests := map[string]struct {
+		mock        func(service *mocks.MockFruitService)
+		body        presenters.RecallFruitsReq
+		wantCode    int
+		wantBody    presenters.RecallRes
+		wantBodyErr presenters.ErrorRes
+	}{
+		"should be success": {
+			mock: func(service *mocks.MockFruitService) {
+				data := dtos.RecallFruitsDto{SupplierID: &supplierID, CreatedFrom: &from, CreatedTo: &now}
+				service.EXPECT().Recall(gomock.Any(), data).Return(&models.Recall{
+					QuarantinedAt: now,
+					Affected:      2,
+					Fruits: []models.RecallFruit{
+						{FruitID: 1, Name: "Apple", BucketID: &bucketID, BucketName: &bucketName},
+						{FruitID: 2, Name: "Apple"},
+					},
+				}, nil)
+			},
+			body: presenters.RecallFruitsReq{
+				SupplierID:  &supplierID,
+				CreatedFrom: "2000-12-01 00:00:00",
+				CreatedTo:   "2000-12-31 23:59:59",
+			},
+			wantCode: http.StatusOK,
+			wantBody: presenters.RecallRes{
+				QuarantinedAt: "2000-12-31 23:59:59",
+				Affected:      2,
+				Data: []presenters.RecallFruitRes{
+					{FruitID: 1, Name: "Apple", BucketID: &bucketID, BucketName: &bucketName},
+					{FruitID: 2, Name: "Apple"},
+				},
+			},
+		},
+		"should throw validation exception when created_from is invalid": {
+			mock:     func(service *mocks.MockFruitService) {},
+			body:     presenters.RecallFruitsReq{CreatedFrom: "2000-12-01", CreatedTo: "2000-12-31 23:59:59"},
+			wantCode: http.StatusBadRequest,
+			wantBodyErr: presenters.ErrorRes{
+				Error:   exceptions.ValidationExceptionName,
+				Message: "invalid created_from",
+			},
+		},
+		"should throw validation exception when created_to is invalid": {
+			mock:     func(service *mocks.MockFruitService) {},
+			body:     presenters.RecallFruitsReq{CreatedFrom: "2000-12-01 00:00:00", CreatedTo: "invalid"},
+			wantCode: http.StatusBadRequest,
+			wantBodyErr: presenters.ErrorRes{
+				Error:   exceptions.ValidationExceptionName,
+				Message: "invalid created_to",
+			},
+		},
+		"should throw validation exception": {
+			mock: func(service *mocks.MockFruitService) {
+				service.EXPECT().Recall(gomock.Any(), gomock.Any()).
+					Return(nil, exceptions.NewValidationException(validator.ValidationErrors{}))
+			},
+			wantCode:    http.StatusBadRequest,
+			wantBodyErr: presenters.ErrorRes{Error: exceptions.ValidationExceptionName},
+		},
+		"should throw internal server error": {
+			mock: func(service *mocks.MockFruitService) {
+				service.EXPECT().Recall(gomock.Any(), gomock.Any()).Return(nil, fmt.Errorf("error"))
+			},
+			body:        presenters.RecallFruitsReq{SupplierID: &supplierID},
+			wantCode:    http.StatusInternalServerError,
+			wantBodyErr: presenters.ErrorRes{Error: http.StatusText(http.StatusInternalServerError)},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			// setup
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			serviceMock := mocks.NewMockFruitService(ctrl)
+			tt.mock(serviceMock)
+
+			r := gin.Default()
+			controller := NewFruit(serviceMock)
+
+			r.POST("/api/v1/fruits/recall", controller.Recall)
+
+			var got presenters.RecallRes
+			var gotErr presenters.ErrorRes
+
+			// given
+			body, _ := json.Marshal(tt.body)
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("POST", "/api/v1/fruits/recall", bytes.NewBuffer(body))
+
+			// when
+			r.ServeHTTP(w, req)
+
+			json.Unmarshal(w.Body.Bytes(), &gotErr)
+
+			// then
+			assert.Equal(t, tt.wantCode, w.Code)
+
+			if gotErr.Error != "" {
+				assert.Equal(t, tt.wantBodyErr, gotErr)
+				return
+			}
+
+			json.Unmarshal(w.Body.Bytes(), &got)
+			assert.Equal(t, tt.wantBody, got)
 		})
 	}
 }
@@ -2,6 +2,8 @@ package controllers
 
 import (
 	"context"
+	"io"
+	"time"
 
 	"github.com/viniosilva/where-are-my-fruits/internal/dtos"
 	"github.com/viniosilva/where-are-my-fruits/internal/models"
@@ -16,11 +18,96 @@ type BucketService interface {
 	Create(ctx context.Context, data dtos.CreateBucketDto) (*models.Bucket, error)
 	List(ctx context.Context, page, pageSize int) ([]models.BucketFruits, error)
 	Delete(ctx context.Context, id int64) error
+	Consolidate(ctx context.Context, apply bool) (*models.BucketConsolidation, error)
+	ListViolations(ctx context.Context) ([]models.BucketViolations, error)
 }
 
 type FruitService interface {
 	Create(ctx context.Context, data dtos.CreateFruitDto) (*models.Fruit, error)
+	CreateBatch(ctx context.Context, data dtos.CreateFruitsBatchDto) (*models.FruitBatch, error)
+	GetByBarcode(ctx context.Context, barcode string) (*models.Fruit, error)
+	ListExpiring(ctx context.Context, within time.Duration) (*models.ExpiringFruits, error)
 	AddOnBucket(ctx context.Context, fruitID, bucketID int64) error
+	Transfer(ctx context.Context, fruitID int64, data dtos.TransferFruitDto) (*models.FruitTransfer, error)
 	RemoveFromBucket(ctx context.Context, fruitID int64) error
 	Delete(ctx context.Context, id int64) error
+	MoveMany(ctx context.Context, data dtos.MoveFruitsDto) (*models.FruitBulk, error)
+	UnassignMany(ctx context.Context, data dtos.SelectFruitsDto) (*models.FruitBulk, error)
+	DeleteMany(ctx context.Context, data dtos.SelectFruitsDto) (*models.FruitBulk, error)
+	Recall(ctx context.Context, data dtos.RecallFruitsDto) (*models.Recall, error)
+}
+
+type FruitStateService interface {
+	Change(ctx context.Context, fruitID int64, data dtos.ChangeFruitStateDto) (*models.FruitStateTransition, error)
+	ListTransitions(ctx context.Context, fruitID int64) ([]models.FruitStateTransition, error)
+}
+
+type FruitPriceService interface {
+	Change(ctx context.Context, fruitID int64, data dtos.ChangeFruitPriceDto) (*models.FruitPrice, error)
+	List(ctx context.Context, fruitID int64) ([]models.FruitPrice, error)
+	Stats(ctx context.Context, data dtos.FruitPriceStatsDto) ([]models.FruitPriceStats, error)
+}
+
+type PhotoService interface {
+	Upload(ctx context.Context, data dtos.UploadPhotoDto) (*models.Photo, error)
+	List(ctx context.Context, ownerType string, ownerID int64) ([]models.Photo, error)
+	Open(ctx context.Context, id int64, thumbnail bool) (*models.Photo, io.ReadCloser, error)
+}
+
+type NoteService interface {
+	Create(ctx context.Context, data dtos.CreateNoteDto) (*models.Note, error)
+	List(ctx context.Context, ownerType string, ownerID int64, page, pageSize int) ([]models.Note, error)
+}
+
+type PickService interface {
+	Create(ctx context.Context, data dtos.CreatePickDto) (*models.Pick, error)
+}
+
+type OrderService interface {
+	Create(ctx context.Context, data dtos.CreateOrderDto) (*models.Order, error)
+	Get(ctx context.Context, id int64) (*models.Order, error)
+	Checkout(ctx context.Context, id int64) (*models.Order, error)
+	Sales(ctx context.Context, data dtos.SalesDto) ([]models.Sales, error)
+}
+
+type ReservationService interface {
+	Create(ctx context.Context, data dtos.CreateReservationDto) (*models.Reservation, error)
+	Get(ctx context.Context, id int64) (*models.Reservation, error)
+	Confirm(ctx context.Context, id int64) (*models.Reservation, error)
+	Release(ctx context.Context, id int64) error
+}
+
+type PurchaseOrderService interface {
+	Create(ctx context.Context, data dtos.CreatePurchaseOrderDto) (*models.PurchaseOrder, error)
+	Get(ctx context.Context, id int64) (*models.PurchaseOrder, error)
+	Receive(ctx context.Context, id int64, data dtos.ReceivePurchaseOrderDto) (*models.PurchaseOrder, error)
+}
+
+type CycleCountService interface {
+	Open(ctx context.Context, bucketID int64) (*models.CycleCount, error)
+	Get(ctx context.Context, id int64) (*models.CycleCount, error)
+	Submit(ctx context.Context, id int64, data dtos.SubmitCycleCountDto) (*models.CycleCount, error)
+	Approve(ctx context.Context, id int64) (*models.CycleCount, error)
+}
+
+type StockService interface {
+	SetReorderPoint(ctx context.Context, data dtos.SetReorderPointDto) (*models.ReorderPoint, error)
+	ListReorderPoints(ctx context.Context, page, pageSize int) ([]models.ReorderPoint, error)
+	Low(ctx context.Context) ([]models.Stock, error)
+	ListAlerts(ctx context.Context, page, pageSize int) ([]models.StockAlert, error)
+	Waste(ctx context.Context, data dtos.WasteDto) ([]models.Waste, error)
+	Valuation(ctx context.Context, data dtos.ValuationDto) ([]models.Valuation, error)
+}
+
+type MovementService interface {
+	List(ctx context.Context, data dtos.ListMovementsDto, page, pageSize int) ([]models.Movement, error)
+}
+
+type SupplierService interface {
+	Create(ctx context.Context, data dtos.CreateSupplierDto) (*models.Supplier, error)
+	List(ctx context.Context, page, pageSize int) ([]models.Supplier, error)
+	Get(ctx context.Context, id int64) (*models.Supplier, error)
+	Update(ctx context.Context, id int64, data dtos.UpdateSupplierDto) (*models.Supplier, error)
+	Delete(ctx context.Context, id int64) error
+	Report(ctx context.Context, id int64) (*models.SupplierFruits, error)
 }
@@ -0,0 +1,122 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/viniosilva/where-are-my-fruits/internal/controllers/presenters"
+	"github.com/viniosilva/where-are-my-fruits/internal/dtos"
+	"github.com/viniosilva/where-are-my-fruits/internal/exceptions"
+)
+
+type MovementController struct {
+	service MovementService
+}
+
+func NewMovement(service MovementService) *MovementController {
+	return &MovementController{
+		service: service,
+	}
+}
+
+// Movement godoc
+// @Summary list fruit movements, newest first
+// @Schemes
+// @Tags movement
+// @Accept json
+// @Produce json
+// @Param fruit_id query int false "Fruit ID"
+// @Param bucket_id query int false "Bucket ID the fruit left or entered"
+// @Param type query string false "Movement type" Enums(created, moved, unassigned, deleted, picked)
+// @Param actor query string false "Actor"
+// @Param from query string false "First day of the period" example(2000-12-01)
+// @Param to query string false "Last day of the period" example(2000-12-31)
+// @Param page query int false "page" default(1)
+// @Param pageSize query int false "pageSize" default(10)
+// @Success 200 {object} presenters.MovementsRes
+// @Failure 400 {object} presenters.ErrorRes
+// @Failure 500 {object} presenters.ErrorRes
+// @Router /v1/movements [get]
+func (impl *MovementController) List(ctx *gin.Context) {
+	var data dtos.ListMovementsDto
+	if v := ctx.Query("fruit_id"); v != "" {
+		id, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, presenters.ErrorRes{Error: exceptions.ValidationExceptionName, Message: "invalid fruit_id"})
+			return
+		}
+		data.FruitID = &id
+	}
+	if v := ctx.Query("bucket_id"); v != "" {
+		id, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, presenters.ErrorRes{Error: exceptions.ValidationExceptionName, Message: "invalid bucket_id"})
+			return
+		}
+		data.BucketID = &id
+	}
+	if v := ctx.Query("from"); v != "" {
+		d, err := time.ParseInLocation(time.DateOnly, v, time.Local)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, presenters.ErrorRes{Error: exceptions.ValidationExceptionName, Message: "invalid from"})
+			return
+		}
+		data.From = &d
+	}
+	if v := ctx.Query("to"); v != "" {
+		d, err := time.ParseInLocation(time.DateOnly, v, time.Local)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, presenters.ErrorRes{Error: exceptions.ValidationExceptionName, Message: "invalid to"})
+			return
+		}
+		d = d.AddDate(0, 0, 1)
+		data.To = &d
+	}
+	if v := ctx.Query("type"); v != "" {
+		data.Type = &v
+	}
+	if v := ctx.Query("actor"); v != "" {
+		data.Actor = &v
+	}
+
+	page, err := strconv.Atoi(ctx.Query("page"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+
+	pageSize, err := strconv.Atoi(ctx.Query("pageSize"))
+	if err != nil || pageSize < 1 {
+		pageSize = 10
+	}
+
+	res, err := impl.service.List(ctx, data, page, pageSize)
+	if err != nil {
+		if e, ok := err.(*exceptions.ValidationException); ok {
+			ctx.JSON(http.StatusBadRequest, presenters.ErrorRes{Error: e.Name, Messages: e.Errors})
+			return
+		}
+
+		ctx.JSON(http.StatusInternalServerError, presenters.ErrorRes{Error: http.StatusText(http.StatusInternalServerError)})
+		return
+	}
+
+	resp := presenters.MovementsRes{Data: []presenters.MovementRes{}}
+	for _, movement := range res {
+		item := presenters.MovementRes{
+			ID:           movement.ID,
+			CreatedAt:    movement.CreatedAt.Format(time.DateTime),
+			FruitID:      movement.FruitID,
+			FromBucketID: movement.FromBucketID,
+			ToBucketID:   movement.ToBucketID,
+			Type:         movement.Type,
+		}
+		if movement.Actor != nil {
+			item.Actor = *movement.Actor
+		}
+		resp.Data = append(resp.Data, item)
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
@@ -0,0 +1,145 @@
+package controllers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/viniosilva/where-are-my-fruits/internal/controllers/presenters"
+	"github.com/viniosilva/where-are-my-fruits/internal/dtos"
+	"github.com/viniosilva/where-are-my-fruits/internal/exceptions"
+	"github.com/viniosilva/where-are-my-fruits/internal/models"
+	"github.com/viniosilva/where-are-my-fruits/mocks"
+)
+
+func TestMovementController_List(t *testing.T) {
+	now := time.Date(2000, 12, 31, 23, 59, 59, 0, time.Local)
+	fruitID := int64(1)
+	bucketID := int64(1)
+	toBucketID := int64(2)
+	kind := models.MovementTypeMoved
+	actor := "jane"
+	from := time.Date(2000, 12, 1, 0, 0, 0, 0, time.Local)
+	to := time.Date(2001, 1, 1, 0, 0, 0, 0, time.Local)
+
+	tests := map[string]struct {
+		mock        func(service *mocks.MockMovementService)
+		query       string
+		wantCode    int
+		wantBody    presenters.MovementsRes
+		wantBodyErr presenters.ErrorRes
+	}{
+		"should be success": {
+			mock: func(service *mocks.MockMovementService) {
+				service.EXPECT().List(gomock.Any(), dtos.ListMovementsDto{}, 1, 10).Return([]models.Movement{
+					{ID: 2, CreatedAt: now, FruitID: 1, FromBucketID: &bucketID, ToBucketID: &toBucketID, Type: models.MovementTypeMoved, Actor: &actor},
+					{ID: 1, CreatedAt: now, FruitID: 1, ToBucketID: &bucketID, Type: models.MovementTypeCreated},
+				}, nil)
+			},
+			wantCode: http.StatusOK,
+			wantBody: presenters.MovementsRes{
+				Data: []presenters.MovementRes{
+					{ID: 2, CreatedAt: "2000-12-31 23:59:59", FruitID: 1, FromBucketID: &bucketID, ToBucketID: &toBucketID, Type: "moved", Actor: "jane"},
+					{ID: 1, CreatedAt: "2000-12-31 23:59:59", FruitID: 1, ToBucketID: &bucketID, Type: "created"},
+				},
+			},
+		},
+		"should be success filtering movements": {
+			mock: func(service *mocks.MockMovementService) {
+				data := dtos.ListMovementsDto{FruitID: &fruitID, BucketID: &bucketID, Type: &kind, Actor: &actor, From: &from, To: &to}
+				service.EXPECT().List(gomock.Any(), data, 2, 5).Return([]models.Movement{}, nil)
+			},
+			query:    "?fruit_id=1&bucket_id=1&type=moved&actor=jane&from=2000-12-01&to=2000-12-31&page=2&pageSize=5",
+			wantCode: http.StatusOK,
+			wantBody: presenters.MovementsRes{Data: []presenters.MovementRes{}},
+		},
+		"should throw bad request when fruit_id is invalid": {
+			mock:        func(service *mocks.MockMovementService) {},
+			query:       "?fruit_id=a",
+			wantCode:    http.StatusBadRequest,
+			wantBodyErr: presenters.ErrorRes{Error: exceptions.ValidationExceptionName, Message: "invalid fruit_id"},
+		},
+		"should throw bad request when bucket_id is invalid": {
+			mock:        func(service *mocks.MockMovementService) {},
+			query:       "?bucket_id=a",
+			wantCode:    http.StatusBadRequest,
+			wantBodyErr: presenters.ErrorRes{Error: exceptions.ValidationExceptionName, Message: "invalid bucket_id"},
+		},
+		"should throw bad request when from is invalid": {
+			mock:        func(service *mocks.MockMovementService) {},
+			query:       "?from=31/12/2000",
+			wantCode:    http.StatusBadRequest,
+			wantBodyErr: presenters.ErrorRes{Error: exceptions.ValidationExceptionName, Message: "invalid from"},
+		},
+		"should throw bad request when to is invalid": {
+			mock:        func(service *mocks.MockMovementService) {},
+			query:       "?to=31/12/2000",
+			wantCode:    http.StatusBadRequest,
+			wantBodyErr: presenters.ErrorRes{Error: exceptions.ValidationExceptionName, Message: "invalid to"},
+		},
+		"should throw validation exception": {
+			mock: func(service *mocks.MockMovementService) {
+				service.EXPECT().List(gomock.Any(), gomock.Any(), 1, 10).Return(nil, exceptions.NewValidationException(validator.ValidationErrors{
+					&mocks.FieldError{Itag: "error 1", Ins: "error 1"},
+				}))
+			},
+			query:    "?type=teleported",
+			wantCode: http.StatusBadRequest,
+			wantBodyErr: presenters.ErrorRes{
+				Error:    exceptions.ValidationExceptionName,
+				Messages: []string{"Key: 'error 1' Error:Field validation for '' failed on the 'error 1' tag"},
+			},
+		},
+		"should throw internal server error": {
+			mock: func(service *mocks.MockMovementService) {
+				service.EXPECT().List(gomock.Any(), gomock.Any(), 1, 10).Return(nil, fmt.Errorf("error"))
+			},
+			wantCode:    http.StatusInternalServerError,
+			wantBodyErr: presenters.ErrorRes{Error: http.StatusText(http.StatusInternalServerError)},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			// setup
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			serviceMock := mocks.NewMockMovementService(ctrl)
+			tt.mock(serviceMock)
+
+			r := gin.Default()
+			controller := NewMovement(serviceMock)
+
+			path := "/api/v1/movements"
+			r.GET(path, controller.List)
+
+			var got presenters.MovementsRes
+			var gotErr presenters.ErrorRes
+
+			// given
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("GET", path+tt.query, nil)
+
+			// when
+			r.ServeHTTP(w, req)
+
+			json.Unmarshal(w.Body.Bytes(), &gotErr)
+			json.Unmarshal(w.Body.Bytes(), &got)
+
+			// then
+			assert.Equal(t, tt.wantCode, w.Code)
+
+			if gotErr.Error != "" {
+				assert.Equal(t, tt.wantBodyErr, gotErr)
+				return
+			}
+			assert.Equal(t, tt.wantBody, got)
+		})
+	}
+}
@@ -0,0 +1,169 @@
+package controllers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/viniosilva/where-are-my-fruits/internal/controllers/presenters"
+	"github.com/viniosilva/where-are-my-fruits/internal/dtos"
+	"github.com/viniosilva/where-are-my-fruits/internal/exceptions"
+	"github.com/viniosilva/where-are-my-fruits/internal/models"
+)
+
+type NoteController struct {
+	service NoteService
+}
+
+func NewNote(service NoteService) *NoteController {
+	return &NoteController{
+		service: service,
+	}
+}
+
+// Note godoc
+// @Summary create fruit note
+// @Schemes
+// @Tags note
+// @Accept json
+// @Produce json
+// @Param fruitID path int64 true "Fruit ID"
+// @Param note body presenters.CreateNoteReq true "Note"
+// @Success 201 {object} presenters.NoteRes
+// @Failure 400 {object} presenters.ErrorRes
+// @Failure 404 {object} presenters.ErrorRes
+// @Failure 500 {object} presenters.ErrorRes
+// @Router /v1/fruits/{fruitID}/notes [post]
+func (impl *NoteController) CreateFruitNote(ctx *gin.Context) {
+	impl.create(ctx, models.NoteOwnerFruit, "fruitID")
+}
+
+// Note godoc
+// @Summary list fruit notes
+// @Schemes
+// @Tags note
+// @Accept json
+// @Produce json
+// @Param fruitID path int64 true "Fruit ID"
+// @Param page query int false "page" default(1)
+// @Param pageSize query int false "pageSize" default(10)
+// @Success 200 {object} presenters.NotesRes
+// @Failure 400 {object} presenters.ErrorRes
+// @Failure 500 {object} presenters.ErrorRes
+// @Router /v1/fruits/{fruitID}/notes [get]
+func (impl *NoteController) ListFruitNotes(ctx *gin.Context) {
+	impl.list(ctx, models.NoteOwnerFruit, "fruitID")
+}
+
+// Note godoc
+// @Summary create bucket note
+// @Schemes
+// @Tags note
+// @Accept json
+// @Produce json
+// @Param bucketID path int64 true "Bucket ID"
+// @Param note body presenters.CreateNoteReq true "Note"
+// @Success 201 {object} presenters.NoteRes
+// @Failure 400 {object} presenters.ErrorRes
+// @Failure 404 {object} presenters.ErrorRes
+// @Failure 500 {object} presenters.ErrorRes
+// @Router /v1/buckets/{bucketID}/notes [post]
+func (impl *NoteController) CreateBucketNote(ctx *gin.Context) {
+	impl.create(ctx, models.NoteOwnerBucket, "bucketID")
+}
+
+// Note godoc
+// @Summary list bucket notes
+// @Schemes
+// @Tags note
+// @Accept json
+// @Produce json
+// @Param bucketID path int64 true "Bucket ID"
+// @Param page query int false "page" default(1)
+// @Param pageSize query int false "pageSize" default(10)
+// @Success 200 {object} presenters.NotesRes
+// @Failure 400 {object} presenters.ErrorRes
+// @Failure 500 {object} presenters.ErrorRes
+// @Router /v1/buckets/{bucketID}/notes [get]
+func (impl *NoteController) ListBucketNotes(ctx *gin.Context) {
+	impl.list(ctx, models.NoteOwnerBucket, "bucketID")
+}
+
+func (impl *NoteController) create(ctx *gin.Context, ownerType, param string) {
+	ownerID, err := strconv.ParseInt(ctx.Param(param), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, presenters.ErrorRes{Error: exceptions.ValidationExceptionName, Message: fmt.Sprintf("invalid %s", param)})
+		return
+	}
+
+	var req presenters.CreateNoteReq
+	ctx.BindJSON(&req)
+
+	data := dtos.CreateNoteDto{
+		OwnerType: ownerType,
+		OwnerID:   ownerID,
+		Author:    req.Author,
+		Text:      req.Text,
+	}
+
+	res, err := impl.service.Create(ctx, data)
+	if err != nil {
+		if e, ok := err.(*exceptions.ValidationException); ok {
+			ctx.JSON(http.StatusBadRequest, presenters.ErrorRes{Error: e.Name, Messages: e.Errors})
+			return
+		}
+		if e, ok := err.(*exceptions.NotFoundException); ok {
+			ctx.JSON(http.StatusNotFound, presenters.ErrorRes{Error: e.Name, Message: e.Error()})
+			return
+		}
+
+		ctx.JSON(http.StatusInternalServerError, presenters.ErrorRes{Error: http.StatusText(http.StatusInternalServerError)})
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, impl.parse(res))
+}
+
+func (impl *NoteController) list(ctx *gin.Context, ownerType, param string) {
+	ownerID, err := strconv.ParseInt(ctx.Param(param), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, presenters.ErrorRes{Error: exceptions.ValidationExceptionName, Message: fmt.Sprintf("invalid %s", param)})
+		return
+	}
+
+	page, err := strconv.Atoi(ctx.Query("page"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+
+	pageSize, err := strconv.Atoi(ctx.Query("pageSize"))
+	if err != nil || pageSize < 1 {
+		pageSize = 10
+	}
+
+	res, err := impl.service.List(ctx, ownerType, ownerID, page, pageSize)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, presenters.ErrorRes{Error: http.StatusText(http.StatusInternalServerError)})
+		return
+	}
+
+	resp := presenters.NotesRes{Data: []presenters.NoteRes{}}
+	for _, note := range res {
+		resp.Data = append(resp.Data, impl.parse(&note))
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+func (impl *NoteController) parse(note *models.Note) presenters.NoteRes {
+	return presenters.NoteRes{
+		ID:        note.ID,
+		CreatedAt: note.CreatedAt.Format(time.DateTime),
+		FruitID:   note.FruitID,
+		BucketID:  note.BucketID,
+		Author:    note.Author,
+		Text:      note.Text,
+	}
+}
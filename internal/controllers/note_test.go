@@ -0,0 +1,227 @@
+package controllers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/viniosilva/where-are-my-fruits/internal/controllers/presenters"
+	"github.com/viniosilva/where-are-my-fruits/internal/dtos"
+	"github.com/viniosilva/where-are-my-fruits/internal/exceptions"
+	"github.com/viniosilva/where-are-my-fruits/internal/models"
+	"github.com/viniosilva/where-are-my-fruits/mocks"
+)
+
+func TestNoteController_CreateBucketNote(t *testing.T) {
+	now := time.Date(2000, 12, 31, 23, 59, 59, 0, time.Local)
+	bucketID := int64(1)
+
+	tests := map[string]struct {
+		mock        func(service *mocks.MockNoteService)
+		bucketID    string
+		body        presenters.CreateNoteReq
+		wantCode    int
+		wantBody    presenters.NoteRes
+		wantBodyErr presenters.ErrorRes
+	}{
+		"should be success": {
+			mock: func(service *mocks.MockNoteService) {
+				data := dtos.CreateNoteDto{OwnerType: models.NoteOwnerBucket, OwnerID: bucketID, Author: "John", Text: "lid cracked"}
+				service.EXPECT().Create(gomock.Any(), data).Return(&models.Note{
+					ID:        1,
+					CreatedAt: now,
+					BucketID:  &bucketID,
+					Author:    "John",
+					Text:      "lid cracked",
+				}, nil)
+			},
+			bucketID: "1",
+			body:     presenters.CreateNoteReq{Author: "John", Text: "lid cracked"},
+			wantCode: http.StatusCreated,
+			wantBody: presenters.NoteRes{
+				ID:        1,
+				CreatedAt: "2000-12-31 23:59:59",
+				BucketID:  &bucketID,
+				Author:    "John",
+				Text:      "lid cracked",
+			},
+		},
+		"should throw bad request when bucketID is invalid": {
+			mock:     func(service *mocks.MockNoteService) {},
+			bucketID: "a",
+			wantCode: http.StatusBadRequest,
+			wantBodyErr: presenters.ErrorRes{
+				Error:   exceptions.ValidationExceptionName,
+				Message: "invalid bucketID",
+			},
+		},
+		"should throw validation exception": {
+			mock: func(service *mocks.MockNoteService) {
+				service.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil, exceptions.NewValidationException(validator.ValidationErrors{
+					&mocks.FieldError{Itag: "error 1", Ins: "error 1"},
+				}))
+			},
+			bucketID: "1",
+			wantCode: http.StatusBadRequest,
+			wantBodyErr: presenters.ErrorRes{
+				Error:    exceptions.ValidationExceptionName,
+				Messages: []string{"Key: 'error 1' Error:Field validation for '' failed on the 'error 1' tag"},
+			},
+		},
+		"should throw not found": {
+			mock: func(service *mocks.MockNoteService) {
+				service.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil, exceptions.NewNotFoundException("Bucket not found"))
+			},
+			bucketID: "1",
+			body:     presenters.CreateNoteReq{Author: "John", Text: "lid cracked"},
+			wantCode: http.StatusNotFound,
+			wantBodyErr: presenters.ErrorRes{
+				Error:   exceptions.NotFoundExceptionName,
+				Message: "Bucket not found",
+			},
+		},
+		"should throw internal server error": {
+			mock: func(service *mocks.MockNoteService) {
+				service.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil, fmt.Errorf("error"))
+			},
+			bucketID:    "1",
+			body:        presenters.CreateNoteReq{Author: "John", Text: "lid cracked"},
+			wantCode:    http.StatusInternalServerError,
+			wantBodyErr: presenters.ErrorRes{Error: http.StatusText(http.StatusInternalServerError)},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			// setup
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			serviceMock := mocks.NewMockNoteService(ctrl)
+			tt.mock(serviceMock)
+
+			r := gin.Default()
+			controller := NewNote(serviceMock)
+
+			r.POST("/api/v1/buckets/:bucketID/notes", controller.CreateBucketNote)
+
+			var got presenters.NoteRes
+			var gotErr presenters.ErrorRes
+
+			// given
+			body, _ := json.Marshal(tt.body)
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("POST", fmt.Sprintf("/api/v1/buckets/%s/notes", tt.bucketID), bytes.NewReader(body))
+
+			// when
+			r.ServeHTTP(w, req)
+
+			json.Unmarshal(w.Body.Bytes(), &gotErr)
+			json.Unmarshal(w.Body.Bytes(), &got)
+
+			// then
+			assert.Equal(t, tt.wantCode, w.Code)
+
+			if gotErr.Error != "" {
+				assert.Equal(t, tt.wantBodyErr, gotErr)
+				return
+			}
+			assert.Equal(t, tt.wantBody, got)
+		})
+	}
+}
+
+func TestNoteController_ListFruitNotes(t *testing.T) {
+	now := time.Date(2000, 12, 31, 23, 59, 59, 0, time.Local)
+	fruitID := int64(1)
+
+	tests := map[string]struct {
+		mock        func(service *mocks.MockNoteService)
+		path        string
+		wantCode    int
+		wantBody    presenters.NotesRes
+		wantBodyErr presenters.ErrorRes
+	}{
+		"should be success": {
+			mock: func(service *mocks.MockNoteService) {
+				service.EXPECT().List(gomock.Any(), models.NoteOwnerFruit, fruitID, 2, 5).Return([]models.Note{
+					{ID: 1, CreatedAt: now, FruitID: &fruitID, Author: "John", Text: "bruised"},
+				}, nil)
+			},
+			path:     "/api/v1/fruits/1/notes?page=2&pageSize=5",
+			wantCode: http.StatusOK,
+			wantBody: presenters.NotesRes{
+				Data: []presenters.NoteRes{
+					{ID: 1, CreatedAt: "2000-12-31 23:59:59", FruitID: &fruitID, Author: "John", Text: "bruised"},
+				},
+			},
+		},
+		"should be success with default pagination": {
+			mock: func(service *mocks.MockNoteService) {
+				service.EXPECT().List(gomock.Any(), models.NoteOwnerFruit, fruitID, 1, 10).Return([]models.Note{}, nil)
+			},
+			path:     "/api/v1/fruits/1/notes",
+			wantCode: http.StatusOK,
+			wantBody: presenters.NotesRes{Data: []presenters.NoteRes{}},
+		},
+		"should throw bad request when fruitID is invalid": {
+			mock:     func(service *mocks.MockNoteService) {},
+			path:     "/api/v1/fruits/a/notes",
+			wantCode: http.StatusBadRequest,
+			wantBodyErr: presenters.ErrorRes{
+				Error:   exceptions.ValidationExceptionName,
+				Message: "invalid fruitID",
+			},
+		},
+		"should throw internal server error": {
+			mock: func(service *mocks.MockNoteService) {
+				service.EXPECT().List(gomock.Any(), models.NoteOwnerFruit, fruitID, 1, 10).Return(nil, fmt.Errorf("error"))
+			},
+			path:        "/api/v1/fruits/1/notes",
+			wantCode:    http.StatusInternalServerError,
+			wantBodyErr: presenters.ErrorRes{Error: http.StatusText(http.StatusInternalServerError)},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			// setup
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			serviceMock := mocks.NewMockNoteService(ctrl)
+			tt.mock(serviceMock)
+
+			r := gin.Default()
+			controller := NewNote(serviceMock)
+
+			r.GET("/api/v1/fruits/:fruitID/notes", controller.ListFruitNotes)
+
+			var got presenters.NotesRes
+			var gotErr presenters.ErrorRes
+
+			// given
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("GET", tt.path, nil)
+
+			// when
+			r.ServeHTTP(w, req)
+
+			json.Unmarshal(w.Body.Bytes(), &gotErr)
+			json.Unmarshal(w.Body.Bytes(), &got)
+
+			// then
+			assert.Equal(t, tt.wantCode, w.Code)
+
+			if gotErr.Error != "" {
+				assert.Equal(t, tt.wantBodyErr, gotErr)
+				return
+			}
+			assert.Equal(t, tt.wantBody, got)
+		})
+	}
+}
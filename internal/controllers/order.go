@@ -0,0 +1,236 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/shopspring/decimal"
+	"github.com/viniosilva/where-are-my-fruits/internal/controllers/presenters"
+	"github.com/viniosilva/where-are-my-fruits/internal/dtos"
+	"github.com/viniosilva/where-are-my-fruits/internal/exceptions"
+	"github.com/viniosilva/where-are-my-fruits/internal/models"
+)
+
+// salesDefaultPeriod is the period covered by the sales report when no start
+// date is given
+const salesDefaultPeriod = 30 * 24 * time.Hour
+
+type OrderController struct {
+	service OrderService
+}
+
+func NewOrder(service OrderService) *OrderController {
+	return &OrderController{
+		service: service,
+	}
+}
+
+// Order godoc
+// @Summary create order
+// @Schemes
+// @Tags order
+// @Accept json
+// @Produce json
+// @Param order body presenters.CreateOrderReq true "Order"
+// @Success 201 {object} presenters.OrderRes
+// @Failure 400 {object} presenters.ErrorRes
+// @Failure 500 {object} presenters.ErrorRes
+// @Router /v1/orders [post]
+func (impl *OrderController) Create(ctx *gin.Context) {
+	var req presenters.CreateOrderReq
+	ctx.BindJSON(&req)
+
+	data := dtos.CreateOrderDto{Items: []dtos.CreateOrderItemDto{}}
+	for _, item := range req.Items {
+		data.Items = append(data.Items, dtos.CreateOrderItemDto{FruitID: item.FruitID})
+	}
+
+	res, err := impl.service.Create(ctx, data)
+	if err != nil {
+		if e, ok := err.(*exceptions.ValidationException); ok {
+			ctx.JSON(http.StatusBadRequest, presenters.ErrorRes{Error: e.Name, Messages: e.Errors})
+			return
+		}
+		if e, ok := err.(*exceptions.ForeignNotFoundException); ok {
+			ctx.JSON(http.StatusBadRequest, presenters.ErrorRes{Error: e.Name, Message: e.Error()})
+			return
+		}
+		if e, ok := err.(*exceptions.ForbiddenException); ok {
+			ctx.JSON(http.StatusBadRequest, presenters.ErrorRes{Error: e.Name, Message: e.Error()})
+			return
+		}
+
+		ctx.JSON(http.StatusInternalServerError, presenters.ErrorRes{Error: http.StatusText(http.StatusInternalServerError)})
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, impl.parse(res))
+}
+
+// Order godoc
+// @Summary get order
+// @Schemes
+// @Tags order
+// @Accept json
+// @Produce json
+// @Param orderID path int64 true "Order ID"
+// @Success 200 {object} presenters.OrderRes
+// @Failure 400 {object} presenters.ErrorRes
+// @Failure 404 {object} presenters.ErrorRes
+// @Failure 500 {object} presenters.ErrorRes
+// @Router /v1/orders/{orderID} [get]
+func (impl *OrderController) Get(ctx *gin.Context) {
+	orderID, err := strconv.ParseInt(ctx.Param("orderID"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, presenters.ErrorRes{Error: exceptions.ValidationExceptionName, Message: "invalid orderID"})
+		return
+	}
+
+	res, err := impl.service.Get(ctx, orderID)
+	if err != nil {
+		if e, ok := err.(*exceptions.NotFoundException); ok {
+			ctx.JSON(http.StatusNotFound, presenters.ErrorRes{Error: e.Name, Message: e.Error()})
+			return
+		}
+
+		ctx.JSON(http.StatusInternalServerError, presenters.ErrorRes{Error: http.StatusText(http.StatusInternalServerError)})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, impl.parse(res))
+}
+
+// Order godoc
+// @Summary checkout order
+// @Schemes
+// @Tags order
+// @Accept json
+// @Produce json
+// @Param orderID path int64 true "Order ID"
+// @Success 200 {object} presenters.OrderRes
+// @Failure 400 {object} presenters.ErrorRes
+// @Failure 404 {object} presenters.ErrorRes
+// @Failure 500 {object} presenters.ErrorRes
+// @Router /v1/orders/{orderID}/checkout [post]
+func (impl *OrderController) Checkout(ctx *gin.Context) {
+	orderID, err := strconv.ParseInt(ctx.Param("orderID"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, presenters.ErrorRes{Error: exceptions.ValidationExceptionName, Message: "invalid orderID"})
+		return
+	}
+
+	res, err := impl.service.Checkout(ctx, orderID)
+	if err != nil {
+		if e, ok := err.(*exceptions.NotFoundException); ok {
+			ctx.JSON(http.StatusNotFound, presenters.ErrorRes{Error: e.Name, Message: e.Error()})
+			return
+		}
+		if e, ok := err.(*exceptions.ForeignNotFoundException); ok {
+			ctx.JSON(http.StatusBadRequest, presenters.ErrorRes{Error: e.Name, Message: e.Error()})
+			return
+		}
+		if e, ok := err.(*exceptions.ForbiddenException); ok {
+			ctx.JSON(http.StatusBadRequest, presenters.ErrorRes{Error: e.Name, Message: e.Error()})
+			return
+		}
+
+		ctx.JSON(http.StatusInternalServerError, presenters.ErrorRes{Error: http.StatusText(http.StatusInternalServerError)})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, impl.parse(res))
+}
+
+// Order godoc
+// @Summary sales report by day and fruit name
+// @Schemes
+// @Tags order
+// @Accept json
+// @Produce json
+// @Param name query string false "Fruit name"
+// @Param from query string false "First day of the period (default 30 days before to)" example(2000-12-01)
+// @Param to query string false "Last day of the period (default today)" example(2000-12-31)
+// @Success 200 {object} presenters.SalesReportRes
+// @Failure 400 {object} presenters.ErrorRes
+// @Failure 500 {object} presenters.ErrorRes
+// @Router /v1/reports/sales [get]
+func (impl *OrderController) Sales(ctx *gin.Context) {
+	today := time.Now()
+	to := time.Date(today.Year(), today.Month(), today.Day(), 0, 0, 0, 0, time.Local)
+	if v := ctx.Query("to"); v != "" {
+		d, err := time.ParseInLocation(time.DateOnly, v, time.Local)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, presenters.ErrorRes{Error: exceptions.ValidationExceptionName, Message: "invalid to"})
+			return
+		}
+		to = d
+	}
+
+	from := to.Add(-salesDefaultPeriod)
+	if v := ctx.Query("from"); v != "" {
+		d, err := time.ParseInLocation(time.DateOnly, v, time.Local)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, presenters.ErrorRes{Error: exceptions.ValidationExceptionName, Message: "invalid from"})
+			return
+		}
+		from = d
+	}
+
+	data := dtos.SalesDto{From: from, To: to.AddDate(0, 0, 1)}
+	if v := ctx.Query("name"); v != "" {
+		data.Name = &v
+	}
+
+	res, err := impl.service.Sales(ctx, data)
+	if err != nil {
+		if e, ok := err.(*exceptions.ValidationException); ok {
+			ctx.JSON(http.StatusBadRequest, presenters.ErrorRes{Error: e.Name, Messages: e.Errors})
+			return
+		}
+
+		ctx.JSON(http.StatusInternalServerError, presenters.ErrorRes{Error: http.StatusText(http.StatusInternalServerError)})
+		return
+	}
+
+	resp := presenters.SalesReportRes{
+		From:         from.Format(time.DateOnly),
+		To:           to.Format(time.DateOnly),
+		Data:         []presenters.SalesRes{},
+		TotalRevenue: decimal.Zero,
+	}
+	for _, sale := range res {
+		resp.Data = append(resp.Data, presenters.SalesRes{
+			Day:      sale.Day,
+			Name:     sale.Name,
+			Quantity: sale.Quantity,
+			Revenue:  sale.Revenue,
+		})
+		resp.TotalQuantity += sale.Quantity
+		resp.TotalRevenue = resp.TotalRevenue.Add(sale.Revenue)
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+func (impl *OrderController) parse(order *models.Order) presenters.OrderRes {
+	res := presenters.OrderRes{
+		ID:        order.ID,
+		CreatedAt: order.CreatedAt.Format(time.DateTime),
+		Total:     order.Total,
+		Items:     []presenters.OrderItemRes{},
+	}
+	if order.CheckedOutAt != nil {
+		res.CheckedOutAt = order.CheckedOutAt.Format(time.DateTime)
+	}
+	for _, item := range order.Items {
+		res.Items = append(res.Items, presenters.OrderItemRes{
+			FruitID: item.FruitID,
+			Name:    item.Name,
+			Price:   item.Price,
+		})
+	}
+
+	return res
+}
@@ -0,0 +1,338 @@
+package controllers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/golang/mock/gomock"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/viniosilva/where-are-my-fruits/internal/controllers/presenters"
+	"github.com/viniosilva/where-are-my-fruits/internal/dtos"
+	"github.com/viniosilva/where-are-my-fruits/internal/exceptions"
+	"github.com/viniosilva/where-are-my-fruits/internal/models"
+	"github.com/viniosilva/where-are-my-fruits/mocks"
+)
+
+func TestOrderController_Create(t *testing.T) {
+	now := time.Date(2000, 12, 31, 23, 59, 59, 0, time.Local)
+
+	tests := map[string]struct {
+		mock        func(service *mocks.MockOrderService)
+		body        presenters.CreateOrderReq
+		wantCode    int
+		wantBody    presenters.OrderRes
+		wantBodyErr presenters.ErrorRes
+	}{
+		"should be success": {
+			mock: func(service *mocks.MockOrderService) {
+				data := dtos.CreateOrderDto{Items: []dtos.CreateOrderItemDto{{FruitID: 1}}}
+				service.EXPECT().Create(gomock.Any(), data).Return(&models.Order{
+					ID:        1,
+					CreatedAt: now,
+					Total:     decimal.RequireFromString("1.99"),
+					Items: []models.OrderItem{
+						{ID: 1, OrderID: 1, FruitID: 1, Name: "Orange", Price: decimal.RequireFromString("1.99")},
+					},
+				}, nil)
+			},
+			body:     presenters.CreateOrderReq{Items: []presenters.CreateOrderItemReq{{FruitID: 1}}},
+			wantCode: http.StatusCreated,
+			wantBody: presenters.OrderRes{
+				ID:        1,
+				CreatedAt: "2000-12-31 23:59:59",
+				Total:     decimal.RequireFromString("1.99"),
+				Items: []presenters.OrderItemRes{
+					{FruitID: 1, Name: "Orange", Price: decimal.RequireFromString("1.99")},
+				},
+			},
+		},
+		"should throw validation exception": {
+			mock: func(service *mocks.MockOrderService) {
+				service.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil, exceptions.NewValidationException(validator.ValidationErrors{
+					&mocks.FieldError{Itag: "error 1", Ins: "error 1"},
+				}))
+			},
+			wantCode: http.StatusBadRequest,
+			wantBodyErr: presenters.ErrorRes{
+				Error:    exceptions.ValidationExceptionName,
+				Messages: []string{"Key: 'error 1' Error:Field validation for '' failed on the 'error 1' tag"},
+			},
+		},
+		"should throw foreign not found": {
+			mock: func(service *mocks.MockOrderService) {
+				service.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil, exceptions.NewForeignNotFoundException("Fruits not found: 1"))
+			},
+			body:     presenters.CreateOrderReq{Items: []presenters.CreateOrderItemReq{{FruitID: 1}}},
+			wantCode: http.StatusBadRequest,
+			wantBodyErr: presenters.ErrorRes{
+				Error:   exceptions.ForeignNotFoundExceptionName,
+				Message: "Fruits not found: 1",
+			},
+		},
+		"should throw forbidden when fruit is not available": {
+			mock: func(service *mocks.MockOrderService) {
+				service.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil, exceptions.NewForbiddenException("Fruit 1 is not available"))
+			},
+			body:     presenters.CreateOrderReq{Items: []presenters.CreateOrderItemReq{{FruitID: 1}}},
+			wantCode: http.StatusBadRequest,
+			wantBodyErr: presenters.ErrorRes{
+				Error:   exceptions.ForbiddenExceptionName,
+				Message: "Fruit 1 is not available",
+			},
+		},
+		"should throw internal server error": {
+			mock: func(service *mocks.MockOrderService) {
+				service.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil, fmt.Errorf("error"))
+			},
+			body:        presenters.CreateOrderReq{Items: []presenters.CreateOrderItemReq{{FruitID: 1}}},
+			wantCode:    http.StatusInternalServerError,
+			wantBodyErr: presenters.ErrorRes{Error: http.StatusText(http.StatusInternalServerError)},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			// setup
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			serviceMock := mocks.NewMockOrderService(ctrl)
+			tt.mock(serviceMock)
+
+			r := gin.Default()
+			controller := NewOrder(serviceMock)
+
+			r.POST("/api/v1/orders", controller.Create)
+
+			var got presenters.OrderRes
+			var gotErr presenters.ErrorRes
+
+			// given
+			body, _ := json.Marshal(tt.body)
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("POST", "/api/v1/orders", bytes.NewReader(body))
+
+			// when
+			r.ServeHTTP(w, req)
+
+			json.Unmarshal(w.Body.Bytes(), &gotErr)
+			json.Unmarshal(w.Body.Bytes(), &got)
+
+			// then
+			assert.Equal(t, tt.wantCode, w.Code)
+
+			if gotErr.Error != "" {
+				assert.Equal(t, tt.wantBodyErr, gotErr)
+				return
+			}
+			assert.Equal(t, tt.wantBody, got)
+		})
+	}
+}
+
+func TestOrderController_Checkout(t *testing.T) {
+	now := time.Date(2000, 12, 31, 23, 59, 59, 0, time.Local)
+
+	tests := map[string]struct {
+		mock        func(service *mocks.MockOrderService)
+		orderID     string
+		wantCode    int
+		wantBody    presenters.OrderRes
+		wantBodyErr presenters.ErrorRes
+	}{
+		"should be success": {
+			mock: func(service *mocks.MockOrderService) {
+				service.EXPECT().Checkout(gomock.Any(), int64(1)).Return(&models.Order{
+					ID:           1,
+					CreatedAt:    now,
+					CheckedOutAt: &now,
+					Total:        decimal.RequireFromString("1.99"),
+					Items: []models.OrderItem{
+						{ID: 1, OrderID: 1, FruitID: 1, Name: "Orange", Price: decimal.RequireFromString("1.99")},
+					},
+				}, nil)
+			},
+			orderID:  "1",
+			wantCode: http.StatusOK,
+			wantBody: presenters.OrderRes{
+				ID:           1,
+				CreatedAt:    "2000-12-31 23:59:59",
+				CheckedOutAt: "2000-12-31 23:59:59",
+				Total:        decimal.RequireFromString("1.99"),
+				Items: []presenters.OrderItemRes{
+					{FruitID: 1, Name: "Orange", Price: decimal.RequireFromString("1.99")},
+				},
+			},
+		},
+		"should throw bad request when orderID is invalid": {
+			mock:     func(service *mocks.MockOrderService) {},
+			orderID:  "a",
+			wantCode: http.StatusBadRequest,
+			wantBodyErr: presenters.ErrorRes{
+				Error:   exceptions.ValidationExceptionName,
+				Message: "invalid orderID",
+			},
+		},
+		"should throw not found": {
+			mock: func(service *mocks.MockOrderService) {
+				service.EXPECT().Checkout(gomock.Any(), int64(1)).Return(nil, exceptions.NewNotFoundException("Order not found"))
+			},
+			orderID:  "1",
+			wantCode: http.StatusNotFound,
+			wantBodyErr: presenters.ErrorRes{
+				Error:   exceptions.NotFoundExceptionName,
+				Message: "Order not found",
+			},
+		},
+		"should throw forbidden when order is checked out": {
+			mock: func(service *mocks.MockOrderService) {
+				service.EXPECT().Checkout(gomock.Any(), int64(1)).Return(nil, exceptions.NewForbiddenException("Order is already checked out"))
+			},
+			orderID:  "1",
+			wantCode: http.StatusBadRequest,
+			wantBodyErr: presenters.ErrorRes{
+				Error:   exceptions.ForbiddenExceptionName,
+				Message: "Order is already checked out",
+			},
+		},
+		"should throw internal server error": {
+			mock: func(service *mocks.MockOrderService) {
+				service.EXPECT().Checkout(gomock.Any(), int64(1)).Return(nil, fmt.Errorf("error"))
+			},
+			orderID:     "1",
+			wantCode:    http.StatusInternalServerError,
+			wantBodyErr: presenters.ErrorRes{Error: http.StatusText(http.StatusInternalServerError)},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			// setup
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			serviceMock := mocks.NewMockOrderService(ctrl)
+			tt.mock(serviceMock)
+
+			r := gin.Default()
+			controller := NewOrder(serviceMock)
+
+			r.POST("/api/v1/orders/:orderID/checkout", controller.Checkout)
+
+			var got presenters.OrderRes
+			var gotErr presenters.ErrorRes
+
+			// given
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("POST", fmt.Sprintf("/api/v1/orders/%s/checkout", tt.orderID), nil)
+
+			// when
+			r.ServeHTTP(w, req)
+
+			json.Unmarshal(w.Body.Bytes(), &gotErr)
+			json.Unmarshal(w.Body.Bytes(), &got)
+
+			// then
+			assert.Equal(t, tt.wantCode, w.Code)
+
+			if gotErr.Error != "" {
+				assert.Equal(t, tt.wantBodyErr, gotErr)
+				return
+			}
+			assert.Equal(t, tt.wantBody, got)
+		})
+	}
+}
+
+func TestOrderController_Sales(t *testing.T) {
+	tests := map[string]struct {
+		mock        func(service *mocks.MockOrderService)
+		query       string
+		wantCode    int
+		wantBody    presenters.SalesReportRes
+		wantBodyErr presenters.ErrorRes
+	}{
+		"should be success": {
+			mock: func(service *mocks.MockOrderService) {
+				name := "Orange"
+				data := dtos.SalesDto{
+					Name: &name,
+					From: time.Date(2000, 12, 1, 0, 0, 0, 0, time.Local),
+					To:   time.Date(2001, 1, 1, 0, 0, 0, 0, time.Local),
+				}
+				service.EXPECT().Sales(gomock.Any(), data).Return([]models.Sales{
+					{Day: "2000-12-30", Name: "Orange", Quantity: 2, Revenue: decimal.RequireFromString("3.98")},
+					{Day: "2000-12-31", Name: "Orange", Quantity: 1, Revenue: decimal.RequireFromString("1.99")},
+				}, nil)
+			},
+			query:    "?name=Orange&from=2000-12-01&to=2000-12-31",
+			wantCode: http.StatusOK,
+			wantBody: presenters.SalesReportRes{
+				From: "2000-12-01",
+				To:   "2000-12-31",
+				Data: []presenters.SalesRes{
+					{Day: "2000-12-30", Name: "Orange", Quantity: 2, Revenue: decimal.RequireFromString("3.98")},
+					{Day: "2000-12-31", Name: "Orange", Quantity: 1, Revenue: decimal.RequireFromString("1.99")},
+				},
+				TotalQuantity: 3,
+				TotalRevenue:  decimal.RequireFromString("5.97"),
+			},
+		},
+		"should throw bad request when from is invalid": {
+			mock:     func(service *mocks.MockOrderService) {},
+			query:    "?from=a",
+			wantCode: http.StatusBadRequest,
+			wantBodyErr: presenters.ErrorRes{
+				Error:   exceptions.ValidationExceptionName,
+				Message: "invalid from",
+			},
+		},
+		"should throw internal server error": {
+			mock: func(service *mocks.MockOrderService) {
+				service.EXPECT().Sales(gomock.Any(), gomock.Any()).Return(nil, fmt.Errorf("error"))
+			},
+			wantCode:    http.StatusInternalServerError,
+			wantBodyErr: presenters.ErrorRes{Error: http.StatusText(http.StatusInternalServerError)},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			// setup
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			serviceMock := mocks.NewMockOrderService(ctrl)
+			tt.mock(serviceMock)
+
+			r := gin.Default()
+			controller := NewOrder(serviceMock)
+
+			r.GET("/api/v1/reports/sales", controller.Sales)
+
+			var got presenters.SalesReportRes
+			var gotErr presenters.ErrorRes
+
+			// given
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("GET", "/api/v1/reports/sales"+tt.query, nil)
+
+			// when
+			r.ServeHTTP(w, req)
+
+			json.Unmarshal(w.Body.Bytes(), &gotErr)
+			json.Unmarshal(w.Body.Bytes(), &got)
+
+			// then
+			assert.Equal(t, tt.wantCode, w.Code)
+
+			if gotErr.Error != "" {
+				assert.Equal(t, tt.wantBodyErr, gotErr)
+				return
+			}
+			assert.Equal(t, tt.wantBody, got)
+		})
+	}
+}
@@ -0,0 +1,235 @@
+package controllers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/viniosilva/where-are-my-fruits/internal/controllers/presenters"
+	"github.com/viniosilva/where-are-my-fruits/internal/dtos"
+	"github.com/viniosilva/where-are-my-fruits/internal/exceptions"
+	"github.com/viniosilva/where-are-my-fruits/internal/models"
+)
+
+type PhotoController struct {
+	service PhotoService
+}
+
+func NewPhoto(service PhotoService) *PhotoController {
+	return &PhotoController{
+		service: service,
+	}
+}
+
+// Photo godoc
+// @Summary upload fruit photo
+// @Schemes
+// @Tags photo
+// @Accept multipart/form-data
+// @Produce json
+// @Param fruitID path int64 true "Fruit ID"
+// @Param file formData file true "jpeg, png or gif image"
+// @Success 201 {object} presenters.PhotoRes
+// @Failure 400 {object} presenters.ErrorRes
+// @Failure 404 {object} presenters.ErrorRes
+// @Failure 500 {object} presenters.ErrorRes
+// @Router /v1/fruits/{fruitID}/photos [post]
+func (impl *PhotoController) UploadFruitPhoto(ctx *gin.Context) {
+	impl.upload(ctx, models.PhotoOwnerFruit, "fruitID")
+}
+
+// Photo godoc
+// @Summary list fruit photos
+// @Schemes
+// @Tags photo
+// @Accept json
+// @Produce json
+// @Param fruitID path int64 true "Fruit ID"
+// @Success 200 {object} presenters.PhotosRes
+// @Failure 400 {object} presenters.ErrorRes
+// @Failure 500 {object} presenters.ErrorRes
+// @Router /v1/fruits/{fruitID}/photos [get]
+func (impl *PhotoController) ListFruitPhotos(ctx *gin.Context) {
+	impl.list(ctx, models.PhotoOwnerFruit, "fruitID")
+}
+
+// Photo godoc
+// @Summary upload bucket photo
+// @Schemes
+// @Tags photo
+// @Accept multipart/form-data
+// @Produce json
+// @Param bucketID path int64 true "Bucket ID"
+// @Param file formData file true "jpeg, png or gif image"
+// @Success 201 {object} presenters.PhotoRes
+// @Failure 400 {object} presenters.ErrorRes
+// @Failure 404 {object} presenters.ErrorRes
+// @Failure 500 {object} presenters.ErrorRes
+// @Router /v1/buckets/{bucketID}/photos [post]
+func (impl *PhotoController) UploadBucketPhoto(ctx *gin.Context) {
+	impl.upload(ctx, models.PhotoOwnerBucket, "bucketID")
+}
+
+// Photo godoc
+// @Summary list bucket photos
+// @Schemes
+// @Tags photo
+// @Accept json
+// @Produce json
+// @Param bucketID path int64 true "Bucket ID"
+// @Success 200 {object} presenters.PhotosRes
+// @Failure 400 {object} presenters.ErrorRes
+// @Failure 500 {object} presenters.ErrorRes
+// @Router /v1/buckets/{bucketID}/photos [get]
+func (impl *PhotoController) ListBucketPhotos(ctx *gin.Context) {
+	impl.list(ctx, models.PhotoOwnerBucket, "bucketID")
+}
+
+// Photo godoc
+// @Summary download photo
+// @Schemes
+// @Tags photo
+// @Produce image/jpeg,image/png,image/gif
+// @Param photoID path int64 true "Photo ID"
+// @Success 200 {file} binary
+// @Failure 400 {object} presenters.ErrorRes
+// @Failure 404 {object} presenters.ErrorRes
+// @Failure 500 {object} presenters.ErrorRes
+// @Router /v1/photos/{photoID} [get]
+func (impl *PhotoController) Download(ctx *gin.Context) {
+	impl.download(ctx, false)
+}
+
+// Photo godoc
+// @Summary download photo thumbnail
+// @Schemes
+// @Tags photo
+// @Produce image/jpeg
+// @Param photoID path int64 true "Photo ID"
+// @Success 200 {file} binary
+// @Failure 400 {object} presenters.ErrorRes
+// @Failure 404 {object} presenters.ErrorRes
+// @Failure 500 {object} presenters.ErrorRes
+// @Router /v1/photos/{photoID}/thumbnail [get]
+func (impl *PhotoController) DownloadThumbnail(ctx *gin.Context) {
+	impl.download(ctx, true)
+}
+
+func (impl *PhotoController) upload(ctx *gin.Context, ownerType, param string) {
+	ownerID, err := strconv.ParseInt(ctx.Param(param), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, presenters.ErrorRes{Error: exceptions.ValidationExceptionName, Message: fmt.Sprintf("invalid %s", param)})
+		return
+	}
+
+	header, err := ctx.FormFile("file")
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, presenters.ErrorRes{Error: exceptions.ValidationExceptionName, Message: "invalid file"})
+		return
+	}
+
+	file, err := header.Open()
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, presenters.ErrorRes{Error: exceptions.ValidationExceptionName, Message: "invalid file"})
+		return
+	}
+	defer file.Close()
+
+	data := dtos.UploadPhotoDto{
+		OwnerType: ownerType,
+		OwnerID:   ownerID,
+		Filename:  header.Filename,
+		Size:      header.Size,
+		Content:   file,
+	}
+
+	res, err := impl.service.Upload(ctx, data)
+	if err != nil {
+		if e, ok := err.(*exceptions.ValidationException); ok {
+			ctx.JSON(http.StatusBadRequest, presenters.ErrorRes{Error: e.Name, Messages: e.Errors})
+			return
+		}
+		if e, ok := err.(*exceptions.ForbiddenException); ok {
+			ctx.JSON(http.StatusBadRequest, presenters.ErrorRes{Error: e.Name, Message: e.Error()})
+			return
+		}
+		if e, ok := err.(*exceptions.NotFoundException); ok {
+			ctx.JSON(http.StatusNotFound, presenters.ErrorRes{Error: e.Name, Message: e.Error()})
+			return
+		}
+
+		ctx.JSON(http.StatusInternalServerError, presenters.ErrorRes{Error: http.StatusText(http.StatusInternalServerError)})
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, impl.parse(res))
+}
+
+func (impl *PhotoController) list(ctx *gin.Context, ownerType, param string) {
+	ownerID, err := strconv.ParseInt(ctx.Param(param), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, presenters.ErrorRes{Error: exceptions.ValidationExceptionName, Message: fmt.Sprintf("invalid %s", param)})
+		return
+	}
+
+	res, err := impl.service.List(ctx, ownerType, ownerID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, presenters.ErrorRes{Error: http.StatusText(http.StatusInternalServerError)})
+		return
+	}
+
+	resp := presenters.PhotosRes{Data: []presenters.PhotoRes{}}
+	for _, photo := range res {
+		resp.Data = append(resp.Data, impl.parse(&photo))
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+func (impl *PhotoController) download(ctx *gin.Context, thumbnail bool) {
+	photoID, err := strconv.ParseInt(ctx.Param("photoID"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, presenters.ErrorRes{Error: exceptions.ValidationExceptionName, Message: "invalid photoID"})
+		return
+	}
+
+	photo, reader, err := impl.service.Open(ctx, photoID, thumbnail)
+	if err != nil {
+		if e, ok := err.(*exceptions.NotFoundException); ok {
+			ctx.JSON(http.StatusNotFound, presenters.ErrorRes{Error: e.Name, Message: e.Error()})
+			return
+		}
+
+		ctx.JSON(http.StatusInternalServerError, presenters.ErrorRes{Error: http.StatusText(http.StatusInternalServerError)})
+		return
+	}
+	defer reader.Close()
+
+	contentType, size := photo.ContentType, photo.Size
+	if thumbnail {
+		contentType, size = models.PhotoThumbnailContentType, -1
+	}
+
+	headers := map[string]string{
+		"Content-Disposition": fmt.Sprintf("inline; filename=%q", photo.Filename),
+	}
+	ctx.DataFromReader(http.StatusOK, size, contentType, reader, headers)
+}
+
+func (impl *PhotoController) parse(photo *models.Photo) presenters.PhotoRes {
+	return presenters.PhotoRes{
+		ID:           photo.ID,
+		CreatedAt:    photo.CreatedAt.Format(time.DateTime),
+		FruitID:      photo.FruitID,
+		BucketID:     photo.BucketID,
+		Filename:     photo.Filename,
+		ContentType:  photo.ContentType,
+		Size:         photo.Size,
+		Width:        photo.Width,
+		Height:       photo.Height,
+		URL:          fmt.Sprintf("/api/v1/photos/%d", photo.ID),
+		ThumbnailURL: fmt.Sprintf("/api/v1/photos/%d/thumbnail", photo.ID),
+	}
+}
@@ -0,0 +1,360 @@
+package controllers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/viniosilva/where-are-my-fruits/internal/controllers/presenters"
+	"github.com/viniosilva/where-are-my-fruits/internal/dtos"
+	"github.com/viniosilva/where-are-my-fruits/internal/exceptions"
+	"github.com/viniosilva/where-are-my-fruits/internal/models"
+	"github.com/viniosilva/where-are-my-fruits/mocks"
+)
+
+func TestPhotoController_UploadFruitPhoto(t *testing.T) {
+	now := time.Date(2000, 12, 31, 23, 59, 59, 0, time.Local)
+	fruitID := int64(1)
+
+	tests := map[string]struct {
+		mock        func(service *mocks.MockPhotoService)
+		fruitID     string
+		file        string
+		wantCode    int
+		wantBody    presenters.PhotoRes
+		wantBodyErr presenters.ErrorRes
+	}{
+		"should be success": {
+			mock: func(service *mocks.MockPhotoService) {
+				service.EXPECT().Upload(gomock.Any(), gomock.Any()).DoAndReturn(func(_ interface{}, data dtos.UploadPhotoDto) (*models.Photo, error) {
+					assert.Equal(t, models.PhotoOwnerFruit, data.OwnerType)
+					assert.Equal(t, fruitID, data.OwnerID)
+					assert.Equal(t, "mango.png", data.Filename)
+					assert.Equal(t, int64(5), data.Size)
+
+					return &models.Photo{
+						ID:          1,
+						CreatedAt:   now,
+						FruitID:     &fruitID,
+						Filename:    "mango.png",
+						ContentType: "image/png",
+						Size:        5,
+						Width:       4,
+						Height:      2,
+					}, nil
+				})
+			},
+			fruitID:  "1",
+			file:     "image",
+			wantCode: http.StatusCreated,
+			wantBody: presenters.PhotoRes{
+				ID:           1,
+				CreatedAt:    "2000-12-31 23:59:59",
+				FruitID:      &fruitID,
+				Filename:     "mango.png",
+				ContentType:  "image/png",
+				Size:         5,
+				Width:        4,
+				Height:       2,
+				URL:          "/api/v1/photos/1",
+				ThumbnailURL: "/api/v1/photos/1/thumbnail",
+			},
+		},
+		"should throw bad request when fruitID is invalid": {
+			mock:     func(service *mocks.MockPhotoService) {},
+			fruitID:  "a",
+			file:     "image",
+			wantCode: http.StatusBadRequest,
+			wantBodyErr: presenters.ErrorRes{
+				Error:   exceptions.ValidationExceptionName,
+				Message: "invalid fruitID",
+			},
+		},
+		"should throw bad request when file is missing": {
+			mock:     func(service *mocks.MockPhotoService) {},
+			fruitID:  "1",
+			wantCode: http.StatusBadRequest,
+			wantBodyErr: presenters.ErrorRes{
+				Error:   exceptions.ValidationExceptionName,
+				Message: "invalid file",
+			},
+		},
+		"should throw forbidden when file is not an image": {
+			mock: func(service *mocks.MockPhotoService) {
+				service.EXPECT().Upload(gomock.Any(), gomock.Any()).
+					Return(nil, exceptions.NewForbiddenException("Photo must be a jpeg, png or gif image"))
+			},
+			fruitID:  "1",
+			file:     "image",
+			wantCode: http.StatusBadRequest,
+			wantBodyErr: presenters.ErrorRes{
+				Error:   exceptions.ForbiddenExceptionName,
+				Message: "Photo must be a jpeg, png or gif image",
+			},
+		},
+		"should throw not found": {
+			mock: func(service *mocks.MockPhotoService) {
+				service.EXPECT().Upload(gomock.Any(), gomock.Any()).Return(nil, exceptions.NewNotFoundException("Fruit not found"))
+			},
+			fruitID:  "1",
+			file:     "image",
+			wantCode: http.StatusNotFound,
+			wantBodyErr: presenters.ErrorRes{
+				Error:   exceptions.NotFoundExceptionName,
+				Message: "Fruit not found",
+			},
+		},
+		"should throw internal server error": {
+			mock: func(service *mocks.MockPhotoService) {
+				service.EXPECT().Upload(gomock.Any(), gomock.Any()).Return(nil, fmt.Errorf("error"))
+			},
+			fruitID:     "1",
+			file:        "image",
+			wantCode:    http.StatusInternalServerError,
+			wantBodyErr: presenters.ErrorRes{Error: http.StatusText(http.StatusInternalServerError)},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			// setup
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			serviceMock := mocks.NewMockPhotoService(ctrl)
+			tt.mock(serviceMock)
+
+			r := gin.Default()
+			controller := NewPhoto(serviceMock)
+
+			r.POST("/api/v1/fruits/:fruitID/photos", controller.UploadFruitPhoto)
+
+			var got presenters.PhotoRes
+			var gotErr presenters.ErrorRes
+
+			// given
+			body := &bytes.Buffer{}
+			writer := multipart.NewWriter(body)
+			if tt.file != "" {
+				part, _ := writer.CreateFormFile("file", "mango.png")
+				part.Write([]byte(tt.file))
+			}
+			writer.Close()
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("POST", fmt.Sprintf("/api/v1/fruits/%s/photos", tt.fruitID), body)
+			req.Header.Set("Content-Type", writer.FormDataContentType())
+
+			// when
+			r.ServeHTTP(w, req)
+
+			json.Unmarshal(w.Body.Bytes(), &gotErr)
+			json.Unmarshal(w.Body.Bytes(), &got)
+
+			// then
+			assert.Equal(t, tt.wantCode, w.Code)
+
+			if gotErr.Error != "" {
+				assert.Equal(t, tt.wantBodyErr, gotErr)
+				return
+			}
+			assert.Equal(t, tt.wantBody, got)
+		})
+	}
+}
+
+func TestPhotoController_ListBucketPhotos(t *testing.T) {
+	now := time.Date(2000, 12, 31, 23, 59, 59, 0, time.Local)
+	bucketID := int64(1)
+
+	tests := map[string]struct {
+		mock        func(service *mocks.MockPhotoService)
+		bucketID    string
+		wantCode    int
+		wantBody    presenters.PhotosRes
+		wantBodyErr presenters.ErrorRes
+	}{
+		"should be success": {
+			mock: func(service *mocks.MockPhotoService) {
+				service.EXPECT().List(gomock.Any(), models.PhotoOwnerBucket, bucketID).Return([]models.Photo{
+					{ID: 1, CreatedAt: now, BucketID: &bucketID, Filename: "bucket.jpg", ContentType: "image/jpeg", Size: 100, Width: 4, Height: 2},
+				}, nil)
+			},
+			bucketID: "1",
+			wantCode: http.StatusOK,
+			wantBody: presenters.PhotosRes{
+				Data: []presenters.PhotoRes{
+					{
+						ID:           1,
+						CreatedAt:    "2000-12-31 23:59:59",
+						BucketID:     &bucketID,
+						Filename:     "bucket.jpg",
+						ContentType:  "image/jpeg",
+						Size:         100,
+						Width:        4,
+						Height:       2,
+						URL:          "/api/v1/photos/1",
+						ThumbnailURL: "/api/v1/photos/1/thumbnail",
+					},
+				},
+			},
+		},
+		"should throw bad request when bucketID is invalid": {
+			mock:     func(service *mocks.MockPhotoService) {},
+			bucketID: "a",
+			wantCode: http.StatusBadRequest,
+			wantBodyErr: presenters.ErrorRes{
+				Error:   exceptions.ValidationExceptionName,
+				Message: "invalid bucketID",
+			},
+		},
+		"should throw internal server error": {
+			mock: func(service *mocks.MockPhotoService) {
+				service.EXPECT().List(gomock.Any(), models.PhotoOwnerBucket, bucketID).Return(nil, fmt.Errorf("error"))
+			},
+			bucketID:    "1",
+			wantCode:    http.StatusInternalServerError,
+			wantBodyErr: presenters.ErrorRes{Error: http.StatusText(http.StatusInternalServerError)},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			// setup
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			serviceMock := mocks.NewMockPhotoService(ctrl)
+			tt.mock(serviceMock)
+
+			r := gin.Default()
+			controller := NewPhoto(serviceMock)
+
+			r.GET("/api/v1/buckets/:bucketID/photos", controller.ListBucketPhotos)
+
+			var got presenters.PhotosRes
+			var gotErr presenters.ErrorRes
+
+			// given
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("GET", fmt.Sprintf("/api/v1/buckets/%s/photos", tt.bucketID), nil)
+
+			// when
+			r.ServeHTTP(w, req)
+
+			json.Unmarshal(w.Body.Bytes(), &gotErr)
+			json.Unmarshal(w.Body.Bytes(), &got)
+
+			// then
+			assert.Equal(t, tt.wantCode, w.Code)
+
+			if gotErr.Error != "" {
+				assert.Equal(t, tt.wantBodyErr, gotErr)
+				return
+			}
+			assert.Equal(t, tt.wantBody, got)
+		})
+	}
+}
+
+func TestPhotoController_Download(t *testing.T) {
+	photo := &models.Photo{ID: 1, Filename: "mango.png", ContentType: "image/png", Size: 5}
+
+	tests := map[string]struct {
+		mock            func(service *mocks.MockPhotoService)
+		path            string
+		wantCode        int
+		wantContentType string
+		wantBody        string
+		wantBodyErr     presenters.ErrorRes
+	}{
+		"should be success": {
+			mock: func(service *mocks.MockPhotoService) {
+				service.EXPECT().Open(gomock.Any(), int64(1), false).Return(photo, io.NopCloser(strings.NewReader("image")), nil)
+			},
+			path:            "/api/v1/photos/1",
+			wantCode:        http.StatusOK,
+			wantContentType: "image/png",
+			wantBody:        "image",
+		},
+		"should be success when thumbnail": {
+			mock: func(service *mocks.MockPhotoService) {
+				service.EXPECT().Open(gomock.Any(), int64(1), true).Return(photo, io.NopCloser(strings.NewReader("thumb")), nil)
+			},
+			path:            "/api/v1/photos/1/thumbnail",
+			wantCode:        http.StatusOK,
+			wantContentType: "image/jpeg",
+			wantBody:        "thumb",
+		},
+		"should throw bad request when photoID is invalid": {
+			mock:     func(service *mocks.MockPhotoService) {},
+			path:     "/api/v1/photos/a",
+			wantCode: http.StatusBadRequest,
+			wantBodyErr: presenters.ErrorRes{
+				Error:   exceptions.ValidationExceptionName,
+				Message: "invalid photoID",
+			},
+		},
+		"should throw not found": {
+			mock: func(service *mocks.MockPhotoService) {
+				service.EXPECT().Open(gomock.Any(), int64(1), false).Return(nil, nil, exceptions.NewNotFoundException("Photo not found"))
+			},
+			path:     "/api/v1/photos/1",
+			wantCode: http.StatusNotFound,
+			wantBodyErr: presenters.ErrorRes{
+				Error:   exceptions.NotFoundExceptionName,
+				Message: "Photo not found",
+			},
+		},
+		"should throw internal server error": {
+			mock: func(service *mocks.MockPhotoService) {
+				service.EXPECT().Open(gomock.Any(), int64(1), false).Return(nil, nil, fmt.Errorf("error"))
+			},
+			path:        "/api/v1/photos/1",
+			wantCode:    http.StatusInternalServerError,
+			wantBodyErr: presenters.ErrorRes{Error: http.StatusText(http.StatusInternalServerError)},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			// setup
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			serviceMock := mocks.NewMockPhotoService(ctrl)
+			tt.mock(serviceMock)
+
+			r := gin.Default()
+			controller := NewPhoto(serviceMock)
+
+			r.GET("/api/v1/photos/:photoID", controller.Download)
+			r.GET("/api/v1/photos/:photoID/thumbnail", controller.DownloadThumbnail)
+
+			var gotErr presenters.ErrorRes
+
+			// given
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("GET", tt.path, nil)
+
+			// when
+			r.ServeHTTP(w, req)
+
+			// then
+			assert.Equal(t, tt.wantCode, w.Code)
+
+			if tt.wantCode != http.StatusOK {
+				json.Unmarshal(w.Body.Bytes(), &gotErr)
+				assert.Equal(t, tt.wantBodyErr, gotErr)
+				return
+			}
+			assert.Equal(t, tt.wantContentType, w.Header().Get("Content-Type"))
+			assert.Equal(t, `inline; filename="mango.png"`, w.Header().Get("Content-Disposition"))
+			assert.Equal(t, tt.wantBody, w.Body.String())
+		})
+	}
+}
@@ -0,0 +1,70 @@
+package controllers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/viniosilva/where-are-my-fruits/internal/controllers/presenters"
+	"github.com/viniosilva/where-are-my-fruits/internal/dtos"
+	"github.com/viniosilva/where-are-my-fruits/internal/exceptions"
+)
+
+type PickController struct {
+	service PickService
+}
+
+func NewPick(service PickService) *PickController {
+	return &PickController{
+		service: service,
+	}
+}
+
+// Pick godoc
+// @Summary pick fruits first-expired-first-out
+// @Schemes
+// @Tags pick
+// @Accept json
+// @Produce json
+// @Param pick body presenters.CreatePickReq true "Pick"
+// @Success 201 {object} presenters.PickRes
+// @Failure 400 {object} presenters.ErrorRes
+// @Failure 500 {object} presenters.ErrorRes
+// @Router /v1/picks [post]
+func (impl *PickController) Create(ctx *gin.Context) {
+	var req presenters.CreatePickReq
+	ctx.BindJSON(&req)
+
+	data := dtos.CreatePickDto{
+		Name:     req.Name,
+		Quantity: req.Quantity,
+	}
+
+	res, err := impl.service.Create(ctx, data)
+	if err != nil {
+		if e, ok := err.(*exceptions.ValidationException); ok {
+			ctx.JSON(http.StatusBadRequest, presenters.ErrorRes{Error: e.Name, Messages: e.Errors})
+			return
+		}
+		if e, ok := err.(*exceptions.ForbiddenException); ok {
+			ctx.JSON(http.StatusBadRequest, presenters.ErrorRes{Error: e.Name, Message: e.Error()})
+			return
+		}
+
+		ctx.JSON(http.StatusInternalServerError, presenters.ErrorRes{Error: http.StatusText(http.StatusInternalServerError)})
+		return
+	}
+
+	resp := presenters.PickRes{Name: res.Name, Quantity: res.Quantity, Items: []presenters.PickItemRes{}}
+	for _, item := range res.Items {
+		resp.Items = append(resp.Items, presenters.PickItemRes{
+			FruitID:    item.FruitID,
+			BucketID:   item.BucketID,
+			BucketName: item.BucketName,
+			ExpiresAt:  item.ExpiresAt.Format(time.DateTime),
+			Price:      item.Price,
+		})
+	}
+
+	ctx.JSON(http.StatusCreated, resp)
+}
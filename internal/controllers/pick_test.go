@@ -0,0 +1,124 @@
+package controllers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/golang/mock/gomock"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/viniosilva/where-are-my-fruits/internal/controllers/presenters"
+	"github.com/viniosilva/where-are-my-fruits/internal/dtos"
+	"github.com/viniosilva/where-are-my-fruits/internal/exceptions"
+	"github.com/viniosilva/where-are-my-fruits/internal/models"
+	"github.com/viniosilva/where-are-my-fruits/mocks"
+)
+
+func TestPickController_Create(t *testing.T) {
+	expiresAt := time.Date(2000, 12, 31, 23, 59, 59, 0, time.Local)
+
+	tests := map[string]struct {
+		mock        func(service *mocks.MockPickService)
+		body        presenters.CreatePickReq
+		wantCode    int
+		wantBody    presenters.PickRes
+		wantBodyErr presenters.ErrorRes
+	}{
+		"should be success": {
+			mock: func(service *mocks.MockPickService) {
+				service.EXPECT().Create(gomock.Any(), dtos.CreatePickDto{Name: "Orange", Quantity: 1}).Return(&models.Pick{
+					Name:     "Orange",
+					Quantity: 1,
+					Items: []models.PickItem{
+						{FruitID: 1, BucketID: 2, BucketName: "B", ExpiresAt: expiresAt, Price: decimal.RequireFromString("1.99")},
+					},
+				}, nil)
+			},
+			body:     presenters.CreatePickReq{Name: "Orange", Quantity: 1},
+			wantCode: http.StatusCreated,
+			wantBody: presenters.PickRes{
+				Name:     "Orange",
+				Quantity: 1,
+				Items: []presenters.PickItemRes{
+					{FruitID: 1, BucketID: 2, BucketName: "B", ExpiresAt: "2000-12-31 23:59:59", Price: decimal.RequireFromString("1.99")},
+				},
+			},
+		},
+		"should throw validation exception": {
+			mock: func(service *mocks.MockPickService) {
+				service.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil, exceptions.NewValidationException(validator.ValidationErrors{
+					&mocks.FieldError{Itag: "error 1", Ins: "error 1"},
+				}))
+			},
+			wantCode: http.StatusBadRequest,
+			wantBodyErr: presenters.ErrorRes{
+				Error:    exceptions.ValidationExceptionName,
+				Messages: []string{"Key: 'error 1' Error:Field validation for '' failed on the 'error 1' tag"},
+			},
+		},
+		"should throw forbidden when there are not enough fruits": {
+			mock: func(service *mocks.MockPickService) {
+				service.EXPECT().Create(gomock.Any(), gomock.Any()).
+					Return(nil, exceptions.NewForbiddenException("Not enough Orange to pick: 1 available"))
+			},
+			body:     presenters.CreatePickReq{Name: "Orange", Quantity: 2},
+			wantCode: http.StatusBadRequest,
+			wantBodyErr: presenters.ErrorRes{
+				Error:   exceptions.ForbiddenExceptionName,
+				Message: "Not enough Orange to pick: 1 available",
+			},
+		},
+		"should throw internal server error": {
+			mock: func(service *mocks.MockPickService) {
+				service.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil, fmt.Errorf("error"))
+			},
+			body:        presenters.CreatePickReq{Name: "Orange", Quantity: 1},
+			wantCode:    http.StatusInternalServerError,
+			wantBodyErr: presenters.ErrorRes{Error: http.StatusText(http.StatusInternalServerError)},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			// setup
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			serviceMock := mocks.NewMockPickService(ctrl)
+			tt.mock(serviceMock)
+
+			r := gin.Default()
+			controller := NewPick(serviceMock)
+
+			r.POST("/api/v1/picks", controller.Create)
+
+			var got presenters.PickRes
+			var gotErr presenters.ErrorRes
+
+			// given
+			body, _ := json.Marshal(tt.body)
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("POST", "/api/v1/picks", bytes.NewReader(body))
+
+			// when
+			r.ServeHTTP(w, req)
+
+			json.Unmarshal(w.Body.Bytes(), &gotErr)
+			json.Unmarshal(w.Body.Bytes(), &got)
+
+			// then
+			assert.Equal(t, tt.wantCode, w.Code)
+
+			if gotErr.Error != "" {
+				assert.Equal(t, tt.wantBodyErr, gotErr)
+				return
+			}
+			assert.Equal(t, tt.wantBody, got)
+		})
+	}
+}
@@ -3,8 +3,9 @@ package presenters
 import "github.com/shopspring/decimal"
 
 type CreateBucketReq struct {
-	Name     string `json:"name" example:"A"`
-	Capacity int    `json:"capacity" example:"10"`
+	Name       string `json:"name" example:"A"`
+	Capacity   int    `json:"capacity" example:"10"`
+	Quarantine bool   `json:"quarantine" example:"false"`
 }
 
 type BucketRes struct {
@@ -14,6 +15,8 @@ type BucketRes struct {
 
 	Name     string `json:"name" example:"A"`
 	Capacity int    `json:"capacity" example:"10"`
+
+	Quarantine bool `json:"quarantine" example:"false"`
 }
 
 type BucketFruitsRes struct {
@@ -23,8 +26,47 @@ type BucketFruitsRes struct {
 	TotalFruits int64           `json:"total_fruit" example:"5"`
 	TotalPrice  decimal.Decimal `json:"total_price" example:"23.54"`
 	Percent     string          `json:"percent" example:"50%"`
+
+	TotalEffectivePrice decimal.Decimal `json:"total_effective_price" example:"18.83"`
+	TotalReserved       int64           `json:"total_reserved" example:"1"`
+
+	States BucketFruitsStatesRes `json:"states"`
+}
+
+type BucketFruitsStatesRes struct {
+	Unripe   int64 `json:"unripe" example:"2"`
+	Ripe     int64 `json:"ripe" example:"2"`
+	Overripe int64 `json:"overripe" example:"1"`
 }
 
 type BucketsFruitsRes struct {
 	Data []BucketFruitsRes `json:"data"`
 }
+
+type BucketConsolidationMoveRes struct {
+	FruitID      int64 `json:"fruit_id" example:"1"`
+	FromBucketID int64 `json:"from_bucket_id" example:"2"`
+	ToBucketID   int64 `json:"to_bucket_id" example:"1"`
+}
+
+type BucketConsolidationRes struct {
+	Applied          bool                         `json:"applied" example:"false"`
+	Moves            []BucketConsolidationMoveRes `json:"moves"`
+	EmptiedBucketIDs []int64                      `json:"emptied_bucket_ids" example:"2"`
+}
+
+type CompatibilityViolationRes struct {
+	Rule  string `json:"rule" example:"ethylene_producers/ethylene_sensitive"`
+	Fruit string `json:"fruit" example:"apple"`
+	Other string `json:"other" example:"kiwi"`
+}
+
+type BucketViolationsRes struct {
+	ID         int64                       `json:"id" example:"1"`
+	Name       string                      `json:"name" example:"A"`
+	Violations []CompatibilityViolationRes `json:"violations"`
+}
+
+type BucketsViolationsRes struct {
+	Data []BucketViolationsRes `json:"data"`
+}
@@ -0,0 +1,52 @@
+package presenters
+
+type SubmitCycleCountReq struct {
+	FruitIDs []int64             `json:"fruit_ids,omitempty" example:"1,2"`
+	Items    []CycleCountItemReq `json:"items,omitempty"`
+}
+
+type CycleCountItemReq struct {
+	Name     string `json:"name" example:"Orange"`
+	Quantity int    `json:"quantity" example:"10"`
+}
+
+type CycleCountRes struct {
+	ID          int64  `json:"id" example:"1"`
+	CreatedAt   string `json:"created_at" example:"2000-12-31 23:00:00"`
+	SubmittedAt string `json:"submitted_at,omitempty" example:"2000-12-31 23:30:00"`
+	ApprovedAt  string `json:"approved_at,omitempty" example:"2000-12-31 23:59:59"`
+	BucketID    int64  `json:"bucket_id" example:"1"`
+	Status      string `json:"status" example:"submitted"`
+
+	Items       []CycleCountItemRes       `json:"items"`
+	Variance    *CycleCountVarianceRes    `json:"variance,omitempty"`
+	Adjustments []CycleCountAdjustmentRes `json:"adjustments"`
+}
+
+type CycleCountItemRes struct {
+	FruitID  *int64 `json:"fruit_id,omitempty" example:"1"`
+	Name     string `json:"name" example:"Orange"`
+	Quantity int    `json:"quantity" example:"1"`
+}
+
+type CycleCountVarianceRes struct {
+	Names           []CycleCountNameVarianceRes `json:"names"`
+	MissingFruitIDs []int64                     `json:"missing_fruit_ids" example:"3"`
+	UnknownFruitIDs []int64                     `json:"unknown_fruit_ids" example:"7"`
+}
+
+type CycleCountNameVarianceRes struct {
+	Name       string `json:"name" example:"Orange"`
+	Expected   int64  `json:"expected" example:"10"`
+	Counted    int64  `json:"counted" example:"9"`
+	Difference int64  `json:"difference" example:"-1"`
+}
+
+type CycleCountAdjustmentRes struct {
+	ID        int64  `json:"id" example:"1"`
+	CreatedAt string `json:"created_at" example:"2000-12-31 23:59:59"`
+	FruitID   *int64 `json:"fruit_id,omitempty" example:"3"`
+	Name      string `json:"name" example:"Orange"`
+	Quantity  int    `json:"quantity" example:"1"`
+	Action    string `json:"action" example:"unassigned"`
+}
@@ -1,6 +1,8 @@
 package presenters
 
 import (
+	"encoding/json"
+
 	"github.com/shopspring/decimal"
 )
 
@@ -8,7 +10,44 @@ type CreateFruitReq struct {
 	Name      string          `json:"name" example:"Orange"`
 	Price     decimal.Decimal `json:"price" example:"1.99"`
 	ExpiresIn string          `json:"expires_in" example:"1m"`
-	BucketID  *int64          `json:"bucket_id" example:"1"`
+	BucketID  BucketIDReq     `json:"bucket_id" swaggertype:"string" example:"1"`
+	SKU       *string         `json:"sku" example:"ORG-001"`
+	Barcode   *string         `json:"barcode" example:"7891234567895"`
+
+	SupplierID    *int64  `json:"supplier_id" example:"1"`
+	OriginCountry *string `json:"origin_country" example:"BR"`
+	HarvestedAt   string  `json:"harvested_at" example:"2000-12-31"`
+
+	Allocation *string `json:"allocation" example:"least_full"`
+}
+
+// BucketIDReq is either a bucket id or "auto" to let a bucket with free
+// capacity be allocated
+type BucketIDReq struct {
+	ID   *int64
+	Auto bool
+}
+
+func (impl *BucketIDReq) UnmarshalJSON(data []byte) error {
+	if string(data) == `"auto"` {
+		impl.Auto = true
+		return nil
+	}
+
+	return json.Unmarshal(data, &impl.ID)
+}
+
+func (impl BucketIDReq) MarshalJSON() ([]byte, error) {
+	if impl.Auto {
+		return []byte(`"auto"`), nil
+	}
+
+	return json.Marshal(impl.ID)
+}
+
+type CreateFruitsBatchReq struct {
+	Mode  string           `json:"mode" example:"all_or_nothing"`
+	Items []CreateFruitReq `json:"items"`
 }
 
 type FruitRes struct {
@@ -20,4 +59,99 @@ type FruitRes struct {
 	Name      string          `json:"name" example:"Orange"`
 	Price     decimal.Decimal `json:"price" example:"1.99"`
 	ExpiresAt string          `json:"expires_at" example:"1m"`
+	State     string          `json:"state" example:"ripe"`
+	SKU       *string         `json:"sku,omitempty" example:"ORG-001"`
+	Barcode   *string         `json:"barcode,omitempty" example:"7891234567895"`
+
+	EffectivePrice decimal.Decimal `json:"effective_price" example:"1.59"`
+
+	SupplierID    *int64  `json:"supplier_id,omitempty" example:"1"`
+	OriginCountry *string `json:"origin_country,omitempty" example:"BR"`
+	HarvestedAt   string  `json:"harvested_at,omitempty" example:"2000-12-31"`
+
+	PurchaseOrderLineID *int64 `json:"purchase_order_line_id,omitempty" example:"1"`
+}
+
+type FruitBatchItemRes struct {
+	Index    int      `json:"index" example:"0"`
+	ID       *int64   `json:"id,omitempty" example:"1"`
+	Error    string   `json:"error,omitempty" example:"validation"`
+	Message  string   `json:"message,omitempty" example:"Bucket is full"`
+	Messages []string `json:"messages,omitempty" example:"invalid field,invalid value"`
+}
+
+type FruitsBatchRes struct {
+	Data    []FruitBatchItemRes `json:"data"`
+	Created int                 `json:"created" example:"1"`
+	Failed  int                 `json:"failed" example:"0"`
+}
+
+type SelectFruitsReq struct {
+	IDs    []int64          `json:"ids" example:"1,2"`
+	Filter *FilterFruitsReq `json:"filter"`
+}
+
+type FilterFruitsReq struct {
+	BucketID   *int64  `json:"bucket_id" example:"1"`
+	SupplierID *int64  `json:"supplier_id" example:"1"`
+	Name       *string `json:"name" example:"Orange"`
+	State      *string `json:"state" example:"ripe"`
+}
+
+type MoveFruitsReq struct {
+	SelectFruitsReq
+	ToBucketID *int64 `json:"to_bucket_id" example:"2"`
+}
+
+type FruitsBulkRes struct {
+	FruitIDs []int64 `json:"fruit_ids" example:"1,2"`
+	Affected int64   `json:"affected" example:"2"`
+}
+
+type TransferFruitReq struct {
+	ToBucketID   *int64 `json:"to_bucket_id" example:"2"`
+	FromBucketID *int64 `json:"from_bucket_id" example:"1"`
+}
+
+type FruitTransferRes struct {
+	FruitID int64      `json:"fruit_id" example:"1"`
+	From    *BucketRes `json:"from"`
+	To      BucketRes  `json:"to"`
+	Moved   bool       `json:"moved" example:"true"`
+}
+
+type ExpiringBucketFruitsRes struct {
+	BucketID   *int64          `json:"bucket_id" example:"1"`
+	BucketName string          `json:"bucket_name,omitempty" example:"A"`
+	Fruits     []FruitRes      `json:"fruits"`
+	TotalPrice decimal.Decimal `json:"total_price" example:"3.98"`
+
+	TotalEffectivePrice decimal.Decimal `json:"total_effective_price" example:"1.99"`
+}
+
+type ExpiringFruitsRes struct {
+	Data       []ExpiringBucketFruitsRes `json:"data"`
+	TotalPrice decimal.Decimal           `json:"total_price" example:"3.98"`
+
+	TotalEffectivePrice decimal.Decimal `json:"total_effective_price" example:"1.99"`
+}
+
+type RecallFruitsReq struct {
+	SupplierID  *int64 `json:"supplier_id" example:"1"`
+	LotID       *int64 `json:"lot_id" example:"1"`
+	CreatedFrom string `json:"created_from,omitempty" example:"2000-12-01 00:00:00"`
+	CreatedTo   string `json:"created_to,omitempty" example:"2000-12-31 23:59:59"`
+}
+
+type RecallFruitRes struct {
+	FruitID    int64   `json:"fruit_id" example:"1"`
+	Name       string  `json:"name" example:"Apple"`
+	BucketID   *int64  `json:"bucket_id,omitempty" example:"1"`
+	BucketName *string `json:"bucket_name,omitempty" example:"A"`
+}
+
+type RecallRes struct {
+	QuarantinedAt string           `json:"quarantined_at" example:"2000-12-31 23:59:59"`
+	Affected      int64            `json:"affected" example:"2"`
+	Data          []RecallFruitRes `json:"data"`
 }
@@ -0,0 +1,34 @@
+package presenters
+
+import "github.com/shopspring/decimal"
+
+type ChangeFruitPriceReq struct {
+	Price  decimal.Decimal `json:"price" example:"2.49"`
+	Reason string          `json:"reason" example:"supplier cost increase"`
+}
+
+type FruitPriceRes struct {
+	ID        int64           `json:"id" example:"1"`
+	CreatedAt string          `json:"created_at" example:"2000-12-31 23:59:59"`
+	FruitID   int64           `json:"fruit_id" example:"1"`
+	Price     decimal.Decimal `json:"price" example:"2.49"`
+	Reason    string          `json:"reason" example:"supplier cost increase"`
+}
+
+type FruitPricesRes struct {
+	Data []FruitPriceRes `json:"data"`
+}
+
+type FruitPriceStatsRes struct {
+	Name    string          `json:"name" example:"Mango"`
+	Min     decimal.Decimal `json:"min" example:"1.99"`
+	Avg     decimal.Decimal `json:"avg" example:"2.24"`
+	Max     decimal.Decimal `json:"max" example:"2.49"`
+	Changes int64           `json:"changes" example:"2"`
+}
+
+type FruitPricesStatsRes struct {
+	From string               `json:"from" example:"2000-12-01"`
+	To   string               `json:"to" example:"2000-12-31"`
+	Data []FruitPriceStatsRes `json:"data"`
+}
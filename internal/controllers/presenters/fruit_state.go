@@ -0,0 +1,17 @@
+package presenters
+
+type ChangeFruitStateReq struct {
+	State string `json:"state" example:"ripe"`
+}
+
+type FruitStateTransitionRes struct {
+	ID        int64  `json:"id" example:"1"`
+	CreatedAt string `json:"created_at" example:"2000-12-31 23:59:59"`
+	FruitID   int64  `json:"fruit_id" example:"1"`
+	FromState string `json:"from_state" example:"unripe"`
+	ToState   string `json:"to_state" example:"ripe"`
+}
+
+type FruitStateTransitionsRes struct {
+	Data []FruitStateTransitionRes `json:"data"`
+}
@@ -0,0 +1,15 @@
+package presenters
+
+type MovementRes struct {
+	ID           int64  `json:"id" example:"1"`
+	CreatedAt    string `json:"created_at" example:"2000-12-31 23:59:59"`
+	FruitID      int64  `json:"fruit_id" example:"1"`
+	FromBucketID *int64 `json:"from_bucket_id,omitempty" example:"1"`
+	ToBucketID   *int64 `json:"to_bucket_id,omitempty" example:"2"`
+	Type         string `json:"type" example:"moved"`
+	Actor        string `json:"actor,omitempty" example:"jane"`
+}
+
+type MovementsRes struct {
+	Data []MovementRes `json:"data"`
+}
@@ -0,0 +1,20 @@
+package presenters
+
+type CreateNoteReq struct {
+	Author string `json:"author" example:"John"`
+	Text   string `json:"text" example:"lid cracked"`
+}
+
+type NoteRes struct {
+	ID        int64  `json:"id" example:"1"`
+	CreatedAt string `json:"created_at" example:"2000-12-31 23:59:59"`
+	FruitID   *int64 `json:"fruit_id,omitempty" example:"1"`
+	BucketID  *int64 `json:"bucket_id,omitempty" example:"1"`
+
+	Author string `json:"author" example:"John"`
+	Text   string `json:"text" example:"lid cracked"`
+}
+
+type NotesRes struct {
+	Data []NoteRes `json:"data"`
+}
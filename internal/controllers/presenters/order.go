@@ -0,0 +1,40 @@
+package presenters
+
+import "github.com/shopspring/decimal"
+
+type CreateOrderReq struct {
+	Items []CreateOrderItemReq `json:"items"`
+}
+
+type CreateOrderItemReq struct {
+	FruitID int64 `json:"fruit_id" example:"1"`
+}
+
+type OrderItemRes struct {
+	FruitID int64           `json:"fruit_id" example:"1"`
+	Name    string          `json:"name" example:"Orange"`
+	Price   decimal.Decimal `json:"price" example:"1.99"`
+}
+
+type OrderRes struct {
+	ID           int64           `json:"id" example:"1"`
+	CreatedAt    string          `json:"created_at" example:"2000-12-31 23:59:59"`
+	CheckedOutAt string          `json:"checked_out_at,omitempty" example:"2000-12-31 23:59:59"`
+	Total        decimal.Decimal `json:"total" example:"1.99"`
+	Items        []OrderItemRes  `json:"items"`
+}
+
+type SalesRes struct {
+	Day      string          `json:"day" example:"2000-12-31"`
+	Name     string          `json:"name" example:"Orange"`
+	Quantity int64           `json:"quantity" example:"2"`
+	Revenue  decimal.Decimal `json:"revenue" example:"3.98"`
+}
+
+type SalesReportRes struct {
+	From          string          `json:"from" example:"2000-12-01"`
+	To            string          `json:"to" example:"2000-12-31"`
+	Data          []SalesRes      `json:"data"`
+	TotalQuantity int64           `json:"total_quantity" example:"2"`
+	TotalRevenue  decimal.Decimal `json:"total_revenue" example:"3.98"`
+}
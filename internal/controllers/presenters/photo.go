@@ -0,0 +1,21 @@
+package presenters
+
+type PhotoRes struct {
+	ID        int64  `json:"id" example:"1"`
+	CreatedAt string `json:"created_at" example:"2000-12-31 23:59:59"`
+	FruitID   *int64 `json:"fruit_id,omitempty" example:"1"`
+	BucketID  *int64 `json:"bucket_id,omitempty" example:"1"`
+
+	Filename    string `json:"filename" example:"bruised-mango.jpg"`
+	ContentType string `json:"content_type" example:"image/jpeg"`
+	Size        int64  `json:"size" example:"204800"`
+	Width       int    `json:"width" example:"1024"`
+	Height      int    `json:"height" example:"768"`
+
+	URL          string `json:"url" example:"/api/v1/photos/1"`
+	ThumbnailURL string `json:"thumbnail_url" example:"/api/v1/photos/1/thumbnail"`
+}
+
+type PhotosRes struct {
+	Data []PhotoRes `json:"data"`
+}
@@ -0,0 +1,22 @@
+package presenters
+
+import "github.com/shopspring/decimal"
+
+type CreatePickReq struct {
+	Name     string `json:"name" example:"Orange"`
+	Quantity int    `json:"quantity" example:"2"`
+}
+
+type PickItemRes struct {
+	FruitID    int64           `json:"fruit_id" example:"1"`
+	BucketID   int64           `json:"bucket_id" example:"1"`
+	BucketName string          `json:"bucket_name" example:"A"`
+	ExpiresAt  string          `json:"expires_at" example:"2000-12-31 23:59:59"`
+	Price      decimal.Decimal `json:"price" example:"1.99"`
+}
+
+type PickRes struct {
+	Name     string        `json:"name" example:"Orange"`
+	Quantity int           `json:"quantity" example:"2"`
+	Items    []PickItemRes `json:"items"`
+}
@@ -0,0 +1,44 @@
+package presenters
+
+import "github.com/shopspring/decimal"
+
+type CreatePurchaseOrderReq struct {
+	SupplierID int64                        `json:"supplier_id" example:"1"`
+	Lines      []CreatePurchaseOrderLineReq `json:"lines"`
+}
+
+type CreatePurchaseOrderLineReq struct {
+	Name     string          `json:"name" example:"Orange"`
+	Price    decimal.Decimal `json:"price" example:"1.99"`
+	Quantity int             `json:"quantity" example:"10"`
+}
+
+type ReceivePurchaseOrderReq struct {
+	Lines []ReceivePurchaseOrderLineReq `json:"lines"`
+}
+
+type ReceivePurchaseOrderLineReq struct {
+	LineID    int64  `json:"line_id" example:"1"`
+	Quantity  int    `json:"quantity" example:"9"`
+	ExpiresIn string `json:"expires_in" example:"168h"`
+	BucketID  *int64 `json:"bucket_id" example:"1"`
+}
+
+type PurchaseOrderLineRes struct {
+	ID               int64           `json:"id" example:"1"`
+	Name             string          `json:"name" example:"Orange"`
+	Price            decimal.Decimal `json:"price" example:"1.99"`
+	Quantity         int             `json:"quantity" example:"10"`
+	ReceivedQuantity *int            `json:"received_quantity,omitempty" example:"9"`
+	Discrepancy      int             `json:"discrepancy" example:"-1"`
+}
+
+type PurchaseOrderRes struct {
+	ID            int64                  `json:"id" example:"1"`
+	CreatedAt     string                 `json:"created_at" example:"2000-12-31 23:59:59"`
+	ReceivedAt    string                 `json:"received_at,omitempty" example:"2000-12-31 23:59:59"`
+	SupplierID    int64                  `json:"supplier_id" example:"1"`
+	Status        string                 `json:"status" example:"received"`
+	Lines         []PurchaseOrderLineRes `json:"lines"`
+	Discrepancies int                    `json:"discrepancies" example:"1"`
+}
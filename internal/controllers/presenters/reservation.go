@@ -0,0 +1,21 @@
+package presenters
+
+type CreateReservationReq struct {
+	Holder   string  `json:"holder" example:"Store 1"`
+	HoldFor  string  `json:"hold_for" example:"15m"`
+	FruitIDs []int64 `json:"fruit_ids,omitempty" example:"1,2"`
+	Name     *string `json:"name,omitempty" example:"Orange"`
+	Quantity int     `json:"quantity,omitempty" example:"2"`
+}
+
+type ReservationRes struct {
+	ID          int64   `json:"id" example:"1"`
+	CreatedAt   string  `json:"created_at" example:"2000-12-31 23:44:59"`
+	ExpiresAt   string  `json:"expires_at" example:"2000-12-31 23:59:59"`
+	ConfirmedAt string  `json:"confirmed_at,omitempty" example:"2000-12-31 23:50:59"`
+	ReleasedAt  string  `json:"released_at,omitempty" example:"2000-12-31 23:50:59"`
+	Holder      string  `json:"holder" example:"Store 1"`
+	Status      string  `json:"status" example:"active"`
+	FruitIDs    []int64 `json:"fruit_ids" example:"1,2"`
+	OrderID     *int64  `json:"order_id,omitempty" example:"1"`
+}
@@ -0,0 +1,84 @@
+package presenters
+
+import "github.com/shopspring/decimal"
+
+type SetReorderPointReq struct {
+	Name        string `json:"name" example:"Orange"`
+	MinQuantity int    `json:"min_quantity" example:"10"`
+}
+
+type ReorderPointRes struct {
+	ID        int64  `json:"id" example:"1"`
+	CreatedAt string `json:"created_at" example:"2000-12-31 23:59:59"`
+	UpdatedAt string `json:"updated_at" example:"2000-12-31 23:59:59"`
+
+	Name        string `json:"name" example:"Orange"`
+	MinQuantity int    `json:"min_quantity" example:"10"`
+}
+
+type ReorderPointsRes struct {
+	Data []ReorderPointRes `json:"data"`
+}
+
+type StockRes struct {
+	Name        string `json:"name" example:"Orange"`
+	Quantity    int64  `json:"quantity" example:"4"`
+	MinQuantity int    `json:"min_quantity" example:"10"`
+	Shortage    int64  `json:"shortage" example:"6"`
+}
+
+type LowStockRes struct {
+	Data []StockRes `json:"data"`
+}
+
+type StockAlertRes struct {
+	ID          int64  `json:"id" example:"1"`
+	CreatedAt   string `json:"created_at" example:"2000-12-31 23:59:59"`
+	Name        string `json:"name" example:"Orange"`
+	Quantity    int64  `json:"quantity" example:"9"`
+	MinQuantity int    `json:"min_quantity" example:"10"`
+	Reason      string `json:"reason" example:"expired"`
+}
+
+type StockAlertsRes struct {
+	Data []StockAlertRes `json:"data"`
+}
+
+type WasteRes struct {
+	Name            string           `json:"name" example:"Orange"`
+	BucketID        *int64           `json:"bucket_id" example:"1"`
+	SupplierID      *int64           `json:"supplier_id" example:"1"`
+	ReceivedFruits  int64            `json:"received_fruits" example:"10"`
+	ExpiredFruits   int64            `json:"expired_fruits" example:"2"`
+	ExpiredPrice    decimal.Decimal  `json:"expired_price" example:"3.98"`
+	DiscardedFruits int64            `json:"discarded_fruits" example:"1"`
+	DiscardedPrice  decimal.Decimal  `json:"discarded_price" example:"1.99"`
+	WastedFruits    int64            `json:"wasted_fruits" example:"3"`
+	WastedPrice     decimal.Decimal  `json:"wasted_price" example:"5.97"`
+	WasteRate       *decimal.Decimal `json:"waste_rate" example:"0.3"`
+}
+
+type WasteReportRes struct {
+	From                string           `json:"from" example:"2000-12-01"`
+	To                  string           `json:"to" example:"2000-12-31"`
+	Data                []WasteRes       `json:"data"`
+	TotalReceivedFruits int64            `json:"total_received_fruits" example:"10"`
+	TotalWastedFruits   int64            `json:"total_wasted_fruits" example:"3"`
+	TotalWastedPrice    decimal.Decimal  `json:"total_wasted_price" example:"5.97"`
+	TotalWasteRate      *decimal.Decimal `json:"total_waste_rate" example:"0.3"`
+}
+
+type ValuationRes struct {
+	BucketID   *int64          `json:"bucket_id" example:"1"`
+	BucketName *string         `json:"bucket_name" example:"A"`
+	Name       string          `json:"name" example:"Orange"`
+	Quantity   int64           `json:"quantity" example:"2"`
+	Value      decimal.Decimal `json:"value" example:"3.98"`
+}
+
+type ValuationReportRes struct {
+	At            string          `json:"at" example:"2000-12-31 23:59:59"`
+	Data          []ValuationRes  `json:"data"`
+	TotalQuantity int64           `json:"total_quantity" example:"2"`
+	TotalValue    decimal.Decimal `json:"total_value" example:"3.98"`
+}
@@ -0,0 +1,40 @@
+package presenters
+
+import "github.com/shopspring/decimal"
+
+type CreateSupplierReq struct {
+	Name    string  `json:"name" example:"Fazenda Boa Vista"`
+	Country string  `json:"country" example:"BR"`
+	Contact *string `json:"contact" example:"contato@boavista.com.br"`
+}
+
+type UpdateSupplierReq struct {
+	Name    string  `json:"name" example:"Fazenda Boa Vista"`
+	Country string  `json:"country" example:"BR"`
+	Contact *string `json:"contact" example:"contato@boavista.com.br"`
+}
+
+type SupplierRes struct {
+	ID        int64  `json:"id" example:"1"`
+	CreatedAt string `json:"created_at" example:"2000-12-31 23:59:59"`
+	DeletedAt string `json:"deleted_at,omitempty" example:"2000-12-31 23:59:59"`
+
+	Name    string  `json:"name" example:"Fazenda Boa Vista"`
+	Country string  `json:"country" example:"BR"`
+	Contact *string `json:"contact,omitempty" example:"contato@boavista.com.br"`
+}
+
+type SuppliersRes struct {
+	Data []SupplierRes `json:"data"`
+}
+
+type SupplierFruitsRes struct {
+	ID             int64           `json:"id" example:"1"`
+	Name           string          `json:"name" example:"Fazenda Boa Vista"`
+	ReceivedFruits int64           `json:"received_fruits" example:"10"`
+	ReceivedPrice  decimal.Decimal `json:"received_price" example:"19.90"`
+	ExpiredFruits  int64           `json:"expired_fruits" example:"2"`
+	ExpiredPrice   decimal.Decimal `json:"expired_price" example:"3.98"`
+	CurrentFruits  int64           `json:"current_fruits" example:"5"`
+	CurrentPrice   decimal.Decimal `json:"current_price" example:"9.95"`
+}
@@ -0,0 +1,190 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/viniosilva/where-are-my-fruits/internal/controllers/presenters"
+	"github.com/viniosilva/where-are-my-fruits/internal/dtos"
+	"github.com/viniosilva/where-are-my-fruits/internal/exceptions"
+	"github.com/viniosilva/where-are-my-fruits/internal/models"
+)
+
+type PurchaseOrderController struct {
+	service PurchaseOrderService
+}
+
+func NewPurchaseOrder(service PurchaseOrderService) *PurchaseOrderController {
+	return &PurchaseOrderController{
+		service: service,
+	}
+}
+
+// PurchaseOrder godoc
+// @Summary create purchase order
+// @Schemes
+// @Tags purchase order
+// @Accept json
+// @Produce json
+// @Param purchaseOrder body presenters.CreatePurchaseOrderReq true "Purchase order"
+// @Success 201 {object} presenters.PurchaseOrderRes
+// @Failure 400 {object} presenters.ErrorRes
+// @Failure 500 {object} presenters.ErrorRes
+// @Router /v1/purchase-orders [post]
+func (impl *PurchaseOrderController) Create(ctx *gin.Context) {
+	var req presenters.CreatePurchaseOrderReq
+	ctx.BindJSON(&req)
+
+	data := dtos.CreatePurchaseOrderDto{SupplierID: req.SupplierID, Lines: []dtos.CreatePurchaseOrderLineDto{}}
+	for _, line := range req.Lines {
+		data.Lines = append(data.Lines, dtos.CreatePurchaseOrderLineDto{
+			Name:     line.Name,
+			Price:    line.Price,
+			Quantity: line.Quantity,
+		})
+	}
+
+	res, err := impl.service.Create(ctx, data)
+	if err != nil {
+		if e, ok := err.(*exceptions.ValidationException); ok {
+			ctx.JSON(http.StatusBadRequest, presenters.ErrorRes{Error: e.Name, Messages: e.Errors})
+			return
+		}
+		if e, ok := err.(*exceptions.ForeignNotFoundException); ok {
+			ctx.JSON(http.StatusBadRequest, presenters.ErrorRes{Error: e.Name, Message: e.Error()})
+			return
+		}
+
+		ctx.JSON(http.StatusInternalServerError, presenters.ErrorRes{Error: http.StatusText(http.StatusInternalServerError)})
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, impl.parse(res))
+}
+
+// PurchaseOrder godoc
+// @Summary get purchase order
+// @Schemes
+// @Tags purchase order
+// @Accept json
+// @Produce json
+// @Param purchaseOrderID path int64 true "Purchase order ID"
+// @Success 200 {object} presenters.PurchaseOrderRes
+// @Failure 400 {object} presenters.ErrorRes
+// @Failure 404 {object} presenters.ErrorRes
+// @Failure 500 {object} presenters.ErrorRes
+// @Router /v1/purchase-orders/{purchaseOrderID} [get]
+func (impl *PurchaseOrderController) Get(ctx *gin.Context) {
+	purchaseOrderID, err := strconv.ParseInt(ctx.Param("purchaseOrderID"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, presenters.ErrorRes{Error: exceptions.ValidationExceptionName, Message: "invalid purchaseOrderID"})
+		return
+	}
+
+	res, err := impl.service.Get(ctx, purchaseOrderID)
+	if err != nil {
+		if e, ok := err.(*exceptions.NotFoundException); ok {
+			ctx.JSON(http.StatusNotFound, presenters.ErrorRes{Error: e.Name, Message: e.Error()})
+			return
+		}
+
+		ctx.JSON(http.StatusInternalServerError, presenters.ErrorRes{Error: http.StatusText(http.StatusInternalServerError)})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, impl.parse(res))
+}
+
+// PurchaseOrder godoc
+// @Summary receive purchase order, turning the received lines into fruits
+// @Schemes
+// @Tags purchase order
+// @Accept json
+// @Produce json
+// @Param purchaseOrderID path int64 true "Purchase order ID"
+// @Param receipt body presenters.ReceivePurchaseOrderReq true "Received lines"
+// @Success 200 {object} presenters.PurchaseOrderRes
+// @Failure 400 {object} presenters.ErrorRes
+// @Failure 404 {object} presenters.ErrorRes
+// @Failure 500 {object} presenters.ErrorRes
+// @Router /v1/purchase-orders/{purchaseOrderID}/receive [post]
+func (impl *PurchaseOrderController) Receive(ctx *gin.Context) {
+	purchaseOrderID, err := strconv.ParseInt(ctx.Param("purchaseOrderID"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, presenters.ErrorRes{Error: exceptions.ValidationExceptionName, Message: "invalid purchaseOrderID"})
+		return
+	}
+
+	var req presenters.ReceivePurchaseOrderReq
+	ctx.BindJSON(&req)
+
+	data := dtos.ReceivePurchaseOrderDto{Lines: []dtos.ReceivePurchaseOrderLineDto{}}
+	for _, line := range req.Lines {
+		var expiresIn *time.Duration
+		if v, err := time.ParseDuration(line.ExpiresIn); err == nil {
+			expiresIn = &v
+		}
+
+		data.Lines = append(data.Lines, dtos.ReceivePurchaseOrderLineDto{
+			LineID:    line.LineID,
+			Quantity:  line.Quantity,
+			ExpiresIn: expiresIn,
+			BucketID:  line.BucketID,
+		})
+	}
+
+	res, err := impl.service.Receive(ctx, purchaseOrderID, data)
+	if err != nil {
+		if e, ok := err.(*exceptions.ValidationException); ok {
+			ctx.JSON(http.StatusBadRequest, presenters.ErrorRes{Error: e.Name, Messages: e.Errors})
+			return
+		}
+		if e, ok := err.(*exceptions.NotFoundException); ok {
+			ctx.JSON(http.StatusNotFound, presenters.ErrorRes{Error: e.Name, Message: e.Error()})
+			return
+		}
+		if e, ok := err.(*exceptions.ForeignNotFoundException); ok {
+			ctx.JSON(http.StatusBadRequest, presenters.ErrorRes{Error: e.Name, Message: e.Error()})
+			return
+		}
+		if e, ok := err.(*exceptions.ForbiddenException); ok {
+			ctx.JSON(http.StatusBadRequest, presenters.ErrorRes{Error: e.Name, Message: e.Error()})
+			return
+		}
+
+		ctx.JSON(http.StatusInternalServerError, presenters.ErrorRes{Error: http.StatusText(http.StatusInternalServerError)})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, impl.parse(res))
+}
+
+func (impl *PurchaseOrderController) parse(order *models.PurchaseOrder) presenters.PurchaseOrderRes {
+	res := presenters.PurchaseOrderRes{
+		ID:         order.ID,
+		CreatedAt:  order.CreatedAt.Format(time.DateTime),
+		SupplierID: order.SupplierID,
+		Status:     order.Status(),
+		Lines:      []presenters.PurchaseOrderLineRes{},
+	}
+	if order.ReceivedAt != nil {
+		res.ReceivedAt = order.ReceivedAt.Format(time.DateTime)
+	}
+	for _, line := range order.Lines {
+		res.Lines = append(res.Lines, presenters.PurchaseOrderLineRes{
+			ID:               line.ID,
+			Name:             line.Name,
+			Price:            line.Price,
+			Quantity:         line.Quantity,
+			ReceivedQuantity: line.ReceivedQuantity,
+			Discrepancy:      line.Discrepancy(),
+		})
+		if line.Discrepancy() != 0 {
+			res.Discrepancies++
+		}
+	}
+
+	return res
+}
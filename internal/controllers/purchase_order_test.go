@@ -0,0 +1,272 @@
+package controllers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/golang/mock/gomock"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/viniosilva/where-are-my-fruits/internal/controllers/presenters"
+	"github.com/viniosilva/where-are-my-fruits/internal/dtos"
+	"github.com/viniosilva/where-are-my-fruits/internal/exceptions"
+	"github.com/viniosilva/where-are-my-fruits/internal/models"
+	"github.com/viniosilva/where-are-my-fruits/mocks"
+)
+
+func TestPurchaseOrderController_Create(t *testing.T) {
+	now := time.Date(2000, 12, 31, 23, 59, 59, 0, time.Local)
+
+	tests := map[string]struct {
+		mock        func(service *mocks.MockPurchaseOrderService)
+		body        presenters.CreatePurchaseOrderReq
+		wantCode    int
+		wantBody    presenters.PurchaseOrderRes
+		wantBodyErr presenters.ErrorRes
+	}{
+		"should be success": {
+			mock: func(service *mocks.MockPurchaseOrderService) {
+				data := dtos.CreatePurchaseOrderDto{
+					SupplierID: 1,
+					Lines:      []dtos.CreatePurchaseOrderLineDto{{Name: "Orange", Price: decimal.RequireFromString("1.99"), Quantity: 10}},
+				}
+				service.EXPECT().Create(gomock.Any(), data).Return(&models.PurchaseOrder{
+					ID:         1,
+					CreatedAt:  now,
+					SupplierID: 1,
+					Lines: []models.PurchaseOrderLine{
+						{ID: 1, PurchaseOrderID: 1, Name: "Orange", Price: decimal.RequireFromString("1.99"), Quantity: 10},
+					},
+				}, nil)
+			},
+			body: presenters.CreatePurchaseOrderReq{
+				SupplierID: 1,
+				Lines:      []presenters.CreatePurchaseOrderLineReq{{Name: "Orange", Price: decimal.RequireFromString("1.99"), Quantity: 10}},
+			},
+			wantCode: http.StatusCreated,
+			wantBody: presenters.PurchaseOrderRes{
+				ID:         1,
+				CreatedAt:  "2000-12-31 23:59:59",
+				SupplierID: 1,
+				Status:     models.PurchaseOrderStatusOpen,
+				Lines: []presenters.PurchaseOrderLineRes{
+					{ID: 1, Name: "Orange", Price: decimal.RequireFromString("1.99"), Quantity: 10},
+				},
+			},
+		},
+		"should throw validation exception": {
+			mock: func(service *mocks.MockPurchaseOrderService) {
+				service.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil, exceptions.NewValidationException(validator.ValidationErrors{
+					&mocks.FieldError{Itag: "error 1", Ins: "error 1"},
+				}))
+			},
+			wantCode: http.StatusBadRequest,
+			wantBodyErr: presenters.ErrorRes{
+				Error:    exceptions.ValidationExceptionName,
+				Messages: []string{"Key: 'error 1' Error:Field validation for '' failed on the 'error 1' tag"},
+			},
+		},
+		"should throw foreign not found": {
+			mock: func(service *mocks.MockPurchaseOrderService) {
+				service.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil, exceptions.NewForeignNotFoundException("Supplier not found"))
+			},
+			wantCode: http.StatusBadRequest,
+			wantBodyErr: presenters.ErrorRes{
+				Error:   exceptions.ForeignNotFoundExceptionName,
+				Message: "Supplier not found",
+			},
+		},
+		"should throw internal server error": {
+			mock: func(service *mocks.MockPurchaseOrderService) {
+				service.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil, fmt.Errorf("error"))
+			},
+			wantCode:    http.StatusInternalServerError,
+			wantBodyErr: presenters.ErrorRes{Error: http.StatusText(http.StatusInternalServerError)},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			// setup
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			serviceMock := mocks.NewMockPurchaseOrderService(ctrl)
+			tt.mock(serviceMock)
+
+			r := gin.Default()
+			controller := NewPurchaseOrder(serviceMock)
+
+			r.POST("/api/v1/purchase-orders", controller.Create)
+
+			var got presenters.PurchaseOrderRes
+			var gotErr presenters.ErrorRes
+
+			// given
+			body, _ := json.Marshal(tt.body)
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("POST", "/api/v1/purchase-orders", bytes.NewReader(body))
+
+			// when
+			r.ServeHTTP(w, req)
+
+			json.Unmarshal(w.Body.Bytes(), &gotErr)
+			json.Unmarshal(w.Body.Bytes(), &got)
+
+			// then
+			assert.Equal(t, tt.wantCode, w.Code)
+
+			if gotErr.Error != "" {
+				assert.Equal(t, tt.wantBodyErr, gotErr)
+				return
+			}
+			assert.Equal(t, tt.wantBody, got)
+		})
+	}
+}
+
+func TestPurchaseOrderController_Receive(t *testing.T) {
+	now := time.Date(2000, 12, 31, 23, 59, 59, 0, time.Local)
+	expiresIn := 168 * time.Hour
+	bucketID := int64(1)
+	nine := 9
+	five := 5
+
+	tests := map[string]struct {
+		mock            func(service *mocks.MockPurchaseOrderService)
+		purchaseOrderID string
+		body            presenters.ReceivePurchaseOrderReq
+		wantCode        int
+		wantBody        presenters.PurchaseOrderRes
+		wantBodyErr     presenters.ErrorRes
+	}{
+		"should be success": {
+			mock: func(service *mocks.MockPurchaseOrderService) {
+				data := dtos.ReceivePurchaseOrderDto{Lines: []dtos.ReceivePurchaseOrderLineDto{
+					{LineID: 1, Quantity: 9, ExpiresIn: &expiresIn, BucketID: &bucketID},
+					{LineID: 2, Quantity: 5, ExpiresIn: &expiresIn},
+				}}
+				service.EXPECT().Receive(gomock.Any(), int64(1), data).Return(&models.PurchaseOrder{
+					ID:         1,
+					CreatedAt:  now,
+					ReceivedAt: &now,
+					SupplierID: 1,
+					Lines: []models.PurchaseOrderLine{
+						{ID: 1, PurchaseOrderID: 1, Name: "Orange", Price: decimal.RequireFromString("1.99"), Quantity: 10, ReceivedQuantity: &nine},
+						{ID: 2, PurchaseOrderID: 1, Name: "Apple", Price: decimal.RequireFromString("0.99"), Quantity: 5, ReceivedQuantity: &five},
+					},
+				}, nil)
+			},
+			purchaseOrderID: "1",
+			body: presenters.ReceivePurchaseOrderReq{Lines: []presenters.ReceivePurchaseOrderLineReq{
+				{LineID: 1, Quantity: 9, ExpiresIn: "168h", BucketID: &bucketID},
+				{LineID: 2, Quantity: 5, ExpiresIn: "168h"},
+			}},
+			wantCode: http.StatusOK,
+			wantBody: presenters.PurchaseOrderRes{
+				ID:         1,
+				CreatedAt:  "2000-12-31 23:59:59",
+				ReceivedAt: "2000-12-31 23:59:59",
+				SupplierID: 1,
+				Status:     models.PurchaseOrderStatusReceived,
+				Lines: []presenters.PurchaseOrderLineRes{
+					{ID: 1, Name: "Orange", Price: decimal.RequireFromString("1.99"), Quantity: 10, ReceivedQuantity: &nine, Discrepancy: -1},
+					{ID: 2, Name: "Apple", Price: decimal.RequireFromString("0.99"), Quantity: 5, ReceivedQuantity: &five},
+				},
+				Discrepancies: 1,
+			},
+		},
+		"should throw bad request when purchaseOrderID is invalid": {
+			mock:            func(service *mocks.MockPurchaseOrderService) {},
+			purchaseOrderID: "a",
+			wantCode:        http.StatusBadRequest,
+			wantBodyErr: presenters.ErrorRes{
+				Error:   exceptions.ValidationExceptionName,
+				Message: "invalid purchaseOrderID",
+			},
+		},
+		"should throw not found": {
+			mock: func(service *mocks.MockPurchaseOrderService) {
+				service.EXPECT().Receive(gomock.Any(), int64(1), gomock.Any()).Return(nil, exceptions.NewNotFoundException("Purchase order not found"))
+			},
+			purchaseOrderID: "1",
+			wantCode:        http.StatusNotFound,
+			wantBodyErr: presenters.ErrorRes{
+				Error:   exceptions.NotFoundExceptionName,
+				Message: "Purchase order not found",
+			},
+		},
+		"should throw foreign not found": {
+			mock: func(service *mocks.MockPurchaseOrderService) {
+				service.EXPECT().Receive(gomock.Any(), int64(1), gomock.Any()).Return(nil, exceptions.NewForeignNotFoundException("Bucket not found"))
+			},
+			purchaseOrderID: "1",
+			wantCode:        http.StatusBadRequest,
+			wantBodyErr: presenters.ErrorRes{
+				Error:   exceptions.ForeignNotFoundExceptionName,
+				Message: "Bucket not found",
+			},
+		},
+		"should throw forbidden when bucket has no room": {
+			mock: func(service *mocks.MockPurchaseOrderService) {
+				service.EXPECT().Receive(gomock.Any(), int64(1), gomock.Any()).Return(nil, exceptions.NewForbiddenException("Not enough room in bucket 1: 5 free"))
+			},
+			purchaseOrderID: "1",
+			wantCode:        http.StatusBadRequest,
+			wantBodyErr: presenters.ErrorRes{
+				Error:   exceptions.ForbiddenExceptionName,
+				Message: "Not enough room in bucket 1: 5 free",
+			},
+		},
+		"should throw internal server error": {
+			mock: func(service *mocks.MockPurchaseOrderService) {
+				service.EXPECT().Receive(gomock.Any(), int64(1), gomock.Any()).Return(nil, fmt.Errorf("error"))
+			},
+			purchaseOrderID: "1",
+			wantCode:        http.StatusInternalServerError,
+			wantBodyErr:     presenters.ErrorRes{Error: http.StatusText(http.StatusInternalServerError)},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			// setup
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			serviceMock := mocks.NewMockPurchaseOrderService(ctrl)
+			tt.mock(serviceMock)
+
+			r := gin.Default()
+			controller := NewPurchaseOrder(serviceMock)
+
+			r.POST("/api/v1/purchase-orders/:purchaseOrderID/receive", controller.Receive)
+
+			var got presenters.PurchaseOrderRes
+			var gotErr presenters.ErrorRes
+
+			// given
+			body, _ := json.Marshal(tt.body)
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("POST", fmt.Sprintf("/api/v1/purchase-orders/%s/receive", tt.purchaseOrderID), bytes.NewReader(body))
+
+			// when
+			r.ServeHTTP(w, req)
+
+			json.Unmarshal(w.Body.Bytes(), &gotErr)
+			json.Unmarshal(w.Body.Bytes(), &got)
+
+			// then
+			assert.Equal(t, tt.wantCode, w.Code)
+
+			if gotErr.Error != "" {
+				assert.Equal(t, tt.wantBodyErr, gotErr)
+				return
+			}
+			assert.Equal(t, tt.wantBody, got)
+		})
+	}
+}
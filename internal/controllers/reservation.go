@@ -0,0 +1,198 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/viniosilva/where-are-my-fruits/internal/controllers/presenters"
+	"github.com/viniosilva/where-are-my-fruits/internal/dtos"
+	"github.com/viniosilva/where-are-my-fruits/internal/exceptions"
+	"github.com/viniosilva/where-are-my-fruits/internal/models"
+)
+
+type ReservationController struct {
+	service ReservationService
+}
+
+func NewReservation(service ReservationService) *ReservationController {
+	return &ReservationController{
+		service: service,
+	}
+}
+
+// Reservation godoc
+// @Summary hold specific fruits, or a quantity of fruits by name, for a while
+// @Schemes
+// @Tags reservation
+// @Accept json
+// @Produce json
+// @Param reservation body presenters.CreateReservationReq true "Reservation"
+// @Success 201 {object} presenters.ReservationRes
+// @Failure 400 {object} presenters.ErrorRes
+// @Failure 500 {object} presenters.ErrorRes
+// @Router /v1/reservations [post]
+func (impl *ReservationController) Create(ctx *gin.Context) {
+	var req presenters.CreateReservationReq
+	ctx.BindJSON(&req)
+
+	var holdFor time.Duration
+	if v, err := time.ParseDuration(req.HoldFor); err == nil {
+		holdFor = v
+	}
+
+	res, err := impl.service.Create(ctx, dtos.CreateReservationDto{
+		Holder:   req.Holder,
+		HoldFor:  holdFor,
+		FruitIDs: req.FruitIDs,
+		Name:     req.Name,
+		Quantity: req.Quantity,
+	})
+	if err != nil {
+		if e, ok := err.(*exceptions.ValidationException); ok {
+			ctx.JSON(http.StatusBadRequest, presenters.ErrorRes{Error: e.Name, Messages: e.Errors})
+			return
+		}
+		if e, ok := err.(*exceptions.ForeignNotFoundException); ok {
+			ctx.JSON(http.StatusBadRequest, presenters.ErrorRes{Error: e.Name, Message: e.Error()})
+			return
+		}
+		if e, ok := err.(*exceptions.ForbiddenException); ok {
+			ctx.JSON(http.StatusBadRequest, presenters.ErrorRes{Error: e.Name, Message: e.Error()})
+			return
+		}
+
+		ctx.JSON(http.StatusInternalServerError, presenters.ErrorRes{Error: http.StatusText(http.StatusInternalServerError)})
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, impl.parse(res))
+}
+
+// Reservation godoc
+// @Summary get reservation
+// @Schemes
+// @Tags reservation
+// @Accept json
+// @Produce json
+// @Param reservationID path int64 true "Reservation ID"
+// @Success 200 {object} presenters.ReservationRes
+// @Failure 400 {object} presenters.ErrorRes
+// @Failure 404 {object} presenters.ErrorRes
+// @Failure 500 {object} presenters.ErrorRes
+// @Router /v1/reservations/{reservationID} [get]
+func (impl *ReservationController) Get(ctx *gin.Context) {
+	reservationID, err := strconv.ParseInt(ctx.Param("reservationID"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, presenters.ErrorRes{Error: exceptions.ValidationExceptionName, Message: "invalid reservationID"})
+		return
+	}
+
+	res, err := impl.service.Get(ctx, reservationID)
+	if err != nil {
+		if e, ok := err.(*exceptions.NotFoundException); ok {
+			ctx.JSON(http.StatusNotFound, presenters.ErrorRes{Error: e.Name, Message: e.Error()})
+			return
+		}
+
+		ctx.JSON(http.StatusInternalServerError, presenters.ErrorRes{Error: http.StatusText(http.StatusInternalServerError)})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, impl.parse(res))
+}
+
+// Reservation godoc
+// @Summary confirm reservation, selling the held fruits
+// @Schemes
+// @Tags reservation
+// @Accept json
+// @Produce json
+// @Param reservationID path int64 true "Reservation ID"
+// @Success 200 {object} presenters.ReservationRes
+// @Failure 400 {object} presenters.ErrorRes
+// @Failure 404 {object} presenters.ErrorRes
+// @Failure 500 {object} presenters.ErrorRes
+// @Router /v1/reservations/{reservationID}/confirm [post]
+func (impl *ReservationController) Confirm(ctx *gin.Context) {
+	reservationID, err := strconv.ParseInt(ctx.Param("reservationID"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, presenters.ErrorRes{Error: exceptions.ValidationExceptionName, Message: "invalid reservationID"})
+		return
+	}
+
+	res, err := impl.service.Confirm(ctx, reservationID)
+	if err != nil {
+		if e, ok := err.(*exceptions.NotFoundException); ok {
+			ctx.JSON(http.StatusNotFound, presenters.ErrorRes{Error: e.Name, Message: e.Error()})
+			return
+		}
+		if e, ok := err.(*exceptions.ForbiddenException); ok {
+			ctx.JSON(http.StatusBadRequest, presenters.ErrorRes{Error: e.Name, Message: e.Error()})
+			return
+		}
+
+		ctx.JSON(http.StatusInternalServerError, presenters.ErrorRes{Error: http.StatusText(http.StatusInternalServerError)})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, impl.parse(res))
+}
+
+// Reservation godoc
+// @Summary release reservation before it lapses
+// @Schemes
+// @Tags reservation
+// @Accept json
+// @Produce json
+// @Param reservationID path int64 true "Reservation ID"
+// @Success 200
+// @Failure 400 {object} presenters.ErrorRes
+// @Failure 404 {object} presenters.ErrorRes
+// @Failure 500 {object} presenters.ErrorRes
+// @Router /v1/reservations/{reservationID} [delete]
+func (impl *ReservationController) Release(ctx *gin.Context) {
+	reservationID, err := strconv.ParseInt(ctx.Param("reservationID"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, presenters.ErrorRes{Error: exceptions.ValidationExceptionName, Message: "invalid reservationID"})
+		return
+	}
+
+	err = impl.service.Release(ctx, reservationID)
+	if err != nil {
+		if e, ok := err.(*exceptions.NotFoundException); ok {
+			ctx.JSON(http.StatusNotFound, presenters.ErrorRes{Error: e.Name, Message: e.Error()})
+			return
+		}
+		if e, ok := err.(*exceptions.ForbiddenException); ok {
+			ctx.JSON(http.StatusBadRequest, presenters.ErrorRes{Error: e.Name, Message: e.Error()})
+			return
+		}
+
+		ctx.JSON(http.StatusInternalServerError, presenters.ErrorRes{Error: http.StatusText(http.StatusInternalServerError)})
+		return
+	}
+
+	ctx.Status(http.StatusOK)
+}
+
+func (impl *ReservationController) parse(reservation *models.Reservation) presenters.ReservationRes {
+	res := presenters.ReservationRes{
+		ID:        reservation.ID,
+		CreatedAt: reservation.CreatedAt.Format(time.DateTime),
+		ExpiresAt: reservation.ExpiresAt.Format(time.DateTime),
+		Holder:    reservation.Holder,
+		Status:    reservation.Status(time.Now()),
+		FruitIDs:  reservation.FruitIDs,
+		OrderID:   reservation.OrderID,
+	}
+	if reservation.ConfirmedAt != nil {
+		res.ConfirmedAt = reservation.ConfirmedAt.Format(time.DateTime)
+	}
+	if reservation.ReleasedAt != nil {
+		res.ReleasedAt = reservation.ReleasedAt.Format(time.DateTime)
+	}
+
+	return res
+}
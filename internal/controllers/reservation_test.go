@@ -0,0 +1,328 @@
+package controllers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/viniosilva/where-are-my-fruits/internal/controllers/presenters"
+	"github.com/viniosilva/where-are-my-fruits/internal/dtos"
+	"github.com/viniosilva/where-are-my-fruits/internal/exceptions"
+	"github.com/viniosilva/where-are-my-fruits/internal/models"
+	"github.com/viniosilva/where-are-my-fruits/mocks"
+)
+
+func TestReservationController_Create(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	holdUntil := now.Add(15 * time.Minute)
+	name := "Orange"
+
+	tests := map[string]struct {
+		mock        func(service *mocks.MockReservationService)
+		body        presenters.CreateReservationReq
+		wantCode    int
+		wantBody    presenters.ReservationRes
+		wantBodyErr presenters.ErrorRes
+	}{
+		"should be success": {
+			mock: func(service *mocks.MockReservationService) {
+				data := dtos.CreateReservationDto{Holder: "Store 1", HoldFor: 15 * time.Minute, Name: &name, Quantity: 2}
+				service.EXPECT().Create(gomock.Any(), data).Return(&models.Reservation{
+					ID:        1,
+					CreatedAt: now,
+					ExpiresAt: holdUntil,
+					Holder:    "Store 1",
+					FruitIDs:  []int64{1, 2},
+				}, nil)
+			},
+			body:     presenters.CreateReservationReq{Holder: "Store 1", HoldFor: "15m", Name: &name, Quantity: 2},
+			wantCode: http.StatusCreated,
+			wantBody: presenters.ReservationRes{
+				ID:        1,
+				CreatedAt: now.Format(time.DateTime),
+				ExpiresAt: holdUntil.Format(time.DateTime),
+				Holder:    "Store 1",
+				Status:    models.ReservationStatusActive,
+				FruitIDs:  []int64{1, 2},
+			},
+		},
+		"should throw validation exception": {
+			mock: func(service *mocks.MockReservationService) {
+				service.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil, exceptions.NewValidationException(validator.ValidationErrors{
+					&mocks.FieldError{Itag: "error 1", Ins: "error 1"},
+				}))
+			},
+			wantCode: http.StatusBadRequest,
+			wantBodyErr: presenters.ErrorRes{
+				Error:    exceptions.ValidationExceptionName,
+				Messages: []string{"Key: 'error 1' Error:Field validation for '' failed on the 'error 1' tag"},
+			},
+		},
+		"should throw foreign not found": {
+			mock: func(service *mocks.MockReservationService) {
+				service.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil, exceptions.NewForeignNotFoundException("Fruits not found: 1"))
+			},
+			body:     presenters.CreateReservationReq{Holder: "Store 1", HoldFor: "15m", FruitIDs: []int64{1}},
+			wantCode: http.StatusBadRequest,
+			wantBodyErr: presenters.ErrorRes{
+				Error:   exceptions.ForeignNotFoundExceptionName,
+				Message: "Fruits not found: 1",
+			},
+		},
+		"should throw forbidden when fruit is reserved": {
+			mock: func(service *mocks.MockReservationService) {
+				service.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil, exceptions.NewForbiddenException("Fruit 1 is reserved"))
+			},
+			body:     presenters.CreateReservationReq{Holder: "Store 1", HoldFor: "15m", FruitIDs: []int64{1}},
+			wantCode: http.StatusBadRequest,
+			wantBodyErr: presenters.ErrorRes{
+				Error:   exceptions.ForbiddenExceptionName,
+				Message: "Fruit 1 is reserved",
+			},
+		},
+		"should throw internal server error": {
+			mock: func(service *mocks.MockReservationService) {
+				service.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil, fmt.Errorf("error"))
+			},
+			body:        presenters.CreateReservationReq{Holder: "Store 1", HoldFor: "15m", FruitIDs: []int64{1}},
+			wantCode:    http.StatusInternalServerError,
+			wantBodyErr: presenters.ErrorRes{Error: http.StatusText(http.StatusInternalServerError)},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			// setup
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			serviceMock := mocks.NewMockReservationService(ctrl)
+			tt.mock(serviceMock)
+
+			r := gin.Default()
+			controller := NewReservation(serviceMock)
+
+			r.POST("/api/v1/reservations", controller.Create)
+
+			var got presenters.ReservationRes
+			var gotErr presenters.ErrorRes
+
+			// given
+			body, _ := json.Marshal(tt.body)
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("POST", "/api/v1/reservations", bytes.NewReader(body))
+
+			// when
+			r.ServeHTTP(w, req)
+
+			json.Unmarshal(w.Body.Bytes(), &gotErr)
+			json.Unmarshal(w.Body.Bytes(), &got)
+
+			// then
+			assert.Equal(t, tt.wantCode, w.Code)
+
+			if gotErr.Error != "" {
+				assert.Equal(t, tt.wantBodyErr, gotErr)
+				return
+			}
+			assert.Equal(t, tt.wantBody, got)
+		})
+	}
+}
+
+func TestReservationController_Confirm(t *testing.T) {
+	createdAt := time.Date(2000, 12, 31, 23, 44, 59, 0, time.Local)
+	confirmedAt := time.Date(2000, 12, 31, 23, 50, 59, 0, time.Local)
+	orderID := int64(1)
+
+	tests := map[string]struct {
+		mock          func(service *mocks.MockReservationService)
+		reservationID string
+		wantCode      int
+		wantBody      presenters.ReservationRes
+		wantBodyErr   presenters.ErrorRes
+	}{
+		"should be success": {
+			mock: func(service *mocks.MockReservationService) {
+				service.EXPECT().Confirm(gomock.Any(), int64(1)).Return(&models.Reservation{
+					ID:          1,
+					CreatedAt:   createdAt,
+					ExpiresAt:   createdAt.Add(15 * time.Minute),
+					ConfirmedAt: &confirmedAt,
+					Holder:      "Store 1",
+					OrderID:     &orderID,
+					FruitIDs:    []int64{1},
+				}, nil)
+			},
+			reservationID: "1",
+			wantCode:      http.StatusOK,
+			wantBody: presenters.ReservationRes{
+				ID:          1,
+				CreatedAt:   "2000-12-31 23:44:59",
+				ExpiresAt:   "2000-12-31 23:59:59",
+				ConfirmedAt: "2000-12-31 23:50:59",
+				Holder:      "Store 1",
+				Status:      models.ReservationStatusConfirmed,
+				FruitIDs:    []int64{1},
+				OrderID:     &orderID,
+			},
+		},
+		"should throw bad request when reservationID is invalid": {
+			mock:          func(service *mocks.MockReservationService) {},
+			reservationID: "a",
+			wantCode:      http.StatusBadRequest,
+			wantBodyErr: presenters.ErrorRes{
+				Error:   exceptions.ValidationExceptionName,
+				Message: "invalid reservationID",
+			},
+		},
+		"should throw not found": {
+			mock: func(service *mocks.MockReservationService) {
+				service.EXPECT().Confirm(gomock.Any(), int64(1)).Return(nil, exceptions.NewNotFoundException("Reservation not found"))
+			},
+			reservationID: "1",
+			wantCode:      http.StatusNotFound,
+			wantBodyErr: presenters.ErrorRes{
+				Error:   exceptions.NotFoundExceptionName,
+				Message: "Reservation not found",
+			},
+		},
+		"should throw forbidden when reservation is lapsed": {
+			mock: func(service *mocks.MockReservationService) {
+				service.EXPECT().Confirm(gomock.Any(), int64(1)).Return(nil, exceptions.NewForbiddenException("Reservation is lapsed"))
+			},
+			reservationID: "1",
+			wantCode:      http.StatusBadRequest,
+			wantBodyErr: presenters.ErrorRes{
+				Error:   exceptions.ForbiddenExceptionName,
+				Message: "Reservation is lapsed",
+			},
+		},
+		"should throw internal server error": {
+			mock: func(service *mocks.MockReservationService) {
+				service.EXPECT().Confirm(gomock.Any(), int64(1)).Return(nil, fmt.Errorf("error"))
+			},
+			reservationID: "1",
+			wantCode:      http.StatusInternalServerError,
+			wantBodyErr:   presenters.ErrorRes{Error: http.StatusText(http.StatusInternalServerError)},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			// setup
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			serviceMock := mocks.NewMockReservationService(ctrl)
+			tt.mock(serviceMock)
+
+			r := gin.Default()
+			controller := NewReservation(serviceMock)
+
+			r.POST("/api/v1/reservations/:reservationID/confirm", controller.Confirm)
+
+			var got presenters.ReservationRes
+			var gotErr presenters.ErrorRes
+
+			// given
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("POST", fmt.Sprintf("/api/v1/reservations/%s/confirm", tt.reservationID), nil)
+
+			// when
+			r.ServeHTTP(w, req)
+
+			json.Unmarshal(w.Body.Bytes(), &gotErr)
+			json.Unmarshal(w.Body.Bytes(), &got)
+
+			// then
+			assert.Equal(t, tt.wantCode, w.Code)
+
+			if gotErr.Error != "" {
+				assert.Equal(t, tt.wantBodyErr, gotErr)
+				return
+			}
+			assert.Equal(t, tt.wantBody, got)
+		})
+	}
+}
+
+func TestReservationController_Release(t *testing.T) {
+	tests := map[string]struct {
+		mock          func(service *mocks.MockReservationService)
+		reservationID string
+		wantCode      int
+		wantBodyErr   presenters.ErrorRes
+	}{
+		"should be success": {
+			mock: func(service *mocks.MockReservationService) {
+				service.EXPECT().Release(gomock.Any(), int64(1)).Return(nil)
+			},
+			reservationID: "1",
+			wantCode:      http.StatusOK,
+		},
+		"should throw not found": {
+			mock: func(service *mocks.MockReservationService) {
+				service.EXPECT().Release(gomock.Any(), int64(1)).Return(exceptions.NewNotFoundException("Reservation not found"))
+			},
+			reservationID: "1",
+			wantCode:      http.StatusNotFound,
+			wantBodyErr: presenters.ErrorRes{
+				Error:   exceptions.NotFoundExceptionName,
+				Message: "Reservation not found",
+			},
+		},
+		"should throw forbidden when reservation is confirmed": {
+			mock: func(service *mocks.MockReservationService) {
+				service.EXPECT().Release(gomock.Any(), int64(1)).Return(exceptions.NewForbiddenException("Reservation is confirmed"))
+			},
+			reservationID: "1",
+			wantCode:      http.StatusBadRequest,
+			wantBodyErr: presenters.ErrorRes{
+				Error:   exceptions.ForbiddenExceptionName,
+				Message: "Reservation is confirmed",
+			},
+		},
+		"should throw internal server error": {
+			mock: func(service *mocks.MockReservationService) {
+				service.EXPECT().Release(gomock.Any(), int64(1)).Return(fmt.Errorf("error"))
+			},
+			reservationID: "1",
+			wantCode:      http.StatusInternalServerError,
+			wantBodyErr:   presenters.ErrorRes{Error: http.StatusText(http.StatusInternalServerError)},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			// setup
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			serviceMock := mocks.NewMockReservationService(ctrl)
+			tt.mock(serviceMock)
+
+			r := gin.Default()
+			controller := NewReservation(serviceMock)
+
+			r.DELETE("/api/v1/reservations/:reservationID", controller.Release)
+
+			var gotErr presenters.ErrorRes
+
+			// given
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("DELETE", fmt.Sprintf("/api/v1/reservations/%s", tt.reservationID), nil)
+
+			// when
+			r.ServeHTTP(w, req)
+
+			json.Unmarshal(w.Body.Bytes(), &gotErr)
+
+			// then
+			assert.Equal(t, tt.wantCode, w.Code)
+			assert.Equal(t, tt.wantBodyErr, gotErr)
+		})
+	}
+}
@@ -0,0 +1,308 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/viniosilva/where-are-my-fruits/internal/controllers/presenters"
+	"github.com/viniosilva/where-are-my-fruits/internal/dtos"
+	"github.com/viniosilva/where-are-my-fruits/internal/exceptions"
+	"github.com/viniosilva/where-are-my-fruits/internal/models"
+)
+
+// wasteDefaultPeriod is the period covered by the waste report when no start
+// day is given
+const wasteDefaultPeriod = 30 * 24 * time.Hour
+
+type StockController struct {
+	service StockService
+}
+
+func NewStock(service StockService) *StockController {
+	return &StockController{
+		service: service,
+	}
+}
+
+// Stock godoc
+// @Summary set the minimum stock wanted of a fruit name
+// @Schemes
+// @Tags stock
+// @Accept json
+// @Produce json
+// @Param reorderPoint body presenters.SetReorderPointReq true "Reorder point"
+// @Success 200 {object} presenters.ReorderPointRes
+// @Failure 400 {object} presenters.ErrorRes
+// @Failure 500 {object} presenters.ErrorRes
+// @Router /v1/stock/reorder-points [put]
+func (impl *StockController) SetReorderPoint(ctx *gin.Context) {
+	var req presenters.SetReorderPointReq
+	ctx.BindJSON(&req)
+
+	res, err := impl.service.SetReorderPoint(ctx, dtos.SetReorderPointDto{
+		Name:        req.Name,
+		MinQuantity: req.MinQuantity,
+	})
+	if err != nil {
+		if e, ok := err.(*exceptions.ValidationException); ok {
+			ctx.JSON(http.StatusBadRequest, presenters.ErrorRes{Error: e.Name, Messages: e.Errors})
+			return
+		}
+
+		ctx.JSON(http.StatusInternalServerError, presenters.ErrorRes{Error: http.StatusText(http.StatusInternalServerError)})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, impl.parseReorderPoint(res))
+}
+
+// Stock godoc
+// @Summary list reorder points
+// @Schemes
+// @Tags stock
+// @Accept json
+// @Produce json
+// @Param page query int false "page" default(1)
+// @Param pageSize query int false "pageSize" default(10)
+// @Success 200 {object} presenters.ReorderPointsRes
+// @Failure 500 {object} presenters.ErrorRes
+// @Router /v1/stock/reorder-points [get]
+func (impl *StockController) ListReorderPoints(ctx *gin.Context) {
+	page, err := strconv.Atoi(ctx.Query("page"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+
+	pageSize, err := strconv.Atoi(ctx.Query("pageSize"))
+	if err != nil || pageSize < 1 {
+		pageSize = 10
+	}
+
+	res, err := impl.service.ListReorderPoints(ctx, page, pageSize)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, presenters.ErrorRes{Error: http.StatusText(http.StatusInternalServerError)})
+		return
+	}
+
+	resp := presenters.ReorderPointsRes{Data: []presenters.ReorderPointRes{}}
+	for _, point := range res {
+		resp.Data = append(resp.Data, impl.parseReorderPoint(&point))
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// Stock godoc
+// @Summary list fruit names whose stock is below their reorder point
+// @Schemes
+// @Tags stock
+// @Accept json
+// @Produce json
+// @Success 200 {object} presenters.LowStockRes
+// @Failure 500 {object} presenters.ErrorRes
+// @Router /v1/stock/low [get]
+func (impl *StockController) Low(ctx *gin.Context) {
+	res, err := impl.service.Low(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, presenters.ErrorRes{Error: http.StatusText(http.StatusInternalServerError)})
+		return
+	}
+
+	resp := presenters.LowStockRes{Data: []presenters.StockRes{}}
+	for _, stock := range res {
+		resp.Data = append(resp.Data, presenters.StockRes{
+			Name:        stock.Name,
+			Quantity:    stock.Quantity,
+			MinQuantity: stock.MinQuantity,
+			Shortage:    int64(stock.MinQuantity) - stock.Quantity,
+		})
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// Stock godoc
+// @Summary list low-stock alerts, newest first
+// @Schemes
+// @Tags stock
+// @Accept json
+// @Produce json
+// @Param page query int false "page" default(1)
+// @Param pageSize query int false "pageSize" default(10)
+// @Success 200 {object} presenters.StockAlertsRes
+// @Failure 500 {object} presenters.ErrorRes
+// @Router /v1/stock/alerts [get]
+func (impl *StockController) ListAlerts(ctx *gin.Context) {
+	page, err := strconv.Atoi(ctx.Query("page"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+
+	pageSize, err := strconv.Atoi(ctx.Query("pageSize"))
+	if err != nil || pageSize < 1 {
+		pageSize = 10
+	}
+
+	res, err := impl.service.ListAlerts(ctx, page, pageSize)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, presenters.ErrorRes{Error: http.StatusText(http.StatusInternalServerError)})
+		return
+	}
+
+	resp := presenters.StockAlertsRes{Data: []presenters.StockAlertRes{}}
+	for _, alert := range res {
+		resp.Data = append(resp.Data, presenters.StockAlertRes{
+			ID:          alert.ID,
+			CreatedAt:   alert.CreatedAt.Format(time.DateTime),
+			Name:        alert.Name,
+			Quantity:    alert.Quantity,
+			MinQuantity: alert.MinQuantity,
+			Reason:      alert.Reason,
+		})
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// Stock godoc
+// @Summary waste report of expired and discarded fruits by fruit name, bucket and supplier
+// @Schemes
+// @Tags stock
+// @Accept json
+// @Produce json
+// @Param from query string false "First day of the period (default 30 days before to)" example(2000-12-01)
+// @Param to query string false "Last day of the period (default today)" example(2000-12-31)
+// @Success 200 {object} presenters.WasteReportRes
+// @Failure 400 {object} presenters.ErrorRes
+// @Failure 500 {object} presenters.ErrorRes
+// @Router /v1/reports/waste [get]
+func (impl *StockController) Waste(ctx *gin.Context) {
+	today := time.Now()
+	to := time.Date(today.Year(), today.Month(), today.Day(), 0, 0, 0, 0, time.Local)
+	if v := ctx.Query("to"); v != "" {
+		d, err := time.ParseInLocation(time.DateOnly, v, time.Local)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, presenters.ErrorRes{Error: exceptions.ValidationExceptionName, Message: "invalid to"})
+			return
+		}
+		to = d
+	}
+
+	from := to.Add(-wasteDefaultPeriod)
+	if v := ctx.Query("from"); v != "" {
+		d, err := time.ParseInLocation(time.DateOnly, v, time.Local)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, presenters.ErrorRes{Error: exceptions.ValidationExceptionName, Message: "invalid from"})
+			return
+		}
+		from = d
+	}
+
+	res, err := impl.service.Waste(ctx, dtos.WasteDto{From: from, To: to.AddDate(0, 0, 1)})
+	if err != nil {
+		if e, ok := err.(*exceptions.ValidationException); ok {
+			ctx.JSON(http.StatusBadRequest, presenters.ErrorRes{Error: e.Name, Messages: e.Errors})
+			return
+		}
+
+		ctx.JSON(http.StatusInternalServerError, presenters.ErrorRes{Error: http.StatusText(http.StatusInternalServerError)})
+		return
+	}
+
+	resp := presenters.WasteReportRes{
+		From: from.Format(time.DateOnly),
+		To:   to.Format(time.DateOnly),
+		Data: []presenters.WasteRes{},
+	}
+	var total models.Waste
+	for _, waste := range res {
+		resp.Data = append(resp.Data, presenters.WasteRes{
+			Name:            waste.Name,
+			BucketID:        waste.BucketID,
+			SupplierID:      waste.SupplierID,
+			ReceivedFruits:  waste.ReceivedFruits,
+			ExpiredFruits:   waste.ExpiredFruits,
+			ExpiredPrice:    waste.ExpiredPrice,
+			DiscardedFruits: waste.DiscardedFruits,
+			DiscardedPrice:  waste.DiscardedPrice,
+			WastedFruits:    waste.WastedFruits(),
+			WastedPrice:     waste.WastedPrice(),
+			WasteRate:       waste.Rate(),
+		})
+		total.ReceivedFruits += waste.ReceivedFruits
+		total.ExpiredFruits += waste.ExpiredFruits
+		total.ExpiredPrice = total.ExpiredPrice.Add(waste.ExpiredPrice)
+		total.DiscardedFruits += waste.DiscardedFruits
+		total.DiscardedPrice = total.DiscardedPrice.Add(waste.DiscardedPrice)
+	}
+	resp.TotalReceivedFruits = total.ReceivedFruits
+	resp.TotalWastedFruits = total.WastedFruits()
+	resp.TotalWastedPrice = total.WastedPrice()
+	resp.TotalWasteRate = total.Rate()
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// Stock godoc
+// @Summary value of the stock at a given moment by bucket and fruit name
+// @Schemes
+// @Tags stock
+// @Accept json
+// @Produce json
+// @Param at query string false "Moment of the valuation (default now)" example(2000-12-31 23:59:59)
+// @Success 200 {object} presenters.ValuationReportRes
+// @Failure 400 {object} presenters.ErrorRes
+// @Failure 500 {object} presenters.ErrorRes
+// @Router /v1/reports/valuation [get]
+func (impl *StockController) Valuation(ctx *gin.Context) {
+	at := time.Now().Truncate(time.Second)
+	if v := ctx.Query("at"); v != "" {
+		d, err := time.ParseInLocation(time.DateTime, v, time.Local)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, presenters.ErrorRes{Error: exceptions.ValidationExceptionName, Message: "invalid at"})
+			return
+		}
+		at = d
+	}
+
+	res, err := impl.service.Valuation(ctx, dtos.ValuationDto{At: at})
+	if err != nil {
+		if e, ok := err.(*exceptions.ValidationException); ok {
+			ctx.JSON(http.StatusBadRequest, presenters.ErrorRes{Error: e.Name, Messages: e.Errors})
+			return
+		}
+
+		ctx.JSON(http.StatusInternalServerError, presenters.ErrorRes{Error: http.StatusText(http.StatusInternalServerError)})
+		return
+	}
+
+	resp := presenters.ValuationReportRes{
+		At:   at.Format(time.DateTime),
+		Data: []presenters.ValuationRes{},
+	}
+	for _, valuation := range res {
+		resp.Data = append(resp.Data, presenters.ValuationRes{
+			BucketID:   valuation.BucketID,
+			BucketName: valuation.BucketName,
+			Name:       valuation.Name,
+			Quantity:   valuation.Quantity,
+			Value:      valuation.Value,
+		})
+		resp.TotalQuantity += valuation.Quantity
+		resp.TotalValue = resp.TotalValue.Add(valuation.Value)
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+func (impl *StockController) parseReorderPoint(point *models.ReorderPoint) presenters.ReorderPointRes {
+	return presenters.ReorderPointRes{
+		ID:          point.ID,
+		CreatedAt:   point.CreatedAt.Format(time.DateTime),
+		UpdatedAt:   point.UpdatedAt.Format(time.DateTime),
+		Name:        point.Name,
+		MinQuantity: point.MinQuantity,
+	}
+}
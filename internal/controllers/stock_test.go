@@ -0,0 +1,516 @@
+package controllers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/golang/mock/gomock"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/viniosilva/where-are-my-fruits/internal/controllers/presenters"
+	"github.com/viniosilva/where-are-my-fruits/internal/dtos"
+	"github.com/viniosilva/where-are-my-fruits/internal/exceptions"
+	"github.com/viniosilva/where-are-my-fruits/internal/models"
+	"github.com/viniosilva/where-are-my-fruits/mocks"
+)
+
+func TestStockController_SetReorderPoint(t *testing.T) {
+	now := time.Date(2000, 12, 31, 23, 59, 59, 0, time.Local)
+
+	tests := map[string]struct {
+		mock        func(service *mocks.MockStockService)
+		body        presenters.SetReorderPointReq
+		wantCode    int
+		wantBody    presenters.ReorderPointRes
+		wantBodyErr presenters.ErrorRes
+	}{
+		"should be success": {
+			mock: func(service *mocks.MockStockService) {
+				data := dtos.SetReorderPointDto{Name: "Orange", MinQuantity: 10}
+				service.EXPECT().SetReorderPoint(gomock.Any(), data).Return(&models.ReorderPoint{
+					ID: 1, CreatedAt: now, UpdatedAt: now, Name: "Orange", MinQuantity: 10,
+				}, nil)
+			},
+			body:     presenters.SetReorderPointReq{Name: "Orange", MinQuantity: 10},
+			wantCode: http.StatusOK,
+			wantBody: presenters.ReorderPointRes{
+				ID:          1,
+				CreatedAt:   "2000-12-31 23:59:59",
+				UpdatedAt:   "2000-12-31 23:59:59",
+				Name:        "Orange",
+				MinQuantity: 10,
+			},
+		},
+		"should throw validation exception": {
+			mock: func(service *mocks.MockStockService) {
+				service.EXPECT().SetReorderPoint(gomock.Any(), gomock.Any()).Return(nil, exceptions.NewValidationException(validator.ValidationErrors{
+					&mocks.FieldError{Itag: "error 1", Ins: "error 1"},
+				}))
+			},
+			body:     presenters.SetReorderPointReq{},
+			wantCode: http.StatusBadRequest,
+			wantBodyErr: presenters.ErrorRes{
+				Error:    exceptions.ValidationExceptionName,
+				Messages: []string{"Key: 'error 1' Error:Field validation for '' failed on the 'error 1' tag"},
+			},
+		},
+		"should throw internal server error": {
+			mock: func(service *mocks.MockStockService) {
+				service.EXPECT().SetReorderPoint(gomock.Any(), gomock.Any()).Return(nil, fmt.Errorf("error"))
+			},
+			wantCode:    http.StatusInternalServerError,
+			wantBodyErr: presenters.ErrorRes{Error: http.StatusText(http.StatusInternalServerError)},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			// setup
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			serviceMock := mocks.NewMockStockService(ctrl)
+			tt.mock(serviceMock)
+
+			r := gin.Default()
+			controller := NewStock(serviceMock)
+
+			path := "/api/v1/stock/reorder-points"
+			r.PUT(path, controller.SetReorderPoint)
+
+			var got presenters.ReorderPointRes
+			var gotErr presenters.ErrorRes
+
+			// given
+			body, _ := json.Marshal(tt.body)
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("PUT", path, bytes.NewReader(body))
+
+			// when
+			r.ServeHTTP(w, req)
+
+			json.Unmarshal(w.Body.Bytes(), &gotErr)
+			json.Unmarshal(w.Body.Bytes(), &got)
+
+			// then
+			assert.Equal(t, tt.wantCode, w.Code)
+
+			if gotErr.Error != "" {
+				assert.Equal(t, tt.wantBodyErr, gotErr)
+				return
+			}
+			assert.Equal(t, tt.wantBody, got)
+		})
+	}
+}
+
+func TestStockController_ListReorderPoints(t *testing.T) {
+	now := time.Date(2000, 12, 31, 23, 59, 59, 0, time.Local)
+
+	tests := map[string]struct {
+		mock        func(service *mocks.MockStockService)
+		wantCode    int
+		wantBody    presenters.ReorderPointsRes
+		wantBodyErr presenters.ErrorRes
+	}{
+		"should be success": {
+			mock: func(service *mocks.MockStockService) {
+				service.EXPECT().ListReorderPoints(gomock.Any(), 1, 10).Return([]models.ReorderPoint{
+					{ID: 1, CreatedAt: now, UpdatedAt: now, Name: "Orange", MinQuantity: 10},
+				}, nil)
+			},
+			wantCode: http.StatusOK,
+			wantBody: presenters.ReorderPointsRes{
+				Data: []presenters.ReorderPointRes{
+					{ID: 1, CreatedAt: "2000-12-31 23:59:59", UpdatedAt: "2000-12-31 23:59:59", Name: "Orange", MinQuantity: 10},
+				},
+			},
+		},
+		"should throw internal server error": {
+			mock: func(service *mocks.MockStockService) {
+				service.EXPECT().ListReorderPoints(gomock.Any(), 1, 10).Return(nil, fmt.Errorf("error"))
+			},
+			wantCode:    http.StatusInternalServerError,
+			wantBodyErr: presenters.ErrorRes{Error: http.StatusText(http.StatusInternalServerError)},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			// setup
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			serviceMock := mocks.NewMockStockService(ctrl)
+			tt.mock(serviceMock)
+
+			r := gin.Default()
+			controller := NewStock(serviceMock)
+
+			path := "/api/v1/stock/reorder-points"
+			r.GET(path, controller.ListReorderPoints)
+
+			var got presenters.ReorderPointsRes
+			var gotErr presenters.ErrorRes
+
+			// given
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("GET", path, nil)
+
+			// when
+			r.ServeHTTP(w, req)
+
+			json.Unmarshal(w.Body.Bytes(), &gotErr)
+			json.Unmarshal(w.Body.Bytes(), &got)
+
+			// then
+			assert.Equal(t, tt.wantCode, w.Code)
+
+			if gotErr.Error != "" {
+				assert.Equal(t, tt.wantBodyErr, gotErr)
+				return
+			}
+			assert.Equal(t, tt.wantBody, got)
+		})
+	}
+}
+
+func TestStockController_Low(t *testing.T) {
+	tests := map[string]struct {
+		mock        func(service *mocks.MockStockService)
+		wantCode    int
+		wantBody    presenters.LowStockRes
+		wantBodyErr presenters.ErrorRes
+	}{
+		"should be success": {
+			mock: func(service *mocks.MockStockService) {
+				service.EXPECT().Low(gomock.Any()).Return([]models.Stock{
+					{Name: "Apple", Quantity: 0, MinQuantity: 5},
+					{Name: "Orange", Quantity: 4, MinQuantity: 10},
+				}, nil)
+			},
+			wantCode: http.StatusOK,
+			wantBody: presenters.LowStockRes{
+				Data: []presenters.StockRes{
+					{Name: "Apple", Quantity: 0, MinQuantity: 5, Shortage: 5},
+					{Name: "Orange", Quantity: 4, MinQuantity: 10, Shortage: 6},
+				},
+			},
+		},
+		"should throw internal server error": {
+			mock: func(service *mocks.MockStockService) {
+				service.EXPECT().Low(gomock.Any()).Return(nil, fmt.Errorf("error"))
+			},
+			wantCode:    http.StatusInternalServerError,
+			wantBodyErr: presenters.ErrorRes{Error: http.StatusText(http.StatusInternalServerError)},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			// setup
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			serviceMock := mocks.NewMockStockService(ctrl)
+			tt.mock(serviceMock)
+
+			r := gin.Default()
+			controller := NewStock(serviceMock)
+
+			path := "/api/v1/stock/low"
+			r.GET(path, controller.Low)
+
+			var got presenters.LowStockRes
+			var gotErr presenters.ErrorRes
+
+			// given
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("GET", path, nil)
+
+			// when
+			r.ServeHTTP(w, req)
+
+			json.Unmarshal(w.Body.Bytes(), &gotErr)
+			json.Unmarshal(w.Body.Bytes(), &got)
+
+			// then
+			assert.Equal(t, tt.wantCode, w.Code)
+
+			if gotErr.Error != "" {
+				assert.Equal(t, tt.wantBodyErr, gotErr)
+				return
+			}
+			assert.Equal(t, tt.wantBody, got)
+		})
+	}
+}
+
+func TestStockController_ListAlerts(t *testing.T) {
+	now := time.Date(2000, 12, 31, 23, 59, 59, 0, time.Local)
+
+	tests := map[string]struct {
+		mock        func(service *mocks.MockStockService)
+		wantCode    int
+		wantBody    presenters.StockAlertsRes
+		wantBodyErr presenters.ErrorRes
+	}{
+		"should be success": {
+			mock: func(service *mocks.MockStockService) {
+				service.EXPECT().ListAlerts(gomock.Any(), 1, 10).Return([]models.StockAlert{
+					{ID: 1, CreatedAt: now, Name: "Orange", Quantity: 9, MinQuantity: 10, Reason: models.StockAlertReasonExpired},
+				}, nil)
+			},
+			wantCode: http.StatusOK,
+			wantBody: presenters.StockAlertsRes{
+				Data: []presenters.StockAlertRes{
+					{ID: 1, CreatedAt: "2000-12-31 23:59:59", Name: "Orange", Quantity: 9, MinQuantity: 10, Reason: "expired"},
+				},
+			},
+		},
+		"should throw internal server error": {
+			mock: func(service *mocks.MockStockService) {
+				service.EXPECT().ListAlerts(gomock.Any(), 1, 10).Return(nil, fmt.Errorf("error"))
+			},
+			wantCode:    http.StatusInternalServerError,
+			wantBodyErr: presenters.ErrorRes{Error: http.StatusText(http.StatusInternalServerError)},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			// setup
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			serviceMock := mocks.NewMockStockService(ctrl)
+			tt.mock(serviceMock)
+
+			r := gin.Default()
+			controller := NewStock(serviceMock)
+
+			path := "/api/v1/stock/alerts"
+			r.GET(path, controller.ListAlerts)
+
+			var got presenters.StockAlertsRes
+			var gotErr presenters.ErrorRes
+
+			// given
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("GET", path, nil)
+
+			// when
+			r.ServeHTTP(w, req)
+
+			json.Unmarshal(w.Body.Bytes(), &gotErr)
+			json.Unmarshal(w.Body.Bytes(), &got)
+
+			// then
+			assert.Equal(t, tt.wantCode, w.Code)
+
+			if gotErr.Error != "" {
+				assert.Equal(t, tt.wantBodyErr, gotErr)
+				return
+			}
+			assert.Equal(t, tt.wantBody, got)
+		})
+	}
+}
+
+func TestStockController_Waste(t *testing.T) {
+	bucketID := int64(1)
+	supplierID := int64(1)
+	orangeRate := decimal.RequireFromString("0.3")
+	totalRate := decimal.RequireFromString("0.4")
+
+	tests := map[string]struct {
+		mock        func(service *mocks.MockStockService)
+		query       string
+		wantCode    int
+		wantBody    presenters.WasteReportRes
+		wantBodyErr presenters.ErrorRes
+	}{
+		"should be success": {
+			mock: func(service *mocks.MockStockService) {
+				data := dtos.WasteDto{
+					From: time.Date(2000, 12, 1, 0, 0, 0, 0, time.Local),
+					To:   time.Date(2001, 1, 1, 0, 0, 0, 0, time.Local),
+				}
+				service.EXPECT().Waste(gomock.Any(), data).Return([]models.Waste{
+					{Name: "Apple", ExpiredFruits: 1, ExpiredPrice: decimal.RequireFromString("0.99"), DiscardedPrice: decimal.RequireFromString("0")},
+					{Name: "Orange", BucketID: &bucketID, SupplierID: &supplierID, ReceivedFruits: 10, ExpiredFruits: 2,
+						ExpiredPrice: decimal.RequireFromString("3.98"), DiscardedFruits: 1, DiscardedPrice: decimal.RequireFromString("1.99")},
+				}, nil)
+			},
+			query:    "?from=2000-12-01&to=2000-12-31",
+			wantCode: http.StatusOK,
+			wantBody: presenters.WasteReportRes{
+				From: "2000-12-01",
+				To:   "2000-12-31",
+				Data: []presenters.WasteRes{
+					{Name: "Apple", ExpiredFruits: 1, ExpiredPrice: decimal.RequireFromString("0.99"), DiscardedPrice: decimal.RequireFromString("0"),
+						WastedFruits: 1, WastedPrice: decimal.RequireFromString("0.99")},
+					{Name: "Orange", BucketID: &bucketID, SupplierID: &supplierID, ReceivedFruits: 10, ExpiredFruits: 2,
+						ExpiredPrice: decimal.RequireFromString("3.98"), DiscardedFruits: 1, DiscardedPrice: decimal.RequireFromString("1.99"),
+						WastedFruits: 3, WastedPrice: decimal.RequireFromString("5.97"), WasteRate: &orangeRate},
+				},
+				TotalReceivedFruits: 10,
+				TotalWastedFruits:   4,
+				TotalWastedPrice:    decimal.RequireFromString("6.96"),
+				TotalWasteRate:      &totalRate,
+			},
+		},
+		"should throw bad request when from is invalid": {
+			mock:        func(service *mocks.MockStockService) {},
+			query:       "?from=a",
+			wantCode:    http.StatusBadRequest,
+			wantBodyErr: presenters.ErrorRes{Error: exceptions.ValidationExceptionName, Message: "invalid from"},
+		},
+		"should throw bad request when to is invalid": {
+			mock:        func(service *mocks.MockStockService) {},
+			query:       "?to=a",
+			wantCode:    http.StatusBadRequest,
+			wantBodyErr: presenters.ErrorRes{Error: exceptions.ValidationExceptionName, Message: "invalid to"},
+		},
+		"should throw validation exception": {
+			mock: func(service *mocks.MockStockService) {
+				service.EXPECT().Waste(gomock.Any(), gomock.Any()).Return(nil, exceptions.NewValidationException(validator.ValidationErrors{
+					&mocks.FieldError{Itag: "error 1", Ins: "error 1"},
+				}))
+			},
+			query:    "?from=2000-12-31&to=2000-12-01",
+			wantCode: http.StatusBadRequest,
+			wantBodyErr: presenters.ErrorRes{
+				Error:    exceptions.ValidationExceptionName,
+				Messages: []string{"Key: 'error 1' Error:Field validation for '' failed on the 'error 1' tag"},
+			},
+		},
+		"should throw internal server error": {
+			mock: func(service *mocks.MockStockService) {
+				service.EXPECT().Waste(gomock.Any(), gomock.Any()).Return(nil, fmt.Errorf("error"))
+			},
+			wantCode:    http.StatusInternalServerError,
+			wantBodyErr: presenters.ErrorRes{Error: http.StatusText(http.StatusInternalServerError)},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			// setup
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			serviceMock := mocks.NewMockStockService(ctrl)
+			tt.mock(serviceMock)
+
+			r := gin.Default()
+			controller := NewStock(serviceMock)
+
+			path := "/api/v1/reports/waste"
+			r.GET(path, controller.Waste)
+
+			var got presenters.WasteReportRes
+			var gotErr presenters.ErrorRes
+
+			// given
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("GET", path+tt.query, nil)
+
+			// when
+			r.ServeHTTP(w, req)
+
+			json.Unmarshal(w.Body.Bytes(), &gotErr)
+			json.Unmarshal(w.Body.Bytes(), &got)
+
+			// then
+			assert.Equal(t, tt.wantCode, w.Code)
+
+			if gotErr.Error != "" {
+				assert.Equal(t, tt.wantBodyErr, gotErr)
+				return
+			}
+			assert.Equal(t, tt.wantBody, got)
+		})
+	}
+}
+
+func TestStockController_Valuation(t *testing.T) {
+	at := time.Date(2000, 12, 31, 23, 59, 59, 0, time.Local)
+	bucketID := int64(1)
+	bucketName := "A"
+
+	tests := map[string]struct {
+		mock        func(service *mocks.MockStockService)
+		query       string
+		wantCode    int
+		wantBody    presenters.ValuationReportRes
+		wantBodyErr presenters.ErrorRes
+	}{
+		"should be success": {
+			mock: func(service *mocks.MockStockService) {
+				service.EXPECT().Valuation(gomock.Any(), dtos.ValuationDto{At: at}).Return([]models.Valuation{
+					{Name: "Apple", Quantity: 1, Value: decimal.RequireFromString("0.99")},
+					{BucketID: &bucketID, BucketName: &bucketName, Name: "Orange", Quantity: 2, Value: decimal.RequireFromString("3.98")},
+				}, nil)
+			},
+			query:    "?at=2000-12-31%2023:59:59",
+			wantCode: http.StatusOK,
+			wantBody: presenters.ValuationReportRes{
+				At: "2000-12-31 23:59:59",
+				Data: []presenters.ValuationRes{
+					{Name: "Apple", Quantity: 1, Value: decimal.RequireFromString("0.99")},
+					{BucketID: &bucketID, BucketName: &bucketName, Name: "Orange", Quantity: 2, Value: decimal.RequireFromString("3.98")},
+				},
+				TotalQuantity: 3,
+				TotalValue:    decimal.RequireFromString("4.97"),
+			},
+		},
+		"should throw bad request when at is invalid": {
+			mock:        func(service *mocks.MockStockService) {},
+			query:       "?at=2000-12-31",
+			wantCode:    http.StatusBadRequest,
+			wantBodyErr: presenters.ErrorRes{Error: exceptions.ValidationExceptionName, Message: "invalid at"},
+		},
+		"should throw internal server error": {
+			mock: func(service *mocks.MockStockService) {
+				service.EXPECT().Valuation(gomock.Any(), gomock.Any()).Return(nil, fmt.Errorf("error"))
+			},
+			wantCode:    http.StatusInternalServerError,
+			wantBodyErr: presenters.ErrorRes{Error: http.StatusText(http.StatusInternalServerError)},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			// setup
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			serviceMock := mocks.NewMockStockService(ctrl)
+			tt.mock(serviceMock)
+
+			r := gin.Default()
+			controller := NewStock(serviceMock)
+
+			path := "/api/v1/reports/valuation"
+			r.GET(path, controller.Valuation)
+
+			var got presenters.ValuationReportRes
+			var gotErr presenters.ErrorRes
+
+			// given
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("GET", path+tt.query, nil)
+
+			// when
+			r.ServeHTTP(w, req)
+
+			json.Unmarshal(w.Body.Bytes(), &gotErr)
+			json.Unmarshal(w.Body.Bytes(), &got)
+
+			// then
+			assert.Equal(t, tt.wantCode, w.Code)
+
+			if gotErr.Error != "" {
+				assert.Equal(t, tt.wantBodyErr, gotErr)
+				return
+			}
+			assert.Equal(t, tt.wantBody, got)
+		})
+	}
+}
@@ -0,0 +1,253 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/viniosilva/where-are-my-fruits/internal/controllers/presenters"
+	"github.com/viniosilva/where-are-my-fruits/internal/dtos"
+	"github.com/viniosilva/where-are-my-fruits/internal/exceptions"
+	"github.com/viniosilva/where-are-my-fruits/internal/models"
+)
+
+type SupplierController struct {
+	service SupplierService
+}
+
+func NewSupplier(service SupplierService) *SupplierController {
+	return &SupplierController{
+		service: service,
+	}
+}
+
+// Supplier godoc
+// @Summary create supplier
+// @Schemes
+// @Tags supplier
+// @Accept json
+// @Produce json
+// @Param supplier body presenters.CreateSupplierReq true "Supplier"
+// @Success 201 {object} presenters.SupplierRes
+// @Failure 400 {object} presenters.ErrorRes
+// @Failure 500 {object} presenters.ErrorRes
+// @Router /v1/suppliers [post]
+func (impl *SupplierController) Create(ctx *gin.Context) {
+	var req presenters.CreateSupplierReq
+	ctx.BindJSON(&req)
+
+	data := dtos.CreateSupplierDto{
+		Name:    req.Name,
+		Country: req.Country,
+		Contact: req.Contact,
+	}
+
+	res, err := impl.service.Create(ctx, data)
+	if err != nil {
+		if e, ok := err.(*exceptions.ValidationException); ok {
+			ctx.JSON(http.StatusBadRequest, presenters.ErrorRes{Error: e.Name, Messages: e.Errors})
+			return
+		}
+
+		ctx.JSON(http.StatusInternalServerError, presenters.ErrorRes{Error: http.StatusText(http.StatusInternalServerError)})
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, impl.parse(res))
+}
+
+// Supplier godoc
+// @Summary list suppliers
+// @Schemes
+// @Tags supplier
+// @Accept json
+// @Produce json
+// @Param page query int false "page" default(1)
+// @Param pageSize query int false "pageSize" default(10)
+// @Success 200 {object} presenters.SuppliersRes
+// @Failure 500 {object} presenters.ErrorRes
+// @Router /v1/suppliers [get]
+func (impl *SupplierController) List(ctx *gin.Context) {
+	page, err := strconv.Atoi(ctx.Query("page"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+
+	pageSize, err := strconv.Atoi(ctx.Query("pageSize"))
+	if err != nil || pageSize < 1 {
+		pageSize = 10
+	}
+
+	res, err := impl.service.List(ctx, page, pageSize)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, presenters.ErrorRes{Error: http.StatusText(http.StatusInternalServerError)})
+		return
+	}
+
+	resp := presenters.SuppliersRes{Data: []presenters.SupplierRes{}}
+	for _, supplier := range res {
+		resp.Data = append(resp.Data, impl.parse(&supplier))
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// Supplier godoc
+// @Summary get supplier
+// @Schemes
+// @Tags supplier
+// @Accept json
+// @Produce json
+// @Param supplierID path int64 true "Supplier ID"
+// @Success 200 {object} presenters.SupplierRes
+// @Failure 400 {object} presenters.ErrorRes
+// @Failure 404 {object} presenters.ErrorRes
+// @Failure 500 {object} presenters.ErrorRes
+// @Router /v1/suppliers/{supplierID} [get]
+func (impl *SupplierController) Get(ctx *gin.Context) {
+	supplierID, err := strconv.ParseInt(ctx.Param("supplierID"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, presenters.ErrorRes{Error: exceptions.ValidationExceptionName, Message: "invalid supplierID"})
+		return
+	}
+
+	res, err := impl.service.Get(ctx, supplierID)
+	if err != nil {
+		if e, ok := err.(*exceptions.NotFoundException); ok {
+			ctx.JSON(http.StatusNotFound, presenters.ErrorRes{Error: e.Name, Message: e.Error()})
+			return
+		}
+
+		ctx.JSON(http.StatusInternalServerError, presenters.ErrorRes{Error: http.StatusText(http.StatusInternalServerError)})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, impl.parse(res))
+}
+
+// Supplier godoc
+// @Summary update supplier
+// @Schemes
+// @Tags supplier
+// @Accept json
+// @Produce json
+// @Param supplierID path int64 true "Supplier ID"
+// @Param supplier body presenters.UpdateSupplierReq true "Supplier"
+// @Success 200 {object} presenters.SupplierRes
+// @Failure 400 {object} presenters.ErrorRes
+// @Failure 404 {object} presenters.ErrorRes
+// @Failure 500 {object} presenters.ErrorRes
+// @Router /v1/suppliers/{supplierID} [put]
+func (impl *SupplierController) Update(ctx *gin.Context) {
+	supplierID, err := strconv.ParseInt(ctx.Param("supplierID"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, presenters.ErrorRes{Error: exceptions.ValidationExceptionName, Message: "invalid supplierID"})
+		return
+	}
+
+	var req presenters.UpdateSupplierReq
+	ctx.BindJSON(&req)
+
+	data := dtos.UpdateSupplierDto{
+		Name:    req.Name,
+		Country: req.Country,
+		Contact: req.Contact,
+	}
+
+	res, err := impl.service.Update(ctx, supplierID, data)
+	if err != nil {
+		if e, ok := err.(*exceptions.ValidationException); ok {
+			ctx.JSON(http.StatusBadRequest, presenters.ErrorRes{Error: e.Name, Messages: e.Errors})
+			return
+		}
+		if e, ok := err.(*exceptions.NotFoundException); ok {
+			ctx.JSON(http.StatusNotFound, presenters.ErrorRes{Error: e.Name, Message: e.Error()})
+			return
+		}
+
+		ctx.JSON(http.StatusInternalServerError, presenters.ErrorRes{Error: http.StatusText(http.StatusInternalServerError)})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, impl.parse(res))
+}
+
+// Supplier godoc
+// @Summary delete supplier
+// @Schemes
+// @Tags supplier
+// @Accept json
+// @Produce json
+// @Param supplierID path int64 true "Supplier ID"
+// @Success 200 {object} nil
+// @Failure 400 {object} presenters.ErrorRes
+// @Failure 500 {object} presenters.ErrorRes
+// @Router /v1/suppliers/{supplierID} [delete]
+func (impl *SupplierController) Delete(ctx *gin.Context) {
+	supplierID, err := strconv.ParseInt(ctx.Param("supplierID"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, presenters.ErrorRes{Error: exceptions.ValidationExceptionName, Message: "invalid supplierID"})
+		return
+	}
+
+	err = impl.service.Delete(ctx, supplierID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, presenters.ErrorRes{Error: http.StatusText(http.StatusInternalServerError)})
+		return
+	}
+
+	ctx.Status(http.StatusOK)
+}
+
+// Supplier godoc
+// @Summary supplier fruits report
+// @Schemes
+// @Tags supplier
+// @Accept json
+// @Produce json
+// @Param supplierID path int64 true "Supplier ID"
+// @Success 200 {object} presenters.SupplierFruitsRes
+// @Failure 400 {object} presenters.ErrorRes
+// @Failure 404 {object} presenters.ErrorRes
+// @Failure 500 {object} presenters.ErrorRes
+// @Router /v1/suppliers/{supplierID}/report [get]
+func (impl *SupplierController) Report(ctx *gin.Context) {
+	supplierID, err := strconv.ParseInt(ctx.Param("supplierID"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, presenters.ErrorRes{Error: exceptions.ValidationExceptionName, Message: "invalid supplierID"})
+		return
+	}
+
+	res, err := impl.service.Report(ctx, supplierID)
+	if err != nil {
+		if e, ok := err.(*exceptions.NotFoundException); ok {
+			ctx.JSON(http.StatusNotFound, presenters.ErrorRes{Error: e.Name, Message: e.Error()})
+			return
+		}
+
+		ctx.JSON(http.StatusInternalServerError, presenters.ErrorRes{Error: http.StatusText(http.StatusInternalServerError)})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, presenters.SupplierFruitsRes{
+		ID:             res.ID,
+		Name:           res.Name,
+		ReceivedFruits: res.ReceivedFruits,
+		ReceivedPrice:  res.ReceivedPrice,
+		ExpiredFruits:  res.ExpiredFruits,
+		ExpiredPrice:   res.ExpiredPrice,
+		CurrentFruits:  res.CurrentFruits,
+		CurrentPrice:   res.CurrentPrice,
+	})
+}
+
+func (impl *SupplierController) parse(supplier *models.Supplier) presenters.SupplierRes {
+	return presenters.SupplierRes{
+		ID:        supplier.ID,
+		CreatedAt: supplier.CreatedAt.Format(time.DateTime),
+		Name:      supplier.Name,
+		Country:   supplier.Country,
+		Contact:   supplier.Contact,
+	}
+}
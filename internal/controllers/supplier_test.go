@@ -0,0 +1,533 @@
+package controllers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/golang/mock/gomock"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/viniosilva/where-are-my-fruits/internal/controllers/presenters"
+	"github.com/viniosilva/where-are-my-fruits/internal/dtos"
+	"github.com/viniosilva/where-are-my-fruits/internal/exceptions"
+	"github.com/viniosilva/where-are-my-fruits/internal/models"
+	"github.com/viniosilva/where-are-my-fruits/mocks"
+)
+
+func TestSupplierController_Create(t *testing.T) {
+	now := time.Date(2000, 12, 31, 23, 59, 59, 0, time.Local)
+
+	tests := map[string]struct {
+		mock        func(service *mocks.MockSupplierService)
+		body        presenters.CreateSupplierReq
+		wantCode    int
+		wantBody    presenters.SupplierRes
+		wantBodyErr presenters.ErrorRes
+	}{
+		"should be success": {
+			mock: func(service *mocks.MockSupplierService) {
+				data := dtos.CreateSupplierDto{Name: "Testing", Country: "BR"}
+				service.EXPECT().Create(gomock.Any(), data).Return(&models.Supplier{
+					ID:        1,
+					CreatedAt: now,
+					Name:      "Testing",
+					Country:   "BR",
+				}, nil)
+			},
+			body:     presenters.CreateSupplierReq{Name: "Testing", Country: "BR"},
+			wantCode: http.StatusCreated,
+			wantBody: presenters.SupplierRes{
+				ID:        1,
+				CreatedAt: "2000-12-31 23:59:59",
+				Name:      "Testing",
+				Country:   "BR",
+			},
+		},
+		"should throw validation exception": {
+			mock: func(service *mocks.MockSupplierService) {
+				service.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil, exceptions.NewValidationException(validator.ValidationErrors{
+					&mocks.FieldError{Itag: "error 1", Ins: "error 1"},
+				}))
+			},
+			body:     presenters.CreateSupplierReq{},
+			wantCode: http.StatusBadRequest,
+			wantBodyErr: presenters.ErrorRes{
+				Error:    exceptions.ValidationExceptionName,
+				Messages: []string{"Key: 'error 1' Error:Field validation for '' failed on the 'error 1' tag"},
+			},
+		},
+		"should throw internal server error": {
+			mock: func(service *mocks.MockSupplierService) {
+				service.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil, fmt.Errorf("error"))
+			},
+			body:        presenters.CreateSupplierReq{},
+			wantCode:    http.StatusInternalServerError,
+			wantBodyErr: presenters.ErrorRes{Error: http.StatusText(http.StatusInternalServerError)},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			// setup
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			serviceMock := mocks.NewMockSupplierService(ctrl)
+			tt.mock(serviceMock)
+
+			r := gin.Default()
+			controller := NewSupplier(serviceMock)
+
+			path := "/api/v1/suppliers"
+			r.POST(path, controller.Create)
+
+			var got presenters.SupplierRes
+			var gotErr presenters.ErrorRes
+
+			// given
+			body, _ := json.Marshal(tt.body)
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("POST", path, bytes.NewReader(body))
+
+			// when
+			r.ServeHTTP(w, req)
+
+			json.Unmarshal(w.Body.Bytes(), &gotErr)
+			json.Unmarshal(w.Body.Bytes(), &got)
+
+			// then
+			assert.Equal(t, tt.wantCode, w.Code)
+
+			if gotErr.Error != "" {
+				assert.Equal(t, tt.wantBodyErr, gotErr)
+				return
+			}
+			assert.Equal(t, tt.wantBody, got)
+		})
+	}
+}
+
+func TestSupplierController_List(t *testing.T) {
+	now := time.Date(2000, 12, 31, 23, 59, 59, 0, time.Local)
+
+	tests := map[string]struct {
+		mock        func(service *mocks.MockSupplierService)
+		wantCode    int
+		wantBody    presenters.SuppliersRes
+		wantBodyErr presenters.ErrorRes
+	}{
+		"should be success": {
+			mock: func(service *mocks.MockSupplierService) {
+				service.EXPECT().List(gomock.Any(), 1, 10).Return([]models.Supplier{
+					{ID: 1, CreatedAt: now, Name: "Testing", Country: "BR"},
+				}, nil)
+			},
+			wantCode: http.StatusOK,
+			wantBody: presenters.SuppliersRes{
+				Data: []presenters.SupplierRes{
+					{ID: 1, CreatedAt: "2000-12-31 23:59:59", Name: "Testing", Country: "BR"},
+				},
+			},
+		},
+		"should throw internal server error": {
+			mock: func(service *mocks.MockSupplierService) {
+				service.EXPECT().List(gomock.Any(), 1, 10).Return(nil, fmt.Errorf("error"))
+			},
+			wantCode:    http.StatusInternalServerError,
+			wantBodyErr: presenters.ErrorRes{Error: http.StatusText(http.StatusInternalServerError)},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			// setup
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			serviceMock := mocks.NewMockSupplierService(ctrl)
+			tt.mock(serviceMock)
+
+			r := gin.Default()
+			controller := NewSupplier(serviceMock)
+
+			path := "/api/v1/suppliers"
+			r.GET(path, controller.List)
+
+			var got presenters.SuppliersRes
+			var gotErr presenters.ErrorRes
+
+			// given
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("GET", path, nil)
+
+			// when
+			r.ServeHTTP(w, req)
+
+			json.Unmarshal(w.Body.Bytes(), &gotErr)
+			json.Unmarshal(w.Body.Bytes(), &got)
+
+			// then
+			assert.Equal(t, tt.wantCode, w.Code)
+
+			if gotErr.Error != "" {
+				assert.Equal(t, tt.wantBodyErr, gotErr)
+				return
+			}
+			assert.Equal(t, tt.wantBody, got)
+		})
+	}
+}
+
+func TestSupplierController_Get(t *testing.T) {
+	now := time.Date(2000, 12, 31, 23, 59, 59, 0, time.Local)
+
+	tests := map[string]struct {
+		mock            func(service *mocks.MockSupplierService)
+		supplierIDParam string
+		wantCode        int
+		wantBody        presenters.SupplierRes
+		wantBodyErr     presenters.ErrorRes
+	}{
+		"should be success": {
+			mock: func(service *mocks.MockSupplierService) {
+				service.EXPECT().Get(gomock.Any(), int64(1)).Return(&models.Supplier{
+					ID: 1, CreatedAt: now, Name: "Testing", Country: "BR",
+				}, nil)
+			},
+			supplierIDParam: "1",
+			wantCode:        http.StatusOK,
+			wantBody:        presenters.SupplierRes{ID: 1, CreatedAt: "2000-12-31 23:59:59", Name: "Testing", Country: "BR"},
+		},
+		"should throw validation exception when supplierID is invalid": {
+			mock:            func(service *mocks.MockSupplierService) {},
+			supplierIDParam: "invalid",
+			wantCode:        http.StatusBadRequest,
+			wantBodyErr: presenters.ErrorRes{
+				Error:   exceptions.ValidationExceptionName,
+				Message: "invalid supplierID",
+			},
+		},
+		"should throw not found exception": {
+			mock: func(service *mocks.MockSupplierService) {
+				service.EXPECT().Get(gomock.Any(), gomock.Any()).Return(nil, exceptions.NewNotFoundException("Supplier not found"))
+			},
+			supplierIDParam: "1",
+			wantCode:        http.StatusNotFound,
+			wantBodyErr: presenters.ErrorRes{
+				Error:   exceptions.NotFoundExceptionName,
+				Message: "Supplier not found",
+			},
+		},
+		"should throw internal server error": {
+			mock: func(service *mocks.MockSupplierService) {
+				service.EXPECT().Get(gomock.Any(), gomock.Any()).Return(nil, fmt.Errorf("error"))
+			},
+			supplierIDParam: "1",
+			wantCode:        http.StatusInternalServerError,
+			wantBodyErr:     presenters.ErrorRes{Error: http.StatusText(http.StatusInternalServerError)},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			// setup
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			serviceMock := mocks.NewMockSupplierService(ctrl)
+			tt.mock(serviceMock)
+
+			r := gin.Default()
+			controller := NewSupplier(serviceMock)
+
+			r.GET("/api/v1/suppliers/:supplierID", controller.Get)
+
+			var got presenters.SupplierRes
+			var gotErr presenters.ErrorRes
+
+			// given
+			path := fmt.Sprintf("/api/v1/suppliers/%s", tt.supplierIDParam)
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("GET", path, nil)
+
+			// when
+			r.ServeHTTP(w, req)
+
+			json.Unmarshal(w.Body.Bytes(), &gotErr)
+			json.Unmarshal(w.Body.Bytes(), &got)
+
+			// then
+			assert.Equal(t, tt.wantCode, w.Code)
+
+			if gotErr.Error != "" {
+				assert.Equal(t, tt.wantBodyErr, gotErr)
+				return
+			}
+			assert.Equal(t, tt.wantBody, got)
+		})
+	}
+}
+
+func TestSupplierController_Update(t *testing.T) {
+	now := time.Date(2000, 12, 31, 23, 59, 59, 0, time.Local)
+
+	tests := map[string]struct {
+		mock            func(service *mocks.MockSupplierService)
+		supplierIDParam string
+		body            presenters.UpdateSupplierReq
+		wantCode        int
+		wantBody        presenters.SupplierRes
+		wantBodyErr     presenters.ErrorRes
+	}{
+		"should be success": {
+			mock: func(service *mocks.MockSupplierService) {
+				data := dtos.UpdateSupplierDto{Name: "Updated", Country: "PT"}
+				service.EXPECT().Update(gomock.Any(), int64(1), data).Return(&models.Supplier{
+					ID: 1, CreatedAt: now, Name: "Updated", Country: "PT",
+				}, nil)
+			},
+			supplierIDParam: "1",
+			body:            presenters.UpdateSupplierReq{Name: "Updated", Country: "PT"},
+			wantCode:        http.StatusOK,
+			wantBody:        presenters.SupplierRes{ID: 1, CreatedAt: "2000-12-31 23:59:59", Name: "Updated", Country: "PT"},
+		},
+		"should throw validation exception when supplierID is invalid": {
+			mock:            func(service *mocks.MockSupplierService) {},
+			supplierIDParam: "invalid",
+			wantCode:        http.StatusBadRequest,
+			wantBodyErr: presenters.ErrorRes{
+				Error:   exceptions.ValidationExceptionName,
+				Message: "invalid supplierID",
+			},
+		},
+		"should throw not found exception": {
+			mock: func(service *mocks.MockSupplierService) {
+				service.EXPECT().Update(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, exceptions.NewNotFoundException("Supplier not found"))
+			},
+			supplierIDParam: "1",
+			body:            presenters.UpdateSupplierReq{Name: "Updated", Country: "PT"},
+			wantCode:        http.StatusNotFound,
+			wantBodyErr: presenters.ErrorRes{
+				Error:   exceptions.NotFoundExceptionName,
+				Message: "Supplier not found",
+			},
+		},
+		"should throw internal server error": {
+			mock: func(service *mocks.MockSupplierService) {
+				service.EXPECT().Update(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, fmt.Errorf("error"))
+			},
+			supplierIDParam: "1",
+			wantCode:        http.StatusInternalServerError,
+			wantBodyErr:     presenters.ErrorRes{Error: http.StatusText(http.StatusInternalServerError)},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			// setup
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			serviceMock := mocks.NewMockSupplierService(ctrl)
+			tt.mock(serviceMock)
+
+			r := gin.Default()
+			controller := NewSupplier(serviceMock)
+
+			r.PUT("/api/v1/suppliers/:supplierID", controller.Update)
+
+			var got presenters.SupplierRes
+			var gotErr presenters.ErrorRes
+
+			// given
+			path := fmt.Sprintf("/api/v1/suppliers/%s", tt.supplierIDParam)
+			body, _ := json.Marshal(tt.body)
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("PUT", path, bytes.NewReader(body))
+
+			// when
+			r.ServeHTTP(w, req)
+
+			json.Unmarshal(w.Body.Bytes(), &gotErr)
+			json.Unmarshal(w.Body.Bytes(), &got)
+
+			// then
+			assert.Equal(t, tt.wantCode, w.Code)
+
+			if gotErr.Error != "" {
+				assert.Equal(t, tt.wantBodyErr, gotErr)
+				return
+			}
+			assert.Equal(t, tt.wantBody, got)
+		})
+	}
+}
+
+func TestSupplierController_Delete(t *testing.T) {
+	tests := map[string]struct {
+		mock            func(service *mocks.MockSupplierService)
+		supplierIDParam string
+		wantCode        int
+		wantBodyErr     presenters.ErrorRes
+	}{
+		"should be success": {
+			mock: func(service *mocks.MockSupplierService) {
+				service.EXPECT().Delete(gomock.Any(), int64(1)).Return(nil)
+			},
+			supplierIDParam: "1",
+			wantCode:        http.StatusOK,
+		},
+		"should throw validation exception when supplierID is invalid": {
+			mock:            func(service *mocks.MockSupplierService) {},
+			supplierIDParam: "invalid",
+			wantCode:        http.StatusBadRequest,
+			wantBodyErr: presenters.ErrorRes{
+				Error:   exceptions.ValidationExceptionName,
+				Message: "invalid supplierID",
+			},
+		},
+		"should throw internal server error": {
+			mock: func(service *mocks.MockSupplierService) {
+				service.EXPECT().Delete(gomock.Any(), gomock.Any()).Return(fmt.Errorf("error"))
+			},
+			supplierIDParam: "1",
+			wantCode:        http.StatusInternalServerError,
+			wantBodyErr:     presenters.ErrorRes{Error: http.StatusText(http.StatusInternalServerError)},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			// setup
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			serviceMock := mocks.NewMockSupplierService(ctrl)
+			tt.mock(serviceMock)
+
+			r := gin.Default()
+			controller := NewSupplier(serviceMock)
+
+			r.DELETE("/api/v1/suppliers/:supplierID", controller.Delete)
+
+			var gotErr presenters.ErrorRes
+
+			// given
+			path := fmt.Sprintf("/api/v1/suppliers/%s", tt.supplierIDParam)
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("DELETE", path, nil)
+
+			// when
+			r.ServeHTTP(w, req)
+
+			json.Unmarshal(w.Body.Bytes(), &gotErr)
+
+			// then
+			assert.Equal(t, tt.wantCode, w.Code)
+
+			if gotErr.Error != "" {
+				assert.Equal(t, tt.wantBodyErr, gotErr)
+				return
+			}
+		})
+	}
+}
+
+func TestSupplierController_Report(t *testing.T) {
+	tests := map[string]struct {
+		mock            func(service *mocks.MockSupplierService)
+		supplierIDParam string
+		wantCode        int
+		wantBody        presenters.SupplierFruitsRes
+		wantBodyErr     presenters.ErrorRes
+	}{
+		"should be success": {
+			mock: func(service *mocks.MockSupplierService) {
+				service.EXPECT().Report(gomock.Any(), int64(1)).Return(&models.SupplierFruits{
+					ID:             1,
+					Name:           "Testing",
+					ReceivedFruits: 3,
+					ReceivedPrice:  decimal.NewFromFloat32(5.97),
+					ExpiredFruits:  1,
+					ExpiredPrice:   decimal.NewFromFloat32(1.99),
+					CurrentFruits:  1,
+					CurrentPrice:   decimal.NewFromFloat32(1.99),
+				}, nil)
+			},
+			supplierIDParam: "1",
+			wantCode:        http.StatusOK,
+			wantBody: presenters.SupplierFruitsRes{
+				ID:             1,
+				Name:           "Testing",
+				ReceivedFruits: 3,
+				ReceivedPrice:  decimal.NewFromFloat32(5.97),
+				ExpiredFruits:  1,
+				ExpiredPrice:   decimal.NewFromFloat32(1.99),
+				CurrentFruits:  1,
+				CurrentPrice:   decimal.NewFromFloat32(1.99),
+			},
+		},
+		"should throw validation exception when supplierID is invalid": {
+			mock:            func(service *mocks.MockSupplierService) {},
+			supplierIDParam: "invalid",
+			wantCode:        http.StatusBadRequest,
+			wantBodyErr: presenters.ErrorRes{
+				Error:   exceptions.ValidationExceptionName,
+				Message: "invalid supplierID",
+			},
+		},
+		"should throw not found exception": {
+			mock: func(service *mocks.MockSupplierService) {
+				service.EXPECT().Report(gomock.Any(), gomock.Any()).Return(nil, exceptions.NewNotFoundException("Supplier not found"))
+			},
+			supplierIDParam: "1",
+			wantCode:        http.StatusNotFound,
+			wantBodyErr: presenters.ErrorRes{
+				Error:   exceptions.NotFoundExceptionName,
+				Message: "Supplier not found",
+			},
+		},
+		"should throw internal server error": {
+			mock: func(service *mocks.MockSupplierService) {
+				service.EXPECT().Report(gomock.Any(), gomock.Any()).Return(nil, fmt.Errorf("error"))
+			},
+			supplierIDParam: "1",
+			wantCode:        http.StatusInternalServerError,
+			wantBodyErr:     presenters.ErrorRes{Error: http.StatusText(http.StatusInternalServerError)},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			// setup
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			serviceMock := mocks.NewMockSupplierService(ctrl)
+			tt.mock(serviceMock)
+
+			r := gin.Default()
+			controller := NewSupplier(serviceMock)
+
+			r.GET("/api/v1/suppliers/:supplierID/report", controller.Report)
+
+			var got presenters.SupplierFruitsRes
+			var gotErr presenters.ErrorRes
+
+			// given
+			path := fmt.Sprintf("/api/v1/suppliers/%s/report", tt.supplierIDParam)
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("GET", path, nil)
+
+			// when
+			r.ServeHTTP(w, req)
+
+			json.Unmarshal(w.Body.Bytes(), &gotErr)
+			json.Unmarshal(w.Body.Bytes(), &got)
+
+			// then
+			assert.Equal(t, tt.wantCode, w.Code)
+
+			if gotErr.Error != "" {
+				assert.Equal(t, tt.wantBodyErr, gotErr)
+				return
+			}
+			assert.Equal(t, tt.wantBody, got)
+		})
+	}
+}
@@ -0,0 +1,13 @@
+package dtos
+
+// SubmitCycleCountDto holds either the ids of the fruits found in the bucket or
+// the quantities found by name
+type SubmitCycleCountDto struct {
+	FruitIDs []int64             `validate:"required_without=Items,omitempty,lte=1000,unique,dive,gt=0"`
+	Items    []CycleCountItemDto `validate:"required_without=FruitIDs,excluded_with=FruitIDs,omitempty,lte=100,unique=Name,dive"`
+}
+
+type CycleCountItemDto struct {
+	Name     string `validate:"required,gt=0,lte=128"`
+	Quantity int    `validate:"gte=0,lte=100000"`
+}
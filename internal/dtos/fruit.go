@@ -11,4 +11,52 @@ type CreateFruitDto struct {
 	Price     decimal.Decimal `validate:"required,dgte=0"`
 	ExpiresIn *time.Duration  `validate:"required"`
 	BucketID  *int64          `validate:"omitempty,gt=0"`
+	SKU       *string         `validate:"omitempty,sku"`
+	Barcode   *string         `validate:"omitempty,gtin"`
+
+	SupplierID    *int64     `validate:"omitempty,gt=0"`
+	OriginCountry *string    `validate:"omitempty,iso3166_1_alpha2"`
+	HarvestedAt   *time.Time `validate:"omitempty"`
+
+	// Allocation is the strategy picking the bucket of the fruit when no
+	// bucket is given
+	Allocation *string `validate:"omitempty,oneof=first_fit least_full most_full group_by_name group_by_expiry,excluded_with=BucketID"`
+}
+
+type TransferFruitDto struct {
+	ToBucketID   *int64 `validate:"required,gt=0"`
+	FromBucketID *int64 `validate:"omitempty,gt=0"`
+}
+
+type CreateFruitsBatchDto struct {
+	Mode  string           `validate:"required,oneof=all_or_nothing best_effort"`
+	Items []CreateFruitDto `validate:"required,gt=0,lte=100"`
+}
+
+// SelectFruitsDto picks fruits either by id or by a filter
+type SelectFruitsDto struct {
+	IDs    []int64          `validate:"required_without=Filter,omitempty,lte=1000,dive,gt=0"`
+	Filter *FilterFruitsDto `validate:"required_without=IDs,omitempty"`
+}
+
+type FilterFruitsDto struct {
+	BucketID   *int64  `validate:"required_without_all=SupplierID Name State,omitempty,gt=0"`
+	SupplierID *int64  `validate:"omitempty,gt=0"`
+	Name       *string `validate:"omitempty,gt=0,lte=128"`
+	State      *string `validate:"omitempty,oneof=unripe ripe overripe expired disposed sold"`
+}
+
+type MoveFruitsDto struct {
+	SelectFruitsDto
+	ToBucketID *int64 `validate:"required,gt=0"`
+}
+
+// RecallFruitsDto picks the fruits to quarantine by supplier, lot and the window
+// they were created in, every given criterion narrowing the recall. A lot is
+// the fruits received on a purchase order line
+type RecallFruitsDto struct {
+	SupplierID  *int64     `validate:"required_without_all=LotID CreatedFrom,omitempty,gt=0"`
+	LotID       *int64     `validate:"omitempty,gt=0"`
+	CreatedFrom *time.Time `validate:"required_with=CreatedTo"`
+	CreatedTo   *time.Time `validate:"required_with=CreatedFrom,omitempty,gtfield=CreatedFrom"`
 }
@@ -0,0 +1,18 @@
+package dtos
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+type ChangeFruitPriceDto struct {
+	Price  decimal.Decimal `validate:"required,dgte=0"`
+	Reason string          `validate:"required,gt=0,lte=128"`
+}
+
+type FruitPriceStatsDto struct {
+	Name *string   `validate:"omitempty,gt=0,lte=128"`
+	From time.Time `validate:"required"`
+	To   time.Time `validate:"required,gtfield=From"`
+}
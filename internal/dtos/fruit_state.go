@@ -0,0 +1,5 @@
+package dtos
+
+type ChangeFruitStateDto struct {
+	State string `validate:"required,oneof=unripe ripe overripe expired disposed"`
+}
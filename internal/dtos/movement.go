@@ -0,0 +1,14 @@
+package dtos
+
+import "time"
+
+// ListMovementsDto filters the ledger. BucketID matches movements from or to
+// the bucket, and the period includes From but not To
+type ListMovementsDto struct {
+	FruitID  *int64  `validate:"omitempty,gt=0"`
+	BucketID *int64  `validate:"omitempty,gt=0"`
+	Type     *string `validate:"omitempty,oneof=created moved unassigned deleted picked"`
+	Actor    *string `validate:"omitempty,gt=0,lte=128"`
+	From     *time.Time
+	To       *time.Time
+}
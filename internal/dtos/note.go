@@ -0,0 +1,8 @@
+package dtos
+
+type CreateNoteDto struct {
+	OwnerType string `validate:"required,oneof=fruit bucket"`
+	OwnerID   int64  `validate:"required,gt=0"`
+	Author    string `validate:"required,gt=0,lte=128"`
+	Text      string `validate:"required,gt=0,lte=1024"`
+}
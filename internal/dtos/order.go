@@ -0,0 +1,17 @@
+package dtos
+
+import "time"
+
+type CreateOrderDto struct {
+	Items []CreateOrderItemDto `validate:"required,gt=0,lte=100,unique=FruitID,dive"`
+}
+
+type CreateOrderItemDto struct {
+	FruitID int64 `validate:"required,gt=0"`
+}
+
+type SalesDto struct {
+	Name *string `validate:"omitempty,gt=0,lte=128"`
+	From time.Time
+	To   time.Time `validate:"required,gtfield=From"`
+}
@@ -0,0 +1,11 @@
+package dtos
+
+import "io"
+
+type UploadPhotoDto struct {
+	OwnerType string    `validate:"required,oneof=fruit bucket"`
+	OwnerID   int64     `validate:"required,gt=0"`
+	Filename  string    `validate:"required,lte=255"`
+	Size      int64     `validate:"required,gt=0"`
+	Content   io.Reader `validate:"required"`
+}
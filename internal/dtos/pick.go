@@ -0,0 +1,6 @@
+package dtos
+
+type CreatePickDto struct {
+	Name     string `validate:"required,gt=0,lte=128"`
+	Quantity int    `validate:"required,gt=0,lte=1000"`
+}
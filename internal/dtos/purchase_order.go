@@ -0,0 +1,31 @@
+package dtos
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+type CreatePurchaseOrderDto struct {
+	SupplierID int64                        `validate:"required,gt=0"`
+	Lines      []CreatePurchaseOrderLineDto `validate:"required,gt=0,lte=100,dive"`
+}
+
+type CreatePurchaseOrderLineDto struct {
+	Name     string          `validate:"required,gt=0,lte=128"`
+	Price    decimal.Decimal `validate:"required,dgte=0"`
+	Quantity int             `validate:"required,gt=0,lte=1000"`
+}
+
+// ReceivePurchaseOrderDto holds what arrived for each line. Lines left out were
+// not delivered at all
+type ReceivePurchaseOrderDto struct {
+	Lines []ReceivePurchaseOrderLineDto `validate:"required,gt=0,lte=100,unique=LineID,dive"`
+}
+
+type ReceivePurchaseOrderLineDto struct {
+	LineID    int64          `validate:"required,gt=0"`
+	Quantity  int            `validate:"gte=0,lte=1000"`
+	ExpiresIn *time.Duration `validate:"required_unless=Quantity 0,omitempty,gt=0"`
+	BucketID  *int64         `validate:"omitempty,gt=0"`
+}
@@ -0,0 +1,13 @@
+package dtos
+
+import "time"
+
+// CreateReservationDto holds either specific fruits or a quantity of fruits by name
+type CreateReservationDto struct {
+	Holder  string        `validate:"required,gt=0,lte=128"`
+	HoldFor time.Duration `validate:"required,gt=0s,lte=24h"`
+
+	FruitIDs []int64 `validate:"required_without=Name,omitempty,lte=100,unique,dive,gt=0"`
+	Name     *string `validate:"required_without=FruitIDs,excluded_with=FruitIDs,omitempty,gt=0,lte=128"`
+	Quantity int     `validate:"required_with=Name,excluded_with=FruitIDs,omitempty,gt=0,lte=100"`
+}
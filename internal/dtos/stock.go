@@ -0,0 +1,17 @@
+package dtos
+
+import "time"
+
+type SetReorderPointDto struct {
+	Name        string `validate:"required,gt=0,lte=128"`
+	MinQuantity int    `validate:"gte=0,lte=100000"`
+}
+
+type WasteDto struct {
+	From time.Time
+	To   time.Time `validate:"required,gtfield=From"`
+}
+
+type ValuationDto struct {
+	At time.Time `validate:"required"`
+}
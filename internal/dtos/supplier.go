@@ -0,0 +1,13 @@
+package dtos
+
+type CreateSupplierDto struct {
+	Name    string  `validate:"required,gt=0,lte=128"`
+	Country string  `validate:"required,iso3166_1_alpha2"`
+	Contact *string `validate:"omitempty,lte=256"`
+}
+
+type UpdateSupplierDto struct {
+	Name    string  `validate:"required,gt=0,lte=128"`
+	Country string  `validate:"required,iso3166_1_alpha2"`
+	Contact *string `validate:"omitempty,lte=256"`
+}
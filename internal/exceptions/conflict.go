@@ -0,0 +1,19 @@
+package exceptions
+
+const ConflictExceptionName = "conflict"
+
+type ConflictException struct {
+	Name    string
+	Message string
+}
+
+func NewConflictException(msg string) *ConflictException {
+	return &ConflictException{
+		Name:    ConflictExceptionName,
+		Message: msg,
+	}
+}
+
+func (impl *ConflictException) Error() string {
+	return impl.Message
+}
@@ -0,0 +1,17 @@
+package exceptions
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConflictException(t *testing.T) {
+	t.Run("should be an error", func(t *testing.T) {
+		got := NewConflictException("error")
+
+		assert.Equal(t, ConflictExceptionName, got.Name)
+		assert.Equal(t, "error", got.Error())
+	})
+
+}
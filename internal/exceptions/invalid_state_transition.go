@@ -0,0 +1,19 @@
+package exceptions
+
+const InvalidStateTransitionExceptionName = "invalid_state_transition"
+
+type InvalidStateTransitionException struct {
+	Name    string
+	Message string
+}
+
+func NewInvalidStateTransitionException(msg string) *InvalidStateTransitionException {
+	return &InvalidStateTransitionException{
+		Name:    InvalidStateTransitionExceptionName,
+		Message: msg,
+	}
+}
+
+func (impl *InvalidStateTransitionException) Error() string {
+	return impl.Message
+}
@@ -0,0 +1,17 @@
+package exceptions
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInvalidStateTransitionException(t *testing.T) {
+	t.Run("should be an error", func(t *testing.T) {
+		got := NewInvalidStateTransitionException("error")
+
+		assert.Equal(t, InvalidStateTransitionExceptionName, got.Name)
+		assert.Equal(t, "error", got.Error())
+	})
+
+}
@@ -1,31 +1,132 @@
 package factories
 
 import (
+	"fmt"
+
 	"github.com/go-playground/validator/v10"
+	"github.com/shopspring/decimal"
 	"github.com/viniosilva/where-are-my-fruits/internal/controllers"
 	"github.com/viniosilva/where-are-my-fruits/internal/infra"
+	"github.com/viniosilva/where-are-my-fruits/internal/models"
 	"github.com/viniosilva/where-are-my-fruits/internal/services"
+	"github.com/viniosilva/where-are-my-fruits/internal/workers"
 	"go.uber.org/zap"
 )
 
 type Factory struct {
-	HealthController *controllers.HealthController
-	BucketController *controllers.BucketController
-	FruitController  *controllers.FruitController
+	HealthController        *controllers.HealthController
+	BucketController        *controllers.BucketController
+	FruitController         *controllers.FruitController
+	FruitStateController    *controllers.FruitStateController
+	FruitPriceController    *controllers.FruitPriceController
+	PhotoController         *controllers.PhotoController
+	NoteController          *controllers.NoteController
+	PickController          *controllers.PickController
+	OrderController         *controllers.OrderController
+	ReservationController   *controllers.ReservationController
+	PurchaseOrderController *controllers.PurchaseOrderController
+	CycleCountController    *controllers.CycleCountController
+	StockController         *controllers.StockController
+	MovementController      *controllers.MovementController
+	SupplierController      *controllers.SupplierController
+
+	RipenessWorker *workers.Worker
+	SweeperWorker  *workers.Worker
+	ReleaserWorker *workers.Worker
 }
 
-func Build(db *infra.Database, logger *zap.SugaredLogger, validate *validator.Validate) (Factory, error) {
+func Build(db *infra.Database, logger *zap.SugaredLogger, validate *validator.Validate, config *infra.Config) (Factory, error) {
+	markdowns := models.MarkdownRules{}
+	for _, markdown := range config.Pricing.Markdowns {
+		if markdown.Within <= 0 {
+			return Factory{}, fmt.Errorf("markdown within %v must be positive", markdown.Within)
+		}
+		if markdown.Percent < 0 || markdown.Percent > 100 {
+			return Factory{}, fmt.Errorf("markdown percent %v must be between 0 and 100", markdown.Percent)
+		}
+		markdowns = append(markdowns, models.MarkdownRule{
+			Within:  markdown.Within,
+			Percent: decimal.NewFromFloat(markdown.Percent),
+		})
+	}
+
+	compatibility := models.CompatibilityRules{}
+	for _, pair := range config.Compatibility.Incompatible {
+		if len(pair) != 2 {
+			return Factory{}, fmt.Errorf("compatibility rule %v must have two sides", pair)
+		}
+		compatibility = append(compatibility, models.CompatibilityRule{
+			A: compatibilityGroup(config.Compatibility, pair[0]),
+			B: compatibilityGroup(config.Compatibility, pair[1]),
+		})
+	}
+
 	healthService := services.NewHealth(db, logger)
-	bucketService := services.NewBucket(db, logger, validate)
-	fruitService := services.NewFruit(db, logger, validate)
+	bucketService := services.NewBucket(db, logger, validate, markdowns, compatibility)
+	fruitService := services.NewFruit(db, logger, validate, markdowns, compatibility)
+	fruitStateService := services.NewFruitState(db, logger, validate)
+	fruitPriceService := services.NewFruitPrice(db, logger, validate)
+	photoService := services.NewPhoto(db, logger, validate, infra.NewLocalBlobStore(config.Storage.Path),
+		config.Photos.MaxSize, config.Photos.ThumbnailSize)
+	noteService := services.NewNote(db, logger, validate)
+	pickService := services.NewPick(db, logger, validate)
+	orderService := services.NewOrder(db, logger, validate)
+	reservationService := services.NewReservation(db, logger, validate)
+	purchaseOrderService := services.NewPurchaseOrder(db, logger, validate, compatibility)
+	cycleCountService := services.NewCycleCount(db, logger, validate)
+	stockService := services.NewStock(db, logger, validate)
+	movementService := services.NewMovement(db, logger, validate)
+	supplierService := services.NewSupplier(db, logger, validate)
 
 	healthController := controllers.NewHealth(healthService)
 	bucketController := controllers.NewBucket(bucketService)
 	fruitController := controllers.NewFruit(fruitService)
+	fruitStateController := controllers.NewFruitState(fruitStateService)
+	fruitPriceController := controllers.NewFruitPrice(fruitPriceService)
+	photoController := controllers.NewPhoto(photoService)
+	noteController := controllers.NewNote(noteService)
+	pickController := controllers.NewPick(pickService)
+	orderController := controllers.NewOrder(orderService)
+	reservationController := controllers.NewReservation(reservationService)
+	purchaseOrderController := controllers.NewPurchaseOrder(purchaseOrderService)
+	cycleCountController := controllers.NewCycleCount(cycleCountService)
+	stockController := controllers.NewStock(stockService)
+	movementController := controllers.NewMovement(movementService)
+	supplierController := controllers.NewSupplier(supplierService)
+
+	ripenessWorker := workers.NewRipeness(fruitStateService, logger, config.Workers.Ripeness.Interval)
+	sweeperWorker := workers.NewSweeper(fruitStateService, logger, config.Workers.Sweeper.Interval,
+		config.Workers.Sweeper.Unassign, config.Workers.Sweeper.Dispose)
+	releaserWorker := workers.NewReleaser(reservationService, logger, config.Workers.Reservations.Interval)
 
 	return Factory{
-		HealthController: healthController,
-		BucketController: bucketController,
-		FruitController:  fruitController,
+		HealthController:        healthController,
+		BucketController:        bucketController,
+		FruitController:         fruitController,
+		FruitStateController:    fruitStateController,
+		FruitPriceController:    fruitPriceController,
+		PhotoController:         photoController,
+		NoteController:          noteController,
+		PickController:          pickController,
+		OrderController:         orderController,
+		ReservationController:   reservationController,
+		PurchaseOrderController: purchaseOrderController,
+		CycleCountController:    cycleCountController,
+		StockController:         stockController,
+		MovementController:      movementController,
+		SupplierController:      supplierController,
+
+		RipenessWorker: ripenessWorker,
+		SweeperWorker:  sweeperWorker,
+		ReleaserWorker: releaserWorker,
 	}, nil
 }
+
+// compatibilityGroup resolves a side of a compatibility rule to the fruits of
+// the category with that name, or to the single fruit otherwise
+func compatibilityGroup(config infra.ConfigCompatibility, name string) models.CompatibilityGroup {
+	if fruits, ok := config.Categories[name]; ok {
+		return models.CompatibilityGroup{Name: name, Fruits: fruits}
+	}
+	return models.CompatibilityGroup{Name: name, Fruits: []string{name}}
+}
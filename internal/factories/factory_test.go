@@ -2,6 +2,7 @@ package factories
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -11,10 +12,46 @@ import (
 func TestFactory_Build(t *testing.T) {
 	t.Run("should be successful", func(t *testing.T) {
 		// when
-		got, err := Build(&infra.Database{}, nil, nil)
+		got, err := Build(&infra.Database{}, nil, nil, &infra.Config{})
 		require.Nil(t, err)
 
 		// then
 		assert.NotNil(t, got)
 	})
+	t.Run("should throw error when a markdown within is not positive", func(t *testing.T) {
+		// given
+		config := &infra.Config{Pricing: infra.ConfigPricing{
+			Markdowns: []infra.ConfigMarkdown{{Within: 0, Percent: 50}},
+		}}
+
+		// when
+		_, err := Build(&infra.Database{}, nil, nil, config)
+
+		// then
+		assert.EqualError(t, err, "markdown within 0s must be positive")
+	})
+	t.Run("should throw error when a markdown percent is out of range", func(t *testing.T) {
+		// given
+		config := &infra.Config{Pricing: infra.ConfigPricing{
+			Markdowns: []infra.ConfigMarkdown{{Within: time.Hour, Percent: 150}},
+		}}
+
+		// when
+		_, err := Build(&infra.Database{}, nil, nil, config)
+
+		// then
+		assert.EqualError(t, err, "markdown percent 150 must be between 0 and 100")
+	})
+	t.Run("should throw error when a compatibility rule is not a pair", func(t *testing.T) {
+		// given
+		config := &infra.Config{Compatibility: infra.ConfigCompatibility{
+			Incompatible: [][]string{{"apple"}},
+		}}
+
+		// when
+		_, err := Build(&infra.Database{}, nil, nil, config)
+
+		// then
+		assert.EqualError(t, err, "compatibility rule [apple] must have two sides")
+	})
 }
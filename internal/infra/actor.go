@@ -0,0 +1,15 @@
+package infra
+
+import "context"
+
+// ActorKey is the context key holding who is making the request
+const ActorKey = "actor"
+
+// Actor returns who is acting in the given context, if anyone said so
+func Actor(ctx context.Context) *string {
+	if actor, ok := ctx.Value(ActorKey).(string); ok && actor != "" {
+		return &actor
+	}
+
+	return nil
+}
@@ -0,0 +1,69 @@
+package infra
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+var ErrBlobNotFound = errors.New("blob not found")
+
+// LocalBlobStore keeps blobs as files under a root directory of the local filesystem
+type LocalBlobStore struct {
+	root string
+}
+
+func NewLocalBlobStore(root string) *LocalBlobStore {
+	return &LocalBlobStore{
+		root: root,
+	}
+}
+
+func (impl *LocalBlobStore) Put(ctx context.Context, key string, content io.Reader) error {
+	path := impl.path(key)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	// Write to a temporary file first so readers never see a partial blob
+	file, err := os.CreateTemp(filepath.Dir(path), ".blob-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(file.Name())
+
+	if _, err := io.Copy(file, content); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(file.Name(), path)
+}
+
+func (impl *LocalBlobStore) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	file, err := os.Open(impl.path(key))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrBlobNotFound
+	}
+
+	return file, err
+}
+
+func (impl *LocalBlobStore) Delete(ctx context.Context, key string) error {
+	err := os.Remove(impl.path(key))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+
+	return err
+}
+
+// path keeps every key inside the root directory, whatever it contains
+func (impl *LocalBlobStore) path(key string) string {
+	return filepath.Join(impl.root, filepath.Clean("/"+key))
+}
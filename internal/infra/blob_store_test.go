@@ -0,0 +1,56 @@
+package infra
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocalBlobStore(t *testing.T) {
+	t.Run("should put, get and delete a blob", func(t *testing.T) {
+		// setup
+		ctx := context.Background()
+		root := t.TempDir()
+		store := NewLocalBlobStore(root)
+
+		// when
+		err := store.Put(ctx, "photos/1.jpg", strings.NewReader("content"))
+		require.Nil(t, err)
+
+		reader, err := store.Get(ctx, "photos/1.jpg")
+		require.Nil(t, err)
+		got, _ := io.ReadAll(reader)
+		reader.Close()
+
+		// then
+		assert.Equal(t, "content", string(got))
+		assert.FileExists(t, filepath.Join(root, "photos", "1.jpg"))
+
+		require.Nil(t, store.Delete(ctx, "photos/1.jpg"))
+		_, err = store.Get(ctx, "photos/1.jpg")
+		assert.Equal(t, ErrBlobNotFound, err)
+		assert.Nil(t, store.Delete(ctx, "photos/1.jpg"))
+	})
+
+	t.Run("should keep keys inside the root directory", func(t *testing.T) {
+		// setup
+		ctx := context.Background()
+		root := t.TempDir()
+		store := NewLocalBlobStore(filepath.Join(root, "blobs"))
+
+		// when
+		err := store.Put(ctx, "../../escaped", strings.NewReader("content"))
+		require.Nil(t, err)
+
+		// then
+		assert.FileExists(t, filepath.Join(root, "blobs", "escaped"))
+		_, err = os.Stat(filepath.Join(root, "escaped"))
+		assert.True(t, os.IsNotExist(err))
+	})
+}
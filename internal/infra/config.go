@@ -8,8 +8,14 @@ import (
 )
 
 type Config struct {
-	Api   ConfigApi   `mapstructure:"api"`
-	MySQL ConfigMySQL `mapstructure:"mysql"`
+	Api     ConfigApi     `mapstructure:"api"`
+	MySQL   ConfigMySQL   `mapstructure:"mysql"`
+	Workers ConfigWorkers `mapstructure:"workers"`
+	Pricing ConfigPricing `mapstructure:"pricing"`
+	Storage ConfigStorage `mapstructure:"storage"`
+	Photos  ConfigPhotos  `mapstructure:"photos"`
+
+	Compatibility ConfigCompatibility `mapstructure:"compatibility"`
 }
 
 type ConfigApi struct {
@@ -28,6 +34,47 @@ type ConfigMySQL struct {
 	MaxIdleConns    int           `mapstructure:"maxIdleConns"`
 }
 
+type ConfigWorkers struct {
+	Ripeness     ConfigWorker        `mapstructure:"ripeness"`
+	Sweeper      ConfigSweeperWorker `mapstructure:"sweeper"`
+	Reservations ConfigWorker        `mapstructure:"reservations"`
+}
+
+type ConfigWorker struct {
+	Interval time.Duration `mapstructure:"interval"`
+}
+
+type ConfigSweeperWorker struct {
+	Interval time.Duration `mapstructure:"interval"`
+	Unassign bool          `mapstructure:"unassign"`
+	Dispose  bool          `mapstructure:"dispose"`
+}
+
+type ConfigPricing struct {
+	Markdowns []ConfigMarkdown `mapstructure:"markdowns"`
+}
+
+type ConfigMarkdown struct {
+	Within  time.Duration `mapstructure:"within"`
+	Percent float64       `mapstructure:"percent"`
+}
+
+type ConfigStorage struct {
+	Path string `mapstructure:"path"`
+}
+
+type ConfigPhotos struct {
+	MaxSize       int64 `mapstructure:"maxSize"`
+	ThumbnailSize int   `mapstructure:"thumbnailSize"`
+}
+
+// ConfigCompatibility lists the pairs of fruits that must not share a bucket.
+// Each side of a pair is a category name or a single fruit name
+type ConfigCompatibility struct {
+	Categories   map[string][]string `mapstructure:"categories"`
+	Incompatible [][]string          `mapstructure:"incompatible"`
+}
+
 func GetConfig(path string) (*Config, error) {
 	viper.AddConfigPath(".")
 
@@ -2,9 +2,11 @@ package infra
 
 import (
 	"database/sql"
+	"errors"
 	"fmt"
 	"time"
 
+	driver "github.com/go-sql-driver/mysql"
 	"gorm.io/driver/mysql"
 	"gorm.io/gorm"
 )
@@ -15,6 +17,7 @@ type Database struct {
 }
 
 const (
+	MYSQL_ERROR_DUPLICATE_ENTRY    uint16 = 1062
 	MYSQL_ERROR_FOREIGN_NOT_EXISTS uint16 = 1452
 	MYSQL_ERROR_NOT_FOUND                 = "record not found"
 )
@@ -46,5 +49,10 @@ func ConfigDB(username, password, host, port, dbname string,
 	return &Database{DB: db, SQL: sql}, nil
 }
 
+func IsMySQLError(err error, number uint16) bool {
+	var mysqlErr *driver.MySQLError
+	return errors.As(err, &mysqlErr) && mysqlErr.Number == number
+}
+
 // Refers: https://gorm.io/docs/connecting_to_the_database.html#MySQL
 // 		   https://gorm.io/docs/generic_interface.html#Connection-Pool
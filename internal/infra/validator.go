@@ -1,14 +1,20 @@
 package infra
 
 import (
+	"regexp"
+
 	"github.com/go-playground/validator/v10"
 	"github.com/shopspring/decimal"
 )
 
+var skuRegex = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_-]{0,63}$`)
+
 func NewValidator() *validator.Validate {
 	validate := validator.New()
 
 	validate.RegisterValidation("dgte", ValidateDecimalGreaterThanOrEqual)
+	validate.RegisterValidation("sku", ValidateSKU)
+	validate.RegisterValidation("gtin", ValidateGTIN)
 
 	return validate
 }
@@ -26,3 +32,41 @@ func ValidateDecimalGreaterThanOrEqual(fl validator.FieldLevel) bool {
 
 	return value.GreaterThanOrEqual(baseValue)
 }
+
+func ValidateSKU(fl validator.FieldLevel) bool {
+	return skuRegex.MatchString(fl.Field().String())
+}
+
+// ValidateGTIN accepts GTIN-8, GTIN-12 (UPC-A), GTIN-13 (EAN-13) and GTIN-14 codes
+// whose last digit matches the GS1 check digit
+func ValidateGTIN(fl validator.FieldLevel) bool {
+	code := fl.Field().String()
+
+	switch len(code) {
+	case 8, 12, 13, 14:
+	default:
+		return false
+	}
+
+	sum := 0
+	for i := len(code) - 1; i >= 0; i-- {
+		digit := int(code[i] - '0')
+		if digit < 0 || digit > 9 {
+			return false
+		}
+		if i == len(code)-1 {
+			continue
+		}
+
+		weight := 1
+		if (len(code)-1-i)%2 == 1 {
+			weight = 3
+		}
+		sum += digit * weight
+	}
+
+	checkDigit := (10 - sum%10) % 10
+	return checkDigit == int(code[len(code)-1]-'0')
+}
+
+// Refers: https://www.gs1.org/services/how-calculate-check-digit-manually
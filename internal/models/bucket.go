@@ -11,6 +11,9 @@ type Bucket struct {
 
 	Name     string `gorm:"column:name"`
 	Capacity int    `gorm:"column:capacity"`
+
+	// Quarantine buckets hold only quarantined fruits
+	Quarantine bool `gorm:"column:quarantine"`
 }
 
 func (Bucket) TableName() string {
@@ -0,0 +1,39 @@
+package models
+
+const (
+	BucketAllocationFirstFit        = "first_fit"
+	BucketAllocationLeastFull       = "least_full"
+	BucketAllocationMostFull        = "most_full"
+	BucketAllocationGroupByName     = "group_by_name"
+	BucketAllocationGroupByExpiry   = "group_by_expiry"
+	BucketAllocationDefaultStrategy = BucketAllocationFirstFit
+)
+
+// BucketOccupancy is how much of a bucket capacity its valid fruits take.
+// TotalPinned counts the reserved or quarantined ones, which consolidation
+// does not move. When allocating a new fruit it is also described against it:
+// ExpiryDistance is the average number of seconds between the expiry of the
+// fruits in the bucket and the new one's, nil when the bucket is empty
+type BucketOccupancy struct {
+	ID             int64
+	Capacity       int
+	TotalFruits    int64
+	TotalPinned    int64
+	SameNameFruits int64
+	ExpiryDistance *float64
+}
+
+// Free is the capacity left in the bucket, never negative
+func (impl BucketOccupancy) Free() int64 {
+	if free := int64(impl.Capacity) - impl.TotalFruits; free > 0 {
+		return free
+	}
+	return 0
+}
+
+// FullerThan tells whether the bucket has a larger share of its capacity in use
+func (impl BucketOccupancy) FullerThan(other BucketOccupancy) bool {
+	return impl.TotalFruits*int64(other.Capacity) > other.TotalFruits*int64(impl.Capacity)
+}
+
+// Refers: https://martinfowler.com/bliki/DDD_Aggregate.html
@@ -0,0 +1,18 @@
+package models
+
+// BucketConsolidation is a plan moving the valid fruits of some buckets into the
+// free capacity of others so that the former end up empty. Applied tells
+// whether the moves were made or only proposed
+type BucketConsolidation struct {
+	Moves            []BucketConsolidationMove
+	EmptiedBucketIDs []int64
+	Applied          bool
+}
+
+type BucketConsolidationMove struct {
+	FruitID      int64
+	FromBucketID int64
+	ToBucketID   int64
+}
+
+// Refers: https://martinfowler.com/bliki/DDD_Aggregate.html
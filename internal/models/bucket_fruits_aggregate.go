@@ -9,6 +9,13 @@ type BucketFruits struct {
 	TotalFruits int64
 	TotalPrice  decimal.Decimal
 	Percent     decimal.Decimal
+
+	TotalEffectivePrice decimal.Decimal
+	TotalReserved       int64
+
+	TotalUnripe   int64
+	TotalRipe     int64
+	TotalOverripe int64
 }
 
 // Refers: https://martinfowler.com/bliki/DDD_Aggregate.html
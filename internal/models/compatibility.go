@@ -0,0 +1,111 @@
+package models
+
+import "strings"
+
+// CompatibilityGroup is the set of fruit names one side of a storage rule
+// refers to, either a configured category or a single name
+type CompatibilityGroup struct {
+	Name   string
+	Fruits []string
+}
+
+// Has tells whether the fruit name belongs to the group, ignoring case as the
+// database collation does
+func (impl CompatibilityGroup) Has(name string) bool {
+	for _, fruit := range impl.Fruits {
+		if strings.EqualFold(fruit, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// CompatibilityRule forbids fruits of one group from sharing a bucket with
+// fruits of the other, like ethylene producers and ethylene sensitive fruits
+type CompatibilityRule struct {
+	A CompatibilityGroup
+	B CompatibilityGroup
+}
+
+func (impl CompatibilityRule) String() string {
+	return impl.A.Name + "/" + impl.B.Name
+}
+
+// Forbids tells whether the rule keeps the two fruit names apart. Fruits of
+// the same name never conflict
+func (impl CompatibilityRule) Forbids(name, other string) bool {
+	if strings.EqualFold(name, other) {
+		return false
+	}
+	return (impl.A.Has(name) && impl.B.Has(other)) || (impl.A.Has(other) && impl.B.Has(name))
+}
+
+type CompatibilityRules []CompatibilityRule
+
+// Violation returns the rule keeping the two fruit names apart, nil when they
+// may share a bucket
+func (impl CompatibilityRules) Violation(name, other string) *CompatibilityRule {
+	for _, rule := range impl {
+		if rule.Forbids(name, other) {
+			return &rule
+		}
+	}
+	return nil
+}
+
+// IncompatibleWith returns the fruit names that must not share a bucket with
+// the given one
+func (impl CompatibilityRules) IncompatibleWith(name string) []string {
+	names := []string{}
+	seen := map[string]bool{strings.ToLower(name): true}
+	add := func(fruits []string) {
+		for _, fruit := range fruits {
+			if key := strings.ToLower(fruit); !seen[key] {
+				seen[key] = true
+				names = append(names, fruit)
+			}
+		}
+	}
+
+	for _, rule := range impl {
+		if rule.A.Has(name) {
+			add(rule.B.Fruits)
+		}
+		if rule.B.Has(name) {
+			add(rule.A.Fruits)
+		}
+	}
+
+	return names
+}
+
+// Violations returns every pair among the fruit names that breaks a rule
+func (impl CompatibilityRules) Violations(names []string) []CompatibilityViolation {
+	violations := []CompatibilityViolation{}
+	for i, name := range names {
+		for _, other := range names[i+1:] {
+			if rule := impl.Violation(name, other); rule != nil {
+				violations = append(violations, CompatibilityViolation{
+					Rule:  rule.String(),
+					Fruit: name,
+					Other: other,
+				})
+			}
+		}
+	}
+
+	return violations
+}
+
+type CompatibilityViolation struct {
+	Rule  string
+	Fruit string
+	Other string
+}
+
+// BucketViolations is a bucket holding fruits that must not share it
+type BucketViolations struct {
+	ID         int64
+	Name       string
+	Violations []CompatibilityViolation
+}
@@ -0,0 +1,46 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompatibilityRules(t *testing.T) {
+	rules := CompatibilityRules{
+		{
+			A: CompatibilityGroup{Name: "ethylene_producers", Fruits: []string{"apple", "banana"}},
+			B: CompatibilityGroup{Name: "ethylene_sensitive", Fruits: []string{"kiwi", "banana"}},
+		},
+		{
+			A: CompatibilityGroup{Name: "melon", Fruits: []string{"melon"}},
+			B: CompatibilityGroup{Name: "kiwi", Fruits: []string{"kiwi"}},
+		},
+	}
+
+	t.Run("should find the rule keeping two names apart ignoring case", func(t *testing.T) {
+		got := rules.Violation("Kiwi", "apple")
+
+		assert.Equal(t, "ethylene_producers/ethylene_sensitive", got.String())
+	})
+
+	t.Run("should let compatible names share a bucket", func(t *testing.T) {
+		assert.Nil(t, rules.Violation("apple", "melon"))
+		assert.Nil(t, rules.Violation("banana", "Banana"))
+	})
+
+	t.Run("should list the names incompatible with a name", func(t *testing.T) {
+		assert.Equal(t, []string{"kiwi", "apple"}, rules.IncompatibleWith("banana"))
+		assert.Equal(t, []string{"apple", "banana", "melon"}, rules.IncompatibleWith("kiwi"))
+		assert.Equal(t, []string{}, rules.IncompatibleWith("grape"))
+	})
+
+	t.Run("should list every pair breaking a rule", func(t *testing.T) {
+		got := rules.Violations([]string{"apple", "grape", "kiwi", "melon"})
+
+		assert.Equal(t, []CompatibilityViolation{
+			{Rule: "ethylene_producers/ethylene_sensitive", Fruit: "apple", Other: "kiwi"},
+			{Rule: "melon/kiwi", Fruit: "kiwi", Other: "melon"},
+		}, got)
+	})
+}
@@ -0,0 +1,80 @@
+package models
+
+import "time"
+
+const (
+	CycleCountStatusOpen      = "open"
+	CycleCountStatusSubmitted = "submitted"
+	CycleCountStatusApproved  = "approved"
+)
+
+const (
+	CycleCountActionUnassigned = "unassigned"
+	CycleCountActionFlagged    = "flagged"
+)
+
+// CycleCount is a physical count of a bucket. It is opened, submitted with
+// what was found and, once approved, the database is adjusted to match it
+type CycleCount struct {
+	ID          int64      `gorm:"column:id"`
+	CreatedAt   time.Time  `gorm:"column:created_at"`
+	SubmittedAt *time.Time `gorm:"column:submitted_at"`
+	ApprovedAt  *time.Time `gorm:"column:approved_at"`
+
+	BucketID    int64                  `gorm:"column:bucket_fk"`
+	Items       []CycleCountItem       `gorm:"foreignKey:CycleCountID"`
+	Adjustments []CycleCountAdjustment `gorm:"foreignKey:CycleCountID"`
+
+	Variance *CycleCountVariance `gorm:"-"`
+}
+
+func (CycleCount) TableName() string {
+	return "cycle_counts"
+}
+
+func (impl CycleCount) Status() string {
+	if impl.ApprovedAt != nil {
+		return CycleCountStatusApproved
+	}
+	if impl.SubmittedAt != nil {
+		return CycleCountStatusSubmitted
+	}
+
+	return CycleCountStatusOpen
+}
+
+// CycleCountItem is what was found in the bucket, either a single fruit by id
+// or a quantity of fruits by name
+type CycleCountItem struct {
+	ID           int64  `gorm:"column:id"`
+	CycleCountID int64  `gorm:"column:cycle_count_fk"`
+	FruitID      *int64 `gorm:"column:fruit_fk"`
+
+	Name     string `gorm:"column:name"`
+	Quantity int    `gorm:"column:quantity"`
+}
+
+func (CycleCountItem) TableName() string {
+	return "cycle_count_items"
+}
+
+// CycleCountAdjustment records what the approval of a count changed, or
+// flagged for someone to look at
+type CycleCountAdjustment struct {
+	ID           int64     `gorm:"column:id"`
+	CreatedAt    time.Time `gorm:"column:created_at"`
+	CycleCountID int64     `gorm:"column:cycle_count_fk"`
+	FruitID      *int64    `gorm:"column:fruit_fk"`
+
+	Name     string `gorm:"column:name"`
+	Quantity int    `gorm:"column:quantity"`
+	Action   string `gorm:"column:action"`
+}
+
+func (CycleCountAdjustment) TableName() string {
+	return "cycle_count_adjustments"
+}
+
+// Refers: https://gorm.io/docs/conventions.html#Pluralized-Table-Name
+//		   https://gorm.io/docs/conventions.html#Column-Name
+//		   https://gorm.io/docs/has_many.html
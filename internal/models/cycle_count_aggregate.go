@@ -0,0 +1,24 @@
+package models
+
+// CycleCountVariance compares what was counted in a bucket against what the
+// database says is in it. Fruit ids are only compared when the count was
+// submitted by fruit id
+type CycleCountVariance struct {
+	Names           []CycleCountNameVariance
+	MissingFruitIDs []int64
+	UnknownFruitIDs []int64
+}
+
+type CycleCountNameVariance struct {
+	Name     string
+	Expected int64
+	Counted  int64
+}
+
+// Difference is how many more (positive) or fewer (negative) fruits were
+// counted than expected
+func (impl CycleCountNameVariance) Difference() int64 {
+	return impl.Counted - impl.Expected
+}
+
+// Refers: https://martinfowler.com/bliki/DDD_Aggregate.html
@@ -0,0 +1,21 @@
+package models
+
+import "github.com/shopspring/decimal"
+
+type ExpiringFruits struct {
+	Buckets    []ExpiringBucketFruits
+	TotalPrice decimal.Decimal
+
+	TotalEffectivePrice decimal.Decimal
+}
+
+type ExpiringBucketFruits struct {
+	BucketID   *int64
+	BucketName string
+	Fruits     []Fruit
+	TotalPrice decimal.Decimal
+
+	TotalEffectivePrice decimal.Decimal
+}
+
+// Refers: https://martinfowler.com/bliki/DDD_Aggregate.html
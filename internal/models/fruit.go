@@ -14,15 +14,42 @@ type Fruit struct {
 	Name      string          `gorm:"column:name"`
 	Price     decimal.Decimal `gorm:"column:price"`
 	ExpiresAt time.Time       `gorm:"column:expires_at"`
+	State     FruitState      `gorm:"column:state"`
+	SKU       *string         `gorm:"column:sku"`
+	Barcode   *string         `gorm:"column:barcode"`
 
-	BucketID *int64 `gorm:"column:bucket_fk"`
-	Bucket   Bucket `gorm:"foreignKey:bucket_fk"`
+	EffectivePrice decimal.Decimal `gorm:"-"`
+
+	OriginCountry *string    `gorm:"column:origin_country"`
+	HarvestedAt   *time.Time `gorm:"column:harvested_at"`
+
+	BucketID   *int64   `gorm:"column:bucket_fk"`
+	Bucket     Bucket   `gorm:"foreignKey:bucket_fk"`
+	SupplierID *int64   `gorm:"column:supplier_fk"`
+	Supplier   Supplier `gorm:"foreignKey:supplier_fk"`
+
+	ReservationID *int64     `gorm:"column:reservation_fk"`
+	ReservedUntil *time.Time `gorm:"column:reserved_until"`
+	QuarantinedAt *time.Time `gorm:"column:quarantined_at"`
+
+	PurchaseOrderLineID *int64 `gorm:"column:purchase_order_line_fk"`
 }
 
 func (Fruit) TableName() string {
 	return "fruits"
 }
 
+// IsReserved tells whether the fruit is held by a reservation at the given moment
+func (impl Fruit) IsReserved(now time.Time) bool {
+	return impl.ReservedUntil != nil && impl.ReservedUntil.After(now)
+}
+
+// IsQuarantined tells whether the fruit was recalled. Quarantined fruits are
+// kept out of sale and may only be stored in quarantine buckets
+func (impl Fruit) IsQuarantined() bool {
+	return impl.QuarantinedAt != nil
+}
+
 // Refers: https://gorm.io/docs/conventions.html#Pluralized-Table-Name
 //		   https://gorm.io/docs/conventions.html#Column-Name
 //		   https://gorm.io/docs/belongs_to.html
@@ -0,0 +1,20 @@
+package models
+
+const (
+	FruitBatchModeAllOrNothing = "all_or_nothing"
+	FruitBatchModeBestEffort   = "best_effort"
+)
+
+// FruitBatch is the outcome of a bulk fruit creation, with one result per
+// requested item in the request order
+type FruitBatch struct {
+	Results []FruitBatchResult
+	Created int
+	Failed  int
+}
+
+type FruitBatchResult struct {
+	Index int
+	Fruit *Fruit
+	Err   error
+}
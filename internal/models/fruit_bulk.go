@@ -0,0 +1,7 @@
+package models
+
+// FruitBulk is the outcome of an operation applied to many fruits at once
+type FruitBulk struct {
+	FruitIDs []int64
+	Affected int64
+}
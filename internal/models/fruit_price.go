@@ -0,0 +1,25 @@
+package models
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+const FruitPriceReasonCreated = "created"
+
+type FruitPrice struct {
+	ID        int64     `gorm:"column:id"`
+	CreatedAt time.Time `gorm:"column:created_at"`
+
+	FruitID int64           `gorm:"column:fruit_fk"`
+	Price   decimal.Decimal `gorm:"column:price"`
+	Reason  string          `gorm:"column:reason"`
+}
+
+func (FruitPrice) TableName() string {
+	return "fruit_prices"
+}
+
+// Refers: https://gorm.io/docs/conventions.html#Pluralized-Table-Name
+//		   https://gorm.io/docs/conventions.html#Column-Name
@@ -0,0 +1,14 @@
+package models
+
+import "github.com/shopspring/decimal"
+
+// FruitPriceStats summarizes the prices set for fruits of the same name
+type FruitPriceStats struct {
+	Name    string
+	Min     decimal.Decimal
+	Avg     decimal.Decimal
+	Max     decimal.Decimal
+	Changes int64
+}
+
+// Refers: https://martinfowler.com/bliki/DDD_Aggregate.html
@@ -0,0 +1,100 @@
+package models
+
+import "time"
+
+type FruitState string
+
+const (
+	FruitStateUnripe   FruitState = "unripe"
+	FruitStateRipe     FruitState = "ripe"
+	FruitStateOverripe FruitState = "overripe"
+	FruitStateExpired  FruitState = "expired"
+	FruitStateDisposed FruitState = "disposed"
+	FruitStateSold     FruitState = "sold"
+)
+
+// Share of the shelf life (from created_at to expires_at) after which a fruit
+// automatically becomes ripe and overripe
+const (
+	FruitRipeShelfLifeRatio     = 0.5
+	FruitOverripeShelfLifeRatio = 0.8
+)
+
+// FruitActiveStates are the states of fruits still counted as stock
+var FruitActiveStates = []FruitState{FruitStateUnripe, FruitStateRipe, FruitStateOverripe}
+
+// FruitOnHandStates are the states of fruits still physically kept, expired
+// ones included until they are disposed
+var FruitOnHandStates = []FruitState{FruitStateUnripe, FruitStateRipe, FruitStateOverripe, FruitStateExpired}
+
+var fruitStateTransitions = map[FruitState][]FruitState{
+	FruitStateUnripe:   {FruitStateRipe, FruitStateExpired, FruitStateDisposed, FruitStateSold},
+	FruitStateRipe:     {FruitStateOverripe, FruitStateExpired, FruitStateDisposed, FruitStateSold},
+	FruitStateOverripe: {FruitStateExpired, FruitStateDisposed, FruitStateSold},
+	FruitStateExpired:  {FruitStateDisposed},
+	FruitStateDisposed: {},
+	FruitStateSold:     {},
+}
+
+// fruitStateProgression is the path followed by the automatic time based progression
+var fruitStateProgression = []FruitState{FruitStateUnripe, FruitStateRipe, FruitStateOverripe, FruitStateExpired}
+
+func (impl FruitState) IsActive() bool {
+	for _, s := range FruitActiveStates {
+		if s == impl {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (impl FruitState) CanTransitionTo(state FruitState) bool {
+	for _, s := range fruitStateTransitions[impl] {
+		if s == state {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ExpectedFruitState returns the state a fruit should be in at the given moment
+// considering only the elapsed share of its shelf life
+func ExpectedFruitState(createdAt, expiresAt, now time.Time) FruitState {
+	if !now.Before(expiresAt) {
+		return FruitStateExpired
+	}
+
+	shelfLife := expiresAt.Sub(createdAt)
+	elapsed := now.Sub(createdAt)
+
+	if float64(elapsed) >= float64(shelfLife)*FruitOverripeShelfLifeRatio {
+		return FruitStateOverripe
+	}
+	if float64(elapsed) >= float64(shelfLife)*FruitRipeShelfLifeRatio {
+		return FruitStateRipe
+	}
+
+	return FruitStateUnripe
+}
+
+// FruitStateProgression returns the states a fruit goes through to move from
+// the current state to the target one, excluding the current state
+func FruitStateProgression(current, target FruitState) []FruitState {
+	currentIdx, targetIdx := -1, -1
+	for i, s := range fruitStateProgression {
+		if s == current {
+			currentIdx = i
+		}
+		if s == target {
+			targetIdx = i
+		}
+	}
+
+	if currentIdx == -1 || targetIdx <= currentIdx {
+		return []FruitState{}
+	}
+
+	return fruitStateProgression[currentIdx+1 : targetIdx+1]
+}
@@ -0,0 +1,79 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFruitState_CanTransitionTo(t *testing.T) {
+	tests := map[string]struct {
+		from FruitState
+		to   FruitState
+		want bool
+	}{
+		"should allow unripe to ripe":        {from: FruitStateUnripe, to: FruitStateRipe, want: true},
+		"should allow ripe to overripe":      {from: FruitStateRipe, to: FruitStateOverripe, want: true},
+		"should allow overripe to expired":   {from: FruitStateOverripe, to: FruitStateExpired, want: true},
+		"should allow expired to disposed":   {from: FruitStateExpired, to: FruitStateDisposed, want: true},
+		"should allow unripe to disposed":    {from: FruitStateUnripe, to: FruitStateDisposed, want: true},
+		"should deny ripe to unripe":         {from: FruitStateRipe, to: FruitStateUnripe, want: false},
+		"should deny unripe to overripe":     {from: FruitStateUnripe, to: FruitStateOverripe, want: false},
+		"should deny disposed to any state":  {from: FruitStateDisposed, to: FruitStateExpired, want: false},
+		"should allow ripe to sold":          {from: FruitStateRipe, to: FruitStateSold, want: true},
+		"should deny expired to sold":        {from: FruitStateExpired, to: FruitStateSold, want: false},
+		"should deny sold to any state":      {from: FruitStateSold, to: FruitStateDisposed, want: false},
+		"should deny transition to same one": {from: FruitStateRipe, to: FruitStateRipe, want: false},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := tt.from.CanTransitionTo(tt.to)
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestExpectedFruitState(t *testing.T) {
+	createdAt := time.Date(2000, 12, 31, 0, 0, 0, 0, time.Local)
+	expiresAt := createdAt.Add(10 * time.Hour)
+
+	tests := map[string]struct {
+		now  time.Time
+		want FruitState
+	}{
+		"should be unripe before half of shelf life": {now: createdAt.Add(4 * time.Hour), want: FruitStateUnripe},
+		"should be ripe after half of shelf life":    {now: createdAt.Add(5 * time.Hour), want: FruitStateRipe},
+		"should be overripe near expiration":         {now: createdAt.Add(8 * time.Hour), want: FruitStateOverripe},
+		"should be expired at expiration":            {now: expiresAt, want: FruitStateExpired},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := ExpectedFruitState(createdAt, expiresAt, tt.now)
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestFruitStateProgression(t *testing.T) {
+	tests := map[string]struct {
+		current FruitState
+		target  FruitState
+		want    []FruitState
+	}{
+		"should go through every state":      {current: FruitStateUnripe, target: FruitStateExpired, want: []FruitState{FruitStateRipe, FruitStateOverripe, FruitStateExpired}},
+		"should go to the next state":        {current: FruitStateRipe, target: FruitStateOverripe, want: []FruitState{FruitStateOverripe}},
+		"should not go back":                 {current: FruitStateOverripe, target: FruitStateRipe, want: []FruitState{}},
+		"should not move from disposed":      {current: FruitStateDisposed, target: FruitStateExpired, want: []FruitState{}},
+		"should not move when already there": {current: FruitStateRipe, target: FruitStateRipe, want: []FruitState{}},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := FruitStateProgression(tt.current, tt.target)
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,21 @@
+package models
+
+import (
+	"time"
+)
+
+type FruitStateTransition struct {
+	ID        int64     `gorm:"column:id"`
+	CreatedAt time.Time `gorm:"column:created_at"`
+
+	FruitID   int64      `gorm:"column:fruit_fk"`
+	FromState FruitState `gorm:"column:from_state"`
+	ToState   FruitState `gorm:"column:to_state"`
+}
+
+func (FruitStateTransition) TableName() string {
+	return "fruit_state_transitions"
+}
+
+// Refers: https://gorm.io/docs/conventions.html#Pluralized-Table-Name
+//		   https://gorm.io/docs/conventions.html#Column-Name
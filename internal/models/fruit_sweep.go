@@ -0,0 +1,7 @@
+package models
+
+type FruitSweep struct {
+	ExpiredFruits    int64
+	DisposedFruits   int64
+	UnassignedFruits int64
+}
@@ -0,0 +1,8 @@
+package models
+
+type FruitTransfer struct {
+	FruitID    int64
+	FromBucket *Bucket
+	ToBucket   Bucket
+	Moved      bool
+}
@@ -0,0 +1,57 @@
+package models
+
+import (
+	"sort"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// MarkdownRule discounts Percent of the price of fruits expiring within the window
+type MarkdownRule struct {
+	Within  time.Duration
+	Percent decimal.Decimal
+}
+
+type MarkdownRules []MarkdownRule
+
+var hundred = decimal.NewFromInt(100)
+
+// Tiers returns the rules sorted by the narrowest window first, each one with
+// the deepest discount among the rules whose window covers it
+func (impl MarkdownRules) Tiers() []MarkdownRule {
+	tiers := append([]MarkdownRule{}, impl...)
+	sort.SliceStable(tiers, func(i, j int) bool {
+		return tiers[i].Within < tiers[j].Within
+	})
+
+	for i := len(tiers) - 2; i >= 0; i-- {
+		if tiers[i+1].Percent.GreaterThan(tiers[i].Percent) {
+			tiers[i].Percent = tiers[i+1].Percent
+		}
+	}
+
+	return tiers
+}
+
+// Discount returns the percent off for a fruit expiring at expiresAt
+func (impl MarkdownRules) Discount(expiresAt, now time.Time) decimal.Decimal {
+	remaining := expiresAt.Sub(now)
+	for _, tier := range impl.Tiers() {
+		if remaining <= tier.Within {
+			return tier.Percent
+		}
+	}
+
+	return decimal.Zero
+}
+
+// EffectivePrice returns the price after markdown rounded to cents
+func (impl MarkdownRules) EffectivePrice(price decimal.Decimal, expiresAt, now time.Time) decimal.Decimal {
+	discount := impl.Discount(expiresAt, now)
+	if discount.IsZero() {
+		return price
+	}
+
+	return price.Mul(hundred.Sub(discount)).Div(hundred).Round(2)
+}
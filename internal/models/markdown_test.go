@@ -0,0 +1,61 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMarkdownRules_EffectivePrice(t *testing.T) {
+	now := time.Date(2000, 12, 31, 23, 59, 59, 0, time.Local)
+	price, _ := decimal.NewFromString("1.99")
+	rules := MarkdownRules{
+		{Within: 48 * time.Hour, Percent: decimal.NewFromInt(20)},
+		{Within: 12 * time.Hour, Percent: decimal.NewFromInt(50)},
+	}
+
+	tests := map[string]struct {
+		rules     MarkdownRules
+		expiresAt time.Time
+		want      string
+	}{
+		"should keep price when there are no rules": {
+			expiresAt: now.Add(time.Hour),
+			want:      "1.99",
+		},
+		"should keep price out of every window": {
+			rules:     rules,
+			expiresAt: now.Add(72 * time.Hour),
+			want:      "1.99",
+		},
+		"should apply 20% within 48h": {
+			rules:     rules,
+			expiresAt: now.Add(48 * time.Hour),
+			want:      "1.59",
+		},
+		"should apply 50% within 12h": {
+			rules:     rules,
+			expiresAt: now.Add(12 * time.Hour),
+			want:      "1",
+		},
+		"should apply the deepest discount covering the window": {
+			rules: MarkdownRules{
+				{Within: 12 * time.Hour, Percent: decimal.NewFromInt(10)},
+				{Within: 48 * time.Hour, Percent: decimal.NewFromInt(30)},
+			},
+			expiresAt: now.Add(6 * time.Hour),
+			want:      "1.39",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			// when
+			got := tt.rules.EffectivePrice(price, tt.expiresAt, now)
+
+			// then
+			assert.Equal(t, tt.want, got.String())
+		})
+	}
+}
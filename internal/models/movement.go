@@ -0,0 +1,32 @@
+package models
+
+import "time"
+
+const (
+	MovementTypeCreated    = "created"
+	MovementTypeMoved      = "moved"
+	MovementTypeUnassigned = "unassigned"
+	MovementTypeDeleted    = "deleted"
+	MovementTypePicked     = "picked"
+)
+
+// Movement is an entry of the append-only ledger of where fruits have been. It
+// is written in the same transaction as the change it records and never updated
+type Movement struct {
+	ID        int64     `gorm:"column:id"`
+	CreatedAt time.Time `gorm:"column:created_at"`
+
+	FruitID      int64  `gorm:"column:fruit_fk"`
+	FromBucketID *int64 `gorm:"column:from_bucket_fk"`
+	ToBucketID   *int64 `gorm:"column:to_bucket_fk"`
+
+	Type  string  `gorm:"column:type"`
+	Actor *string `gorm:"column:actor"`
+}
+
+func (Movement) TableName() string {
+	return "movements"
+}
+
+// Refers: https://gorm.io/docs/conventions.html#Pluralized-Table-Name
+//		   https://gorm.io/docs/conventions.html#Column-Name
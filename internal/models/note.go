@@ -0,0 +1,29 @@
+package models
+
+import (
+	"time"
+)
+
+const (
+	NoteOwnerFruit  = "fruit"
+	NoteOwnerBucket = "bucket"
+)
+
+// Note is a free text comment left on either a fruit or a bucket
+type Note struct {
+	ID        int64     `gorm:"column:id"`
+	CreatedAt time.Time `gorm:"column:created_at"`
+
+	FruitID  *int64 `gorm:"column:fruit_fk"`
+	BucketID *int64 `gorm:"column:bucket_fk"`
+
+	Author string `gorm:"column:author"`
+	Text   string `gorm:"column:text"`
+}
+
+func (Note) TableName() string {
+	return "notes"
+}
+
+// Refers: https://gorm.io/docs/conventions.html#Pluralized-Table-Name
+//		   https://gorm.io/docs/conventions.html#Column-Name
@@ -0,0 +1,40 @@
+package models
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// Order is open until checked out, when its fruits are sold and its total
+// becomes revenue
+type Order struct {
+	ID           int64      `gorm:"column:id"`
+	CreatedAt    time.Time  `gorm:"column:created_at"`
+	CheckedOutAt *time.Time `gorm:"column:checked_out_at"`
+
+	Total decimal.Decimal `gorm:"column:total"`
+	Items []OrderItem     `gorm:"foreignKey:OrderID"`
+}
+
+func (Order) TableName() string {
+	return "orders"
+}
+
+// OrderItem keeps the fruit name and price at the time the order was placed
+type OrderItem struct {
+	ID      int64 `gorm:"column:id"`
+	OrderID int64 `gorm:"column:order_fk"`
+	FruitID int64 `gorm:"column:fruit_fk"`
+
+	Name  string          `gorm:"column:name"`
+	Price decimal.Decimal `gorm:"column:price"`
+}
+
+func (OrderItem) TableName() string {
+	return "order_items"
+}
+
+// Refers: https://gorm.io/docs/conventions.html#Pluralized-Table-Name
+//		   https://gorm.io/docs/conventions.html#Column-Name
+//		   https://gorm.io/docs/has_many.html
@@ -0,0 +1,33 @@
+package models
+
+import (
+	"time"
+)
+
+const (
+	PhotoOwnerFruit  = "fruit"
+	PhotoOwnerBucket = "bucket"
+)
+
+// PhotoThumbnailContentType is the format every thumbnail is stored in
+const PhotoThumbnailContentType = "image/jpeg"
+
+// Photo is an image attached to either a fruit or a bucket. The image itself
+// and its thumbnail live in the blob store under BlobKey and ThumbnailKey
+type Photo struct {
+	ID        int64     `gorm:"column:id"`
+	CreatedAt time.Time `gorm:"column:created_at"`
+
+	FruitID  *int64 `gorm:"column:fruit_fk"`
+	BucketID *int64 `gorm:"column:bucket_fk"`
+
+	Filename     string `gorm:"column:filename"`
+	ContentType  string `gorm:"column:content_type"`
+	Size         int64  `gorm:"column:size"`
+	Width        int    `gorm:"column:width"`
+	Height       int    `gorm:"column:height"`
+	BlobKey      string `gorm:"column:blob_key"`
+	ThumbnailKey string `gorm:"column:thumbnail_key"`
+}
+
+// Refers: https://gorm.io/docs/conventions.html#Column-Name
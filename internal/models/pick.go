@@ -0,0 +1,25 @@
+package models
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// Pick is the list of fruits taken out of the buckets to fulfil a request,
+// in the order they should be picked
+type Pick struct {
+	Name     string
+	Quantity int
+	Items    []PickItem
+}
+
+type PickItem struct {
+	FruitID    int64
+	BucketID   int64
+	BucketName string
+	ExpiresAt  time.Time
+	Price      decimal.Decimal
+}
+
+// Refers: https://martinfowler.com/bliki/DDD_Aggregate.html
@@ -0,0 +1,65 @@
+package models
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+const (
+	PurchaseOrderStatusOpen     = "open"
+	PurchaseOrderStatusReceived = "received"
+)
+
+// PurchaseOrder is what is expected from a supplier. It is open until
+// received, when its lines become fruits
+type PurchaseOrder struct {
+	ID         int64      `gorm:"column:id"`
+	CreatedAt  time.Time  `gorm:"column:created_at"`
+	ReceivedAt *time.Time `gorm:"column:received_at"`
+
+	SupplierID int64               `gorm:"column:supplier_fk"`
+	Lines      []PurchaseOrderLine `gorm:"foreignKey:PurchaseOrderID"`
+}
+
+func (PurchaseOrder) TableName() string {
+	return "purchase_orders"
+}
+
+func (impl PurchaseOrder) Status() string {
+	if impl.ReceivedAt != nil {
+		return PurchaseOrderStatusReceived
+	}
+
+	return PurchaseOrderStatusOpen
+}
+
+// PurchaseOrderLine is the quantity ordered of a fruit and, once the order is
+// received, the quantity that actually arrived
+type PurchaseOrderLine struct {
+	ID              int64 `gorm:"column:id"`
+	PurchaseOrderID int64 `gorm:"column:purchase_order_fk"`
+
+	Name             string          `gorm:"column:name"`
+	Price            decimal.Decimal `gorm:"column:price"`
+	Quantity         int             `gorm:"column:quantity"`
+	ReceivedQuantity *int            `gorm:"column:received_quantity"`
+}
+
+func (PurchaseOrderLine) TableName() string {
+	return "purchase_order_lines"
+}
+
+// Discrepancy is how many more (positive) or fewer (negative) fruits were
+// received than ordered
+func (impl PurchaseOrderLine) Discrepancy() int {
+	if impl.ReceivedQuantity == nil {
+		return 0
+	}
+
+	return *impl.ReceivedQuantity - impl.Quantity
+}
+
+// Refers: https://gorm.io/docs/conventions.html#Pluralized-Table-Name
+//		   https://gorm.io/docs/conventions.html#Column-Name
+//		   https://gorm.io/docs/has_many.html
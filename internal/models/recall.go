@@ -0,0 +1,21 @@
+package models
+
+import "time"
+
+// Recall is the list of fruits quarantined for coming from a supplier, lot or
+// window, with the bucket each one should be pulled out of. Affected counts
+// the fruits that were not already quarantined
+type Recall struct {
+	QuarantinedAt time.Time
+	Affected      int64
+	Fruits        []RecallFruit
+}
+
+type RecallFruit struct {
+	FruitID    int64
+	Name       string
+	BucketID   *int64
+	BucketName *string
+}
+
+// Refers: https://martinfowler.com/bliki/DDD_Aggregate.html
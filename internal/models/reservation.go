@@ -0,0 +1,48 @@
+package models
+
+import (
+	"time"
+)
+
+const (
+	ReservationStatusActive    = "active"
+	ReservationStatusLapsed    = "lapsed"
+	ReservationStatusConfirmed = "confirmed"
+	ReservationStatusReleased  = "released"
+)
+
+// Reservation holds fruits for a holder until it expires. While active, the
+// fruits cannot be picked, moved or sold by anyone else
+type Reservation struct {
+	ID          int64      `gorm:"column:id"`
+	CreatedAt   time.Time  `gorm:"column:created_at"`
+	ExpiresAt   time.Time  `gorm:"column:expires_at"`
+	ConfirmedAt *time.Time `gorm:"column:confirmed_at"`
+	ReleasedAt  *time.Time `gorm:"column:released_at"`
+
+	Holder  string `gorm:"column:holder"`
+	OrderID *int64 `gorm:"column:order_fk"`
+
+	FruitIDs []int64 `gorm:"-"`
+}
+
+func (Reservation) TableName() string {
+	return "reservations"
+}
+
+func (impl Reservation) Status(now time.Time) string {
+	if impl.ConfirmedAt != nil {
+		return ReservationStatusConfirmed
+	}
+	if impl.ReleasedAt != nil {
+		return ReservationStatusReleased
+	}
+	if !impl.ExpiresAt.After(now) {
+		return ReservationStatusLapsed
+	}
+
+	return ReservationStatusActive
+}
+
+// Refers: https://gorm.io/docs/conventions.html#Pluralized-Table-Name
+//		   https://gorm.io/docs/conventions.html#Column-Name
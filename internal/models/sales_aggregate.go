@@ -0,0 +1,12 @@
+package models
+
+import "github.com/shopspring/decimal"
+
+type Sales struct {
+	Day      string
+	Name     string
+	Quantity int64
+	Revenue  decimal.Decimal
+}
+
+// Refers: https://martinfowler.com/bliki/DDD_Aggregate.html
@@ -0,0 +1,44 @@
+package models
+
+import "time"
+
+const (
+	StockAlertReasonDeleted = "deleted"
+	StockAlertReasonRemoved = "removed"
+	StockAlertReasonExpired = "expired"
+	StockAlertReasonPicked  = "picked"
+	StockAlertReasonSold    = "sold"
+)
+
+// ReorderPoint is the minimum stock wanted of a fruit name
+type ReorderPoint struct {
+	ID        int64     `gorm:"column:id"`
+	CreatedAt time.Time `gorm:"column:created_at"`
+	UpdatedAt time.Time `gorm:"column:updated_at"`
+
+	Name        string `gorm:"column:name"`
+	MinQuantity int    `gorm:"column:min_quantity"`
+}
+
+func (ReorderPoint) TableName() string {
+	return "reorder_points"
+}
+
+// StockAlert records the moment the stock of a fruit name fell below its
+// reorder point and what made it fall
+type StockAlert struct {
+	ID        int64     `gorm:"column:id"`
+	CreatedAt time.Time `gorm:"column:created_at"`
+
+	Name        string `gorm:"column:name"`
+	Quantity    int64  `gorm:"column:quantity"`
+	MinQuantity int    `gorm:"column:min_quantity"`
+	Reason      string `gorm:"column:reason"`
+}
+
+func (StockAlert) TableName() string {
+	return "stock_alerts"
+}
+
+// Refers: https://gorm.io/docs/conventions.html#Pluralized-Table-Name
+//		   https://gorm.io/docs/conventions.html#Column-Name
@@ -0,0 +1,10 @@
+package models
+
+// Stock is the current quantity of a fruit name against its reorder point
+type Stock struct {
+	Name        string
+	Quantity    int64
+	MinQuantity int
+}
+
+// Refers: https://martinfowler.com/bliki/DDD_Aggregate.html
@@ -0,0 +1,22 @@
+package models
+
+import (
+	"time"
+)
+
+type Supplier struct {
+	ID        int64      `gorm:"column:id"`
+	CreatedAt time.Time  `gorm:"column:created_at"`
+	DeletedAt *time.Time `gorm:"column:deleted_at"`
+
+	Name    string  `gorm:"column:name"`
+	Country string  `gorm:"column:country"`
+	Contact *string `gorm:"column:contact"`
+}
+
+func (Supplier) TableName() string {
+	return "suppliers"
+}
+
+// Refers: https://gorm.io/docs/conventions.html#Pluralized-Table-Name
+//		   https://gorm.io/docs/conventions.html#Column-Name
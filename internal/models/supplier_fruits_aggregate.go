@@ -0,0 +1,16 @@
+package models
+
+import "github.com/shopspring/decimal"
+
+type SupplierFruits struct {
+	ID             int64
+	Name           string
+	ReceivedFruits int64
+	ReceivedPrice  decimal.Decimal
+	ExpiredFruits  int64
+	ExpiredPrice   decimal.Decimal
+	CurrentFruits  int64
+	CurrentPrice   decimal.Decimal
+}
+
+// Refers: https://martinfowler.com/bliki/DDD_Aggregate.html
@@ -0,0 +1,15 @@
+package models
+
+import "github.com/shopspring/decimal"
+
+// Valuation is the quantity and value of the valid stock of a fruit name kept
+// in a bucket at a given moment
+type Valuation struct {
+	BucketID   *int64
+	BucketName *string
+	Name       string
+	Quantity   int64
+	Value      decimal.Decimal
+}
+
+// Refers: https://martinfowler.com/bliki/DDD_Aggregate.html
@@ -0,0 +1,37 @@
+package models
+
+import "github.com/shopspring/decimal"
+
+// Waste totals the fruits of a name, bucket and supplier that expired or were
+// discarded in a period, against the fruits received in it
+type Waste struct {
+	Name            string
+	BucketID        *int64
+	SupplierID      *int64
+	ReceivedFruits  int64
+	ExpiredFruits   int64
+	ExpiredPrice    decimal.Decimal
+	DiscardedFruits int64
+	DiscardedPrice  decimal.Decimal
+}
+
+func (impl Waste) WastedFruits() int64 {
+	return impl.ExpiredFruits + impl.DiscardedFruits
+}
+
+func (impl Waste) WastedPrice() decimal.Decimal {
+	return impl.ExpiredPrice.Add(impl.DiscardedPrice)
+}
+
+// Rate is the share of the received fruits that went to waste, nil when no
+// fruit was received
+func (impl Waste) Rate() *decimal.Decimal {
+	if impl.ReceivedFruits == 0 {
+		return nil
+	}
+
+	rate := decimal.NewFromInt(impl.WastedFruits()).DivRound(decimal.NewFromInt(impl.ReceivedFruits), 4)
+	return &rate
+}
+
+// Refers: https://martinfowler.com/bliki/DDD_Aggregate.html
@@ -0,0 +1,35 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWaste_Rate(t *testing.T) {
+	tests := map[string]struct {
+		waste Waste
+		want  string
+	}{
+		"should be the share of received fruits wasted": {
+			waste: Waste{ReceivedFruits: 3, ExpiredFruits: 1, DiscardedFruits: 1},
+			want:  "0.6667",
+		},
+		"should be nil when nothing was received": {
+			waste: Waste{ExpiredFruits: 1},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			// when
+			got := tt.waste.Rate()
+
+			// then
+			if tt.want == "" {
+				assert.Nil(t, got)
+				return
+			}
+			assert.Equal(t, tt.want, got.String())
+		})
+	}
+}
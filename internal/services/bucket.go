@@ -3,25 +3,33 @@ package services
 import (
 	"context"
 	"database/sql"
+	"fmt"
+	"time"
 
+	"github.com/shopspring/decimal"
 	"github.com/viniosilva/where-are-my-fruits/internal/dtos"
 	"github.com/viniosilva/where-are-my-fruits/internal/exceptions"
 	"github.com/viniosilva/where-are-my-fruits/internal/infra"
 	"github.com/viniosilva/where-are-my-fruits/internal/models"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type BucketService struct {
-	db       *infra.Database
-	logger   Logger
-	validate Validate
+	db            *infra.Database
+	logger        Logger
+	validate      Validate
+	markdowns     models.MarkdownRules
+	compatibility models.CompatibilityRules
 }
 
-func NewBucket(db *infra.Database, logger Logger, validate Validate) *BucketService {
+func NewBucket(db *infra.Database, logger Logger, validate Validate, markdowns models.MarkdownRules, compatibility models.CompatibilityRules) *BucketService {
 	return &BucketService{
-		db:       db,
-		logger:   logger,
-		validate: validate,
+		db:            db,
+		logger:        logger,
+		validate:      validate,
+		markdowns:     markdowns,
+		compatibility: compatibility,
 	}
 }
 
@@ -34,6 +42,8 @@ func (impl *BucketService) Create(ctx context.Context, data dtos.CreateBucketDto
 		CreatedAt: _time.Now(),
 		Name:      data.Name,
 		Capacity:  data.Capacity,
+
+		Quarantine: data.Quarantine,
 	}
 
 	res := impl.db.DB.Create(&bucket)
@@ -47,17 +57,24 @@ func (impl *BucketService) Create(ctx context.Context, data dtos.CreateBucketDto
 
 func (impl *BucketService) List(ctx context.Context, page, pageSize int) ([]models.BucketFruits, error) {
 	offset := (page - 1) * pageSize
+	now := _time.Now()
+
+	effectivePrice, effectivePriceArgs := markdownPriceSQL(impl.markdowns, now)
+	args := append([]interface{}{models.FruitStateUnripe, models.FruitStateRipe, models.FruitStateOverripe, now}, effectivePriceArgs...)
 
 	rows, err := impl.db.DB.Model(&models.Bucket{}).
-		Select(`buckets.id,
+		Select(fmt.Sprintf(`buckets.id,
 				buckets.name,
 				buckets.capacity,
 				COUNT(fruits.id) AS total_fruits,
-				IFNULL(SUM(fruits.price), 0) AS total_price,
-				(COUNT(fruits.id) * 100 / buckets.capacity) AS percent`).
-		Joins(`LEFT JOIN fruits ON fruits.bucket_fk = buckets.id
-				AND fruits.deleted_at IS NULL
-				AND fruits.expires_at > ?`, _time.Now()).
+				IFNULL(SUM(IF(fruits.quarantined_at IS NULL, fruits.price, 0)), 0) AS total_price,
+				(COUNT(fruits.id) * 100 / buckets.capacity) AS percent,
+				IFNULL(SUM(fruits.state = ?), 0) AS total_unripe,
+				IFNULL(SUM(fruits.state = ?), 0) AS total_ripe,
+				IFNULL(SUM(fruits.state = ?), 0) AS total_overripe,
+				IFNULL(SUM(fruits.reserved_until > ?), 0) AS total_reserved,
+				IFNULL(SUM(IF(fruits.quarantined_at IS NULL, %s, 0)), 0) AS total_effective_price`, effectivePrice), args...).
+		Scopes(withValidFruits(now)).
 		Group("buckets.id").
 		Order("percent DESC, buckets.created_at").
 		Offset(offset).
@@ -81,6 +98,11 @@ func (impl *BucketService) List(ctx context.Context, page, pageSize int) ([]mode
 			&bucketFruits.TotalFruits,
 			&bucketFruits.TotalPrice,
 			&bucketFruits.Percent,
+			&bucketFruits.TotalUnripe,
+			&bucketFruits.TotalRipe,
+			&bucketFruits.TotalOverripe,
+			&bucketFruits.TotalReserved,
+			&bucketFruits.TotalEffectivePrice,
 		}
 
 		if err := rows.Scan(dest...); err != nil {
@@ -103,7 +125,8 @@ func (impl *BucketService) Delete(ctx context.Context, id int64) error {
 			Where(`bucket_fk = ?
 				AND deleted_at IS NULL
 				AND expires_at > ?
-			`, id, now).
+				AND state IN ?
+			`, id, now, models.FruitActiveStates).
 			Count(&totalFruits)
 		if err := res.Error; err != nil {
 			return err
@@ -130,4 +153,145 @@ func (impl *BucketService) Delete(ctx context.Context, id int64) error {
 	return nil
 }
 
+// ListViolations returns the buckets whose valid fruits break the storage
+// compatibility rules, with every pair of names breaking them
+func (impl *BucketService) ListViolations(ctx context.Context) ([]models.BucketViolations, error) {
+	bucketsViolations := make([]models.BucketViolations, 0)
+	if len(impl.compatibility) == 0 {
+		return bucketsViolations, nil
+	}
+
+	rows, err := impl.db.DB.Model(&models.Bucket{}).
+		Select("DISTINCT buckets.id, buckets.name, fruits.name").
+		Scopes(withValidFruits(_time.Now())).
+		Where("buckets.deleted_at IS NULL AND fruits.id IS NOT NULL").
+		Order("buckets.id, fruits.name").
+		Rows()
+	if err != nil {
+		impl.logger.Error(err.Error())
+		return nil, err
+	}
+	defer rows.Close()
+
+	buckets := []models.BucketViolations{}
+	names := map[int64][]string{}
+	for rows.Next() {
+		var bucket models.BucketViolations
+		var name string
+		dest := []interface{}{
+			&bucket.ID,
+			&bucket.Name,
+			&name,
+		}
+
+		if err := rows.Scan(dest...); err != nil {
+			impl.logger.Error(err.Error())
+			return nil, err
+		}
+
+		if _, ok := names[bucket.ID]; !ok {
+			buckets = append(buckets, bucket)
+		}
+		names[bucket.ID] = append(names[bucket.ID], name)
+	}
+
+	for _, bucket := range buckets {
+		bucket.Violations = impl.compatibility.Violations(names[bucket.ID])
+		if len(bucket.Violations) > 0 {
+			bucketsViolations = append(bucketsViolations, bucket)
+		}
+	}
+
+	return bucketsViolations, nil
+}
+
+// Consolidate plans moving the valid fruits of partially full buckets into the
+// others so that as many buckets as possible end up empty. It is a dry run
+// unless apply is set, then the moves are made in one transaction
+func (impl *BucketService) Consolidate(ctx context.Context, apply bool) (*models.BucketConsolidation, error) {
+	now := _time.Now()
+
+	if !apply {
+		consolidation, err := planConsolidation(impl.db.DB, impl.compatibility, now)
+		if err != nil {
+			impl.logger.Error(err.Error())
+			return nil, err
+		}
+
+		return consolidation, nil
+	}
+
+	var consolidation *models.BucketConsolidation
+	err := impl.db.DB.Transaction(func(tx *gorm.DB) error {
+		var err error
+		consolidation, err = planConsolidation(tx, impl.compatibility, now, clause.Locking{Strength: "UPDATE"})
+		if err != nil {
+			return err
+		}
+
+		toBucketIDs := []int64{}
+		fruitIDs := map[int64][]int64{}
+		movements := []models.Movement{}
+		for _, move := range consolidation.Moves {
+			from, to := move.FromBucketID, move.ToBucketID
+			if _, ok := fruitIDs[to]; !ok {
+				toBucketIDs = append(toBucketIDs, to)
+			}
+			fruitIDs[to] = append(fruitIDs[to], move.FruitID)
+			movements = append(movements, newMovement(ctx, models.MovementTypeMoved, move.FruitID, &from, &to, now))
+		}
+
+		for _, bucketID := range toBucketIDs {
+			res := tx.Model(&models.Fruit{}).
+				Where("id IN ?", fruitIDs[bucketID]).
+				Update("bucket_fk", bucketID)
+			if err := res.Error; err != nil {
+				return err
+			}
+		}
+
+		return recordMovements(tx, movements)
+	}, &sql.TxOptions{Isolation: sql.LevelReadCommitted})
+
+	if err != nil {
+		impl.logger.Error(err.Error())
+		return nil, err
+	}
+
+	consolidation.Applied = true
+
+	return consolidation, nil
+}
+
+// withValidFruits joins each bucket to the valid fruits it holds, the ones taking
+// its capacity
+func withValidFruits(now time.Time) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Joins(`LEFT JOIN fruits ON fruits.bucket_fk = buckets.id
+				AND fruits.deleted_at IS NULL
+				AND fruits.expires_at > ?
+				AND fruits.state IN ?`, now, models.FruitActiveStates)
+	}
+}
+
+// markdownPriceSQL returns the SQL expression of the fruit price after the
+// markdown rules, matching models.MarkdownRules.EffectivePrice
+func markdownPriceSQL(markdowns models.MarkdownRules, now time.Time) (string, []interface{}) {
+	tiers := markdowns.Tiers()
+	if len(tiers) == 0 {
+		return "fruits.price", nil
+	}
+
+	hundred := decimal.NewFromInt(100)
+	expr := "ROUND(fruits.price * CASE"
+	args := []interface{}{}
+	for _, tier := range tiers {
+		expr += " WHEN fruits.expires_at <= ? THEN CAST(? AS DECIMAL(10,6))"
+		args = append(args, now.Add(tier.Within), hundred.Sub(tier.Percent).Div(hundred))
+	}
+	expr += " ELSE 1 END, 2)"
+
+	return expr, args
+}
+
 // Refers: https://gorm.io/docs/scopes.html#Pagination
@@ -0,0 +1,129 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/viniosilva/where-are-my-fruits/internal/exceptions"
+	"github.com/viniosilva/where-are-my-fruits/internal/models"
+	"gorm.io/gorm"
+)
+
+// bucketAllocator picks the index of the bucket a new fruit goes to among the
+// buckets with free capacity, given oldest first
+type bucketAllocator func(buckets []models.BucketOccupancy) int
+
+var bucketAllocators = map[string]bucketAllocator{
+	models.BucketAllocationFirstFit: func(buckets []models.BucketOccupancy) int {
+		return 0
+	},
+	models.BucketAllocationLeastFull: func(buckets []models.BucketOccupancy) int {
+		picked := 0
+		for i, bucket := range buckets {
+			if buckets[picked].FullerThan(bucket) {
+				picked = i
+			}
+		}
+		return picked
+	},
+	models.BucketAllocationMostFull: func(buckets []models.BucketOccupancy) int {
+		picked := 0
+		for i, bucket := range buckets {
+			if bucket.FullerThan(buckets[picked]) {
+				picked = i
+			}
+		}
+		return picked
+	},
+	models.BucketAllocationGroupByName: func(buckets []models.BucketOccupancy) int {
+		picked := 0
+		for i, bucket := range buckets {
+			if bucket.SameNameFruits > buckets[picked].SameNameFruits {
+				picked = i
+			}
+		}
+		return picked
+	},
+	models.BucketAllocationGroupByExpiry: func(buckets []models.BucketOccupancy) int {
+		picked := 0
+		for i, bucket := range buckets {
+			if bucket.ExpiryDistance == nil {
+				continue
+			}
+			if distance := buckets[picked].ExpiryDistance; distance == nil || *bucket.ExpiryDistance < *distance {
+				picked = i
+			}
+		}
+		return picked
+	},
+}
+
+// allocateBucket picks with the given strategy a bucket with free capacity for
+// the fruit, within the transaction creating it. Quarantine buckets and the
+// ones holding fruits the compatibility rules keep apart from it are left out.
+// The picked bucket is claimed, locking and checking it again, and the next
+// best one is tried when it was filled in meanwhile
+func allocateBucket(ctx context.Context, tx *gorm.DB, strategy string, fruit models.Fruit, rules models.CompatibilityRules, now time.Time,
+	claim func(bucketID int64) error) (int64, error) {
+	query := tx.Session(&gorm.Session{NewDB: true})
+	if incompatible := rules.IncompatibleWith(fruit.Name); len(incompatible) > 0 {
+		query = query.Where(`NOT EXISTS (SELECT 1 FROM fruits AS others
+				WHERE others.bucket_fk = buckets.id
+				AND others.deleted_at IS NULL
+				AND others.expires_at > ?
+				AND others.state IN ?
+				AND others.name IN ?)`, now, models.FruitActiveStates, incompatible)
+	}
+
+	rows, err := query.Model(&models.Bucket{}).
+		Select(`buckets.id,
+				buckets.capacity,
+				COUNT(fruits.id) AS total_fruits,
+				IFNULL(SUM(fruits.name = ?), 0) AS same_name_fruits,
+				AVG(ABS(TIMESTAMPDIFF(SECOND, fruits.expires_at, ?))) AS expiry_distance`, fruit.Name, fruit.ExpiresAt).
+		Scopes(withValidFruits(now)).
+		Where("buckets.deleted_at IS NULL AND buckets.quarantine = ?", false).
+		Group("buckets.id").
+		Having("COUNT(fruits.id) < buckets.capacity").
+		Order("buckets.created_at, buckets.id").
+		Rows()
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+
+	buckets := []models.BucketOccupancy{}
+	for rows.Next() {
+		bucket := models.BucketOccupancy{}
+		dest := []interface{}{
+			&bucket.ID,
+			&bucket.Capacity,
+			&bucket.TotalFruits,
+			&bucket.SameNameFruits,
+			&bucket.ExpiryDistance,
+		}
+
+		if err := rows.Scan(dest...); err != nil {
+			return 0, err
+		}
+
+		buckets = append(buckets, bucket)
+	}
+
+	for len(buckets) > 0 {
+		picked := bucketAllocators[strategy](buckets)
+		bucketID := buckets[picked].ID
+
+		err := claim(bucketID)
+		if err == nil {
+			return bucketID, nil
+		}
+		if _, ok := err.(*exceptions.ForbiddenException); !ok {
+			return 0, err
+		}
+
+		buckets = append(buckets[:picked], buckets[picked+1:]...)
+	}
+
+	return 0, exceptions.NewForbiddenException("No bucket has free capacity")
+}
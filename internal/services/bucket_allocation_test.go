@@ -0,0 +1,70 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/viniosilva/where-are-my-fruits/internal/models"
+)
+
+func TestBucketAllocators(t *testing.T) {
+	near := 60.0
+	far := 3600.0
+	buckets := []models.BucketOccupancy{
+		{ID: 1, Capacity: 10, TotalFruits: 5, SameNameFruits: 0, ExpiryDistance: &far},
+		{ID: 2, Capacity: 4, TotalFruits: 3, SameNameFruits: 1, ExpiryDistance: &near},
+		{ID: 3, Capacity: 10, TotalFruits: 0},
+		{ID: 4, Capacity: 10, TotalFruits: 6, SameNameFruits: 4, ExpiryDistance: &far},
+	}
+
+	tests := map[string]struct {
+		strategy string
+		buckets  []models.BucketOccupancy
+		want     int64
+	}{
+		"first_fit should pick the oldest bucket": {
+			strategy: models.BucketAllocationFirstFit,
+			buckets:  buckets,
+			want:     1,
+		},
+		"least_full should pick the bucket with the lowest share in use": {
+			strategy: models.BucketAllocationLeastFull,
+			buckets:  buckets,
+			want:     3,
+		},
+		"most_full should pick the bucket with the highest share in use": {
+			strategy: models.BucketAllocationMostFull,
+			buckets:  buckets,
+			want:     2,
+		},
+		"group_by_name should pick the bucket with most fruits of the same name": {
+			strategy: models.BucketAllocationGroupByName,
+			buckets:  buckets,
+			want:     4,
+		},
+		"group_by_name should pick the oldest bucket when no bucket has the name": {
+			strategy: models.BucketAllocationGroupByName,
+			buckets:  buckets[2:3],
+			want:     3,
+		},
+		"group_by_expiry should pick the bucket whose fruits expire closest": {
+			strategy: models.BucketAllocationGroupByExpiry,
+			buckets:  buckets,
+			want:     2,
+		},
+		"group_by_expiry should skip empty buckets": {
+			strategy: models.BucketAllocationGroupByExpiry,
+			buckets:  []models.BucketOccupancy{buckets[2], buckets[3]},
+			want:     4,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			// when
+			got := tt.buckets[bucketAllocators[tt.strategy](tt.buckets)]
+
+			// then
+			assert.Equal(t, tt.want, got.ID)
+		})
+	}
+}
@@ -0,0 +1,194 @@
+package services
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/viniosilva/where-are-my-fruits/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// splitForConsolidation picks the fewest buckets whose capacity holds all the
+// valid fruits, the others to be emptied into them. Buckets holding pinned
+// fruits are always kept, then the largest ones, the fullest first among the
+// same capacity so that less fruits move
+func splitForConsolidation(buckets []models.BucketOccupancy) (kept, emptied []models.BucketOccupancy) {
+	candidates := []models.BucketOccupancy{}
+	var total, capacity int64
+	for _, bucket := range buckets {
+		total += bucket.TotalFruits
+		if bucket.TotalPinned > 0 {
+			kept = append(kept, bucket)
+			capacity += int64(bucket.Capacity)
+		} else {
+			candidates = append(candidates, bucket)
+		}
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].Capacity != candidates[j].Capacity {
+			return candidates[i].Capacity > candidates[j].Capacity
+		}
+		return candidates[i].TotalFruits > candidates[j].TotalFruits
+	})
+
+	for i, bucket := range candidates {
+		if capacity >= total {
+			return kept, candidates[i:]
+		}
+		kept = append(kept, bucket)
+		capacity += int64(bucket.Capacity)
+	}
+
+	return kept, nil
+}
+
+// planConsolidation proposes the moves emptying the most buckets from their
+// current occupancy. Quarantine buckets are left as they are, reserved or
+// quarantined fruits pin the bucket they are in and no fruit goes where the
+// compatibility rules keep it apart from the fruits there. The locks are added
+// to the queries when the plan is about to be applied
+func planConsolidation(db *gorm.DB, rules models.CompatibilityRules, now time.Time, locks ...clause.Expression) (*models.BucketConsolidation, error) {
+	rows, err := db.Session(&gorm.Session{NewDB: true}).Model(&models.Bucket{}).
+		Select(`buckets.id,
+				buckets.capacity,
+				COUNT(fruits.id) AS total_fruits,
+				IFNULL(SUM(fruits.reserved_until > ? OR fruits.quarantined_at IS NOT NULL), 0) AS total_pinned`, now).
+		Scopes(withValidFruits(now)).
+		Where("buckets.deleted_at IS NULL AND buckets.quarantine = ?", false).
+		Group("buckets.id").
+		Having("COUNT(fruits.id) > 0").
+		Order("buckets.id").
+		Clauses(locks...).
+		Rows()
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	buckets := []models.BucketOccupancy{}
+	for rows.Next() {
+		bucket := models.BucketOccupancy{}
+		dest := []interface{}{
+			&bucket.ID,
+			&bucket.Capacity,
+			&bucket.TotalFruits,
+			&bucket.TotalPinned,
+		}
+
+		if err := rows.Scan(dest...); err != nil {
+			return nil, err
+		}
+
+		buckets = append(buckets, bucket)
+	}
+
+	consolidation := models.BucketConsolidation{
+		Moves:            []models.BucketConsolidationMove{},
+		EmptiedBucketIDs: []int64{},
+	}
+
+	kept, emptied := splitForConsolidation(buckets)
+	if len(emptied) == 0 {
+		return &consolidation, nil
+	}
+
+	ids := []int64{}
+	for _, bucket := range emptied {
+		ids = append(ids, bucket.ID)
+	}
+
+	var fruits []models.Fruit
+	res := db.Session(&gorm.Session{NewDB: true}).
+		Where(`bucket_fk IN ?
+			AND deleted_at IS NULL
+			AND expires_at > ?
+			AND state IN ?
+		`, ids, now, models.FruitActiveStates).
+		Order("bucket_fk, id").
+		Clauses(locks...).
+		Find(&fruits)
+	if err := res.Error; err != nil {
+		return nil, err
+	}
+
+	names := map[int64][]string{}
+	if len(rules) > 0 {
+		keptIDs := []int64{}
+		for _, bucket := range kept {
+			keptIDs = append(keptIDs, bucket.ID)
+		}
+
+		// Get the names already in the buckets kept
+		var placed []models.Fruit
+		res := db.Session(&gorm.Session{NewDB: true}).
+			Distinct("bucket_fk", "name").
+			Where(`bucket_fk IN ?
+				AND deleted_at IS NULL
+				AND expires_at > ?
+				AND state IN ?
+			`, keptIDs, now, models.FruitActiveStates).
+			Find(&placed)
+		if err := res.Error; err != nil {
+			return nil, err
+		}
+
+		for _, fruit := range placed {
+			names[*fruit.BucketID] = append(names[*fruit.BucketID], fruit.Name)
+		}
+	}
+
+	free := make([]int64, len(kept))
+	for i, bucket := range kept {
+		free[i] = bucket.Free()
+	}
+
+	// The fruits left behind are the ones pinned, filled in since the
+	// occupancy was read or with no compatible bucket to go
+	left := map[int64]bool{}
+	for _, fruit := range fruits {
+		target := -1
+		if !fruit.IsReserved(now) && !fruit.IsQuarantined() {
+			incompatible := rules.IncompatibleWith(fruit.Name)
+			for i, bucket := range kept {
+				if free[i] > 0 && !holdsAny(names[bucket.ID], incompatible) {
+					target = i
+					break
+				}
+			}
+		}
+		if target == -1 {
+			left[*fruit.BucketID] = true
+			continue
+		}
+
+		consolidation.Moves = append(consolidation.Moves, models.BucketConsolidationMove{
+			FruitID:      fruit.ID,
+			FromBucketID: *fruit.BucketID,
+			ToBucketID:   kept[target].ID,
+		})
+		free[target]--
+		names[kept[target].ID] = append(names[kept[target].ID], fruit.Name)
+	}
+
+	for _, bucket := range emptied {
+		if !left[bucket.ID] {
+			consolidation.EmptiedBucketIDs = append(consolidation.EmptiedBucketIDs, bucket.ID)
+		}
+	}
+
+	return &consolidation, nil
+}
+
+func holdsAny(names, others []string) bool {
+	for _, name := range names {
+		for _, other := range others {
+			if strings.EqualFold(name, other) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,69 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/viniosilva/where-are-my-fruits/internal/models"
+)
+
+func TestSplitForConsolidation(t *testing.T) {
+	tests := map[string]struct {
+		buckets     []models.BucketOccupancy
+		wantKept    []int64
+		wantEmptied []int64
+	}{
+		"should keep the largest buckets and empty the others": {
+			buckets: []models.BucketOccupancy{
+				{ID: 1, Capacity: 4, TotalFruits: 2},
+				{ID: 2, Capacity: 10, TotalFruits: 6},
+				{ID: 3, Capacity: 10, TotalFruits: 1},
+			},
+			wantKept:    []int64{2},
+			wantEmptied: []int64{3, 1},
+		},
+		"should keep the fullest among buckets of the same capacity": {
+			buckets: []models.BucketOccupancy{
+				{ID: 1, Capacity: 10, TotalFruits: 3},
+				{ID: 2, Capacity: 10, TotalFruits: 5},
+			},
+			wantKept:    []int64{2},
+			wantEmptied: []int64{1},
+		},
+		"should keep buckets holding pinned fruits": {
+			buckets: []models.BucketOccupancy{
+				{ID: 1, Capacity: 10, TotalFruits: 5},
+				{ID: 2, Capacity: 4, TotalFruits: 1, TotalPinned: 1},
+			},
+			wantKept:    []int64{2, 1},
+			wantEmptied: []int64{},
+		},
+		"should keep every bucket when none can be emptied": {
+			buckets: []models.BucketOccupancy{
+				{ID: 1, Capacity: 10, TotalFruits: 8},
+				{ID: 2, Capacity: 10, TotalFruits: 7},
+			},
+			wantKept:    []int64{1, 2},
+			wantEmptied: []int64{},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			// when
+			kept, emptied := splitForConsolidation(tt.buckets)
+
+			// then
+			keptIDs := []int64{}
+			for _, bucket := range kept {
+				keptIDs = append(keptIDs, bucket.ID)
+			}
+			emptiedIDs := []int64{}
+			for _, bucket := range emptied {
+				emptiedIDs = append(emptiedIDs, bucket.ID)
+			}
+
+			assert.Equal(t, tt.wantKept, keptIDs)
+			assert.Equal(t, tt.wantEmptied, emptiedIDs)
+		})
+	}
+}
@@ -30,7 +30,7 @@ func TestBucketService_NewBucket(t *testing.T) {
 		validate := infra.NewValidator()
 
 		// given
-		got := NewBucket(nil, loggerMock, validate)
+		got := NewBucket(nil, loggerMock, validate, nil, nil)
 
 		// then
 		assert.NotNil(t, got)
@@ -130,7 +130,7 @@ func TestBucketService_Create(t *testing.T) {
 			tt.mock(sqlMock, loggerMock, timeMock)
 
 			// given
-			service := NewBucket(database, loggerMock, validate)
+			service := NewBucket(database, loggerMock, validate, nil, nil)
 
 			// when
 			got, err := service.Create(ctx, tt.data)
@@ -159,9 +159,12 @@ func TestBucketService_List(t *testing.T) {
 				mTime.EXPECT().Now().Return(now)
 
 				rows := sqlmock.
-					NewRows([]string{"id", "name", "capacity", "total_fruits", "total_price", "percent"}).
-					AddRow(int64(1), "Testing", 4, int64(3), decimal.NewFromFloat32(16.32), decimal.NewFromInt32(75)).
-					AddRow(int64(2), "Testing_2", 3, int64(1), decimal.NewFromFloat32(6.25), decimal.NewFromFloat32(33.33))
+					NewRows([]string{"id", "name", "capacity", "total_fruits", "total_price", "percent",
+						"total_unripe", "total_ripe", "total_overripe", "total_reserved", "total_effective_price"}).
+					AddRow(int64(1), "Testing", 4, int64(3), decimal.NewFromFloat32(16.32), decimal.NewFromInt32(75),
+						int64(1), int64(1), int64(1), int64(1), decimal.NewFromFloat32(13.06)).
+					AddRow(int64(2), "Testing_2", 3, int64(1), decimal.NewFromFloat32(6.25), decimal.NewFromFloat32(33.33),
+						int64(0), int64(1), int64(0), int64(0), decimal.NewFromFloat32(6.25))
 
 				db.ExpectQuery("SELECT").WillReturnRows(rows)
 			},
@@ -175,6 +178,13 @@ func TestBucketService_List(t *testing.T) {
 					TotalFruits: 3,
 					TotalPrice:  decimal.NewFromFloat32(16.32),
 					Percent:     decimal.NewFromInt32(75),
+
+					TotalEffectivePrice: decimal.NewFromFloat32(13.06),
+					TotalReserved:       1,
+
+					TotalUnripe:   1,
+					TotalRipe:     1,
+					TotalOverripe: 1,
 				},
 				{
 					ID:          2,
@@ -183,6 +193,12 @@ func TestBucketService_List(t *testing.T) {
 					TotalFruits: 1,
 					TotalPrice:  decimal.NewFromFloat32(6.25),
 					Percent:     decimal.NewFromFloat32(33.33),
+
+					TotalEffectivePrice: decimal.NewFromFloat32(6.25),
+
+					TotalUnripe:   0,
+					TotalRipe:     1,
+					TotalOverripe: 0,
 				},
 			},
 		},
@@ -202,8 +218,10 @@ func TestBucketService_List(t *testing.T) {
 				mTime.EXPECT().Now().Return(now)
 
 				rows := sqlmock.
-					NewRows([]string{"id", "name", "capacity", "total_fruits", "total_price", "percent"}).
-					AddRow(int64(1), "Testing", 4, int64(3), decimal.NewFromFloat32(16.32), nil)
+					NewRows([]string{"id", "name", "capacity", "total_fruits", "total_price", "percent",
+						"total_unripe", "total_ripe", "total_overripe", "total_reserved", "total_effective_price"}).
+					AddRow(int64(1), "Testing", 4, int64(3), decimal.NewFromFloat32(16.32), nil,
+						int64(1), int64(1), int64(1), int64(1), decimal.NewFromFloat32(13.06))
 
 				db.ExpectQuery("SELECT").WillReturnRows(rows)
 				logger.EXPECT().Error(gomock.Any())
@@ -243,8 +261,13 @@ func TestBucketService_List(t *testing.T) {
 
 			tt.mock(sqlMock, loggerMock, timeMock)
 
+			markdowns := models.MarkdownRules{
+				{Within: 48 * time.Hour, Percent: decimal.NewFromInt(20)},
+				{Within: 12 * time.Hour, Percent: decimal.NewFromInt(50)},
+			}
+
 			// given
-			service := NewBucket(database, loggerMock, validate)
+			service := NewBucket(database, loggerMock, validate, markdowns, nil)
 
 			// when
 			got, err := service.List(ctx, tt.page, tt.pageSize)
@@ -367,7 +390,7 @@ func TestBucketService_Delete(t *testing.T) {
 			tt.mock(sqlMock, loggerMock, timeMock)
 
 			// given
-			service := NewBucket(database, loggerMock, nil)
+			service := NewBucket(database, loggerMock, nil, nil, nil)
 
 			// when
 			err = service.Delete(ctx, tt.fruitID)
@@ -379,3 +402,301 @@ func TestBucketService_Delete(t *testing.T) {
 		})
 	}
 }
+
+func TestBucketService_Consolidate(t *testing.T) {
+	now := time.Date(2000, 12, 31, 23, 59, 59, 0, time.Local)
+	reservedUntil := now.Add(time.Hour)
+
+	occupancyColumns := []string{"id", "capacity", "total_fruits", "total_pinned"}
+	fruitsColumns := []string{"id", "bucket_fk", "reserved_until", "quarantined_at"}
+
+	tests := map[string]struct {
+		mock    func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime)
+		rules   models.CompatibilityRules
+		apply   bool
+		want    *models.BucketConsolidation
+		wantErr string
+	}{
+		"should plan moves without applying them": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {
+				mTime.EXPECT().Now().Return(now)
+
+				db.ExpectQuery("SELECT buckets.id").WillReturnRows(sqlmock.NewRows(occupancyColumns).
+					AddRow(1, 10, 6, 0).
+					AddRow(2, 4, 2, 0).
+					AddRow(3, 10, 1, 0))
+				db.ExpectQuery("SELECT \\* FROM `fruits`").WithArgs(3, 2, now, models.FruitStateUnripe, models.FruitStateRipe, models.FruitStateOverripe).
+					WillReturnRows(sqlmock.NewRows(fruitsColumns).
+						AddRow(4, 2, nil, nil).
+						AddRow(5, 2, nil, nil).
+						AddRow(7, 3, nil, nil))
+			},
+			want: &models.BucketConsolidation{
+				Moves: []models.BucketConsolidationMove{
+					{FruitID: 4, FromBucketID: 2, ToBucketID: 1},
+					{FruitID: 5, FromBucketID: 2, ToBucketID: 1},
+					{FruitID: 7, FromBucketID: 3, ToBucketID: 1},
+				},
+				EmptiedBucketIDs: []int64{3, 2},
+			},
+		},
+		"should apply moves in one transaction": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {
+				mTime.EXPECT().Now().Return(now)
+
+				db.ExpectBegin()
+				db.ExpectQuery("SELECT buckets.id.* FOR UPDATE").WillReturnRows(sqlmock.NewRows(occupancyColumns).
+					AddRow(1, 10, 6, 0).
+					AddRow(2, 4, 2, 0))
+				db.ExpectQuery("SELECT \\* FROM `fruits`.* FOR UPDATE").WillReturnRows(sqlmock.NewRows(fruitsColumns).
+					AddRow(4, 2, nil, nil).
+					AddRow(5, 2, nil, nil))
+				db.ExpectExec("UPDATE `fruits` SET `bucket_fk`").WithArgs(1, 4, 5).WillReturnResult(sqlmock.NewResult(0, 2))
+				db.ExpectExec("INSERT INTO `movements`").WillReturnResult(sqlmock.NewResult(1, 2))
+				db.ExpectCommit()
+			},
+			apply: true,
+			want: &models.BucketConsolidation{
+				Moves: []models.BucketConsolidationMove{
+					{FruitID: 4, FromBucketID: 2, ToBucketID: 1},
+					{FruitID: 5, FromBucketID: 2, ToBucketID: 1},
+				},
+				EmptiedBucketIDs: []int64{2},
+				Applied:          true,
+			},
+		},
+		"should leave a bucket with a fruit reserved meanwhile": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {
+				mTime.EXPECT().Now().Return(now)
+
+				db.ExpectQuery("SELECT buckets.id").WillReturnRows(sqlmock.NewRows(occupancyColumns).
+					AddRow(1, 10, 6, 0).
+					AddRow(2, 4, 2, 0))
+				db.ExpectQuery("SELECT \\* FROM `fruits`").WillReturnRows(sqlmock.NewRows(fruitsColumns).
+					AddRow(4, 2, nil, nil).
+					AddRow(5, 2, reservedUntil, nil))
+			},
+			want: &models.BucketConsolidation{
+				Moves: []models.BucketConsolidationMove{
+					{FruitID: 4, FromBucketID: 2, ToBucketID: 1},
+				},
+				EmptiedBucketIDs: []int64{},
+			},
+		},
+		"should leave a fruit incompatible with the buckets kept": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {
+				mTime.EXPECT().Now().Return(now)
+
+				db.ExpectQuery("SELECT buckets.id").WillReturnRows(sqlmock.NewRows(occupancyColumns).
+					AddRow(1, 10, 6, 0).
+					AddRow(2, 10, 1, 0).
+					AddRow(3, 4, 1, 0))
+				db.ExpectQuery("SELECT \\* FROM `fruits`").WithArgs(2, 3, now, models.FruitStateUnripe, models.FruitStateRipe, models.FruitStateOverripe).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "bucket_fk", "name"}).
+						AddRow(7, 2, "apple").
+						AddRow(8, 3, "banana"))
+				db.ExpectQuery("SELECT DISTINCT `bucket_fk`,`name` FROM `fruits`").WithArgs(1, now, models.FruitStateUnripe, models.FruitStateRipe, models.FruitStateOverripe).
+					WillReturnRows(sqlmock.NewRows([]string{"bucket_fk", "name"}).
+						AddRow(1, "kiwi"))
+			},
+			rules: models.CompatibilityRules{
+				{
+					A: models.CompatibilityGroup{Name: "apple", Fruits: []string{"apple"}},
+					B: models.CompatibilityGroup{Name: "kiwi", Fruits: []string{"kiwi"}},
+				},
+			},
+			want: &models.BucketConsolidation{
+				Moves: []models.BucketConsolidationMove{
+					{FruitID: 8, FromBucketID: 3, ToBucketID: 1},
+				},
+				EmptiedBucketIDs: []int64{3},
+			},
+		},
+		"should propose nothing when no bucket can be emptied": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {
+				mTime.EXPECT().Now().Return(now)
+
+				db.ExpectQuery("SELECT buckets.id").WillReturnRows(sqlmock.NewRows(occupancyColumns).
+					AddRow(1, 10, 8, 0).
+					AddRow(2, 10, 7, 0))
+			},
+			want: &models.BucketConsolidation{
+				Moves:            []models.BucketConsolidationMove{},
+				EmptiedBucketIDs: []int64{},
+			},
+		},
+		"should throw error when reading occupancy": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {
+				mTime.EXPECT().Now().Return(now)
+
+				db.ExpectQuery("SELECT buckets.id").WillReturnError(fmt.Errorf("error"))
+
+				logger.EXPECT().Error(gomock.Any())
+			},
+			wantErr: "error",
+		},
+		"should throw error when applying moves": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {
+				mTime.EXPECT().Now().Return(now)
+
+				db.ExpectBegin()
+				db.ExpectQuery("SELECT buckets.id").WillReturnRows(sqlmock.NewRows(occupancyColumns).
+					AddRow(1, 10, 6, 0).
+					AddRow(2, 4, 2, 0))
+				db.ExpectQuery("SELECT \\* FROM `fruits`").WillReturnRows(sqlmock.NewRows(fruitsColumns).
+					AddRow(4, 2, nil, nil).
+					AddRow(5, 2, nil, nil))
+				db.ExpectExec("UPDATE `fruits`").WillReturnError(fmt.Errorf("error"))
+				db.ExpectRollback()
+
+				logger.EXPECT().Error(gomock.Any())
+			},
+			apply:   true,
+			wantErr: "error",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			//setup
+			ctx := context.Background()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			db, sqlMock, err := sqlmock.New()
+			require.Nil(t, err)
+			defer db.Close()
+
+			dialector := mysql.New(mysql.Config{
+				DSN:                       "sqlmock_db_0",
+				DriverName:                "mysql",
+				Conn:                      db,
+				SkipInitializeWithVersion: true,
+			})
+
+			gormDB, err := gorm.Open(dialector, &gorm.Config{})
+			require.Nil(t, err)
+			database := &infra.Database{DB: gormDB, SQL: db}
+
+			loggerMock := mocks.NewMockLogger(ctrl)
+			timeMock := mocks.NewMockTime(ctrl)
+			_time = timeMock
+
+			tt.mock(sqlMock, loggerMock, timeMock)
+
+			// given
+			service := NewBucket(database, loggerMock, nil, nil, tt.rules)
+
+			// when
+			got, err := service.Consolidate(ctx, tt.apply)
+
+			// then
+			if err != nil || tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			}
+			assert.Equal(t, tt.want, got)
+			assert.Nil(t, sqlMock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestBucketService_ListViolations(t *testing.T) {
+	now := time.Date(2000, 12, 31, 23, 59, 59, 0, time.Local)
+	rules := models.CompatibilityRules{
+		{
+			A: models.CompatibilityGroup{Name: "ethylene_producers", Fruits: []string{"apple", "banana"}},
+			B: models.CompatibilityGroup{Name: "ethylene_sensitive", Fruits: []string{"kiwi"}},
+		},
+	}
+
+	tests := map[string]struct {
+		mock    func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime)
+		rules   models.CompatibilityRules
+		want    []models.BucketViolations
+		wantErr string
+	}{
+		"should list buckets breaking the rules": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {
+				mTime.EXPECT().Now().Return(now)
+
+				db.ExpectQuery("SELECT DISTINCT buckets.id, buckets.name, fruits.name").
+					WithArgs(now, models.FruitStateUnripe, models.FruitStateRipe, models.FruitStateOverripe).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "name", "name"}).
+						AddRow(1, "A", "apple").
+						AddRow(1, "A", "banana").
+						AddRow(1, "A", "kiwi").
+						AddRow(2, "B", "apple").
+						AddRow(2, "B", "grape"))
+			},
+			rules: rules,
+			want: []models.BucketViolations{
+				{
+					ID:   1,
+					Name: "A",
+					Violations: []models.CompatibilityViolation{
+						{Rule: "ethylene_producers/ethylene_sensitive", Fruit: "apple", Other: "kiwi"},
+						{Rule: "ethylene_producers/ethylene_sensitive", Fruit: "banana", Other: "kiwi"},
+					},
+				},
+			},
+		},
+		"should be empty when there are no rules": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {},
+			want: []models.BucketViolations{},
+		},
+		"should throw error": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {
+				mTime.EXPECT().Now().Return(now)
+
+				db.ExpectQuery("SELECT").WillReturnError(fmt.Errorf("error"))
+
+				logger.EXPECT().Error(gomock.Any())
+			},
+			rules:   rules,
+			wantErr: "error",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			//setup
+			ctx := context.Background()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			db, sqlMock, err := sqlmock.New()
+			require.Nil(t, err)
+			defer db.Close()
+
+			dialector := mysql.New(mysql.Config{
+				DSN:                       "sqlmock_db_0",
+				DriverName:                "mysql",
+				Conn:                      db,
+				SkipInitializeWithVersion: true,
+			})
+
+			gormDB, err := gorm.Open(dialector, &gorm.Config{})
+			require.Nil(t, err)
+			database := &infra.Database{DB: gormDB, SQL: db}
+
+			loggerMock := mocks.NewMockLogger(ctrl)
+			timeMock := mocks.NewMockTime(ctrl)
+			_time = timeMock
+
+			tt.mock(sqlMock, loggerMock, timeMock)
+
+			// given
+			service := NewBucket(database, loggerMock, nil, nil, tt.rules)
+
+			// when
+			got, err := service.ListViolations(ctx)
+
+			// then
+			if err != nil || tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			}
+			assert.Equal(t, tt.want, got)
+			assert.Nil(t, sqlMock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package services
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/viniosilva/where-are-my-fruits/internal/exceptions"
+	"github.com/viniosilva/where-are-my-fruits/internal/models"
+	"gorm.io/gorm"
+)
+
+// validateCompatibility refuses putting fruits of the given names in the bucket
+// when a storage rule keeps them apart, from each other or from the valid
+// fruits already there
+func validateCompatibility(tx *gorm.DB, rules models.CompatibilityRules, bucketID int64, names []string, now time.Time) error {
+	incompatible := []string{}
+	for i, name := range names {
+		for _, other := range names[i+1:] {
+			if rules.Violation(name, other) != nil {
+				return incompatibleException(name, other)
+			}
+		}
+		incompatible = append(incompatible, rules.IncompatibleWith(name)...)
+	}
+	if len(incompatible) == 0 {
+		return nil
+	}
+
+	// Get the incompatible names already in the bucket
+	var others []string
+	res := tx.Session(&gorm.Session{NewDB: true}).Model(&models.Fruit{}).
+		Distinct("name").
+		Where(`bucket_fk = ?
+			AND deleted_at IS NULL
+			AND expires_at > ?
+			AND state IN ?
+			AND name IN ?
+		`, bucketID, now, models.FruitActiveStates, incompatible).
+		Pluck("name", &others)
+	if err := res.Error; err != nil {
+		return err
+	}
+
+	for _, name := range names {
+		for _, other := range others {
+			if rules.Violation(name, other) != nil {
+				return incompatibleException(name, other)
+			}
+		}
+	}
+
+	return nil
+}
+
+func incompatibleException(name, other string) error {
+	return exceptions.NewForbiddenException(fmt.Sprintf("Fruit %s cannot share a bucket with %s", name, other))
+}
@@ -0,0 +1,102 @@
+package services
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/viniosilva/where-are-my-fruits/internal/models"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+func TestValidateCompatibility(t *testing.T) {
+	now := time.Date(2000, 12, 31, 23, 59, 59, 0, time.Local)
+	rules := models.CompatibilityRules{
+		{
+			A: models.CompatibilityGroup{Name: "ethylene_producers", Fruits: []string{"apple", "banana"}},
+			B: models.CompatibilityGroup{Name: "ethylene_sensitive", Fruits: []string{"kiwi"}},
+		},
+	}
+
+	tests := map[string]struct {
+		mock    func(db sqlmock.Sqlmock)
+		rules   models.CompatibilityRules
+		names   []string
+		wantErr string
+	}{
+		"should be success when there are no rules": {
+			mock:  func(db sqlmock.Sqlmock) {},
+			names: []string{"apple", "kiwi"},
+		},
+		"should be success when the bucket holds no incompatible fruit": {
+			mock: func(db sqlmock.Sqlmock) {
+				db.ExpectQuery("SELECT DISTINCT `name` FROM `fruits`").
+					WithArgs(1, now, models.FruitStateUnripe, models.FruitStateRipe, models.FruitStateOverripe, "kiwi").
+					WillReturnRows(sqlmock.NewRows([]string{"name"}))
+			},
+			rules: rules,
+			names: []string{"apple"},
+		},
+		"should be success when the fruit is in no rule": {
+			mock:  func(db sqlmock.Sqlmock) {},
+			rules: rules,
+			names: []string{"grape"},
+		},
+		"should throw forbidden error when the bucket holds an incompatible fruit": {
+			mock: func(db sqlmock.Sqlmock) {
+				db.ExpectQuery("SELECT DISTINCT `name` FROM `fruits`").
+					WillReturnRows(sqlmock.NewRows([]string{"name"}).AddRow("apple"))
+			},
+			rules:   rules,
+			names:   []string{"kiwi"},
+			wantErr: "Fruit kiwi cannot share a bucket with apple",
+		},
+		"should throw forbidden error when the fruits are incompatible with each other": {
+			mock:    func(db sqlmock.Sqlmock) {},
+			rules:   rules,
+			names:   []string{"banana", "kiwi"},
+			wantErr: "Fruit banana cannot share a bucket with kiwi",
+		},
+		"should throw error when getting the fruits in the bucket": {
+			mock: func(db sqlmock.Sqlmock) {
+				db.ExpectQuery("SELECT DISTINCT `name` FROM `fruits`").WillReturnError(fmt.Errorf("error"))
+			},
+			rules:   rules,
+			names:   []string{"apple"},
+			wantErr: "error",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			//setup
+			db, sqlMock, err := sqlmock.New()
+			require.Nil(t, err)
+			defer db.Close()
+
+			dialector := mysql.New(mysql.Config{
+				DSN:                       "sqlmock_db_0",
+				DriverName:                "mysql",
+				Conn:                      db,
+				SkipInitializeWithVersion: true,
+			})
+
+			gormDB, err := gorm.Open(dialector, &gorm.Config{})
+			require.Nil(t, err)
+
+			tt.mock(sqlMock)
+
+			// when
+			err = validateCompatibility(gormDB, tt.rules, 1, tt.names, now)
+
+			// then
+			if err != nil || tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			}
+			assert.Nil(t, sqlMock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,430 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/viniosilva/where-are-my-fruits/internal/dtos"
+	"github.com/viniosilva/where-are-my-fruits/internal/exceptions"
+	"github.com/viniosilva/where-are-my-fruits/internal/infra"
+	"github.com/viniosilva/where-are-my-fruits/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type CycleCountService struct {
+	db       *infra.Database
+	logger   Logger
+	validate Validate
+}
+
+func NewCycleCount(db *infra.Database, logger Logger, validate Validate) *CycleCountService {
+	return &CycleCountService{
+		db:       db,
+		logger:   logger,
+		validate: validate,
+	}
+}
+
+// Open starts a count of the bucket. A bucket has at most one count not yet
+// approved at a time
+func (impl *CycleCountService) Open(ctx context.Context, bucketID int64) (*models.CycleCount, error) {
+	count := models.CycleCount{
+		CreatedAt:   _time.Now(),
+		BucketID:    bucketID,
+		Items:       []models.CycleCountItem{},
+		Adjustments: []models.CycleCountAdjustment{},
+	}
+
+	err := impl.db.DB.Transaction(func(tx *gorm.DB) error {
+		var bucket models.Bucket
+		res := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ? AND deleted_at IS NULL", bucketID).
+			First(&bucket)
+		if err := res.Error; err != nil {
+			if err.Error() == infra.MYSQL_ERROR_NOT_FOUND {
+				return exceptions.NewNotFoundException("Bucket not found")
+			}
+
+			return err
+		}
+
+		var open int64
+		res = tx.Session(&gorm.Session{NewDB: true}).Model(&models.CycleCount{}).
+			Where("bucket_fk = ? AND approved_at IS NULL", bucketID).
+			Count(&open)
+		if err := res.Error; err != nil {
+			return err
+		}
+		if open > 0 {
+			return exceptions.NewConflictException(fmt.Sprintf("Bucket %d already has a cycle count in progress", bucketID))
+		}
+
+		return tx.Session(&gorm.Session{NewDB: true}).Omit(clause.Associations).Create(&count).Error
+	}, &sql.TxOptions{Isolation: sql.LevelReadCommitted})
+
+	if err != nil {
+		impl.logError(err)
+		return nil, err
+	}
+
+	return &count, nil
+}
+
+// Get returns the count and, while it waits for approval, its variance against
+// the current content of the bucket
+func (impl *CycleCountService) Get(ctx context.Context, id int64) (*models.CycleCount, error) {
+	var count models.CycleCount
+	res := impl.db.DB.Preload("Items").Preload("Adjustments").Where("id = ?", id).First(&count)
+	if err := res.Error; err != nil {
+		if err.Error() == infra.MYSQL_ERROR_NOT_FOUND {
+			err := exceptions.NewNotFoundException("Cycle count not found")
+			impl.logger.Warn(err.Error())
+			return nil, err
+		}
+
+		impl.logger.Error(err.Error())
+		return nil, err
+	}
+
+	if count.Status() == models.CycleCountStatusSubmitted {
+		variance, _, err := cycleCountVariance(impl.db.DB, count)
+		if err != nil {
+			impl.logger.Error(err.Error())
+			return nil, err
+		}
+		count.Variance = variance
+	}
+
+	return &count, nil
+}
+
+// Submit records what was physically found in the bucket and returns the
+// variance against what the database says is in it
+func (impl *CycleCountService) Submit(ctx context.Context, id int64, data dtos.SubmitCycleCountDto) (*models.CycleCount, error) {
+	if err := impl.validate.Struct(data); err != nil {
+		return nil, exceptions.NewValidationException(err)
+	}
+
+	now := _time.Now()
+
+	var count models.CycleCount
+	err := impl.db.DB.Transaction(func(tx *gorm.DB) error {
+		if err := impl.lock(tx, id, &count); err != nil {
+			return err
+		}
+		if status := count.Status(); status != models.CycleCountStatusOpen {
+			return exceptions.NewForbiddenException(fmt.Sprintf("Cycle count is %s", status))
+		}
+
+		items, err := impl.items(tx.Session(&gorm.Session{NewDB: true}), id, data)
+		if err != nil {
+			return err
+		}
+		if len(items) > 0 {
+			if err := tx.Session(&gorm.Session{NewDB: true}).Create(&items).Error; err != nil {
+				return err
+			}
+		}
+
+		res := tx.Session(&gorm.Session{NewDB: true}).Model(&models.CycleCount{}).
+			Where("id = ?", id).
+			Update("submitted_at", now)
+		if err := res.Error; err != nil {
+			return err
+		}
+
+		count.SubmittedAt = &now
+		count.Items = items
+		count.Adjustments = []models.CycleCountAdjustment{}
+		count.Variance, _, err = cycleCountVariance(tx.Session(&gorm.Session{NewDB: true}), count)
+
+		return err
+	}, &sql.TxOptions{Isolation: sql.LevelReadCommitted})
+
+	if err != nil {
+		impl.logError(err)
+		return nil, err
+	}
+
+	return &count, nil
+}
+
+// Approve reconciles the bucket with the submitted count: fruits missing from
+// the count are unassigned from the bucket and fruits found but unknown to the
+// bucket are flagged. Every adjustment is recorded against the count. A
+// reserved fruit missing from a count by fruit id aborts the approval
+func (impl *CycleCountService) Approve(ctx context.Context, id int64) (*models.CycleCount, error) {
+	now := _time.Now()
+
+	var count models.CycleCount
+	err := impl.db.DB.Transaction(func(tx *gorm.DB) error {
+		if err := impl.lock(tx, id, &count); err != nil {
+			return err
+		}
+		if status := count.Status(); status != models.CycleCountStatusSubmitted {
+			return exceptions.NewForbiddenException(fmt.Sprintf("Cycle count is %s", status))
+		}
+
+		count.Items = make([]models.CycleCountItem, 0)
+		res := tx.Session(&gorm.Session{NewDB: true}).Where("cycle_count_fk = ?", id).Order("id").Find(&count.Items)
+		if err := res.Error; err != nil {
+			return err
+		}
+
+		variance, expected, err := cycleCountVariance(tx.Session(&gorm.Session{NewDB: true}).Clauses(clause.Locking{Strength: "UPDATE"}), count)
+		if err != nil {
+			return err
+		}
+
+		count.Adjustments = cycleCountAdjustments(count, variance, expected, now)
+
+		reserved := map[int64]bool{}
+		for _, fruit := range expected {
+			reserved[fruit.ID] = fruit.IsReserved(now)
+		}
+
+		unassigned := []int64{}
+		movements := []models.Movement{}
+		for _, adjustment := range count.Adjustments {
+			if adjustment.Action == models.CycleCountActionUnassigned {
+				if reserved[*adjustment.FruitID] {
+					return exceptions.NewForbiddenException(fmt.Sprintf("Fruit %d is reserved", *adjustment.FruitID))
+				}
+
+				unassigned = append(unassigned, *adjustment.FruitID)
+				movements = append(movements, newMovement(ctx, models.MovementTypeUnassigned, *adjustment.FruitID, &count.BucketID, nil, now))
+			}
+		}
+		if len(unassigned) > 0 {
+			res := tx.Session(&gorm.Session{NewDB: true}).Model(&models.Fruit{}).
+				Where("id IN ?", unassigned).
+				Update("bucket_fk", nil)
+			if err := res.Error; err != nil {
+				return err
+			}
+			if err := recordMovements(tx, movements); err != nil {
+				return err
+			}
+		}
+		if len(count.Adjustments) > 0 {
+			if err := tx.Session(&gorm.Session{NewDB: true}).Create(&count.Adjustments).Error; err != nil {
+				return err
+			}
+		}
+
+		count.ApprovedAt = &now
+		return tx.Session(&gorm.Session{NewDB: true}).Model(&models.CycleCount{}).
+			Where("id = ?", id).
+			Update("approved_at", now).Error
+	}, &sql.TxOptions{Isolation: sql.LevelReadCommitted})
+
+	if err != nil {
+		impl.logError(err)
+		return nil, err
+	}
+
+	return &count, nil
+}
+
+func (impl *CycleCountService) lock(tx *gorm.DB, id int64, count *models.CycleCount) error {
+	res := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", id).First(count)
+	if err := res.Error; err != nil {
+		if err.Error() == infra.MYSQL_ERROR_NOT_FOUND {
+			return exceptions.NewNotFoundException("Cycle count not found")
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+// items turns the submitted count into items, naming each counted fruit after
+// what the database knows of it
+func (impl *CycleCountService) items(tx *gorm.DB, id int64, data dtos.SubmitCycleCountDto) ([]models.CycleCountItem, error) {
+	items := []models.CycleCountItem{}
+	for _, item := range data.Items {
+		items = append(items, models.CycleCountItem{CycleCountID: id, Name: item.Name, Quantity: item.Quantity})
+	}
+	if len(data.FruitIDs) == 0 {
+		return items, nil
+	}
+
+	fruits := make([]models.Fruit, 0)
+	res := tx.Select("id", "name").Where("id IN ? AND deleted_at IS NULL", data.FruitIDs).Find(&fruits)
+	if err := res.Error; err != nil {
+		return nil, err
+	}
+
+	names := map[int64]string{}
+	for _, fruit := range fruits {
+		names[fruit.ID] = fruit.Name
+	}
+
+	missing := []string{}
+	for _, fruitID := range data.FruitIDs {
+		name, ok := names[fruitID]
+		if !ok {
+			missing = append(missing, strconv.FormatInt(fruitID, 10))
+			continue
+		}
+
+		fruitID := fruitID
+		items = append(items, models.CycleCountItem{CycleCountID: id, FruitID: &fruitID, Name: name, Quantity: 1})
+	}
+	if len(missing) > 0 {
+		return nil, exceptions.NewForeignNotFoundException(fmt.Sprintf("Fruits not found: %s", strings.Join(missing, ", ")))
+	}
+
+	return items, nil
+}
+
+// cycleCountVariance compares the count items against the fruits on hand in the
+// bucket, which are returned ordered by name and expiration
+func cycleCountVariance(tx *gorm.DB, count models.CycleCount) (*models.CycleCountVariance, []models.Fruit, error) {
+	expected := make([]models.Fruit, 0)
+	res := tx.Select("id", "name", "expires_at", "reserved_until").
+		Where("bucket_fk = ? AND deleted_at IS NULL AND state IN ?", count.BucketID, models.FruitOnHandStates).
+		Order("name, expires_at, id").
+		Find(&expected)
+	if err := res.Error; err != nil {
+		return nil, nil, err
+	}
+
+	names := map[string]*models.CycleCountNameVariance{}
+	nameOf := func(name string) *models.CycleCountNameVariance {
+		if _, ok := names[name]; !ok {
+			names[name] = &models.CycleCountNameVariance{Name: name}
+		}
+		return names[name]
+	}
+
+	inBucket := map[int64]bool{}
+	for _, fruit := range expected {
+		inBucket[fruit.ID] = true
+		nameOf(fruit.Name).Expected++
+	}
+
+	byFruit := false
+	counted := map[int64]bool{}
+	variance := &models.CycleCountVariance{
+		Names:           []models.CycleCountNameVariance{},
+		MissingFruitIDs: []int64{},
+		UnknownFruitIDs: []int64{},
+	}
+	for _, item := range count.Items {
+		nameOf(item.Name).Counted += int64(item.Quantity)
+		if item.FruitID == nil {
+			continue
+		}
+
+		byFruit = true
+		counted[*item.FruitID] = true
+		if !inBucket[*item.FruitID] {
+			variance.UnknownFruitIDs = append(variance.UnknownFruitIDs, *item.FruitID)
+		}
+	}
+
+	if byFruit {
+		for _, fruit := range expected {
+			if !counted[fruit.ID] {
+				variance.MissingFruitIDs = append(variance.MissingFruitIDs, fruit.ID)
+			}
+		}
+	}
+
+	for _, name := range names {
+		variance.Names = append(variance.Names, *name)
+	}
+	sort.Slice(variance.Names, func(i, j int) bool {
+		return variance.Names[i].Name < variance.Names[j].Name
+	})
+
+	return variance, expected, nil
+}
+
+// cycleCountAdjustments decides what the approval changes. Counts by fruit id
+// unassign exactly the missing fruits and flag the unknown ones; counts by
+// quantity unassign the shortage of each name, earliest to expire first and
+// never a reserved fruit, and flag any surplus
+func cycleCountAdjustments(count models.CycleCount, variance *models.CycleCountVariance, expected []models.Fruit, now time.Time) []models.CycleCountAdjustment {
+	adjustments := []models.CycleCountAdjustment{}
+	adjust := func(fruitID *int64, name string, quantity int, action string) {
+		adjustments = append(adjustments, models.CycleCountAdjustment{
+			CreatedAt:    now,
+			CycleCountID: count.ID,
+			FruitID:      fruitID,
+			Name:         name,
+			Quantity:     quantity,
+			Action:       action,
+		})
+	}
+
+	byFruit := false
+	counted := map[int64]string{}
+	for _, item := range count.Items {
+		if item.FruitID != nil {
+			byFruit = true
+			counted[*item.FruitID] = item.Name
+		}
+	}
+
+	if byFruit {
+		fruits := map[int64]models.Fruit{}
+		for _, fruit := range expected {
+			fruits[fruit.ID] = fruit
+		}
+
+		for _, fruitID := range variance.MissingFruitIDs {
+			fruitID := fruitID
+			adjust(&fruitID, fruits[fruitID].Name, 1, models.CycleCountActionUnassigned)
+		}
+		for _, fruitID := range variance.UnknownFruitIDs {
+			fruitID := fruitID
+			adjust(&fruitID, counted[fruitID], 1, models.CycleCountActionFlagged)
+		}
+
+		return adjustments
+	}
+
+	shortages := map[string]int64{}
+	for _, name := range variance.Names {
+		if diff := name.Difference(); diff < 0 {
+			shortages[name.Name] = -diff
+		}
+	}
+	for _, fruit := range expected {
+		if shortages[fruit.Name] > 0 && !fruit.IsReserved(now) {
+			fruitID := fruit.ID
+			adjust(&fruitID, fruit.Name, 1, models.CycleCountActionUnassigned)
+			shortages[fruit.Name]--
+		}
+	}
+	for _, name := range variance.Names {
+		if diff := name.Difference(); diff > 0 {
+			adjust(nil, name.Name, int(diff), models.CycleCountActionFlagged)
+		}
+	}
+
+	return adjustments
+}
+
+func (impl *CycleCountService) logError(err error) {
+	if _, ok := err.(*exceptions.NotFoundException); ok {
+		impl.logger.Warn(err.Error())
+	} else if _, ok := err.(*exceptions.ForeignNotFoundException); ok {
+		impl.logger.Warn(err.Error())
+	} else if _, ok := err.(*exceptions.ForbiddenException); ok {
+		impl.logger.Warn(err.Error())
+	} else if _, ok := err.(*exceptions.ConflictException); ok {
+		impl.logger.Warn(err.Error())
+	} else {
+		impl.logger.Error(err.Error())
+	}
+}
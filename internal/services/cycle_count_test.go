@@ -0,0 +1,668 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/viniosilva/where-are-my-fruits/internal/dtos"
+	"github.com/viniosilva/where-are-my-fruits/internal/infra"
+	"github.com/viniosilva/where-are-my-fruits/internal/models"
+	"github.com/viniosilva/where-are-my-fruits/mocks"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+func TestCycleCountService_NewCycleCount(t *testing.T) {
+	t.Run("should be success", func(t *testing.T) {
+		//setup
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		loggerMock := mocks.NewMockLogger(ctrl)
+		validate := infra.NewValidator()
+
+		// given
+		got := NewCycleCount(nil, loggerMock, validate)
+
+		// then
+		assert.NotNil(t, got)
+	})
+}
+
+func TestCycleCountService_Open(t *testing.T) {
+	now := time.Date(2000, 12, 31, 23, 59, 59, 0, time.Local)
+
+	tests := map[string]struct {
+		mock    func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime)
+		want    *models.CycleCount
+		wantErr string
+	}{
+		"should be success": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {
+				mTime.EXPECT().Now().Return(now)
+				db.ExpectBegin()
+				db.ExpectQuery("SELECT (.+) FROM `buckets` WHERE (.+) FOR UPDATE").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "capacity"}).AddRow(int64(1), 10)) // lock bucket
+				db.ExpectQuery("SELECT count(.+) FROM `cycle_counts` WHERE bucket_fk = (.+) AND approved_at IS NULL").
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(int64(0))) // count in progress
+				db.ExpectExec("INSERT INTO `cycle_counts`").WillReturnResult(sqlmock.NewResult(1, 1))
+				db.ExpectCommit()
+			},
+			want: &models.CycleCount{
+				ID:          1,
+				CreatedAt:   now,
+				BucketID:    1,
+				Items:       []models.CycleCountItem{},
+				Adjustments: []models.CycleCountAdjustment{},
+			},
+		},
+		"should throw error when bucket not found": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {
+				mTime.EXPECT().Now().Return(now)
+				db.ExpectBegin()
+				db.ExpectQuery("SELECT").WillReturnError(fmt.Errorf(infra.MYSQL_ERROR_NOT_FOUND))
+				db.ExpectRollback()
+
+				logger.EXPECT().Warn(gomock.Any())
+			},
+			wantErr: "Bucket not found",
+		},
+		"should throw error when bucket has a count in progress": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {
+				mTime.EXPECT().Now().Return(now)
+				db.ExpectBegin()
+				db.ExpectQuery("SELECT").WillReturnRows(sqlmock.NewRows([]string{"id", "capacity"}).AddRow(int64(1), 10))
+				db.ExpectQuery("SELECT count").WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(int64(1)))
+				db.ExpectRollback()
+
+				logger.EXPECT().Warn(gomock.Any())
+			},
+			wantErr: "Bucket 1 already has a cycle count in progress",
+		},
+		"should throw error": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {
+				mTime.EXPECT().Now().Return(now)
+				db.ExpectBegin()
+				db.ExpectQuery("SELECT").WillReturnError(fmt.Errorf("error"))
+				db.ExpectRollback()
+
+				logger.EXPECT().Error(gomock.Any())
+			},
+			wantErr: "error",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			//setup
+			ctx := context.Background()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			db, sqlMock, err := sqlmock.New()
+			require.Nil(t, err)
+			defer db.Close()
+
+			dialector := mysql.New(mysql.Config{
+				DSN:                       "sqlmock_db_0",
+				DriverName:                "mysql",
+				Conn:                      db,
+				SkipInitializeWithVersion: true,
+			})
+
+			gormDB, err := gorm.Open(dialector, &gorm.Config{})
+			require.Nil(t, err)
+			database := &infra.Database{DB: gormDB, SQL: db}
+
+			loggerMock := mocks.NewMockLogger(ctrl)
+			validate := infra.NewValidator()
+			timeMock := mocks.NewMockTime(ctrl)
+			_time = timeMock
+
+			tt.mock(sqlMock, loggerMock, timeMock)
+
+			// given
+			service := NewCycleCount(database, loggerMock, validate)
+
+			// when
+			got, err := service.Open(ctx, 1)
+
+			// then
+			assert.Equal(t, tt.want, got)
+			if err != nil || tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			}
+			assert.Nil(t, sqlMock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestCycleCountService_Get(t *testing.T) {
+	now := time.Date(2000, 12, 31, 23, 59, 59, 0, time.Local)
+	fruitID := int64(1)
+
+	tests := map[string]struct {
+		mock    func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime)
+		want    *models.CycleCount
+		wantErr string
+	}{
+		"should be success with variance when submitted": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {
+				db.ExpectQuery("SELECT (.+) FROM `cycle_counts`").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "created_at", "submitted_at", "approved_at", "bucket_fk"}).
+						AddRow(int64(1), now, now, nil, int64(1)))
+				db.ExpectQuery("SELECT (.+) FROM `cycle_count_adjustments`").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "cycle_count_fk"}))
+				db.ExpectQuery("SELECT (.+) FROM `cycle_count_items`").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "cycle_count_fk", "fruit_fk", "name", "quantity"}).
+						AddRow(int64(1), int64(1), int64(1), "Orange", 1))
+				db.ExpectQuery("SELECT `id`,`name`,`expires_at`,`reserved_until` FROM `fruits` WHERE bucket_fk = (.+) ORDER BY name, expires_at, id").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "name", "expires_at"}).
+						AddRow(int64(1), "Orange", now).
+						AddRow(int64(2), "Orange", now))
+			},
+			want: &models.CycleCount{
+				ID:          1,
+				CreatedAt:   now,
+				SubmittedAt: &now,
+				BucketID:    1,
+				Items:       []models.CycleCountItem{{ID: 1, CycleCountID: 1, FruitID: &fruitID, Name: "Orange", Quantity: 1}},
+				Adjustments: []models.CycleCountAdjustment{},
+				Variance: &models.CycleCountVariance{
+					Names:           []models.CycleCountNameVariance{{Name: "Orange", Expected: 2, Counted: 1}},
+					MissingFruitIDs: []int64{2},
+					UnknownFruitIDs: []int64{},
+				},
+			},
+		},
+		"should be success without variance when open": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {
+				db.ExpectQuery("SELECT (.+) FROM `cycle_counts`").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "created_at", "submitted_at", "approved_at", "bucket_fk"}).
+						AddRow(int64(1), now, nil, nil, int64(1)))
+				db.ExpectQuery("SELECT (.+) FROM `cycle_count_adjustments`").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "cycle_count_fk"}))
+				db.ExpectQuery("SELECT (.+) FROM `cycle_count_items`").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "cycle_count_fk"}))
+			},
+			want: &models.CycleCount{
+				ID:          1,
+				CreatedAt:   now,
+				BucketID:    1,
+				Items:       []models.CycleCountItem{},
+				Adjustments: []models.CycleCountAdjustment{},
+			},
+		},
+		"should throw error when cycle count not found": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {
+				db.ExpectQuery("SELECT").WillReturnError(fmt.Errorf(infra.MYSQL_ERROR_NOT_FOUND))
+				logger.EXPECT().Warn(gomock.Any())
+			},
+			wantErr: "Cycle count not found",
+		},
+		"should throw error": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {
+				db.ExpectQuery("SELECT").WillReturnError(fmt.Errorf("error"))
+				logger.EXPECT().Error(gomock.Any())
+			},
+			wantErr: "error",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			//setup
+			ctx := context.Background()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			db, sqlMock, err := sqlmock.New()
+			require.Nil(t, err)
+			defer db.Close()
+
+			dialector := mysql.New(mysql.Config{
+				DSN:                       "sqlmock_db_0",
+				DriverName:                "mysql",
+				Conn:                      db,
+				SkipInitializeWithVersion: true,
+			})
+
+			gormDB, err := gorm.Open(dialector, &gorm.Config{})
+			require.Nil(t, err)
+			database := &infra.Database{DB: gormDB, SQL: db}
+
+			loggerMock := mocks.NewMockLogger(ctrl)
+			validate := infra.NewValidator()
+			timeMock := mocks.NewMockTime(ctrl)
+			_time = timeMock
+
+			tt.mock(sqlMock, loggerMock, timeMock)
+
+			// given
+			service := NewCycleCount(database, loggerMock, validate)
+
+			// when
+			got, err := service.Get(ctx, 1)
+
+			// then
+			assert.Equal(t, tt.want, got)
+			if err != nil || tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			}
+			assert.Nil(t, sqlMock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestCycleCountService_Submit(t *testing.T) {
+	now := time.Date(2000, 12, 31, 23, 59, 59, 0, time.Local)
+	fruitID1 := int64(1)
+	fruitID3 := int64(3)
+
+	tests := map[string]struct {
+		mock    func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime)
+		data    dtos.SubmitCycleCountDto
+		want    *models.CycleCount
+		wantErr string
+	}{
+		"should be success when counted by fruit id": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {
+				mTime.EXPECT().Now().Return(now)
+				db.ExpectBegin()
+				db.ExpectQuery("SELECT (.+) FROM `cycle_counts` WHERE (.+) FOR UPDATE").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "created_at", "submitted_at", "approved_at", "bucket_fk"}).
+						AddRow(int64(1), now, nil, nil, int64(1))) // lock cycle count
+				db.ExpectQuery("SELECT `id`,`name` FROM `fruits` WHERE id IN").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).
+						AddRow(int64(1), "Orange").
+						AddRow(int64(3), "Apple")) // find counted fruits
+				db.ExpectExec("INSERT INTO `cycle_count_items`").WillReturnResult(sqlmock.NewResult(1, 2))
+				db.ExpectExec("UPDATE `cycle_counts` SET `submitted_at`").WillReturnResult(sqlmock.NewResult(1, 1))
+				db.ExpectQuery("SELECT `id`,`name`,`expires_at`,`reserved_until` FROM `fruits`").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "name", "expires_at"}).
+						AddRow(int64(1), "Orange", now).
+						AddRow(int64(2), "Orange", now)) // find fruits in bucket
+				db.ExpectCommit()
+			},
+			data: dtos.SubmitCycleCountDto{FruitIDs: []int64{1, 3}},
+			want: &models.CycleCount{
+				ID:          1,
+				CreatedAt:   now,
+				SubmittedAt: &now,
+				BucketID:    1,
+				Items: []models.CycleCountItem{
+					{ID: 1, CycleCountID: 1, FruitID: &fruitID1, Name: "Orange", Quantity: 1},
+					{ID: 2, CycleCountID: 1, FruitID: &fruitID3, Name: "Apple", Quantity: 1},
+				},
+				Adjustments: []models.CycleCountAdjustment{},
+				Variance: &models.CycleCountVariance{
+					Names: []models.CycleCountNameVariance{
+						{Name: "Apple", Expected: 0, Counted: 1},
+						{Name: "Orange", Expected: 2, Counted: 1},
+					},
+					MissingFruitIDs: []int64{2},
+					UnknownFruitIDs: []int64{3},
+				},
+			},
+		},
+		"should be success when counted by quantity": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {
+				mTime.EXPECT().Now().Return(now)
+				db.ExpectBegin()
+				db.ExpectQuery("SELECT").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "created_at", "submitted_at", "approved_at", "bucket_fk"}).
+						AddRow(int64(1), now, nil, nil, int64(1)))
+				db.ExpectExec("INSERT INTO `cycle_count_items`").WillReturnResult(sqlmock.NewResult(1, 1))
+				db.ExpectExec("UPDATE `cycle_counts`").WillReturnResult(sqlmock.NewResult(1, 1))
+				db.ExpectQuery("SELECT").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "name", "expires_at"}).
+						AddRow(int64(1), "Orange", now))
+				db.ExpectCommit()
+			},
+			data: dtos.SubmitCycleCountDto{Items: []dtos.CycleCountItemDto{{Name: "Orange", Quantity: 3}}},
+			want: &models.CycleCount{
+				ID:          1,
+				CreatedAt:   now,
+				SubmittedAt: &now,
+				BucketID:    1,
+				Items:       []models.CycleCountItem{{ID: 1, CycleCountID: 1, Name: "Orange", Quantity: 3}},
+				Adjustments: []models.CycleCountAdjustment{},
+				Variance: &models.CycleCountVariance{
+					Names:           []models.CycleCountNameVariance{{Name: "Orange", Expected: 1, Counted: 3}},
+					MissingFruitIDs: []int64{},
+					UnknownFruitIDs: []int64{},
+				},
+			},
+		},
+		"should throw error on validate when nothing is counted": {
+			mock:    func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {},
+			data:    dtos.SubmitCycleCountDto{},
+			wantErr: "Key: 'SubmitCycleCountDto.FruitIDs' Error:Field validation for 'FruitIDs' failed on the 'required_without' tag, Key: 'SubmitCycleCountDto.Items' Error:Field validation for 'Items' failed on the 'required_without' tag",
+		},
+		"should throw error when cycle count not found": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {
+				mTime.EXPECT().Now().Return(now)
+				db.ExpectBegin()
+				db.ExpectQuery("SELECT").WillReturnError(fmt.Errorf(infra.MYSQL_ERROR_NOT_FOUND))
+				db.ExpectRollback()
+
+				logger.EXPECT().Warn(gomock.Any())
+			},
+			data:    dtos.SubmitCycleCountDto{FruitIDs: []int64{1}},
+			wantErr: "Cycle count not found",
+		},
+		"should throw error when cycle count is already submitted": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {
+				mTime.EXPECT().Now().Return(now)
+				db.ExpectBegin()
+				db.ExpectQuery("SELECT").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "created_at", "submitted_at", "approved_at", "bucket_fk"}).
+						AddRow(int64(1), now, now, nil, int64(1)))
+				db.ExpectRollback()
+
+				logger.EXPECT().Warn(gomock.Any())
+			},
+			data:    dtos.SubmitCycleCountDto{FruitIDs: []int64{1}},
+			wantErr: "Cycle count is submitted",
+		},
+		"should throw error when counted fruits are not found": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {
+				mTime.EXPECT().Now().Return(now)
+				db.ExpectBegin()
+				db.ExpectQuery("SELECT").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "created_at", "submitted_at", "approved_at", "bucket_fk"}).
+						AddRow(int64(1), now, nil, nil, int64(1)))
+				db.ExpectQuery("SELECT").WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(int64(1), "Orange"))
+				db.ExpectRollback()
+
+				logger.EXPECT().Warn(gomock.Any())
+			},
+			data:    dtos.SubmitCycleCountDto{FruitIDs: []int64{1, 2, 3}},
+			wantErr: "Fruits not found: 2, 3",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			//setup
+			ctx := context.Background()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			db, sqlMock, err := sqlmock.New()
+			require.Nil(t, err)
+			defer db.Close()
+
+			dialector := mysql.New(mysql.Config{
+				DSN:                       "sqlmock_db_0",
+				DriverName:                "mysql",
+				Conn:                      db,
+				SkipInitializeWithVersion: true,
+			})
+
+			gormDB, err := gorm.Open(dialector, &gorm.Config{})
+			require.Nil(t, err)
+			database := &infra.Database{DB: gormDB, SQL: db}
+
+			loggerMock := mocks.NewMockLogger(ctrl)
+			validate := infra.NewValidator()
+			timeMock := mocks.NewMockTime(ctrl)
+			_time = timeMock
+
+			tt.mock(sqlMock, loggerMock, timeMock)
+
+			// given
+			service := NewCycleCount(database, loggerMock, validate)
+
+			// when
+			got, err := service.Submit(ctx, 1, tt.data)
+
+			// then
+			assert.Equal(t, tt.want, got)
+			if err != nil || tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			}
+			assert.Nil(t, sqlMock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestCycleCountService_Approve(t *testing.T) {
+	now := time.Date(2000, 12, 31, 23, 59, 59, 0, time.Local)
+	yesterday := now.Add(-24 * time.Hour)
+	fruitID1 := int64(1)
+	fruitID2 := int64(2)
+	fruitID3 := int64(3)
+
+	tests := map[string]struct {
+		mock    func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime)
+		want    *models.CycleCount
+		wantErr string
+	}{
+		"should unassign missing fruits and flag unknown ones when counted by fruit id": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {
+				mTime.EXPECT().Now().Return(now)
+				db.ExpectBegin()
+				db.ExpectQuery("SELECT (.+) FROM `cycle_counts` WHERE (.+) FOR UPDATE").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "created_at", "submitted_at", "approved_at", "bucket_fk"}).
+						AddRow(int64(1), yesterday, yesterday, nil, int64(1))) // lock cycle count
+				db.ExpectQuery("SELECT (.+) FROM `cycle_count_items`").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "cycle_count_fk", "fruit_fk", "name", "quantity"}).
+						AddRow(int64(1), int64(1), int64(1), "Orange", 1).
+						AddRow(int64(2), int64(1), int64(3), "Apple", 1)) // find items
+				db.ExpectQuery("SELECT (.+) FROM `fruits` WHERE (.+) FOR UPDATE").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "name", "expires_at"}).
+						AddRow(int64(1), "Orange", now).
+						AddRow(int64(2), "Orange", now)) // lock fruits in bucket
+				db.ExpectExec("UPDATE `fruits` SET `bucket_fk`=(.+) WHERE id IN").
+					WithArgs(nil, int64(2)).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				db.ExpectExec("INSERT INTO `movements`").
+					WithArgs(now, int64(2), int64(1), nil, models.MovementTypeUnassigned, nil).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				db.ExpectExec("INSERT INTO `cycle_count_adjustments`").WillReturnResult(sqlmock.NewResult(1, 2))
+				db.ExpectExec("UPDATE `cycle_counts` SET `approved_at`").WillReturnResult(sqlmock.NewResult(1, 1))
+				db.ExpectCommit()
+			},
+			want: &models.CycleCount{
+				ID:          1,
+				CreatedAt:   yesterday,
+				SubmittedAt: &yesterday,
+				ApprovedAt:  &now,
+				BucketID:    1,
+				Items: []models.CycleCountItem{
+					{ID: 1, CycleCountID: 1, FruitID: &fruitID1, Name: "Orange", Quantity: 1},
+					{ID: 2, CycleCountID: 1, FruitID: &fruitID3, Name: "Apple", Quantity: 1},
+				},
+				Adjustments: []models.CycleCountAdjustment{
+					{ID: 1, CreatedAt: now, CycleCountID: 1, FruitID: &fruitID2, Name: "Orange", Quantity: 1, Action: models.CycleCountActionUnassigned},
+					{ID: 2, CreatedAt: now, CycleCountID: 1, FruitID: &fruitID3, Name: "Apple", Quantity: 1, Action: models.CycleCountActionFlagged},
+				},
+			},
+		},
+		"should unassign shortage earliest to expire first and flag surplus when counted by quantity": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {
+				mTime.EXPECT().Now().Return(now)
+				db.ExpectBegin()
+				db.ExpectQuery("SELECT").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "created_at", "submitted_at", "approved_at", "bucket_fk"}).
+						AddRow(int64(1), yesterday, yesterday, nil, int64(1)))
+				db.ExpectQuery("SELECT").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "cycle_count_fk", "fruit_fk", "name", "quantity"}).
+						AddRow(int64(1), int64(1), nil, "Apple", 2).
+						AddRow(int64(2), int64(1), nil, "Orange", 1))
+				db.ExpectQuery("SELECT").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "name", "expires_at"}).
+						AddRow(int64(3), "Apple", now).
+						AddRow(int64(1), "Orange", now).
+						AddRow(int64(2), "Orange", now.Add(time.Hour)))
+				db.ExpectExec("UPDATE `fruits`").
+					WithArgs(nil, int64(1)).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				db.ExpectExec("INSERT INTO `movements`").WillReturnResult(sqlmock.NewResult(1, 1))
+				db.ExpectExec("INSERT INTO `cycle_count_adjustments`").WillReturnResult(sqlmock.NewResult(1, 2))
+				db.ExpectExec("UPDATE `cycle_counts`").WillReturnResult(sqlmock.NewResult(1, 1))
+				db.ExpectCommit()
+			},
+			want: &models.CycleCount{
+				ID:          1,
+				CreatedAt:   yesterday,
+				SubmittedAt: &yesterday,
+				ApprovedAt:  &now,
+				BucketID:    1,
+				Items: []models.CycleCountItem{
+					{ID: 1, CycleCountID: 1, Name: "Apple", Quantity: 2},
+					{ID: 2, CycleCountID: 1, Name: "Orange", Quantity: 1},
+				},
+				Adjustments: []models.CycleCountAdjustment{
+					{ID: 1, CreatedAt: now, CycleCountID: 1, FruitID: &fruitID1, Name: "Orange", Quantity: 1, Action: models.CycleCountActionUnassigned},
+					{ID: 2, CreatedAt: now, CycleCountID: 1, Name: "Apple", Quantity: 1, Action: models.CycleCountActionFlagged},
+				},
+			},
+		},
+		"should unassign shortage leaving reserved fruits when counted by quantity": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {
+				mTime.EXPECT().Now().Return(now)
+				db.ExpectBegin()
+				db.ExpectQuery("SELECT").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "created_at", "submitted_at", "approved_at", "bucket_fk"}).
+						AddRow(int64(1), yesterday, yesterday, nil, int64(1)))
+				db.ExpectQuery("SELECT").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "cycle_count_fk", "fruit_fk", "name", "quantity"}).
+						AddRow(int64(1), int64(1), nil, "Orange", 1))
+				db.ExpectQuery("SELECT").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "name", "expires_at", "reserved_until"}).
+						AddRow(int64(1), "Orange", now, now.Add(time.Hour)).
+						AddRow(int64(2), "Orange", now.Add(time.Hour), nil))
+				db.ExpectExec("UPDATE `fruits`").
+					WithArgs(nil, int64(2)).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				db.ExpectExec("INSERT INTO `movements`").WillReturnResult(sqlmock.NewResult(1, 1))
+				db.ExpectExec("INSERT INTO `cycle_count_adjustments`").WillReturnResult(sqlmock.NewResult(1, 1))
+				db.ExpectExec("UPDATE `cycle_counts`").WillReturnResult(sqlmock.NewResult(1, 1))
+				db.ExpectCommit()
+			},
+			want: &models.CycleCount{
+				ID:          1,
+				CreatedAt:   yesterday,
+				SubmittedAt: &yesterday,
+				ApprovedAt:  &now,
+				BucketID:    1,
+				Items: []models.CycleCountItem{
+					{ID: 1, CycleCountID: 1, Name: "Orange", Quantity: 1},
+				},
+				Adjustments: []models.CycleCountAdjustment{
+					{ID: 1, CreatedAt: now, CycleCountID: 1, FruitID: &fruitID2, Name: "Orange", Quantity: 1, Action: models.CycleCountActionUnassigned},
+				},
+			},
+		},
+		"should throw error when a reserved fruit is missing from a count by fruit id": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {
+				mTime.EXPECT().Now().Return(now)
+				db.ExpectBegin()
+				db.ExpectQuery("SELECT").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "created_at", "submitted_at", "approved_at", "bucket_fk"}).
+						AddRow(int64(1), yesterday, yesterday, nil, int64(1)))
+				db.ExpectQuery("SELECT").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "cycle_count_fk", "fruit_fk", "name", "quantity"}).
+						AddRow(int64(1), int64(1), int64(1), "Orange", 1))
+				db.ExpectQuery("SELECT").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "name", "expires_at", "reserved_until"}).
+						AddRow(int64(1), "Orange", now, nil).
+						AddRow(int64(2), "Orange", now, now.Add(time.Hour)))
+				db.ExpectRollback()
+
+				logger.EXPECT().Warn(gomock.Any())
+			},
+			wantErr: "Fruit 2 is reserved",
+		},
+		"should throw error when cycle count is open": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {
+				mTime.EXPECT().Now().Return(now)
+				db.ExpectBegin()
+				db.ExpectQuery("SELECT").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "created_at", "submitted_at", "approved_at", "bucket_fk"}).
+						AddRow(int64(1), yesterday, nil, nil, int64(1)))
+				db.ExpectRollback()
+
+				logger.EXPECT().Warn(gomock.Any())
+			},
+			wantErr: "Cycle count is open",
+		},
+		"should throw error when cycle count not found": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {
+				mTime.EXPECT().Now().Return(now)
+				db.ExpectBegin()
+				db.ExpectQuery("SELECT").WillReturnError(fmt.Errorf(infra.MYSQL_ERROR_NOT_FOUND))
+				db.ExpectRollback()
+
+				logger.EXPECT().Warn(gomock.Any())
+			},
+			wantErr: "Cycle count not found",
+		},
+		"should throw error": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {
+				mTime.EXPECT().Now().Return(now)
+				db.ExpectBegin()
+				db.ExpectQuery("SELECT").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "created_at", "submitted_at", "approved_at", "bucket_fk"}).
+						AddRow(int64(1), yesterday, yesterday, nil, int64(1)))
+				db.ExpectQuery("SELECT").WillReturnError(fmt.Errorf("error"))
+				db.ExpectRollback()
+
+				logger.EXPECT().Error(gomock.Any())
+			},
+			wantErr: "error",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			//setup
+			ctx := context.Background()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			db, sqlMock, err := sqlmock.New()
+			require.Nil(t, err)
+			defer db.Close()
+
+			dialector := mysql.New(mysql.Config{
+				DSN:                       "sqlmock_db_0",
+				DriverName:                "mysql",
+				Conn:                      db,
+				SkipInitializeWithVersion: true,
+			})
+
+			gormDB, err := gorm.Open(dialector, &gorm.Config{})
+			require.Nil(t, err)
+			database := &infra.Database{DB: gormDB, SQL: db}
+
+			loggerMock := mocks.NewMockLogger(ctrl)
+			validate := infra.NewValidator()
+			timeMock := mocks.NewMockTime(ctrl)
+			_time = timeMock
+
+			tt.mock(sqlMock, loggerMock, timeMock)
+
+			// given
+			service := NewCycleCount(database, loggerMock, validate)
+
+			// when
+			got, err := service.Approve(ctx, 1)
+
+			// then
+			assert.Equal(t, tt.want, got)
+			if err != nil || tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			}
+			assert.Nil(t, sqlMock.ExpectationsWereMet())
+		})
+	}
+}
@@ -3,25 +3,39 @@ package services
 import (
 	"context"
 	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
 
+	"github.com/shopspring/decimal"
 	"github.com/viniosilva/where-are-my-fruits/internal/dtos"
 	"github.com/viniosilva/where-are-my-fruits/internal/exceptions"
 	"github.com/viniosilva/where-are-my-fruits/internal/infra"
 	"github.com/viniosilva/where-are-my-fruits/internal/models"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
+// errFruitBatchAborted rolls back an all_or_nothing batch once an item fails
+var errFruitBatchAborted = errors.New("fruit batch aborted")
+
 type FruitService struct {
-	db       *infra.Database
-	logger   Logger
-	validate Validate
+	db            *infra.Database
+	logger        Logger
+	validate      Validate
+	markdowns     models.MarkdownRules
+	compatibility models.CompatibilityRules
 }
 
-func NewFruit(db *infra.Database, logger Logger, validate Validate) *FruitService {
+func NewFruit(db *infra.Database, logger Logger, validate Validate, markdowns models.MarkdownRules, compatibility models.CompatibilityRules) *FruitService {
 	return &FruitService{
-		db:       db,
-		logger:   logger,
-		validate: validate,
+		db:            db,
+		logger:        logger,
+		validate:      validate,
+		markdowns:     markdowns,
+		compatibility: compatibility,
 	}
 }
 
@@ -31,37 +45,407 @@ func (impl *FruitService) Create(ctx context.Context, data dtos.CreateFruitDto)
 	}
 
 	now := _time.Now()
-	fruit := models.Fruit{
-		CreatedAt: now,
-		Name:      data.Name,
-		Price:     data.Price,
-		ExpiresAt: now.Add(*data.ExpiresIn),
+	fruit := newFruit(data, now)
+
+	err := impl.db.DB.Transaction(func(tx *gorm.DB) error {
+		if data.Allocation != nil {
+			bucketID, err := allocateBucket(ctx, tx, *data.Allocation, fruit, impl.compatibility, now, func(bucketID int64) error {
+				_, err := impl.validateBucket(ctx, tx, bucketID, fruit, now)
+				return err
+			})
+			if err != nil {
+				return err
+			}
+			fruit.BucketID = &bucketID
+		} else if data.BucketID != nil {
+			if _, err := impl.validateBucket(ctx, tx, *data.BucketID, fruit, now); err != nil {
+				return err
+			}
+		}
+		if data.SupplierID != nil {
+			if err := impl.validateSupplier(ctx, tx, *data.SupplierID); err != nil {
+				return err
+			}
+		}
+
+		err := tx.Create(&fruit).Error
+		if infra.IsMySQLError(err, infra.MYSQL_ERROR_DUPLICATE_ENTRY) {
+			return exceptions.NewConflictException("Fruit sku or barcode already exists")
+		}
+		if err != nil {
+			return err
+		}
+
+		if err := tx.Create(newFruitPrice(fruit)).Error; err != nil {
+			return err
+		}
+
+		return recordMovements(tx, []models.Movement{
+			newMovement(ctx, models.MovementTypeCreated, fruit.ID, nil, fruit.BucketID, now),
+		})
+	}, &sql.TxOptions{Isolation: sql.LevelReadCommitted})
+
+	if err != nil {
+		if _, ok := err.(*exceptions.ForeignNotFoundException); ok {
+			impl.logger.Warn(err.Error())
+		} else if _, ok := err.(*exceptions.ForbiddenException); ok {
+			impl.logger.Warn(err.Error())
+		} else if _, ok := err.(*exceptions.ConflictException); ok {
+			impl.logger.Warn(err.Error())
+		} else {
+			impl.logger.Error(err.Error())
+		}
+
+		return nil, err
+	}
+
+	fruit.EffectivePrice = impl.markdowns.EffectivePrice(fruit.Price, fruit.ExpiresAt, now)
+
+	return &fruit, nil
+}
+
+// CreateBatch creates many fruits in one transaction. Bucket capacity is checked
+// once for all the items targeting the same bucket. In all_or_nothing mode any
+// failing item aborts the whole batch, while in best_effort mode the valid items
+// are still created
+func (impl *FruitService) CreateBatch(ctx context.Context, data dtos.CreateFruitsBatchDto) (*models.FruitBatch, error) {
+	if err := impl.validate.Struct(data); err != nil {
+		return nil, exceptions.NewValidationException(err)
+	}
+
+	now := _time.Now()
+	allOrNothing := data.Mode == models.FruitBatchModeAllOrNothing
+	batch := models.FruitBatch{Results: make([]models.FruitBatchResult, len(data.Items))}
+
+	bucketIDs := []int64{}
+	bucketItems := map[int64][]int{}
+	supplierIDs := []int64{}
+	supplierItems := map[int64][]int{}
+	for i, item := range data.Items {
+		batch.Results[i].Index = i
+		if err := impl.validate.Struct(item); err != nil {
+			batch.Results[i].Err = exceptions.NewValidationException(err)
+			continue
+		}
+
+		if item.BucketID != nil {
+			if _, ok := bucketItems[*item.BucketID]; !ok {
+				bucketIDs = append(bucketIDs, *item.BucketID)
+			}
+			bucketItems[*item.BucketID] = append(bucketItems[*item.BucketID], i)
+		}
+		if item.SupplierID != nil {
+			if _, ok := supplierItems[*item.SupplierID]; !ok {
+				supplierIDs = append(supplierIDs, *item.SupplierID)
+			}
+			supplierItems[*item.SupplierID] = append(supplierItems[*item.SupplierID], i)
+		}
+	}
+
+	failed := func() bool {
+		for _, result := range batch.Results {
+			if result.Err != nil {
+				return true
+			}
+		}
+		return false
+	}
+
+	var err error
+	if !allOrNothing || !failed() {
+		err = impl.db.DB.Transaction(func(tx *gorm.DB) error {
+			for _, supplierID := range supplierIDs {
+				if err := impl.validateSupplier(ctx, tx, supplierID); err != nil {
+					if _, ok := err.(*exceptions.ForeignNotFoundException); !ok {
+						return err
+					}
+					for _, i := range supplierItems[supplierID] {
+						batch.Results[i].Err = err
+					}
+				}
+			}
+
+			for _, bucketID := range bucketIDs {
+				bucket, free, err := bucketFreeCapacity(ctx, tx.Clauses(clause.Locking{Strength: "UPDATE"}), bucketID)
+				if err != nil {
+					if _, ok := err.(*exceptions.ForeignNotFoundException); !ok {
+						return err
+					}
+				}
+
+				accepted := []int{}
+				names := []string{}
+				for _, i := range bucketItems[bucketID] {
+					if batch.Results[i].Err != nil {
+						continue
+					}
+					if err != nil {
+						batch.Results[i].Err = err
+						continue
+					}
+					if free <= 0 {
+						batch.Results[i].Err = exceptions.NewForbiddenException("Bucket is full")
+						continue
+					}
+					if err := validateQuarantine(bucket, models.Fruit{}); err != nil {
+						batch.Results[i].Err = err
+						continue
+					}
+					accepted = append(accepted, i)
+					names = append(names, data.Items[i].Name)
+					free--
+				}
+				if len(names) == 0 {
+					continue
+				}
+
+				// The items going to the same bucket must be compatible with
+				// each other too
+				if err := validateCompatibility(tx, impl.compatibility, bucketID, names, now); err != nil {
+					if _, ok := err.(*exceptions.ForbiddenException); !ok {
+						return err
+					}
+					for _, i := range accepted {
+						batch.Results[i].Err = err
+					}
+				}
+			}
+
+			if allOrNothing && failed() {
+				return errFruitBatchAborted
+			}
+
+			movements := []models.Movement{}
+			for i, item := range data.Items {
+				if batch.Results[i].Err != nil {
+					continue
+				}
+
+				fruit := newFruit(item, now)
+				err := tx.Create(&fruit).Error
+				if infra.IsMySQLError(err, infra.MYSQL_ERROR_DUPLICATE_ENTRY) {
+					batch.Results[i].Err = exceptions.NewConflictException("Fruit sku or barcode already exists")
+					if allOrNothing {
+						return errFruitBatchAborted
+					}
+					continue
+				}
+				if err != nil {
+					return err
+				}
+				if err := tx.Create(newFruitPrice(fruit)).Error; err != nil {
+					return err
+				}
+
+				fruit.EffectivePrice = impl.markdowns.EffectivePrice(fruit.Price, fruit.ExpiresAt, now)
+				batch.Results[i].Fruit = &fruit
+				movements = append(movements, newMovement(ctx, models.MovementTypeCreated, fruit.ID, nil, fruit.BucketID, now))
+			}
+
+			return recordMovements(tx, movements)
+		}, &sql.TxOptions{Isolation: sql.LevelReadCommitted})
+	}
+
+	if err != nil && err != errFruitBatchAborted {
+		impl.logger.Error(err.Error())
+		return nil, err
+	}
+
+	for i, result := range batch.Results {
+		if err == errFruitBatchAborted {
+			batch.Results[i].Fruit = nil
+		}
+
+		if batch.Results[i].Fruit != nil {
+			batch.Created++
+		} else if result.Err != nil {
+			batch.Failed++
+		}
+	}
+	if batch.Failed > 0 {
+		impl.logger.Warn(fmt.Sprintf("fruit batch: %d of %d items failed", batch.Failed, len(batch.Results)))
+	}
+
+	return &batch, nil
+}
+
+func (impl *FruitService) GetByBarcode(ctx context.Context, barcode string) (*models.Fruit, error) {
+	var fruit models.Fruit
+	res := impl.db.DB.Where("barcode = ? AND deleted_at IS NULL", barcode).First(&fruit)
+	if err := res.Error; err != nil {
+		if err.Error() == infra.MYSQL_ERROR_NOT_FOUND {
+			err := exceptions.NewNotFoundException("Fruit not found")
+			impl.logger.Warn(err.Error())
+			return nil, err
+		}
+
+		impl.logger.Error(err.Error())
+		return nil, err
 	}
 
-	if data.BucketID == nil {
-		res := impl.db.DB.Create(&fruit)
+	fruit.EffectivePrice = impl.markdowns.EffectivePrice(fruit.Price, fruit.ExpiresAt, _time.Now())
+
+	return &fruit, nil
+}
+
+func (impl *FruitService) ListExpiring(ctx context.Context, within time.Duration) (*models.ExpiringFruits, error) {
+	now := _time.Now()
+
+	fruits := make([]models.Fruit, 0)
+	res := impl.db.DB.
+		Where(`deleted_at IS NULL
+			AND expires_at > ?
+			AND expires_at <= ?
+			AND state IN ?
+		`, now, now.Add(within), models.FruitActiveStates).
+		Order("expires_at, id").
+		Find(&fruits)
+
+	if err := res.Error; err != nil {
+		impl.logger.Error(err.Error())
+		return nil, err
+	}
+
+	bucketIDs := make([]int64, 0)
+	for _, fruit := range fruits {
+		if fruit.BucketID != nil {
+			bucketIDs = append(bucketIDs, *fruit.BucketID)
+		}
+	}
+
+	bucketNames := map[int64]string{}
+	if len(bucketIDs) > 0 {
+		buckets := make([]models.Bucket, 0)
+		res = impl.db.DB.Where("id IN ?", bucketIDs).Find(&buckets)
 		if err := res.Error; err != nil {
 			impl.logger.Error(err.Error())
 			return nil, err
 		}
 
-		return &fruit, nil
+		for _, bucket := range buckets {
+			bucketNames[bucket.ID] = bucket.Name
+		}
+	}
+
+	// Fruits are sorted by expiry, so buckets keep the order of their first expiring fruit.
+	// The effective price totals tell the value still at risk once marked down
+	expiring := models.ExpiringFruits{Buckets: []models.ExpiringBucketFruits{}, TotalPrice: decimal.Zero, TotalEffectivePrice: decimal.Zero}
+	groups := map[int64]int{}
+	for _, fruit := range fruits {
+		fruit.EffectivePrice = impl.markdowns.EffectivePrice(fruit.Price, fruit.ExpiresAt, now)
+
+		var key int64
+		if fruit.BucketID != nil {
+			key = *fruit.BucketID
+		}
+
+		i, ok := groups[key]
+		if !ok {
+			i = len(expiring.Buckets)
+			groups[key] = i
+			expiring.Buckets = append(expiring.Buckets, models.ExpiringBucketFruits{
+				BucketID:   fruit.BucketID,
+				BucketName: bucketNames[key],
+				Fruits:     []models.Fruit{},
+				TotalPrice: decimal.Zero,
+
+				TotalEffectivePrice: decimal.Zero,
+			})
+		}
+
+		expiring.Buckets[i].Fruits = append(expiring.Buckets[i].Fruits, fruit)
+		expiring.Buckets[i].TotalPrice = expiring.Buckets[i].TotalPrice.Add(fruit.Price)
+		expiring.Buckets[i].TotalEffectivePrice = expiring.Buckets[i].TotalEffectivePrice.Add(fruit.EffectivePrice)
+		expiring.TotalPrice = expiring.TotalPrice.Add(fruit.Price)
+		expiring.TotalEffectivePrice = expiring.TotalEffectivePrice.Add(fruit.EffectivePrice)
+	}
+
+	return &expiring, nil
+}
+
+func (impl *FruitService) AddOnBucket(ctx context.Context, fruitID, bucketID int64) error {
+	_, err := impl.Transfer(ctx, fruitID, dtos.TransferFruitDto{ToBucketID: &bucketID})
+	return err
+}
+
+// Transfer moves a fruit to another bucket. When the expected source bucket is
+// given, the fruit must still be there
+func (impl *FruitService) Transfer(ctx context.Context, fruitID int64, data dtos.TransferFruitDto) (*models.FruitTransfer, error) {
+	if err := impl.validate.Struct(data); err != nil {
+		return nil, exceptions.NewValidationException(err)
 	}
 
-	fruit.BucketID = data.BucketID
+	now := _time.Now()
+	transfer := models.FruitTransfer{FruitID: fruitID}
+
 	err := impl.db.DB.Transaction(func(tx *gorm.DB) error {
-		if err := impl.validateBucket(ctx, tx, *data.BucketID); err != nil {
+		var fruit models.Fruit
+		res := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ?", fruitID).
+			First(&fruit)
+		if err := res.Error; err != nil {
+			if err.Error() == infra.MYSQL_ERROR_NOT_FOUND {
+				return exceptions.NewNotFoundException("Fruit not found")
+			}
+
 			return err
 		}
 
-		return tx.Create(&fruit).Error
+		if fruit.DeletedAt != nil {
+			return exceptions.NewForbiddenException("Fruit is deleted")
+		}
+		if !fruit.ExpiresAt.After(now) || !fruit.State.IsActive() {
+			return exceptions.NewForbiddenException("Fruit is expired")
+		}
+		if fruit.IsReserved(now) {
+			return exceptions.NewForbiddenException("Fruit is reserved")
+		}
+		if data.FromBucketID != nil && (fruit.BucketID == nil || *fruit.BucketID != *data.FromBucketID) {
+			return exceptions.NewConflictException("Fruit is not in the expected bucket")
+		}
+
+		if fruit.BucketID != nil {
+			var from models.Bucket
+			if err := tx.Where("id = ?", *fruit.BucketID).First(&from).Error; err != nil {
+				return err
+			}
+			transfer.FromBucket = &from
+
+			if from.ID == *data.ToBucketID {
+				transfer.ToBucket = from
+				return nil
+			}
+		}
+
+		to, err := impl.validateBucket(ctx, tx, *data.ToBucketID, fruit, now)
+		if err != nil {
+			return err
+		}
+
+		res = tx.Model(&models.Fruit{}).
+			Where("id = ?", fruit.ID).
+			Update("bucket_fk", to.ID)
+		if err := res.Error; err != nil {
+			return err
+		}
+
+		transfer.ToBucket = *to
+		transfer.Moved = true
+
+		return recordMovements(tx, []models.Movement{
+			newMovement(ctx, models.MovementTypeMoved, fruit.ID, fruit.BucketID, &to.ID, now),
+		})
 	}, &sql.TxOptions{Isolation: sql.LevelReadCommitted})
 
 	if err != nil {
-		if _, ok := err.(*exceptions.ForeignNotFoundException); ok {
+		if _, ok := err.(*exceptions.NotFoundException); ok {
+			impl.logger.Warn(err.Error())
+		} else if _, ok := err.(*exceptions.ForeignNotFoundException); ok {
 			impl.logger.Warn(err.Error())
 		} else if _, ok := err.(*exceptions.ForbiddenException); ok {
 			impl.logger.Warn(err.Error())
+		} else if _, ok := err.(*exceptions.ConflictException); ok {
+			impl.logger.Warn(err.Error())
 		} else {
 			impl.logger.Error(err.Error())
 		}
@@ -69,34 +453,47 @@ func (impl *FruitService) Create(ctx context.Context, data dtos.CreateFruitDto)
 		return nil, err
 	}
 
-	return &fruit, nil
+	return &transfer, nil
 }
 
-func (impl *FruitService) AddOnBucket(ctx context.Context, fruitID, bucketID int64) error {
+func (impl *FruitService) RemoveFromBucket(ctx context.Context, fruitID int64) error {
+	now := _time.Now()
+
 	err := impl.db.DB.Transaction(func(tx *gorm.DB) error {
-		err := impl.validateBucket(ctx, tx, bucketID)
-		if err != nil {
+		var fruit models.Fruit
+		res := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Select("id", "bucket_fk", "reserved_until").
+			Where("id = ?", fruitID).
+			First(&fruit)
+		if err := res.Error; err != nil {
+			if err.Error() == infra.MYSQL_ERROR_NOT_FOUND {
+				return exceptions.NewNotFoundException("Fruit not found")
+			}
+
 			return err
 		}
+		if fruit.BucketID == nil {
+			return nil
+		}
+		if fruit.IsReserved(now) {
+			return exceptions.NewForbiddenException("Fruit is reserved")
+		}
 
-		res := tx.Model(&models.Fruit{}).
+		res = tx.Session(&gorm.Session{NewDB: true}).Model(&models.Fruit{}).
 			Where("id = ?", fruitID).
-			Update("bucket_fk", bucketID)
-
+			Update("bucket_fk", nil)
 		if err := res.Error; err != nil {
 			return err
 		}
-		if res.RowsAffected == 0 {
-			return exceptions.NewNotFoundException("Fruit not found")
-		}
-		return nil
+
+		return recordMovements(tx, []models.Movement{
+			newMovement(ctx, models.MovementTypeUnassigned, fruit.ID, fruit.BucketID, nil, now),
+		})
 	}, &sql.TxOptions{Isolation: sql.LevelReadCommitted})
 
 	if err != nil {
 		if _, ok := err.(*exceptions.NotFoundException); ok {
 			impl.logger.Warn(err.Error())
-		} else if _, ok := err.(*exceptions.ForeignNotFoundException); ok {
-			impl.logger.Warn(err.Error())
 		} else if _, ok := err.(*exceptions.ForbiddenException); ok {
 			impl.logger.Warn(err.Error())
 		} else {
@@ -109,66 +506,482 @@ func (impl *FruitService) AddOnBucket(ctx context.Context, fruitID, bucketID int
 	return nil
 }
 
-func (impl *FruitService) RemoveFromBucket(ctx context.Context, fruitID int64) error {
-	res := impl.db.DB.Model(&models.Fruit{}).
-		Where("id = ?", fruitID).
-		Update("bucket_fk", nil)
+// MoveMany moves the selected fruits to a bucket in one transaction, checking the
+// bucket capacity once for the whole set. Expired or reserved fruits picked by id
+// abort the move, while the ones matched by a filter are left where they are
+func (impl *FruitService) MoveMany(ctx context.Context, data dtos.MoveFruitsDto) (*models.FruitBulk, error) {
+	if err := impl.validate.Struct(data); err != nil {
+		return nil, exceptions.NewValidationException(err)
+	}
 
-	if err := res.Error; err != nil {
-		impl.logger.Error(err.Error())
-		return err
+	now := _time.Now()
+	bulk := models.FruitBulk{FruitIDs: []int64{}}
+
+	err := impl.db.DB.Transaction(func(tx *gorm.DB) error {
+		fruits, err := impl.selectFruits(ctx, tx, data.SelectFruitsDto)
+		if err != nil {
+			return err
+		}
+
+		moved := []models.Fruit{}
+		ids := []int64{}
+		names := []string{}
+		movements := []models.Movement{}
+		for _, fruit := range fruits {
+			if !fruit.ExpiresAt.After(now) || !fruit.State.IsActive() {
+				if len(data.IDs) > 0 {
+					return exceptions.NewForbiddenException(fmt.Sprintf("Fruit %d is expired", fruit.ID))
+				}
+				continue
+			}
+			if fruit.IsReserved(now) {
+				if len(data.IDs) > 0 {
+					return exceptions.NewForbiddenException(fmt.Sprintf("Fruit %d is reserved", fruit.ID))
+				}
+				continue
+			}
+
+			bulk.FruitIDs = append(bulk.FruitIDs, fruit.ID)
+			if fruit.BucketID == nil || *fruit.BucketID != *data.ToBucketID {
+				moved = append(moved, fruit)
+				ids = append(ids, fruit.ID)
+				names = append(names, fruit.Name)
+				movements = append(movements, newMovement(ctx, models.MovementTypeMoved, fruit.ID, fruit.BucketID, data.ToBucketID, now))
+			}
+		}
+		if len(ids) == 0 {
+			return nil
+		}
+
+		bucket, free, err := bucketFreeCapacity(ctx, tx.Clauses(clause.Locking{Strength: "UPDATE"}), *data.ToBucketID)
+		if err != nil {
+			return err
+		}
+		if free < int64(len(ids)) {
+			return exceptions.NewForbiddenException("Bucket is full")
+		}
+		for _, fruit := range moved {
+			if err := validateQuarantine(bucket, fruit); err != nil {
+				return err
+			}
+		}
+		if err := validateCompatibility(tx, impl.compatibility, *data.ToBucketID, names, now); err != nil {
+			return err
+		}
+
+		res := tx.Model(&models.Fruit{}).
+			Where("id IN ?", ids).
+			Update("bucket_fk", *data.ToBucketID)
+		bulk.Affected = res.RowsAffected
+		if err := res.Error; err != nil {
+			return err
+		}
+
+		return recordMovements(tx, movements)
+	}, &sql.TxOptions{Isolation: sql.LevelReadCommitted})
+
+	if err != nil {
+		impl.logBulkError(err)
+		return nil, err
 	}
-	if res.RowsAffected == 0 {
-		err := exceptions.NewNotFoundException("Fruit not found")
-		impl.logger.Warn(err.Error())
-		return err
+
+	return &bulk, nil
+}
+
+// Recall quarantines the fruits matching the recall and lists them with the
+// bucket each one is in. Sold or disposed fruits are no longer in stock, so
+// they are left out
+func (impl *FruitService) Recall(ctx context.Context, data dtos.RecallFruitsDto) (*models.Recall, error) {
+	if err := impl.validate.Struct(data); err != nil {
+		return nil, exceptions.NewValidationException(err)
+	}
+
+	now := _time.Now()
+	recall := models.Recall{QuarantinedAt: now, Fruits: []models.RecallFruit{}}
+
+	err := impl.db.DB.Transaction(func(tx *gorm.DB) error {
+		query := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Select("id", "name", "bucket_fk", "quarantined_at").
+			Where("deleted_at IS NULL AND state NOT IN ?", []models.FruitState{models.FruitStateSold, models.FruitStateDisposed})
+		if data.SupplierID != nil {
+			query = query.Where("supplier_fk = ?", *data.SupplierID)
+		}
+		if data.LotID != nil {
+			query = query.Where("purchase_order_line_fk = ?", *data.LotID)
+		}
+		if data.CreatedFrom != nil {
+			query = query.Where("created_at >= ? AND created_at < ?", *data.CreatedFrom, *data.CreatedTo)
+		}
+
+		fruits := make([]models.Fruit, 0)
+		if err := query.Order("id").Find(&fruits).Error; err != nil {
+			return err
+		}
+
+		ids := []int64{}
+		bucketIDs := []int64{}
+		seen := map[int64]bool{}
+		for _, fruit := range fruits {
+			if !fruit.IsQuarantined() {
+				ids = append(ids, fruit.ID)
+			}
+			if fruit.BucketID != nil && !seen[*fruit.BucketID] {
+				seen[*fruit.BucketID] = true
+				bucketIDs = append(bucketIDs, *fruit.BucketID)
+			}
+		}
+
+		if len(ids) > 0 {
+			res := tx.Session(&gorm.Session{NewDB: true}).Model(&models.Fruit{}).
+				Where("id IN ?", ids).
+				Update("quarantined_at", now)
+			recall.Affected = res.RowsAffected
+			if err := res.Error; err != nil {
+				return err
+			}
+		}
+
+		bucketNames := map[int64]string{}
+		if len(bucketIDs) > 0 {
+			buckets := make([]models.Bucket, 0)
+			res := tx.Session(&gorm.Session{NewDB: true}).Where("id IN ?", bucketIDs).Find(&buckets)
+			if err := res.Error; err != nil {
+				return err
+			}
+
+			for _, bucket := range buckets {
+				bucketNames[bucket.ID] = bucket.Name
+			}
+		}
+
+		for _, fruit := range fruits {
+			item := models.RecallFruit{FruitID: fruit.ID, Name: fruit.Name, BucketID: fruit.BucketID}
+			if fruit.BucketID != nil {
+				name := bucketNames[*fruit.BucketID]
+				item.BucketName = &name
+			}
+			recall.Fruits = append(recall.Fruits, item)
+		}
+
+		return nil
+	}, &sql.TxOptions{Isolation: sql.LevelReadCommitted})
+
+	if err != nil {
+		impl.logBulkError(err)
+		return nil, err
 	}
 
-	return res.Error
+	return &recall, nil
 }
 
+// UnassignMany removes the selected fruits from their buckets in one transaction
+func (impl *FruitService) UnassignMany(ctx context.Context, data dtos.SelectFruitsDto) (*models.FruitBulk, error) {
+	now := _time.Now()
+	return impl.updateMany(ctx, data, now, "bucket_fk", nil, func(tx *gorm.DB, fruits []models.Fruit) error {
+		movements := []models.Movement{}
+		for _, fruit := range fruits {
+			if fruit.BucketID != nil {
+				movements = append(movements, newMovement(ctx, models.MovementTypeUnassigned, fruit.ID, fruit.BucketID, nil, now))
+			}
+		}
+
+		return recordMovements(tx, movements)
+	})
+}
+
+// DeleteMany soft deletes the selected fruits in one transaction, raising stock
+// alerts for the names taken below their reorder point
+func (impl *FruitService) DeleteMany(ctx context.Context, data dtos.SelectFruitsDto) (*models.FruitBulk, error) {
+	now := _time.Now()
+	return impl.updateMany(ctx, data, now, "deleted_at", now, func(tx *gorm.DB, fruits []models.Fruit) error {
+		if err := emitStockAlerts(tx, removedStock(fruits, now), models.StockAlertReasonDeleted, now); err != nil {
+			return err
+		}
+
+		movements := make([]models.Movement, 0, len(fruits))
+		for _, fruit := range fruits {
+			movements = append(movements, newMovement(ctx, models.MovementTypeDeleted, fruit.ID, fruit.BucketID, nil, now))
+		}
+
+		return recordMovements(tx, movements)
+	})
+}
+
+// Delete soft deletes the fruit, raising a stock alert when it takes the stock
+// of its name below the reorder point
 func (impl *FruitService) Delete(ctx context.Context, id int64) error {
-	res := impl.db.DB.Model(&models.Fruit{}).
-		Where("id = ? AND deleted_at IS NULL", id).
-		Update("deleted_at", _time.Now())
+	now := _time.Now()
+
+	err := impl.db.DB.Transaction(func(tx *gorm.DB) error {
+		fruits := make([]models.Fruit, 0)
+		res := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Select("id", "name", "expires_at", "state", "bucket_fk", "reserved_until").
+			Where("id = ? AND deleted_at IS NULL", id).
+			Find(&fruits)
+		if err := res.Error; err != nil {
+			return err
+		}
+		if len(fruits) == 0 {
+			return nil
+		}
+		if fruits[0].IsReserved(now) {
+			return exceptions.NewForbiddenException("Fruit is reserved")
+		}
+
+		res = tx.Session(&gorm.Session{NewDB: true}).Model(&models.Fruit{}).
+			Where("id = ?", id).
+			Update("deleted_at", now)
+		if err := res.Error; err != nil {
+			return err
+		}
+
+		if err := emitStockAlerts(tx, removedStock(fruits, now), models.StockAlertReasonDeleted, now); err != nil {
+			return err
+		}
+
+		return recordMovements(tx, []models.Movement{
+			newMovement(ctx, models.MovementTypeDeleted, id, fruits[0].BucketID, nil, now),
+		})
+	}, &sql.TxOptions{Isolation: sql.LevelReadCommitted})
+
+	if err != nil {
+		if _, ok := err.(*exceptions.ForbiddenException); ok {
+			impl.logger.Warn(err.Error())
+		} else {
+			impl.logger.Error(err.Error())
+		}
 
-	if err := res.Error; err != nil {
-		impl.logger.Error(err.Error())
 		return err
 	}
 
 	return nil
 }
 
-func (impl *FruitService) validateBucket(ctx context.Context, tx *gorm.DB, bucketID int64) error {
+func (impl *FruitService) validateBucket(ctx context.Context, tx *gorm.DB, bucketID int64, fruit models.Fruit, now time.Time) (*models.Bucket, error) {
+	bucket, free, err := bucketFreeCapacity(ctx, tx.Clauses(clause.Locking{Strength: "UPDATE"}), bucketID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Validate current bucket capacity
+	if free <= 0 {
+		return nil, exceptions.NewForbiddenException("Bucket is full")
+	}
+
+	if err := validateQuarantine(bucket, fruit); err != nil {
+		return nil, err
+	}
+
+	// Validate storage compatibility with the fruits in the bucket
+	if err := validateCompatibility(tx, impl.compatibility, bucketID, []string{fruit.Name}, now); err != nil {
+		return nil, err
+	}
+
+	return bucket, nil
+}
+
+// validateQuarantine keeps quarantined fruits in quarantine buckets, apart from
+// every other fruit
+func validateQuarantine(bucket *models.Bucket, fruit models.Fruit) error {
+	if fruit.IsQuarantined() && !bucket.Quarantine {
+		return exceptions.NewForbiddenException("Quarantined fruits can only go to a quarantine bucket")
+	}
+	if !fruit.IsQuarantined() && bucket.Quarantine {
+		return exceptions.NewForbiddenException("Quarantine bucket only holds quarantined fruits")
+	}
+
+	return nil
+}
+
+// bucketFreeCapacity returns the bucket and how many more valid fruits it can hold
+func bucketFreeCapacity(ctx context.Context, tx *gorm.DB, bucketID int64) (*models.Bucket, int64, error) {
 	now := _time.Now()
 
 	// Get bucket by ID
 	var bucket models.Bucket
 	res := tx.Where("id = ? AND deleted_at IS NULL", bucketID).First(&bucket)
-	err := res.Error
-	if err != nil && err.Error() == infra.MYSQL_ERROR_NOT_FOUND {
-		return exceptions.NewForeignNotFoundException("Bucket not found")
+	if err := res.Error; err != nil {
+		if err.Error() == infra.MYSQL_ERROR_NOT_FOUND {
+			return nil, 0, exceptions.NewForeignNotFoundException("Bucket not found")
+		}
+
+		return nil, 0, err
 	}
 
 	// Get total valid fruits by bucket
 	var totalFruits int64
-	res = tx.Model(&models.Fruit{}).
+	res = tx.Session(&gorm.Session{NewDB: true}).Model(&models.Fruit{}).
 		Where(`bucket_fk = ?
 			AND deleted_at IS NULL
 			AND expires_at > ?
-		`, bucketID, now).
+			AND state IN ?
+		`, bucketID, now, models.FruitActiveStates).
 		Count(&totalFruits)
 	if err := res.Error; err != nil {
-		return err
+		return nil, 0, err
 	}
 
-	// Validate current bucket capacity
-	if totalFruits >= int64(bucket.Capacity) {
-		return exceptions.NewForbiddenException("Bucket is full")
+	return &bucket, int64(bucket.Capacity) - totalFruits, nil
+}
+
+func (impl *FruitService) validateSupplier(ctx context.Context, tx *gorm.DB, supplierID int64) error {
+	var supplier models.Supplier
+	res := tx.Where("id = ? AND deleted_at IS NULL", supplierID).First(&supplier)
+	if err := res.Error; err != nil {
+		if err.Error() == infra.MYSQL_ERROR_NOT_FOUND {
+			return exceptions.NewForeignNotFoundException("Supplier not found")
+		}
+
+		return err
 	}
 
 	return nil
 }
 
+// updateMany sets the column on the selected fruits, then calls after, when
+// given, within the same transaction. Reserved fruits picked by id abort the
+// update, while the ones matched by a filter are left as they are
+func (impl *FruitService) updateMany(ctx context.Context, data dtos.SelectFruitsDto, now time.Time, column string, value interface{},
+	after func(tx *gorm.DB, fruits []models.Fruit) error) (*models.FruitBulk, error) {
+	if err := impl.validate.Struct(data); err != nil {
+		return nil, exceptions.NewValidationException(err)
+	}
+
+	bulk := models.FruitBulk{FruitIDs: []int64{}}
+
+	err := impl.db.DB.Transaction(func(tx *gorm.DB) error {
+		selected, err := impl.selectFruits(ctx, tx, data)
+		if err != nil {
+			return err
+		}
+
+		fruits := []models.Fruit{}
+		for _, fruit := range selected {
+			if fruit.IsReserved(now) {
+				if len(data.IDs) > 0 {
+					return exceptions.NewForbiddenException(fmt.Sprintf("Fruit %d is reserved", fruit.ID))
+				}
+				continue
+			}
+
+			fruits = append(fruits, fruit)
+			bulk.FruitIDs = append(bulk.FruitIDs, fruit.ID)
+		}
+		if len(bulk.FruitIDs) == 0 {
+			return nil
+		}
+
+		res := tx.Model(&models.Fruit{}).
+			Where("id IN ?", bulk.FruitIDs).
+			Update(column, value)
+		bulk.Affected = res.RowsAffected
+		if err := res.Error; err != nil {
+			return err
+		}
+
+		if after == nil {
+			return nil
+		}
+
+		return after(tx, fruits)
+	}, &sql.TxOptions{Isolation: sql.LevelReadCommitted})
+
+	if err != nil {
+		impl.logBulkError(err)
+		return nil, err
+	}
+
+	return &bulk, nil
+}
+
+// selectFruits locks the not deleted fruits picked by id or matching the filter.
+// Every requested id must exist
+func (impl *FruitService) selectFruits(ctx context.Context, tx *gorm.DB, data dtos.SelectFruitsDto) ([]models.Fruit, error) {
+	query := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Select("id", "name", "expires_at", "state", "bucket_fk", "reserved_until", "quarantined_at").
+		Where("deleted_at IS NULL")
+
+	if len(data.IDs) > 0 {
+		query = query.Where("id IN ?", data.IDs)
+	}
+	if filter := data.Filter; filter != nil {
+		if filter.BucketID != nil {
+			query = query.Where("bucket_fk = ?", *filter.BucketID)
+		}
+		if filter.SupplierID != nil {
+			query = query.Where("supplier_fk = ?", *filter.SupplierID)
+		}
+		if filter.Name != nil {
+			query = query.Where("name = ?", *filter.Name)
+		}
+		if filter.State != nil {
+			query = query.Where("state = ?", *filter.State)
+		}
+	}
+
+	fruits := make([]models.Fruit, 0)
+	if err := query.Order("id").Find(&fruits).Error; err != nil {
+		return nil, err
+	}
+
+	if len(data.IDs) > 0 {
+		found := map[int64]bool{}
+		for _, fruit := range fruits {
+			found[fruit.ID] = true
+		}
+
+		missing := []string{}
+		for _, id := range data.IDs {
+			if !found[id] {
+				missing = append(missing, strconv.FormatInt(id, 10))
+			}
+		}
+		if len(missing) > 0 {
+			return nil, exceptions.NewNotFoundException(fmt.Sprintf("Fruits not found: %s", strings.Join(missing, ", ")))
+		}
+	}
+
+	return fruits, nil
+}
+
+func (impl *FruitService) logBulkError(err error) {
+	if _, ok := err.(*exceptions.NotFoundException); ok {
+		impl.logger.Warn(err.Error())
+	} else if _, ok := err.(*exceptions.ForeignNotFoundException); ok {
+		impl.logger.Warn(err.Error())
+	} else if _, ok := err.(*exceptions.ForbiddenException); ok {
+		impl.logger.Warn(err.Error())
+	} else {
+		impl.logger.Error(err.Error())
+	}
+}
+
+func newFruit(data dtos.CreateFruitDto, now time.Time) models.Fruit {
+	return models.Fruit{
+		CreatedAt: now,
+		Name:      data.Name,
+		Price:     data.Price,
+		ExpiresAt: now.Add(*data.ExpiresIn),
+		State:     models.FruitStateUnripe,
+		SKU:       data.SKU,
+		Barcode:   data.Barcode,
+
+		OriginCountry: data.OriginCountry,
+		HarvestedAt:   data.HarvestedAt,
+
+		BucketID:   data.BucketID,
+		SupplierID: data.SupplierID,
+	}
+}
+
+// newFruitPrice is the first entry of the price history of a fruit
+func newFruitPrice(fruit models.Fruit) *models.FruitPrice {
+	return &models.FruitPrice{
+		CreatedAt: fruit.CreatedAt,
+		FruitID:   fruit.ID,
+		Price:     fruit.Price,
+		Reason:    models.FruitPriceReasonCreated,
+	}
+}
+
 // Refers: https://gorm.io/docs/transactions.html#Transaction
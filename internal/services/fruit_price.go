@@ -0,0 +1,146 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/viniosilva/where-are-my-fruits/internal/dtos"
+	"github.com/viniosilva/where-are-my-fruits/internal/exceptions"
+	"github.com/viniosilva/where-are-my-fruits/internal/infra"
+	"github.com/viniosilva/where-are-my-fruits/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type FruitPriceService struct {
+	db       *infra.Database
+	logger   Logger
+	validate Validate
+}
+
+func NewFruitPrice(db *infra.Database, logger Logger, validate Validate) *FruitPriceService {
+	return &FruitPriceService{
+		db:       db,
+		logger:   logger,
+		validate: validate,
+	}
+}
+
+func (impl *FruitPriceService) Change(ctx context.Context, fruitID int64, data dtos.ChangeFruitPriceDto) (*models.FruitPrice, error) {
+	if err := impl.validate.Struct(data); err != nil {
+		return nil, exceptions.NewValidationException(err)
+	}
+
+	var price models.FruitPrice
+	err := impl.db.DB.Transaction(func(tx *gorm.DB) error {
+		var fruit models.Fruit
+		res := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ? AND deleted_at IS NULL", fruitID).
+			First(&fruit)
+		if err := res.Error; err != nil {
+			if err.Error() == infra.MYSQL_ERROR_NOT_FOUND {
+				return exceptions.NewNotFoundException("Fruit not found")
+			}
+
+			return err
+		}
+
+		price = models.FruitPrice{
+			CreatedAt: _time.Now(),
+			FruitID:   fruit.ID,
+			Price:     data.Price,
+			Reason:    data.Reason,
+		}
+		if err := tx.Create(&price).Error; err != nil {
+			return err
+		}
+
+		return tx.Model(&models.Fruit{}).
+			Where("id = ?", fruit.ID).
+			Update("price", data.Price).Error
+	}, &sql.TxOptions{Isolation: sql.LevelReadCommitted})
+
+	if err != nil {
+		if _, ok := err.(*exceptions.NotFoundException); ok {
+			impl.logger.Warn(err.Error())
+		} else {
+			impl.logger.Error(err.Error())
+		}
+
+		return nil, err
+	}
+
+	return &price, nil
+}
+
+func (impl *FruitPriceService) List(ctx context.Context, fruitID int64) ([]models.FruitPrice, error) {
+	prices := make([]models.FruitPrice, 0)
+	res := impl.db.DB.
+		Where("fruit_fk = ?", fruitID).
+		Order("created_at, id").
+		Find(&prices)
+
+	if err := res.Error; err != nil {
+		impl.logger.Error(err.Error())
+		return nil, err
+	}
+
+	return prices, nil
+}
+
+// Stats returns the min, average and max price in effect for each fruit name in
+// the [From, To) period, the prices set in it along with the price each fruit
+// already had when it started. Changes counts only the prices set in the period
+func (impl *FruitPriceService) Stats(ctx context.Context, data dtos.FruitPriceStatsDto) ([]models.FruitPriceStats, error) {
+	if err := impl.validate.Struct(data); err != nil {
+		return nil, exceptions.NewValidationException(err)
+	}
+
+	query := impl.db.DB.Model(&models.FruitPrice{}).
+		Select(`fruits.name,
+				MIN(fruit_prices.price) AS min_price,
+				ROUND(AVG(fruit_prices.price), 2) AS avg_price,
+				MAX(fruit_prices.price) AS max_price,
+				IFNULL(SUM(fruit_prices.created_at >= ?), 0) AS changes`, data.From).
+		Joins("JOIN fruits ON fruits.id = fruit_prices.fruit_fk").
+		Where(`(fruit_prices.created_at >= ? AND fruit_prices.created_at < ?)
+			OR fruit_prices.id = (SELECT previous.id FROM fruit_prices AS previous
+				WHERE previous.fruit_fk = fruit_prices.fruit_fk AND previous.created_at <= ?
+				ORDER BY previous.created_at DESC, previous.id DESC LIMIT 1)`, data.From, data.To, data.From)
+	if data.Name != nil {
+		query = query.Where("fruits.name = ?", *data.Name)
+	}
+
+	rows, err := query.
+		Group("fruits.name").
+		Order("fruits.name").
+		Rows()
+	if err != nil {
+		impl.logger.Error(err.Error())
+		return nil, err
+	}
+	defer rows.Close()
+
+	stats := make([]models.FruitPriceStats, 0)
+	for rows.Next() {
+		stat := models.FruitPriceStats{}
+		dest := []interface{}{
+			&stat.Name,
+			&stat.Min,
+			&stat.Avg,
+			&stat.Max,
+			&stat.Changes,
+		}
+
+		if err := rows.Scan(dest...); err != nil {
+			impl.logger.Error(err.Error())
+			return nil, err
+		}
+
+		stats = append(stats, stat)
+	}
+
+	return stats, nil
+}
+
+// Refers: https://gorm.io/docs/advanced_query.html#Locking
@@ -0,0 +1,345 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/golang/mock/gomock"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/viniosilva/where-are-my-fruits/internal/dtos"
+	"github.com/viniosilva/where-are-my-fruits/internal/infra"
+	"github.com/viniosilva/where-are-my-fruits/internal/models"
+	"github.com/viniosilva/where-are-my-fruits/mocks"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+func TestFruitPriceService_NewFruitPrice(t *testing.T) {
+	t.Run("should be success", func(t *testing.T) {
+		//setup
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		loggerMock := mocks.NewMockLogger(ctrl)
+		validate := infra.NewValidator()
+
+		// given
+		got := NewFruitPrice(nil, loggerMock, validate)
+
+		// then
+		assert.NotNil(t, got)
+	})
+}
+
+func TestFruitPriceService_Change(t *testing.T) {
+	now := time.Date(2000, 12, 31, 23, 59, 59, 0, time.Local)
+
+	tests := map[string]struct {
+		mock    func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime)
+		fruitID int64
+		data    dtos.ChangeFruitPriceDto
+		want    *models.FruitPrice
+		wantErr string
+	}{
+		"should be success": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {
+				mTime.EXPECT().Now().Return(now)
+
+				fruitRows := sqlmock.NewRows([]string{"id", "price"}).AddRow(int64(1), "1.99")
+
+				db.ExpectBegin()
+				db.ExpectQuery("SELECT (.+) FOR UPDATE").WillReturnRows(fruitRows)                    // find fruit
+				db.ExpectExec("INSERT INTO `fruit_prices`").WillReturnResult(sqlmock.NewResult(2, 1)) // record price history
+				db.ExpectExec("UPDATE `fruits` SET `price`").WillReturnResult(sqlmock.NewResult(1, 1))
+				db.ExpectCommit()
+			},
+			fruitID: 1,
+			data:    dtos.ChangeFruitPriceDto{Price: decimal.RequireFromString("2.49"), Reason: "supplier cost increase"},
+			want: &models.FruitPrice{
+				ID:        2,
+				CreatedAt: now,
+				FruitID:   1,
+				Price:     decimal.RequireFromString("2.49"),
+				Reason:    "supplier cost increase",
+			},
+		},
+		"should throw error on validate when price is negative and reason is empty": {
+			mock:    func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {},
+			fruitID: 1,
+			data:    dtos.ChangeFruitPriceDto{Price: decimal.NewFromInt(-1)},
+			wantErr: "Key: 'ChangeFruitPriceDto.Price' Error:Field validation for 'Price' failed on the 'dgte' tag, " +
+				"Key: 'ChangeFruitPriceDto.Reason' Error:Field validation for 'Reason' failed on the 'required' tag",
+		},
+		"should throw error when fruit not found": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {
+				db.ExpectBegin()
+				db.ExpectQuery("SELECT").WillReturnError(fmt.Errorf(infra.MYSQL_ERROR_NOT_FOUND))
+				db.ExpectRollback()
+
+				logger.EXPECT().Warn(gomock.Any())
+			},
+			fruitID: 1,
+			data:    dtos.ChangeFruitPriceDto{Price: decimal.NewFromInt(1), Reason: "promotion"},
+			wantErr: "Fruit not found",
+		},
+		"should throw error on update": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {
+				mTime.EXPECT().Now().Return(now)
+
+				fruitRows := sqlmock.NewRows([]string{"id", "price"}).AddRow(int64(1), "1.99")
+
+				db.ExpectBegin()
+				db.ExpectQuery("SELECT").WillReturnRows(fruitRows)
+				db.ExpectExec("INSERT").WillReturnResult(sqlmock.NewResult(2, 1))
+				db.ExpectExec("UPDATE").WillReturnError(fmt.Errorf("error"))
+				db.ExpectRollback()
+
+				logger.EXPECT().Error(gomock.Any())
+			},
+			fruitID: 1,
+			data:    dtos.ChangeFruitPriceDto{Price: decimal.NewFromInt(1), Reason: "promotion"},
+			wantErr: "error",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			//setup
+			ctx := context.Background()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			db, sqlMock, err := sqlmock.New()
+			require.Nil(t, err)
+			defer db.Close()
+
+			dialector := mysql.New(mysql.Config{
+				DSN:                       "sqlmock_db_0",
+				DriverName:                "mysql",
+				Conn:                      db,
+				SkipInitializeWithVersion: true,
+			})
+
+			gormDB, err := gorm.Open(dialector, &gorm.Config{})
+			require.Nil(t, err)
+			database := &infra.Database{DB: gormDB, SQL: db}
+
+			loggerMock := mocks.NewMockLogger(ctrl)
+			validate := infra.NewValidator()
+			timeMock := mocks.NewMockTime(ctrl)
+			_time = timeMock
+
+			tt.mock(sqlMock, loggerMock, timeMock)
+
+			// given
+			service := NewFruitPrice(database, loggerMock, validate)
+
+			// when
+			got, err := service.Change(ctx, tt.fruitID, tt.data)
+
+			// then
+			assert.Equal(t, tt.want, got)
+			if err != nil || tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			}
+			assert.Nil(t, sqlMock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestFruitPriceService_List(t *testing.T) {
+	now := time.Date(2000, 12, 31, 23, 59, 59, 0, time.Local)
+
+	tests := map[string]struct {
+		mock    func(db sqlmock.Sqlmock, logger *mocks.MockLogger)
+		fruitID int64
+		want    []models.FruitPrice
+		wantErr string
+	}{
+		"should be success": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger) {
+				rows := sqlmock.NewRows([]string{"id", "created_at", "fruit_fk", "price", "reason"}).
+					AddRow(int64(1), now, int64(1), "1.99", "created").
+					AddRow(int64(2), now, int64(1), "2.49", "supplier cost increase")
+
+				db.ExpectQuery("SELECT (.+) FROM `fruit_prices` WHERE fruit_fk = (.+) ORDER BY created_at, id").
+					WillReturnRows(rows)
+			},
+			fruitID: 1,
+			want: []models.FruitPrice{
+				{ID: 1, CreatedAt: now, FruitID: 1, Price: decimal.RequireFromString("1.99"), Reason: "created"},
+				{ID: 2, CreatedAt: now, FruitID: 1, Price: decimal.RequireFromString("2.49"), Reason: "supplier cost increase"},
+			},
+		},
+		"should throw error": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger) {
+				db.ExpectQuery("SELECT").WillReturnError(fmt.Errorf("error"))
+				logger.EXPECT().Error(gomock.Any())
+			},
+			fruitID: 1,
+			wantErr: "error",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			//setup
+			ctx := context.Background()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			db, sqlMock, err := sqlmock.New()
+			require.Nil(t, err)
+			defer db.Close()
+
+			dialector := mysql.New(mysql.Config{
+				DSN:                       "sqlmock_db_0",
+				DriverName:                "mysql",
+				Conn:                      db,
+				SkipInitializeWithVersion: true,
+			})
+
+			gormDB, err := gorm.Open(dialector, &gorm.Config{})
+			require.Nil(t, err)
+			database := &infra.Database{DB: gormDB, SQL: db}
+
+			loggerMock := mocks.NewMockLogger(ctrl)
+
+			tt.mock(sqlMock, loggerMock)
+
+			// given
+			service := NewFruitPrice(database, loggerMock, nil)
+
+			// when
+			got, err := service.List(ctx, tt.fruitID)
+
+			// then
+			assert.Equal(t, tt.want, got)
+			if err != nil || tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestFruitPriceService_Stats(t *testing.T) {
+	from := time.Date(2000, 12, 1, 0, 0, 0, 0, time.Local)
+	to := time.Date(2001, 1, 1, 0, 0, 0, 0, time.Local)
+	name := "Mango"
+
+	tests := map[string]struct {
+		mock    func(db sqlmock.Sqlmock, logger *mocks.MockLogger)
+		data    dtos.FruitPriceStatsDto
+		want    []models.FruitPriceStats
+		wantErr string
+	}{
+		"should be success": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger) {
+				rows := sqlmock.NewRows([]string{"name", "min_price", "avg_price", "max_price", "changes"}).
+					AddRow("Mango", "1.99", "2.24", "2.49", int64(2))
+
+				db.ExpectQuery("SELECT (.+) FROM `fruit_prices` JOIN fruits (.+) AND fruits.name = (.+) GROUP BY `fruits`.`name`").
+					WithArgs(from, from, to, from, name).
+					WillReturnRows(rows)
+			},
+			data: dtos.FruitPriceStatsDto{Name: &name, From: from, To: to},
+			want: []models.FruitPriceStats{
+				{
+					Name:    "Mango",
+					Min:     decimal.RequireFromString("1.99"),
+					Avg:     decimal.RequireFromString("2.24"),
+					Max:     decimal.RequireFromString("2.49"),
+					Changes: 2,
+				},
+			},
+		},
+		"should be success with the price in effect since before the period": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger) {
+				rows := sqlmock.NewRows([]string{"name", "min_price", "avg_price", "max_price", "changes"}).
+					AddRow("Mango", "1.99", "1.99", "1.99", int64(0))
+
+				db.ExpectQuery("SELECT (.+) OR fruit_prices.id = \\(SELECT previous.id FROM fruit_prices AS previous (.+) previous.created_at <= \\?").
+					WithArgs(from, from, to, from, name).
+					WillReturnRows(rows)
+			},
+			data: dtos.FruitPriceStatsDto{Name: &name, From: from, To: to},
+			want: []models.FruitPriceStats{
+				{
+					Name:    "Mango",
+					Min:     decimal.RequireFromString("1.99"),
+					Avg:     decimal.RequireFromString("1.99"),
+					Max:     decimal.RequireFromString("1.99"),
+					Changes: 0,
+				},
+			},
+		},
+		"should be success when there are no prices in the period": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger) {
+				rows := sqlmock.NewRows([]string{"name", "min_price", "avg_price", "max_price", "changes"})
+				db.ExpectQuery("SELECT").WithArgs(from, from, to, from).WillReturnRows(rows)
+			},
+			data: dtos.FruitPriceStatsDto{From: from, To: to},
+			want: []models.FruitPriceStats{},
+		},
+		"should throw error on validate when period ends before it starts": {
+			mock:    func(db sqlmock.Sqlmock, logger *mocks.MockLogger) {},
+			data:    dtos.FruitPriceStatsDto{From: to, To: from},
+			wantErr: "Key: 'FruitPriceStatsDto.To' Error:Field validation for 'To' failed on the 'gtfield' tag",
+		},
+		"should throw error": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger) {
+				db.ExpectQuery("SELECT").WillReturnError(fmt.Errorf("error"))
+				logger.EXPECT().Error(gomock.Any())
+			},
+			data:    dtos.FruitPriceStatsDto{From: from, To: to},
+			wantErr: "error",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			//setup
+			ctx := context.Background()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			db, sqlMock, err := sqlmock.New()
+			require.Nil(t, err)
+			defer db.Close()
+
+			dialector := mysql.New(mysql.Config{
+				DSN:                       "sqlmock_db_0",
+				DriverName:                "mysql",
+				Conn:                      db,
+				SkipInitializeWithVersion: true,
+			})
+
+			gormDB, err := gorm.Open(dialector, &gorm.Config{})
+			require.Nil(t, err)
+			database := &infra.Database{DB: gormDB, SQL: db}
+
+			loggerMock := mocks.NewMockLogger(ctrl)
+			validate := infra.NewValidator()
+
+			tt.mock(sqlMock, loggerMock)
+
+			// given
+			service := NewFruitPrice(database, loggerMock, validate)
+
+			// when
+			got, err := service.Stats(ctx, tt.data)
+
+			// then
+			assert.Equal(t, tt.want, got)
+			if err != nil || tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			}
+			assert.Nil(t, sqlMock.ExpectationsWereMet())
+		})
+	}
+}
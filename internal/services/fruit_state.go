@@ -0,0 +1,322 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/viniosilva/where-are-my-fruits/internal/dtos"
+	"github.com/viniosilva/where-are-my-fruits/internal/exceptions"
+	"github.com/viniosilva/where-are-my-fruits/internal/infra"
+	"github.com/viniosilva/where-are-my-fruits/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type FruitStateService struct {
+	db       *infra.Database
+	logger   Logger
+	validate Validate
+}
+
+func NewFruitState(db *infra.Database, logger Logger, validate Validate) *FruitStateService {
+	return &FruitStateService{
+		db:       db,
+		logger:   logger,
+		validate: validate,
+	}
+}
+
+func (impl *FruitStateService) Change(ctx context.Context, fruitID int64, data dtos.ChangeFruitStateDto) (*models.FruitStateTransition, error) {
+	if err := impl.validate.Struct(data); err != nil {
+		return nil, exceptions.NewValidationException(err)
+	}
+
+	var transition models.FruitStateTransition
+	err := impl.db.DB.Transaction(func(tx *gorm.DB) error {
+		var fruit models.Fruit
+		res := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ? AND deleted_at IS NULL", fruitID).
+			First(&fruit)
+		if err := res.Error; err != nil {
+			if err.Error() == infra.MYSQL_ERROR_NOT_FOUND {
+				return exceptions.NewNotFoundException("Fruit not found")
+			}
+
+			return err
+		}
+
+		state := models.FruitState(data.State)
+		if !fruit.State.CanTransitionTo(state) {
+			return exceptions.NewInvalidStateTransitionException(
+				fmt.Sprintf("Fruit cannot transition from %s to %s", fruit.State, state))
+		}
+
+		transition = models.FruitStateTransition{
+			CreatedAt: _time.Now(),
+			FruitID:   fruit.ID,
+			FromState: fruit.State,
+			ToState:   state,
+		}
+		if err := tx.Create(&transition).Error; err != nil {
+			return err
+		}
+
+		res = tx.Model(&models.Fruit{}).
+			Where("id = ?", fruit.ID).
+			Update("state", state)
+		if err := res.Error; err != nil {
+			return err
+		}
+
+		if state.IsActive() {
+			return nil
+		}
+
+		return emitStockAlerts(tx, removedStock([]models.Fruit{fruit}, transition.CreatedAt), models.StockAlertReasonRemoved, transition.CreatedAt)
+	}, &sql.TxOptions{Isolation: sql.LevelReadCommitted})
+
+	if err != nil {
+		if _, ok := err.(*exceptions.NotFoundException); ok {
+			impl.logger.Warn(err.Error())
+		} else if _, ok := err.(*exceptions.InvalidStateTransitionException); ok {
+			impl.logger.Warn(err.Error())
+		} else {
+			impl.logger.Error(err.Error())
+		}
+
+		return nil, err
+	}
+
+	return &transition, nil
+}
+
+func (impl *FruitStateService) ListTransitions(ctx context.Context, fruitID int64) ([]models.FruitStateTransition, error) {
+	transitions := make([]models.FruitStateTransition, 0)
+	res := impl.db.DB.
+		Where("fruit_fk = ?", fruitID).
+		Order("created_at, id").
+		Find(&transitions)
+
+	if err := res.Error; err != nil {
+		impl.logger.Error(err.Error())
+		return nil, err
+	}
+
+	return transitions, nil
+}
+
+// Progress moves every active fruit forward to the state expected by its elapsed
+// shelf life, recording one transition per state it goes through. Only fruits
+// past the share of the shelf life ending their current state are locked
+func (impl *FruitStateService) Progress(ctx context.Context) (int64, error) {
+	now := _time.Now()
+	progressed := int64(0)
+
+	err := impl.db.DB.Transaction(func(tx *gorm.DB) error {
+		var fruits []models.Fruit
+		res := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Select("id", "name", "created_at", "expires_at", "state").
+			Where(`deleted_at IS NULL
+				AND state IN ?
+				AND (expires_at <= ?
+					OR (state = ? AND TIMESTAMPDIFF(MICROSECOND, created_at, ?) >= TIMESTAMPDIFF(MICROSECOND, created_at, expires_at) * ?)
+					OR (state = ? AND TIMESTAMPDIFF(MICROSECOND, created_at, ?) >= TIMESTAMPDIFF(MICROSECOND, created_at, expires_at) * ?))
+			`, models.FruitActiveStates, now,
+				models.FruitStateUnripe, now, models.FruitRipeShelfLifeRatio,
+				models.FruitStateRipe, now, models.FruitOverripeShelfLifeRatio).
+			Find(&fruits)
+		if err := res.Error; err != nil {
+			return err
+		}
+
+		transitions := []models.FruitStateTransition{}
+		fruitIDsByState := map[models.FruitState][]int64{}
+		expired := map[string]int64{}
+		for _, fruit := range fruits {
+			expected := models.ExpectedFruitState(fruit.CreatedAt, fruit.ExpiresAt, now)
+			states := models.FruitStateProgression(fruit.State, expected)
+			if len(states) == 0 {
+				continue
+			}
+
+			from := fruit.State
+			for _, state := range states {
+				transitions = append(transitions, models.FruitStateTransition{
+					CreatedAt: now,
+					FruitID:   fruit.ID,
+					FromState: from,
+					ToState:   state,
+				})
+				from = state
+			}
+
+			fruitIDsByState[expected] = append(fruitIDsByState[expected], fruit.ID)
+			if expected == models.FruitStateExpired {
+				expired[fruit.Name]++
+			}
+			progressed++
+		}
+
+		if len(transitions) == 0 {
+			return nil
+		}
+		if err := tx.Create(&transitions).Error; err != nil {
+			return err
+		}
+
+		for _, state := range []models.FruitState{models.FruitStateRipe, models.FruitStateOverripe, models.FruitStateExpired} {
+			ids, ok := fruitIDsByState[state]
+			if !ok {
+				continue
+			}
+
+			res := tx.Model(&models.Fruit{}).
+				Where("id IN ?", ids).
+				Update("state", state)
+			if err := res.Error; err != nil {
+				return err
+			}
+		}
+
+		return emitStockAlerts(tx, expired, models.StockAlertReasonExpired, now)
+	}, &sql.TxOptions{Isolation: sql.LevelReadCommitted})
+
+	if err != nil {
+		impl.logger.Error(err.Error())
+		return 0, err
+	}
+
+	return progressed, nil
+}
+
+// Sweep marks every fruit past its expiry as expired and, when asked, disposes
+// of expired fruits and unassigns them from their buckets
+func (impl *FruitStateService) Sweep(ctx context.Context, unassign, dispose bool) (*models.FruitSweep, error) {
+	now := _time.Now()
+	sweep := models.FruitSweep{}
+
+	states := append([]models.FruitState{}, models.FruitActiveStates...)
+	if dispose {
+		states = append(states, models.FruitStateExpired)
+	}
+
+	// Expired and disposed fruits are only left to unassign while in a bucket
+	unassigned := []models.FruitState{}
+	if unassign {
+		unassigned = append(unassigned, models.FruitStateExpired, models.FruitStateDisposed)
+	}
+
+	err := impl.db.DB.Transaction(func(tx *gorm.DB) error {
+		var fruits []models.Fruit
+		res := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Select("id", "name", "state", "bucket_fk").
+			Where(`deleted_at IS NULL
+				AND expires_at <= ?
+				AND (state IN ? OR (state IN ? AND bucket_fk IS NOT NULL))
+			`, now, states, unassigned).
+			Find(&fruits)
+		if err := res.Error; err != nil {
+			return err
+		}
+
+		transitions := []models.FruitStateTransition{}
+		expiredIDs := []int64{}
+		disposedIDs := []int64{}
+		unassignedIDs := []int64{}
+		movements := []models.Movement{}
+		expired := map[string]int64{}
+
+		for _, fruit := range fruits {
+			state := fruit.State
+			if state.IsActive() {
+				expired[fruit.Name]++
+			}
+
+			for _, next := range models.FruitStateProgression(state, models.FruitStateExpired) {
+				transitions = append(transitions, models.FruitStateTransition{
+					CreatedAt: now,
+					FruitID:   fruit.ID,
+					FromState: state,
+					ToState:   next,
+				})
+				state = next
+			}
+
+			if dispose && state == models.FruitStateExpired {
+				transitions = append(transitions, models.FruitStateTransition{
+					CreatedAt: now,
+					FruitID:   fruit.ID,
+					FromState: state,
+					ToState:   models.FruitStateDisposed,
+				})
+				state = models.FruitStateDisposed
+			}
+
+			if state != fruit.State {
+				if state == models.FruitStateDisposed {
+					disposedIDs = append(disposedIDs, fruit.ID)
+				} else {
+					expiredIDs = append(expiredIDs, fruit.ID)
+				}
+			}
+			if unassign && fruit.BucketID != nil {
+				unassignedIDs = append(unassignedIDs, fruit.ID)
+				movements = append(movements, newMovement(ctx, models.MovementTypeUnassigned, fruit.ID, fruit.BucketID, nil, now))
+			}
+		}
+
+		if len(transitions) > 0 {
+			if err := tx.Create(&transitions).Error; err != nil {
+				return err
+			}
+		}
+
+		updates := []struct {
+			ids    []int64
+			column string
+			value  interface{}
+		}{
+			{expiredIDs, "state", models.FruitStateExpired},
+			{disposedIDs, "state", models.FruitStateDisposed},
+			{unassignedIDs, "bucket_fk", nil},
+		}
+		for _, update := range updates {
+			if len(update.ids) == 0 {
+				continue
+			}
+
+			res := tx.Model(&models.Fruit{}).
+				Where("id IN ?", update.ids).
+				Update(update.column, update.value)
+			if err := res.Error; err != nil {
+				return err
+			}
+		}
+
+		for _, transition := range transitions {
+			switch transition.ToState {
+			case models.FruitStateExpired:
+				sweep.ExpiredFruits++
+			case models.FruitStateDisposed:
+				sweep.DisposedFruits++
+			}
+		}
+		sweep.UnassignedFruits = int64(len(unassignedIDs))
+
+		if err := recordMovements(tx, movements); err != nil {
+			return err
+		}
+
+		return emitStockAlerts(tx, expired, models.StockAlertReasonExpired, now)
+	}, &sql.TxOptions{Isolation: sql.LevelReadCommitted})
+
+	if err != nil {
+		impl.logger.Error(err.Error())
+		return nil, err
+	}
+
+	return &sweep, nil
+}
+
+// Refers: https://gorm.io/docs/advanced_query.html#Locking
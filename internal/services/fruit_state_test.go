@@ -0,0 +1,504 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/viniosilva/where-are-my-fruits/internal/dtos"
+	"github.com/viniosilva/where-are-my-fruits/internal/infra"
+	"github.com/viniosilva/where-are-my-fruits/internal/models"
+	"github.com/viniosilva/where-are-my-fruits/mocks"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+func TestFruitStateService_NewFruitState(t *testing.T) {
+	t.Run("should be success", func(t *testing.T) {
+		//setup
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		loggerMock := mocks.NewMockLogger(ctrl)
+		validate := infra.NewValidator()
+
+		// given
+		got := NewFruitState(nil, loggerMock, validate)
+
+		// then
+		assert.NotNil(t, got)
+	})
+}
+
+func TestFruitStateService_Change(t *testing.T) {
+	now := time.Date(2000, 12, 31, 23, 59, 59, 0, time.Local)
+
+	tests := map[string]struct {
+		mock    func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime)
+		fruitID int64
+		data    dtos.ChangeFruitStateDto
+		want    *models.FruitStateTransition
+		wantErr string
+	}{
+		"should be success": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {
+				mTime.EXPECT().Now().Return(now)
+
+				fruitRows := sqlmock.NewRows([]string{"id", "state"}).AddRow(int64(1), "unripe")
+
+				db.ExpectBegin()
+				db.ExpectQuery("SELECT").WillReturnRows(fruitRows)                // find fruit
+				db.ExpectExec("INSERT").WillReturnResult(sqlmock.NewResult(1, 1)) // create transition
+				db.ExpectExec("UPDATE").WillReturnResult(sqlmock.NewResult(1, 1)) // update fruit state
+				db.ExpectCommit()
+			},
+			fruitID: 1,
+			data:    dtos.ChangeFruitStateDto{State: "ripe"},
+			want: &models.FruitStateTransition{
+				ID:        1,
+				CreatedAt: now,
+				FruitID:   1,
+				FromState: models.FruitStateUnripe,
+				ToState:   models.FruitStateRipe,
+			},
+		},
+		"should raise stock alert when fruit is disposed": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {
+				mTime.EXPECT().Now().Return(now)
+
+				fruitRows := sqlmock.NewRows([]string{"id", "name", "expires_at", "state"}).
+					AddRow(int64(1), "Orange", now.Add(time.Hour), "ripe")
+
+				db.ExpectBegin()
+				db.ExpectQuery("SELECT").WillReturnRows(fruitRows)                // find fruit
+				db.ExpectExec("INSERT").WillReturnResult(sqlmock.NewResult(1, 1)) // create transition
+				db.ExpectExec("UPDATE").WillReturnResult(sqlmock.NewResult(1, 1)) // update fruit state
+				db.ExpectQuery("SELECT (.+) FROM `reorder_points`").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "name", "min_quantity"}).AddRow(int64(1), "Orange", 1)) // find reorder points
+				db.ExpectQuery("SELECT name, COUNT(.+) FROM `fruits`").
+					WillReturnRows(sqlmock.NewRows([]string{"name", "quantity"})) // count stock
+				db.ExpectExec("INSERT INTO `stock_alerts`").
+					WithArgs(now, "Orange", int64(0), 1, models.StockAlertReasonRemoved).
+					WillReturnResult(sqlmock.NewResult(1, 1)) // create alert
+				db.ExpectCommit()
+			},
+			fruitID: 1,
+			data:    dtos.ChangeFruitStateDto{State: "disposed"},
+			want: &models.FruitStateTransition{
+				ID:        1,
+				CreatedAt: now,
+				FruitID:   1,
+				FromState: models.FruitStateRipe,
+				ToState:   models.FruitStateDisposed,
+			},
+		},
+		"should throw error on validate when state is unknown": {
+			mock:    func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {},
+			fruitID: 1,
+			data:    dtos.ChangeFruitStateDto{State: "rotten"},
+			wantErr: "Key: 'ChangeFruitStateDto.State' Error:Field validation for 'State' failed on the 'oneof' tag",
+		},
+		"should throw error when fruit not found": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {
+				db.ExpectBegin()
+				db.ExpectQuery("SELECT").WillReturnError(fmt.Errorf(infra.MYSQL_ERROR_NOT_FOUND))
+				db.ExpectRollback()
+
+				logger.EXPECT().Warn(gomock.Any())
+			},
+			fruitID: 1,
+			data:    dtos.ChangeFruitStateDto{State: "ripe"},
+			wantErr: "Fruit not found",
+		},
+		"should throw error when transition is not allowed": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {
+				fruitRows := sqlmock.NewRows([]string{"id", "state"}).AddRow(int64(1), "overripe")
+
+				db.ExpectBegin()
+				db.ExpectQuery("SELECT").WillReturnRows(fruitRows)
+				db.ExpectRollback()
+
+				logger.EXPECT().Warn(gomock.Any())
+			},
+			fruitID: 1,
+			data:    dtos.ChangeFruitStateDto{State: "unripe"},
+			wantErr: "Fruit cannot transition from overripe to unripe",
+		},
+		"should throw error on update": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {
+				mTime.EXPECT().Now().Return(now)
+
+				fruitRows := sqlmock.NewRows([]string{"id", "state"}).AddRow(int64(1), "unripe")
+
+				db.ExpectBegin()
+				db.ExpectQuery("SELECT").WillReturnRows(fruitRows)
+				db.ExpectExec("INSERT").WillReturnResult(sqlmock.NewResult(1, 1))
+				db.ExpectExec("UPDATE").WillReturnError(fmt.Errorf("error"))
+				db.ExpectRollback()
+
+				logger.EXPECT().Error(gomock.Any())
+			},
+			fruitID: 1,
+			data:    dtos.ChangeFruitStateDto{State: "ripe"},
+			wantErr: "error",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			//setup
+			ctx := context.Background()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			db, sqlMock, err := sqlmock.New()
+			require.Nil(t, err)
+			defer db.Close()
+
+			dialector := mysql.New(mysql.Config{
+				DSN:                       "sqlmock_db_0",
+				DriverName:                "mysql",
+				Conn:                      db,
+				SkipInitializeWithVersion: true,
+			})
+
+			gormDB, err := gorm.Open(dialector, &gorm.Config{})
+			require.Nil(t, err)
+			database := &infra.Database{DB: gormDB, SQL: db}
+
+			loggerMock := mocks.NewMockLogger(ctrl)
+			validate := infra.NewValidator()
+			timeMock := mocks.NewMockTime(ctrl)
+			_time = timeMock
+
+			tt.mock(sqlMock, loggerMock, timeMock)
+
+			// given
+			service := NewFruitState(database, loggerMock, validate)
+
+			// when
+			got, err := service.Change(ctx, tt.fruitID, tt.data)
+
+			// then
+			assert.Equal(t, tt.want, got)
+			if err != nil || tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestFruitStateService_ListTransitions(t *testing.T) {
+	now := time.Date(2000, 12, 31, 23, 59, 59, 0, time.Local)
+
+	tests := map[string]struct {
+		mock    func(db sqlmock.Sqlmock, logger *mocks.MockLogger)
+		fruitID int64
+		want    []models.FruitStateTransition
+		wantErr string
+	}{
+		"should be success": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger) {
+				rows := sqlmock.NewRows([]string{"id", "created_at", "fruit_fk", "from_state", "to_state"}).
+					AddRow(int64(1), now, int64(1), "unripe", "ripe")
+
+				db.ExpectQuery("SELECT").WillReturnRows(rows)
+			},
+			fruitID: 1,
+			want: []models.FruitStateTransition{
+				{ID: 1, CreatedAt: now, FruitID: 1, FromState: models.FruitStateUnripe, ToState: models.FruitStateRipe},
+			},
+		},
+		"should throw error": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger) {
+				db.ExpectQuery("SELECT").WillReturnError(fmt.Errorf("error"))
+				logger.EXPECT().Error(gomock.Any())
+			},
+			fruitID: 1,
+			wantErr: "error",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			//setup
+			ctx := context.Background()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			db, sqlMock, err := sqlmock.New()
+			require.Nil(t, err)
+			defer db.Close()
+
+			dialector := mysql.New(mysql.Config{
+				DSN:                       "sqlmock_db_0",
+				DriverName:                "mysql",
+				Conn:                      db,
+				SkipInitializeWithVersion: true,
+			})
+
+			gormDB, err := gorm.Open(dialector, &gorm.Config{})
+			require.Nil(t, err)
+			database := &infra.Database{DB: gormDB, SQL: db}
+
+			loggerMock := mocks.NewMockLogger(ctrl)
+
+			tt.mock(sqlMock, loggerMock)
+
+			// given
+			service := NewFruitState(database, loggerMock, nil)
+
+			// when
+			got, err := service.ListTransitions(ctx, tt.fruitID)
+
+			// then
+			assert.Equal(t, tt.want, got)
+			if err != nil || tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestFruitStateService_Progress(t *testing.T) {
+	now := time.Date(2000, 12, 31, 23, 59, 59, 0, time.Local)
+
+	tests := map[string]struct {
+		mock    func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime)
+		want    int64
+		wantErr string
+	}{
+		"should be success": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {
+				mTime.EXPECT().Now().Return(now)
+
+				fruitRows := sqlmock.NewRows([]string{"id", "name", "created_at", "expires_at", "state"}).
+					AddRow(int64(1), "Orange", now.Add(-10*time.Hour), now.Add(time.Hour), "unripe"). // to overripe
+					AddRow(int64(2), "Orange", now.Add(-10*time.Hour), now.Add(-time.Hour), "ripe")   // to expired
+
+				db.ExpectBegin()
+				db.ExpectQuery("SELECT (.+) FROM `fruits` WHERE (.+) AND \\(expires_at <= \\?(.+)FOR UPDATE").
+					WithArgs(models.FruitStateUnripe, models.FruitStateRipe, models.FruitStateOverripe, now,
+						models.FruitStateUnripe, now, models.FruitRipeShelfLifeRatio,
+						models.FruitStateRipe, now, models.FruitOverripeShelfLifeRatio).
+					WillReturnRows(fruitRows) // find active fruits due to progress
+				db.ExpectExec("INSERT").WillReturnResult(sqlmock.NewResult(1, 4)) // create transitions
+				db.ExpectExec("UPDATE").WillReturnResult(sqlmock.NewResult(1, 1)) // update overripe fruits
+				db.ExpectExec("UPDATE").WillReturnResult(sqlmock.NewResult(2, 1)) // update expired fruits
+				db.ExpectQuery("SELECT (.+) FROM `reorder_points`").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "name", "min_quantity"}).AddRow(int64(1), "Orange", 4)) // find reorder points
+				db.ExpectQuery("SELECT name, COUNT(.+) FROM `fruits`").
+					WillReturnRows(sqlmock.NewRows([]string{"name", "quantity"}).AddRow("Orange", int64(3))) // count stock
+				db.ExpectExec("INSERT INTO `stock_alerts`").
+					WithArgs(now, "Orange", int64(3), 4, models.StockAlertReasonExpired).
+					WillReturnResult(sqlmock.NewResult(1, 1)) // create alert
+				db.ExpectCommit()
+			},
+			want: 2,
+		},
+		"should be success when there is nothing to progress": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {
+				mTime.EXPECT().Now().Return(now)
+
+				fruitRows := sqlmock.NewRows([]string{"id", "created_at", "expires_at", "state"})
+
+				db.ExpectBegin()
+				db.ExpectQuery("SELECT").WillReturnRows(fruitRows)
+				db.ExpectCommit()
+			},
+			want: 0,
+		},
+		"should throw error": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {
+				mTime.EXPECT().Now().Return(now)
+
+				db.ExpectBegin()
+				db.ExpectQuery("SELECT").WillReturnError(fmt.Errorf("error"))
+				db.ExpectRollback()
+
+				logger.EXPECT().Error(gomock.Any())
+			},
+			wantErr: "error",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			//setup
+			ctx := context.Background()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			db, sqlMock, err := sqlmock.New()
+			require.Nil(t, err)
+			defer db.Close()
+
+			dialector := mysql.New(mysql.Config{
+				DSN:                       "sqlmock_db_0",
+				DriverName:                "mysql",
+				Conn:                      db,
+				SkipInitializeWithVersion: true,
+			})
+
+			gormDB, err := gorm.Open(dialector, &gorm.Config{})
+			require.Nil(t, err)
+			database := &infra.Database{DB: gormDB, SQL: db}
+
+			loggerMock := mocks.NewMockLogger(ctrl)
+			timeMock := mocks.NewMockTime(ctrl)
+			_time = timeMock
+
+			tt.mock(sqlMock, loggerMock, timeMock)
+
+			// given
+			service := NewFruitState(database, loggerMock, nil)
+
+			// when
+			got, err := service.Progress(ctx)
+
+			// then
+			assert.Equal(t, tt.want, got)
+			if err != nil || tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			}
+			assert.Nil(t, sqlMock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestFruitStateService_Sweep(t *testing.T) {
+	now := time.Date(2000, 12, 31, 23, 59, 59, 0, time.Local)
+	bucketID := int64(1)
+
+	tests := map[string]struct {
+		mock     func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime)
+		unassign bool
+		dispose  bool
+		want     *models.FruitSweep
+		wantErr  string
+	}{
+		"should mark expired fruits": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {
+				mTime.EXPECT().Now().Return(now)
+
+				fruitRows := sqlmock.NewRows([]string{"id", "name", "state", "bucket_fk"}).
+					AddRow(int64(1), "Orange", "ripe", bucketID).
+					AddRow(int64(2), "Orange", "overripe", nil)
+
+				db.ExpectBegin()
+				db.ExpectQuery("SELECT").WillReturnRows(fruitRows)                // find expired fruits
+				db.ExpectExec("INSERT").WillReturnResult(sqlmock.NewResult(1, 3)) // create transitions
+				db.ExpectExec("UPDATE").WillReturnResult(sqlmock.NewResult(1, 2)) // update expired fruits
+				db.ExpectQuery("SELECT (.+) FROM `reorder_points`").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "name", "min_quantity"}).AddRow(int64(1), "Orange", 4)) // find reorder points
+				db.ExpectQuery("SELECT name, COUNT(.+) FROM `fruits`").
+					WillReturnRows(sqlmock.NewRows([]string{"name", "quantity"}).AddRow("Orange", int64(3))) // count stock
+				db.ExpectExec("INSERT INTO `stock_alerts`").
+					WithArgs(now, "Orange", int64(3), 4, models.StockAlertReasonExpired).
+					WillReturnResult(sqlmock.NewResult(1, 1)) // create alert
+				db.ExpectCommit()
+			},
+			want: &models.FruitSweep{ExpiredFruits: 2},
+		},
+		"should mark expired, dispose and unassign fruits": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {
+				mTime.EXPECT().Now().Return(now)
+
+				fruitRows := sqlmock.NewRows([]string{"id", "name", "state", "bucket_fk"}).
+					AddRow(int64(1), "Orange", "ripe", bucketID).
+					AddRow(int64(2), "Orange", "expired", bucketID).
+					AddRow(int64(3), "Orange", "disposed", bucketID)
+
+				db.ExpectBegin()
+				db.ExpectQuery("SELECT (.+) OR \\(state IN (.+) AND bucket_fk IS NOT NULL\\)").
+					WithArgs(now, models.FruitStateUnripe, models.FruitStateRipe, models.FruitStateOverripe, models.FruitStateExpired,
+						models.FruitStateExpired, models.FruitStateDisposed).
+					WillReturnRows(fruitRows) // find expired fruits
+				db.ExpectExec("INSERT").WillReturnResult(sqlmock.NewResult(1, 4)) // create transitions
+				db.ExpectExec("UPDATE").WillReturnResult(sqlmock.NewResult(1, 2)) // update disposed fruits
+				db.ExpectExec("UPDATE").WillReturnResult(sqlmock.NewResult(1, 3)) // unassign fruits
+				db.ExpectExec("INSERT").WillReturnResult(sqlmock.NewResult(1, 3)) // record movements
+				db.ExpectQuery("SELECT (.+) FROM `reorder_points`").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "name", "min_quantity"})) // find reorder points
+				db.ExpectCommit()
+			},
+			unassign: true,
+			dispose:  true,
+			want:     &models.FruitSweep{ExpiredFruits: 1, DisposedFruits: 2, UnassignedFruits: 3},
+		},
+		"should be success when there is nothing to sweep": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {
+				mTime.EXPECT().Now().Return(now)
+
+				fruitRows := sqlmock.NewRows([]string{"id", "state", "bucket_fk"})
+
+				db.ExpectBegin()
+				db.ExpectQuery("SELECT").WillReturnRows(fruitRows)
+				db.ExpectCommit()
+			},
+			unassign: true,
+			dispose:  true,
+			want:     &models.FruitSweep{},
+		},
+		"should throw error": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {
+				mTime.EXPECT().Now().Return(now)
+
+				db.ExpectBegin()
+				db.ExpectQuery("SELECT").WillReturnError(fmt.Errorf("error"))
+				db.ExpectRollback()
+
+				logger.EXPECT().Error(gomock.Any())
+			},
+			wantErr: "error",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			//setup
+			ctx := context.Background()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			db, sqlMock, err := sqlmock.New()
+			require.Nil(t, err)
+			defer db.Close()
+
+			dialector := mysql.New(mysql.Config{
+				DSN:                       "sqlmock_db_0",
+				DriverName:                "mysql",
+				Conn:                      db,
+				SkipInitializeWithVersion: true,
+			})
+
+			gormDB, err := gorm.Open(dialector, &gorm.Config{})
+			require.Nil(t, err)
+			database := &infra.Database{DB: gormDB, SQL: db}
+
+			loggerMock := mocks.NewMockLogger(ctrl)
+			timeMock := mocks.NewMockTime(ctrl)
+			_time = timeMock
+
+			tt.mock(sqlMock, loggerMock, timeMock)
+
+			// given
+			service := NewFruitState(database, loggerMock, nil)
+
+			// when
+			got, err := service.Sweep(ctx, tt.unassign, tt.dispose)
+
+			// then
+			assert.Equal(t, tt.want, got)
+			if err != nil || tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			}
+			assert.Nil(t, sqlMock.ExpectationsWereMet())
+		})
+	}
+}
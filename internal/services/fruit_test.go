@@ -8,11 +8,13 @@ import (
 	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	mysqlDriver "github.com/go-sql-driver/mysql"
 	"github.com/golang/mock/gomock"
 	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/viniosilva/where-are-my-fruits/internal/dtos"
+	"github.com/viniosilva/where-are-my-fruits/internal/exceptions"
 	"github.com/viniosilva/where-are-my-fruits/internal/infra"
 	"github.com/viniosilva/where-are-my-fruits/internal/models"
 	"github.com/viniosilva/where-are-my-fruits/mocks"
@@ -30,7 +32,7 @@ func TestFruitService_NewFruit(t *testing.T) {
 		validate := infra.NewValidator()
 
 		// given
-		got := NewFruit(nil, loggerMock, validate)
+		got := NewFruit(nil, loggerMock, validate, nil, nil)
 
 		// then
 		assert.NotNil(t, got)
@@ -41,6 +43,14 @@ func TestFruitService_Create(t *testing.T) {
 	now := time.Date(2000, 12, 31, 23, 59, 59, 0, time.Local)
 	expiresIn, _ := time.ParseDuration("1s")
 	bucketID := int64(1)
+	otherBucketID := int64(2)
+	sku := "ORG-001"
+	barcode := "7891234567895"
+	invalidSKU := "-ORG 001"
+	invalidBarcode := "7891234567890"
+	supplierID := int64(1)
+	groupByName := models.BucketAllocationGroupByName
+	invalidAllocation := "random"
 
 	tests := map[string]struct {
 		mock    func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime)
@@ -53,6 +63,8 @@ func TestFruitService_Create(t *testing.T) {
 				mTime.EXPECT().Now().Return(now)
 				db.ExpectBegin()
 				db.ExpectExec("INSERT").WillReturnResult(sqlmock.NewResult(1, 1))
+				db.ExpectExec("INSERT INTO `fruit_prices`").WillReturnResult(sqlmock.NewResult(1, 1))
+				db.ExpectExec("INSERT INTO `movements`").WillReturnResult(sqlmock.NewResult(1, 1)) // record movements
 				db.ExpectCommit()
 			},
 			data: dtos.CreateFruitDto{
@@ -61,11 +73,13 @@ func TestFruitService_Create(t *testing.T) {
 				ExpiresIn: &expiresIn,
 			},
 			want: &models.Fruit{
-				ID:        1,
-				CreatedAt: now,
-				Name:      "Testing lorem ipsum dolor sit amet, consectetur adipiscing elit. Mauris at ligula metus. Nullam eget viverra enim. Integer a vel",
-				Price:     decimal.NewFromInt32(0),
-				ExpiresAt: time.Date(2001, 1, 1, 0, 0, 0, 0, time.Local),
+				ID:             1,
+				CreatedAt:      now,
+				Name:           "Testing lorem ipsum dolor sit amet, consectetur adipiscing elit. Mauris at ligula metus. Nullam eget viverra enim. Integer a vel",
+				Price:          decimal.NewFromInt32(0),
+				EffectivePrice: decimal.NewFromInt32(0),
+				ExpiresAt:      time.Date(2001, 1, 1, 0, 0, 0, 0, time.Local),
+				State:          models.FruitStateUnripe,
 			},
 		},
 		"should be success when bucketID is setted": {
@@ -78,9 +92,11 @@ func TestFruitService_Create(t *testing.T) {
 				countTotalFruitsRows := sqlmock.NewRows([]string{"total"}).AddRow(int64(0))
 
 				db.ExpectBegin()
-				db.ExpectQuery("SELECT").WillReturnRows(bucketRows)               // find bucket
-				db.ExpectQuery("SELECT").WillReturnRows(countTotalFruitsRows)     // count fruits per bucket
-				db.ExpectExec("INSERT").WillReturnResult(sqlmock.NewResult(1, 1)) // create fruit with bucketID
+				db.ExpectQuery("SELECT").WillReturnRows(bucketRows)                                   // find bucket
+				db.ExpectQuery("SELECT").WillReturnRows(countTotalFruitsRows)                         // count fruits per bucket
+				db.ExpectExec("INSERT").WillReturnResult(sqlmock.NewResult(1, 1))                     // create fruit with bucketID
+				db.ExpectExec("INSERT INTO `fruit_prices`").WillReturnResult(sqlmock.NewResult(1, 1)) // record price history
+				db.ExpectExec("INSERT INTO `movements`").WillReturnResult(sqlmock.NewResult(1, 1))    // record movements
 				db.ExpectCommit()
 			},
 			data: dtos.CreateFruitDto{
@@ -90,13 +106,120 @@ func TestFruitService_Create(t *testing.T) {
 				BucketID:  &bucketID,
 			},
 			want: &models.Fruit{
-				ID:        1,
-				CreatedAt: now,
-				Name:      "Testing",
-				Price:     decimal.NewFromInt32(1),
-				ExpiresAt: time.Date(2001, 1, 1, 0, 0, 0, 0, time.Local),
-				BucketID:  &bucketID,
+				ID:             1,
+				CreatedAt:      now,
+				Name:           "Testing",
+				Price:          decimal.NewFromInt32(1),
+				EffectivePrice: decimal.NewFromInt32(1),
+				ExpiresAt:      time.Date(2001, 1, 1, 0, 0, 0, 0, time.Local),
+				State:          models.FruitStateUnripe,
+				BucketID:       &bucketID,
+			},
+		},
+		"should be success allocating a bucket": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {
+				mTime.EXPECT().Now().Return(now).Times(2)
+
+				bucketRows := sqlmock.NewRows([]string{"id", "capacity", "total_fruits", "same_name_fruits", "expiry_distance"}).
+					AddRow(int64(2), 10, int64(9), int64(0), 60.0).
+					AddRow(bucketID, 10, int64(1), int64(1), 3600.0)
+
+				db.ExpectBegin()
+				db.ExpectQuery("SELECT buckets.id, (.+) FROM `buckets` LEFT JOIN fruits (.+) WHERE buckets.deleted_at IS NULL AND buckets.quarantine = \\? GROUP BY `buckets`.`id` HAVING COUNT\\(fruits.id\\) < buckets.capacity ORDER BY buckets.created_at, buckets.id").
+					WillReturnRows(bucketRows) // find buckets with free capacity
+				db.ExpectQuery("SELECT (.+) FROM `buckets` WHERE (.+) FOR UPDATE").WithArgs(bucketID).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "name", "capacity"}).AddRow(bucketID, "Testing", 10)) // lock allocated bucket
+				db.ExpectQuery("SELECT count").WillReturnRows(sqlmock.NewRows([]string{"total"}).AddRow(int64(1))) // count fruits in bucket
+				db.ExpectExec("INSERT").WithArgs(now, nil, "Testing", decimal.NewFromInt32(1), time.Date(2001, 1, 1, 0, 0, 0, 0, time.Local),
+					models.FruitStateUnripe, nil, nil, nil, nil, &bucketID, nil, nil, nil, nil, nil).
+					WillReturnResult(sqlmock.NewResult(1, 1)) // create fruit with allocated bucket
+				db.ExpectExec("INSERT INTO `fruit_prices`").WillReturnResult(sqlmock.NewResult(1, 1)) // record price history
+				db.ExpectExec("INSERT INTO `movements`").WillReturnResult(sqlmock.NewResult(1, 1))    // record movements
+				db.ExpectCommit()
+			},
+			data: dtos.CreateFruitDto{
+				Name:       "Testing",
+				Price:      decimal.NewFromInt32(1),
+				ExpiresIn:  &expiresIn,
+				Allocation: &groupByName,
+			},
+			want: &models.Fruit{
+				ID:             1,
+				CreatedAt:      now,
+				Name:           "Testing",
+				Price:          decimal.NewFromInt32(1),
+				EffectivePrice: decimal.NewFromInt32(1),
+				ExpiresAt:      time.Date(2001, 1, 1, 0, 0, 0, 0, time.Local),
+				State:          models.FruitStateUnripe,
+				BucketID:       &bucketID,
+			},
+		},
+		"should be success allocating the next bucket when the picked one filled in meanwhile": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {
+				mTime.EXPECT().Now().Return(now).Times(3)
+
+				bucketRows := sqlmock.NewRows([]string{"id", "capacity", "total_fruits", "same_name_fruits", "expiry_distance"}).
+					AddRow(int64(2), 10, int64(9), int64(0), 60.0).
+					AddRow(bucketID, 10, int64(1), int64(1), 3600.0)
+
+				db.ExpectBegin()
+				db.ExpectQuery("SELECT buckets.id").WillReturnRows(bucketRows) // find buckets with free capacity
+				db.ExpectQuery("SELECT (.+) FROM `buckets` WHERE (.+) FOR UPDATE").WithArgs(bucketID).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "name", "capacity"}).AddRow(bucketID, "Testing", 10)) // lock allocated bucket
+				db.ExpectQuery("SELECT count").WillReturnRows(sqlmock.NewRows([]string{"total"}).AddRow(int64(10))) // count fruits in bucket
+				db.ExpectQuery("SELECT (.+) FROM `buckets` WHERE (.+) FOR UPDATE").WithArgs(int64(2)).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "name", "capacity"}).AddRow(int64(2), "Other", 10)) // lock next bucket
+				db.ExpectQuery("SELECT count").WillReturnRows(sqlmock.NewRows([]string{"total"}).AddRow(int64(9))) // count fruits in bucket
+				db.ExpectExec("INSERT INTO `fruits`").WillReturnResult(sqlmock.NewResult(1, 1))                    // create fruit with allocated bucket
+				db.ExpectExec("INSERT INTO `fruit_prices`").WillReturnResult(sqlmock.NewResult(1, 1))              // record price history
+				db.ExpectExec("INSERT INTO `movements`").WillReturnResult(sqlmock.NewResult(1, 1))                 // record movements
+				db.ExpectCommit()
+			},
+			data: dtos.CreateFruitDto{
+				Name:       "Testing",
+				Price:      decimal.NewFromInt32(1),
+				ExpiresIn:  &expiresIn,
+				Allocation: &groupByName,
+			},
+			want: &models.Fruit{
+				ID:             1,
+				CreatedAt:      now,
+				Name:           "Testing",
+				Price:          decimal.NewFromInt32(1),
+				EffectivePrice: decimal.NewFromInt32(1),
+				ExpiresAt:      time.Date(2001, 1, 1, 0, 0, 0, 0, time.Local),
+				State:          models.FruitStateUnripe,
+				BucketID:       &otherBucketID,
+			},
+		},
+		"should throw error when no bucket has free capacity": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {
+				mTime.EXPECT().Now().Return(now)
+
+				db.ExpectBegin()
+				db.ExpectQuery("SELECT").WillReturnRows(sqlmock.NewRows([]string{"id", "capacity", "total_fruits", "same_name_fruits", "expiry_distance"}))
+				db.ExpectRollback()
+
+				logger.EXPECT().Warn(gomock.Any())
+			},
+			data: dtos.CreateFruitDto{
+				Name:       "Testing",
+				Price:      decimal.NewFromInt32(1),
+				ExpiresIn:  &expiresIn,
+				Allocation: &groupByName,
+			},
+			wantErr: "No bucket has free capacity",
+		},
+		"should throw error on validate when allocation is unknown and a bucket is given": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {},
+			data: dtos.CreateFruitDto{
+				Name:       "Testing",
+				Price:      decimal.NewFromInt32(1),
+				ExpiresIn:  &expiresIn,
+				BucketID:   &bucketID,
+				Allocation: &invalidAllocation,
 			},
+			wantErr: "Key: 'CreateFruitDto.Allocation' Error:Field validation for 'Allocation' failed on the 'oneof' tag",
 		},
 		"should throw error on validate when name is greater than 128, price is lower than 0 and expiresIn is empty": {
 			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {},
@@ -120,6 +243,65 @@ func TestFruitService_Create(t *testing.T) {
 			},
 			wantErr: "Key: 'CreateFruitDto.Name' Error:Field validation for 'Name' failed on the 'required' tag",
 		},
+		"should be success when sku and barcode are setted": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {
+				mTime.EXPECT().Now().Return(now)
+				db.ExpectBegin()
+				db.ExpectExec("INSERT").WillReturnResult(sqlmock.NewResult(1, 1))
+				db.ExpectExec("INSERT INTO `fruit_prices`").WillReturnResult(sqlmock.NewResult(1, 1))
+				db.ExpectExec("INSERT INTO `movements`").WillReturnResult(sqlmock.NewResult(1, 1)) // record movements
+				db.ExpectCommit()
+			},
+			data: dtos.CreateFruitDto{
+				Name:      "Testing",
+				Price:     decimal.NewFromInt32(1),
+				ExpiresIn: &expiresIn,
+				SKU:       &sku,
+				Barcode:   &barcode,
+			},
+			want: &models.Fruit{
+				ID:             1,
+				CreatedAt:      now,
+				Name:           "Testing",
+				Price:          decimal.NewFromInt32(1),
+				EffectivePrice: decimal.NewFromInt32(1),
+				ExpiresAt:      time.Date(2001, 1, 1, 0, 0, 0, 0, time.Local),
+				State:          models.FruitStateUnripe,
+				SKU:            &sku,
+				Barcode:        &barcode,
+			},
+		},
+		"should throw error on validate when sku is invalid and barcode checksum does not match": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {},
+			data: dtos.CreateFruitDto{
+				Name:      "Testing",
+				Price:     decimal.NewFromInt32(1),
+				ExpiresIn: &expiresIn,
+				SKU:       &invalidSKU,
+				Barcode:   &invalidBarcode,
+			},
+			wantErr: strings.Join([]string{
+				"Key: 'CreateFruitDto.SKU' Error:Field validation for 'SKU' failed on the 'sku' tag",
+				"Key: 'CreateFruitDto.Barcode' Error:Field validation for 'Barcode' failed on the 'gtin' tag",
+			}, ", "),
+		},
+		"should throw error when sku or barcode already exists": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {
+				mTime.EXPECT().Now().Return(now)
+				db.ExpectBegin()
+				db.ExpectExec("INSERT").WillReturnError(&mysqlDriver.MySQLError{Number: infra.MYSQL_ERROR_DUPLICATE_ENTRY})
+				db.ExpectRollback()
+
+				logger.EXPECT().Warn(gomock.Any())
+			},
+			data: dtos.CreateFruitDto{
+				Name:      "Testing",
+				Price:     decimal.NewFromInt32(1),
+				ExpiresIn: &expiresIn,
+				Barcode:   &barcode,
+			},
+			wantErr: "Fruit sku or barcode already exists",
+		},
 		"should throw error when bucket not found": {
 			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {
 				mTime.EXPECT().Now().AnyTimes().Return(now)
@@ -136,6 +318,22 @@ func TestFruitService_Create(t *testing.T) {
 			},
 			wantErr: "Bucket not found",
 		},
+		"should throw error when supplier not found": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {
+				mTime.EXPECT().Now().Return(now)
+				db.ExpectBegin()
+				db.ExpectQuery("SELECT").WillReturnError(fmt.Errorf(infra.MYSQL_ERROR_NOT_FOUND)) // find supplier
+				db.ExpectRollback()
+				logger.EXPECT().Warn(gomock.Any())
+			},
+			data: dtos.CreateFruitDto{
+				Name:       "Testing",
+				Price:      decimal.NewFromFloat32(1.99),
+				ExpiresIn:  &expiresIn,
+				SupplierID: &supplierID,
+			},
+			wantErr: "Supplier not found",
+		},
 		"should throw error when bucket is full": {
 			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {
 				mTime.EXPECT().Now().AnyTimes().Return(now)
@@ -233,7 +431,7 @@ func TestFruitService_Create(t *testing.T) {
 			tt.mock(sqlMock, loggerMock, timeMock)
 
 			// given
-			service := NewFruit(database, loggerMock, validate)
+			service := NewFruit(database, loggerMock, validate, nil, nil)
 
 			// when
 			got, err := service.Create(ctx, tt.data)
@@ -247,124 +445,216 @@ func TestFruitService_Create(t *testing.T) {
 	}
 }
 
-func TestFruitService_AddOnBucket(t *testing.T) {
+func TestFruitService_CreateBatch(t *testing.T) {
 	now := time.Date(2000, 12, 31, 23, 59, 59, 0, time.Local)
+	expiresIn, _ := time.ParseDuration("1s")
+	bucketID := int64(1)
+	sku := "ORG-001"
+
+	item := dtos.CreateFruitDto{Name: "Testing", Price: decimal.NewFromInt32(1), ExpiresIn: &expiresIn, BucketID: &bucketID}
+	itemWithSKU := dtos.CreateFruitDto{Name: "Testing", Price: decimal.NewFromInt32(1), ExpiresIn: &expiresIn, SKU: &sku}
+	invalidItem := dtos.CreateFruitDto{Name: "", Price: decimal.NewFromInt32(1), ExpiresIn: &expiresIn}
+	invalidItemErr := exceptions.NewValidationException(infra.NewValidator().Struct(invalidItem))
+
+	fruit := func(id int64, data dtos.CreateFruitDto) *models.Fruit {
+		return &models.Fruit{
+			ID:             id,
+			CreatedAt:      now,
+			Name:           data.Name,
+			Price:          data.Price,
+			EffectivePrice: data.Price,
+			ExpiresAt:      time.Date(2001, 1, 1, 0, 0, 0, 0, time.Local),
+			State:          models.FruitStateUnripe,
+			SKU:            data.SKU,
+			BucketID:       data.BucketID,
+		}
+	}
 
 	tests := map[string]struct {
-		mock     func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime)
-		fruitID  int64
-		bucketID int64
-		wantErr  string
+		mock    func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime)
+		rules   models.CompatibilityRules
+		data    dtos.CreateFruitsBatchDto
+		want    *models.FruitBatch
+		wantErr string
 	}{
-		"should be success when bucket exists and is not full": {
+		"should create every item when bucket has capacity for all of them": {
 			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {
 				mTime.EXPECT().Now().AnyTimes().Return(now)
 
 				bucketRows := sqlmock.NewRows([]string{"id", "name", "capacity"}).
-					AddRow(int64(1), "Testing", 1)
-
+					AddRow(int64(1), "Testing", 2)
 				countTotalFruitsRows := sqlmock.NewRows([]string{"total"}).AddRow(int64(0))
 
 				db.ExpectBegin()
-				db.ExpectQuery("SELECT").WillReturnRows(bucketRows)               // find bucket
-				db.ExpectQuery("SELECT").WillReturnRows(countTotalFruitsRows)     // count fruits per bucket
-				db.ExpectExec("UPDATE").WillReturnResult(sqlmock.NewResult(1, 1)) // update fruit with bucketID
+				db.ExpectQuery("SELECT (.+) FOR UPDATE").WillReturnRows(bucketRows) // find bucket
+				db.ExpectQuery("SELECT count").WillReturnRows(countTotalFruitsRows) // count fruits per bucket once
+				db.ExpectExec("INSERT").WillReturnResult(sqlmock.NewResult(1, 1))
+				db.ExpectExec("INSERT INTO `fruit_prices`").WillReturnResult(sqlmock.NewResult(1, 1))
+				db.ExpectExec("INSERT").WillReturnResult(sqlmock.NewResult(2, 1))
+				db.ExpectExec("INSERT INTO `fruit_prices`").WillReturnResult(sqlmock.NewResult(2, 1))
+				db.ExpectExec("INSERT INTO `movements`").WillReturnResult(sqlmock.NewResult(1, 1)) // record movements
 				db.ExpectCommit()
 			},
-			fruitID:  1,
-			bucketID: 1,
+			data: dtos.CreateFruitsBatchDto{Mode: models.FruitBatchModeAllOrNothing, Items: []dtos.CreateFruitDto{item, item}},
+			want: &models.FruitBatch{
+				Results: []models.FruitBatchResult{
+					{Index: 0, Fruit: fruit(1, item)},
+					{Index: 1, Fruit: fruit(2, item)},
+				},
+				Created: 2,
+			},
 		},
-		"should throw error when bucket not found": {
+		"should create nothing when bucket has not capacity for all items in all_or_nothing mode": {
 			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {
-				mTime.EXPECT().Now().Return(now)
+				mTime.EXPECT().Now().AnyTimes().Return(now)
+
+				bucketRows := sqlmock.NewRows([]string{"id", "name", "capacity"}).
+					AddRow(int64(1), "Testing", 2)
+				countTotalFruitsRows := sqlmock.NewRows([]string{"total"}).AddRow(int64(1))
+
 				db.ExpectBegin()
-				db.ExpectQuery("SELECT").WillReturnError(fmt.Errorf(infra.MYSQL_ERROR_NOT_FOUND)) // find bucket
+				db.ExpectQuery("SELECT (.+) FOR UPDATE").WillReturnRows(bucketRows)
+				db.ExpectQuery("SELECT count").WillReturnRows(countTotalFruitsRows)
 				db.ExpectRollback()
+
 				logger.EXPECT().Warn(gomock.Any())
 			},
-			fruitID:  1,
-			bucketID: 1,
-			wantErr:  "Bucket not found",
+			data: dtos.CreateFruitsBatchDto{Mode: models.FruitBatchModeAllOrNothing, Items: []dtos.CreateFruitDto{item, item}},
+			want: &models.FruitBatch{
+				Results: []models.FruitBatchResult{
+					{Index: 0},
+					{Index: 1, Err: exceptions.NewForbiddenException("Bucket is full")},
+				},
+				Failed: 1,
+			},
 		},
-		"should throw error when bucket is full": {
+		"should fail items incompatible with each other in the same bucket": {
 			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {
-				mTime.EXPECT().Now().Return(now)
+				mTime.EXPECT().Now().AnyTimes().Return(now)
 
 				bucketRows := sqlmock.NewRows([]string{"id", "name", "capacity"}).
-					AddRow(int64(1), "Testing", 1)
-
-				countTotalFruitsRows := sqlmock.NewRows([]string{"total"}).AddRow(int64(1))
+					AddRow(int64(1), "Testing", 2)
+				countTotalFruitsRows := sqlmock.NewRows([]string{"total"}).AddRow(int64(0))
 
 				db.ExpectBegin()
-				db.ExpectQuery("SELECT").WillReturnRows(bucketRows)           // find bucket
-				db.ExpectQuery("SELECT").WillReturnRows(countTotalFruitsRows) // count fruits per bucket
-				db.ExpectRollback()
-
+				db.ExpectQuery("SELECT (.+) FOR UPDATE").WillReturnRows(bucketRows)
+				db.ExpectQuery("SELECT count").WillReturnRows(countTotalFruitsRows)
+				db.ExpectCommit()
 				logger.EXPECT().Warn(gomock.Any())
 			},
-			fruitID:  1,
-			bucketID: 1,
-			wantErr:  "Bucket is full",
+			rules: models.CompatibilityRules{
+				{
+					A: models.CompatibilityGroup{Name: "apple", Fruits: []string{"apple"}},
+					B: models.CompatibilityGroup{Name: "kiwi", Fruits: []string{"kiwi"}},
+				},
+			},
+			data: dtos.CreateFruitsBatchDto{
+				Mode: models.FruitBatchModeBestEffort,
+				Items: []dtos.CreateFruitDto{
+					{Name: "apple", Price: decimal.NewFromInt32(1), ExpiresIn: &expiresIn, BucketID: &bucketID},
+					{Name: "kiwi", Price: decimal.NewFromInt32(1), ExpiresIn: &expiresIn, BucketID: &bucketID},
+				},
+			},
+			want: &models.FruitBatch{
+				Results: []models.FruitBatchResult{
+					{Index: 0, Err: exceptions.NewForbiddenException("Fruit apple cannot share a bucket with kiwi")},
+					{Index: 1, Err: exceptions.NewForbiddenException("Fruit apple cannot share a bucket with kiwi")},
+				},
+				Failed: 2,
+			},
 		},
-		"should throw error when fruit not found": {
+		"should not touch database when an item is invalid in all_or_nothing mode": {
 			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {
 				mTime.EXPECT().Now().Return(now)
+				logger.EXPECT().Warn(gomock.Any())
+			},
+			data: dtos.CreateFruitsBatchDto{Mode: models.FruitBatchModeAllOrNothing, Items: []dtos.CreateFruitDto{itemWithSKU, invalidItem}},
+			want: &models.FruitBatch{
+				Results: []models.FruitBatchResult{
+					{Index: 0},
+					{Index: 1, Err: invalidItemErr},
+				},
+				Failed: 1,
+			},
+		},
+		"should create valid items in best_effort mode": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {
+				mTime.EXPECT().Now().AnyTimes().Return(now)
 
 				bucketRows := sqlmock.NewRows([]string{"id", "name", "capacity"}).
 					AddRow(int64(1), "Testing", 1)
-
 				countTotalFruitsRows := sqlmock.NewRows([]string{"total"}).AddRow(int64(0))
 
 				db.ExpectBegin()
-				db.ExpectQuery("SELECT").WillReturnRows(bucketRows)               // find bucket
-				db.ExpectQuery("SELECT").WillReturnRows(countTotalFruitsRows)     // count fruits per bucket
-				db.ExpectExec("UPDATE").WillReturnResult(sqlmock.NewResult(1, 0)) // not found on update fruit
-				db.ExpectRollback()
+				db.ExpectQuery("SELECT (.+) FOR UPDATE").WillReturnRows(bucketRows)
+				db.ExpectQuery("SELECT count").WillReturnRows(countTotalFruitsRows)
+				db.ExpectExec("INSERT").WillReturnResult(sqlmock.NewResult(1, 1))
+				db.ExpectExec("INSERT INTO `fruit_prices`").WillReturnResult(sqlmock.NewResult(1, 1))
+				db.ExpectExec("INSERT").WillReturnError(&mysqlDriver.MySQLError{Number: infra.MYSQL_ERROR_DUPLICATE_ENTRY})
+				db.ExpectExec("INSERT INTO `movements`").WillReturnResult(sqlmock.NewResult(1, 1)) // record movements
+				db.ExpectCommit()
 
 				logger.EXPECT().Warn(gomock.Any())
 			},
-			fruitID:  1,
-			bucketID: 1,
-			wantErr:  "Fruit not found",
+			data: dtos.CreateFruitsBatchDto{Mode: models.FruitBatchModeBestEffort, Items: []dtos.CreateFruitDto{item, invalidItem, item, itemWithSKU}},
+			want: &models.FruitBatch{
+				Results: []models.FruitBatchResult{
+					{Index: 0, Fruit: fruit(1, item)},
+					{Index: 1, Err: invalidItemErr},
+					{Index: 2, Err: exceptions.NewForbiddenException("Bucket is full")},
+					{Index: 3, Err: exceptions.NewConflictException("Fruit sku or barcode already exists")},
+				},
+				Created: 1,
+				Failed:  3,
+			},
 		},
-		"should throw error on count fruits": {
+		"should mark items of a missing bucket": {
 			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {
-				mTime.EXPECT().Now().Return(now)
-
-				bucketRows := sqlmock.NewRows([]string{"id", "name", "capacity"}).
-					AddRow(int64(1), "Testing", 1)
+				mTime.EXPECT().Now().AnyTimes().Return(now)
 
 				db.ExpectBegin()
-				db.ExpectQuery("SELECT").WillReturnRows(bucketRows)           // find bucket
-				db.ExpectQuery("SELECT").WillReturnError(fmt.Errorf("error")) // update fruit with bucketID
-				db.ExpectRollback()
+				db.ExpectQuery("SELECT (.+) FOR UPDATE").WillReturnError(fmt.Errorf(infra.MYSQL_ERROR_NOT_FOUND))
+				db.ExpectExec("INSERT").WillReturnResult(sqlmock.NewResult(1, 1))
+				db.ExpectExec("INSERT INTO `fruit_prices`").WillReturnResult(sqlmock.NewResult(1, 1))
+				db.ExpectExec("INSERT INTO `movements`").WillReturnResult(sqlmock.NewResult(1, 1)) // record movements
+				db.ExpectCommit()
 
-				logger.EXPECT().Error(gomock.Any())
+				logger.EXPECT().Warn(gomock.Any())
+			},
+			data: dtos.CreateFruitsBatchDto{Mode: models.FruitBatchModeBestEffort, Items: []dtos.CreateFruitDto{item, itemWithSKU}},
+			want: &models.FruitBatch{
+				Results: []models.FruitBatchResult{
+					{Index: 0, Err: exceptions.NewForeignNotFoundException("Bucket not found")},
+					{Index: 1, Fruit: fruit(1, itemWithSKU)},
+				},
+				Created: 1,
+				Failed:  1,
 			},
-			fruitID:  1,
-			bucketID: 1,
-			wantErr:  "error",
 		},
-		"should throw error on update": {
+		"should throw error on validate when items are empty and mode is invalid": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {},
+			data: dtos.CreateFruitsBatchDto{Mode: "invalid"},
+			wantErr: strings.Join([]string{
+				"Key: 'CreateFruitsBatchDto.Mode' Error:Field validation for 'Mode' failed on the 'oneof' tag",
+				"Key: 'CreateFruitsBatchDto.Items' Error:Field validation for 'Items' failed on the 'required' tag",
+			}, ", "),
+		},
+		"should throw error on count fruits": {
 			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {
-				mTime.EXPECT().Now().Return(now)
+				mTime.EXPECT().Now().AnyTimes().Return(now)
 
 				bucketRows := sqlmock.NewRows([]string{"id", "name", "capacity"}).
 					AddRow(int64(1), "Testing", 1)
 
-				countTotalFruitsRows := sqlmock.NewRows([]string{"total"}).AddRow(int64(0))
-
 				db.ExpectBegin()
-				db.ExpectQuery("SELECT").WillReturnRows(bucketRows)           // find bucket
-				db.ExpectQuery("SELECT").WillReturnRows(countTotalFruitsRows) // count fruits per bucket
-				db.ExpectExec("UPDATE").WillReturnError(fmt.Errorf("error"))  // update fruit with bucketID
+				db.ExpectQuery("SELECT (.+) FOR UPDATE").WillReturnRows(bucketRows)
+				db.ExpectQuery("SELECT count").WillReturnError(fmt.Errorf("error"))
 				db.ExpectRollback()
 
 				logger.EXPECT().Error(gomock.Any())
 			},
-			fruitID:  1,
-			bucketID: 1,
-			wantErr:  "error",
+			data:    dtos.CreateFruitsBatchDto{Mode: models.FruitBatchModeBestEffort, Items: []dtos.CreateFruitDto{item}},
+			wantErr: "error",
 		},
 	}
 	for name, tt := range tests {
@@ -391,59 +681,71 @@ func TestFruitService_AddOnBucket(t *testing.T) {
 			database := &infra.Database{DB: gormDB, SQL: db}
 
 			loggerMock := mocks.NewMockLogger(ctrl)
+			validate := infra.NewValidator()
 			timeMock := mocks.NewMockTime(ctrl)
 			_time = timeMock
 
 			tt.mock(sqlMock, loggerMock, timeMock)
 
 			// given
-			service := NewFruit(database, loggerMock, nil)
+			service := NewFruit(database, loggerMock, validate, nil, tt.rules)
 
 			// when
-			err = service.AddOnBucket(ctx, tt.fruitID, tt.bucketID)
+			got, err := service.CreateBatch(ctx, tt.data)
 
 			// then
+			assert.Equal(t, tt.want, got)
 			if err != nil || tt.wantErr != "" {
 				assert.EqualError(t, err, tt.wantErr)
 			}
+			assert.Nil(t, sqlMock.ExpectationsWereMet())
 		})
 	}
 }
 
-func TestFruitService_RemoveFromBucket(t *testing.T) {
+func TestFruitService_GetByBarcode(t *testing.T) {
+	now := time.Date(2000, 12, 31, 23, 59, 59, 0, time.Local)
+	barcode := "7891234567895"
+
 	tests := map[string]struct {
-		mock    func(db sqlmock.Sqlmock, logger *mocks.MockLogger)
-		fruitID int64
+		mock    func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime)
+		barcode string
+		want    *models.Fruit
 		wantErr string
 	}{
-		"should be success when fruits exist": {
-			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger) {
-				db.ExpectBegin()
-				db.ExpectExec("UPDATE").WillReturnResult(sqlmock.NewResult(1, 1)) // update fruit
-				db.ExpectCommit()
+		"should be success": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {
+				rows := sqlmock.NewRows([]string{"id", "created_at", "name", "price", "expires_at", "barcode"}).
+					AddRow(int64(1), now, "Testing", decimal.NewFromInt32(1), now.Add(time.Hour), barcode)
+
+				db.ExpectQuery("SELECT").WillReturnRows(rows)
+				mTime.EXPECT().Now().Return(now)
+			},
+			barcode: barcode,
+			want: &models.Fruit{
+				ID:             1,
+				CreatedAt:      now,
+				Name:           "Testing",
+				Price:          decimal.NewFromInt32(1),
+				EffectivePrice: decimal.RequireFromString("0.50"),
+				ExpiresAt:      now.Add(time.Hour),
+				Barcode:        &barcode,
 			},
-			fruitID: 1,
 		},
 		"should throw error when fruit not found": {
-			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger) {
-				db.ExpectBegin()
-				db.ExpectExec("UPDATE").WillReturnResult(sqlmock.NewResult(1, 0)) // update fruit
-				db.ExpectCommit()
-
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {
+				db.ExpectQuery("SELECT").WillReturnError(fmt.Errorf(infra.MYSQL_ERROR_NOT_FOUND))
 				logger.EXPECT().Warn(gomock.Any())
 			},
-			fruitID: 1,
+			barcode: barcode,
 			wantErr: "Fruit not found",
 		},
 		"should throw error": {
-			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger) {
-				db.ExpectBegin()
-				db.ExpectExec("UPDATE").WillReturnError(fmt.Errorf("error")) // update fruit
-				db.ExpectRollback()
-
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {
+				db.ExpectQuery("SELECT").WillReturnError(fmt.Errorf("error"))
 				logger.EXPECT().Error(gomock.Any())
 			},
-			fruitID: 1,
+			barcode: barcode,
 			wantErr: "error",
 		},
 	}
@@ -471,58 +773,116 @@ func TestFruitService_RemoveFromBucket(t *testing.T) {
 			database := &infra.Database{DB: gormDB, SQL: db}
 
 			loggerMock := mocks.NewMockLogger(ctrl)
+			timeMock := mocks.NewMockTime(ctrl)
+			_time = timeMock
+
+			tt.mock(sqlMock, loggerMock, timeMock)
 
-			tt.mock(sqlMock, loggerMock)
+			markdowns := models.MarkdownRules{{Within: 12 * time.Hour, Percent: decimal.NewFromInt(50)}}
 
 			// given
-			service := NewFruit(database, loggerMock, nil)
+			service := NewFruit(database, loggerMock, nil, markdowns, nil)
 
 			// when
-			err = service.RemoveFromBucket(ctx, tt.fruitID)
+			got, err := service.GetByBarcode(ctx, tt.barcode)
 
 			// then
+			assert.Equal(t, tt.want, got)
 			if err != nil || tt.wantErr != "" {
 				assert.EqualError(t, err, tt.wantErr)
 			}
 		})
 	}
 }
-func TestFruitService_Delete(t *testing.T) {
+
+func TestFruitService_ListExpiring(t *testing.T) {
 	now := time.Date(2000, 12, 31, 23, 59, 59, 0, time.Local)
+	bucketID := int64(1)
 
 	tests := map[string]struct {
 		mock    func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime)
-		fruitID int64
+		within  time.Duration
+		want    *models.ExpiringFruits
 		wantErr string
 	}{
-		"should be success when fruit exists": {
+		"should be success": {
 			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {
 				mTime.EXPECT().Now().Return(now)
-				db.ExpectBegin()
-				db.ExpectExec("UPDATE").WillReturnResult(sqlmock.NewResult(1, 1)) // update fruit
-				db.ExpectCommit()
+
+				fruitRows := sqlmock.NewRows([]string{"id", "name", "price", "expires_at", "bucket_fk"}).
+					AddRow(int64(1), "Testing", decimal.NewFromInt32(1), now.Add(time.Hour), bucketID).
+					AddRow(int64(2), "Testing", decimal.NewFromInt32(2), now.Add(2*time.Hour), nil).
+					AddRow(int64(3), "Testing", decimal.NewFromInt32(3), now.Add(3*time.Hour), bucketID)
+				bucketRows := sqlmock.NewRows([]string{"id", "name"}).AddRow(bucketID, "A")
+
+				db.ExpectQuery("SELECT").WillReturnRows(fruitRows)  // find expiring fruits
+				db.ExpectQuery("SELECT").WillReturnRows(bucketRows) // find buckets
+			},
+			within: 48 * time.Hour,
+			want: &models.ExpiringFruits{
+				Buckets: []models.ExpiringBucketFruits{
+					{
+						BucketID:   &bucketID,
+						BucketName: "A",
+						Fruits: []models.Fruit{
+							{ID: 1, Name: "Testing", Price: decimal.NewFromInt32(1), EffectivePrice: decimal.RequireFromString("0.50"), ExpiresAt: now.Add(time.Hour), BucketID: &bucketID},
+							{ID: 3, Name: "Testing", Price: decimal.NewFromInt32(3), EffectivePrice: decimal.RequireFromString("1.50"), ExpiresAt: now.Add(3 * time.Hour), BucketID: &bucketID},
+						},
+						TotalPrice: decimal.NewFromInt32(4),
+
+						TotalEffectivePrice: decimal.RequireFromString("2.00"),
+					},
+					{
+						Fruits: []models.Fruit{
+							{ID: 2, Name: "Testing", Price: decimal.NewFromInt32(2), EffectivePrice: decimal.RequireFromString("1.00"), ExpiresAt: now.Add(2 * time.Hour)},
+						},
+						TotalPrice: decimal.NewFromInt32(2),
+
+						TotalEffectivePrice: decimal.RequireFromString("1.00"),
+					},
+				},
+				TotalPrice: decimal.NewFromInt32(6),
+
+				TotalEffectivePrice: decimal.RequireFromString("3.00"),
 			},
-			fruitID: 1,
 		},
-		"should be success when fruit not exists": {
+		"should be success when there are no expiring fruits": {
 			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {
 				mTime.EXPECT().Now().Return(now)
-				db.ExpectBegin()
-				db.ExpectExec("UPDATE").WillReturnResult(sqlmock.NewResult(1, 0)) // update fruit
-				db.ExpectCommit()
+
+				fruitRows := sqlmock.NewRows([]string{"id", "name", "price", "expires_at", "bucket_fk"})
+				db.ExpectQuery("SELECT").WillReturnRows(fruitRows)
+			},
+			within: 48 * time.Hour,
+			want: &models.ExpiringFruits{
+				Buckets:    []models.ExpiringBucketFruits{},
+				TotalPrice: decimal.Zero,
+
+				TotalEffectivePrice: decimal.Zero,
 			},
-			fruitID: 1,
 		},
-		"should throw error": {
+		"should throw error on find fruits": {
 			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {
 				mTime.EXPECT().Now().Return(now)
-				db.ExpectBegin()
-				db.ExpectExec("UPDATE").WillReturnError(fmt.Errorf("error")) // update fruit
-				db.ExpectRollback()
 
+				db.ExpectQuery("SELECT").WillReturnError(fmt.Errorf("error"))
 				logger.EXPECT().Error(gomock.Any())
 			},
-			fruitID: 1,
+			within:  48 * time.Hour,
+			wantErr: "error",
+		},
+		"should throw error on find buckets": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {
+				mTime.EXPECT().Now().Return(now)
+
+				fruitRows := sqlmock.NewRows([]string{"id", "name", "price", "expires_at", "bucket_fk"}).
+					AddRow(int64(1), "Testing", decimal.NewFromInt32(1), now.Add(time.Hour), bucketID)
+
+				db.ExpectQuery("SELECT").WillReturnRows(fruitRows)
+				db.ExpectQuery("SELECT").WillReturnError(fmt.Errorf("error"))
+				logger.EXPECT().Error(gomock.Any())
+			},
+			within:  48 * time.Hour,
 			wantErr: "error",
 		},
 	}
@@ -555,16 +915,1297 @@ func TestFruitService_Delete(t *testing.T) {
 
 			tt.mock(sqlMock, loggerMock, timeMock)
 
+			markdowns := models.MarkdownRules{{Within: 12 * time.Hour, Percent: decimal.NewFromInt(50)}}
+
 			// given
-			service := NewFruit(database, loggerMock, nil)
+			service := NewFruit(database, loggerMock, nil, markdowns, nil)
 
 			// when
-			err = service.Delete(ctx, tt.fruitID)
+			got, err := service.ListExpiring(ctx, tt.within)
 
 			// then
+			assert.Equal(t, tt.want, got)
+			if err != nil || tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestFruitService_AddOnBucket(t *testing.T) {
+	now := time.Date(2000, 12, 31, 23, 59, 59, 0, time.Local)
+
+	tests := map[string]struct {
+		mock     func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime)
+		fruitID  int64
+		bucketID int64
+		wantErr  string
+	}{
+		"should be success when bucket exists and is not full": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {
+				mTime.EXPECT().Now().AnyTimes().Return(now)
+
+				fruitRows := sqlmock.NewRows([]string{"id", "expires_at", "state"}).
+					AddRow(int64(1), now.Add(time.Hour), "unripe")
+				bucketRows := sqlmock.NewRows([]string{"id", "name", "capacity"}).
+					AddRow(int64(1), "Testing", 1)
+
+				countTotalFruitsRows := sqlmock.NewRows([]string{"total"}).AddRow(int64(0))
+
+				db.ExpectBegin()
+				db.ExpectQuery("SELECT").WillReturnRows(fruitRows)                                 // find fruit
+				db.ExpectQuery("SELECT").WillReturnRows(bucketRows)                                // find bucket
+				db.ExpectQuery("SELECT").WillReturnRows(countTotalFruitsRows)                      // count fruits per bucket
+				db.ExpectExec("UPDATE").WillReturnResult(sqlmock.NewResult(1, 1))                  // update fruit with bucketID
+				db.ExpectExec("INSERT INTO `movements`").WillReturnResult(sqlmock.NewResult(1, 1)) // record movements
+				db.ExpectCommit()
+			},
+			fruitID:  1,
+			bucketID: 1,
+		},
+		"should throw error when bucket not found": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {
+				mTime.EXPECT().Now().AnyTimes().Return(now)
+
+				fruitRows := sqlmock.NewRows([]string{"id", "expires_at", "state"}).
+					AddRow(int64(1), now.Add(time.Hour), "unripe")
+
+				db.ExpectBegin()
+				db.ExpectQuery("SELECT").WillReturnRows(fruitRows)                                // find fruit
+				db.ExpectQuery("SELECT").WillReturnError(fmt.Errorf(infra.MYSQL_ERROR_NOT_FOUND)) // find bucket
+				db.ExpectRollback()
+				logger.EXPECT().Warn(gomock.Any())
+			},
+			fruitID:  1,
+			bucketID: 1,
+			wantErr:  "Bucket not found",
+		},
+		"should throw error when bucket is full": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {
+				mTime.EXPECT().Now().AnyTimes().Return(now)
+
+				fruitRows := sqlmock.NewRows([]string{"id", "expires_at", "state"}).
+					AddRow(int64(1), now.Add(time.Hour), "unripe")
+				bucketRows := sqlmock.NewRows([]string{"id", "name", "capacity"}).
+					AddRow(int64(1), "Testing", 1)
+
+				countTotalFruitsRows := sqlmock.NewRows([]string{"total"}).AddRow(int64(1))
+
+				db.ExpectBegin()
+				db.ExpectQuery("SELECT").WillReturnRows(fruitRows)            // find fruit
+				db.ExpectQuery("SELECT").WillReturnRows(bucketRows)           // find bucket
+				db.ExpectQuery("SELECT").WillReturnRows(countTotalFruitsRows) // count fruits per bucket
+				db.ExpectRollback()
+
+				logger.EXPECT().Warn(gomock.Any())
+			},
+			fruitID:  1,
+			bucketID: 1,
+			wantErr:  "Bucket is full",
+		},
+		"should throw error when fruit not found": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {
+				mTime.EXPECT().Now().Return(now)
+
+				db.ExpectBegin()
+				db.ExpectQuery("SELECT").WillReturnError(fmt.Errorf(infra.MYSQL_ERROR_NOT_FOUND)) // find fruit
+				db.ExpectRollback()
+
+				logger.EXPECT().Warn(gomock.Any())
+			},
+			fruitID:  1,
+			bucketID: 1,
+			wantErr:  "Fruit not found",
+		},
+		"should throw error on count fruits": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {
+				mTime.EXPECT().Now().AnyTimes().Return(now)
+
+				fruitRows := sqlmock.NewRows([]string{"id", "expires_at", "state"}).
+					AddRow(int64(1), now.Add(time.Hour), "unripe")
+				bucketRows := sqlmock.NewRows([]string{"id", "name", "capacity"}).
+					AddRow(int64(1), "Testing", 1)
+
+				db.ExpectBegin()
+				db.ExpectQuery("SELECT").WillReturnRows(fruitRows)            // find fruit
+				db.ExpectQuery("SELECT").WillReturnRows(bucketRows)           // find bucket
+				db.ExpectQuery("SELECT").WillReturnError(fmt.Errorf("error")) // count fruits per bucket
+				db.ExpectRollback()
+
+				logger.EXPECT().Error(gomock.Any())
+			},
+			fruitID:  1,
+			bucketID: 1,
+			wantErr:  "error",
+		},
+		"should throw error on update": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {
+				mTime.EXPECT().Now().AnyTimes().Return(now)
+
+				fruitRows := sqlmock.NewRows([]string{"id", "expires_at", "state"}).
+					AddRow(int64(1), now.Add(time.Hour), "unripe")
+				bucketRows := sqlmock.NewRows([]string{"id", "name", "capacity"}).
+					AddRow(int64(1), "Testing", 1)
+
+				countTotalFruitsRows := sqlmock.NewRows([]string{"total"}).AddRow(int64(0))
+
+				db.ExpectBegin()
+				db.ExpectQuery("SELECT").WillReturnRows(fruitRows)            // find fruit
+				db.ExpectQuery("SELECT").WillReturnRows(bucketRows)           // find bucket
+				db.ExpectQuery("SELECT").WillReturnRows(countTotalFruitsRows) // count fruits per bucket
+				db.ExpectExec("UPDATE").WillReturnError(fmt.Errorf("error"))  // update fruit with bucketID
+				db.ExpectRollback()
+
+				logger.EXPECT().Error(gomock.Any())
+			},
+			fruitID:  1,
+			bucketID: 1,
+			wantErr:  "error",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			//setup
+			ctx := context.Background()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			db, sqlMock, err := sqlmock.New()
+			require.Nil(t, err)
+			defer db.Close()
+
+			dialector := mysql.New(mysql.Config{
+				DSN:                       "sqlmock_db_0",
+				DriverName:                "mysql",
+				Conn:                      db,
+				SkipInitializeWithVersion: true,
+			})
+
+			gormDB, err := gorm.Open(dialector, &gorm.Config{})
+			require.Nil(t, err)
+			database := &infra.Database{DB: gormDB, SQL: db}
+
+			loggerMock := mocks.NewMockLogger(ctrl)
+			validate := infra.NewValidator()
+			timeMock := mocks.NewMockTime(ctrl)
+			_time = timeMock
+
+			tt.mock(sqlMock, loggerMock, timeMock)
+
+			// given
+			service := NewFruit(database, loggerMock, validate, nil, nil)
+
+			// when
+			err = service.AddOnBucket(ctx, tt.fruitID, tt.bucketID)
+
+			// then
+			if err != nil || tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			}
+			assert.Nil(t, sqlMock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestFruitService_Transfer(t *testing.T) {
+	now := time.Date(2000, 12, 31, 23, 59, 59, 0, time.Local)
+	fromBucketID := int64(1)
+	toBucketID := int64(2)
+	otherBucketID := int64(3)
+
+	tests := map[string]struct {
+		mock    func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime)
+		fruitID int64
+		data    dtos.TransferFruitDto
+		want    *models.FruitTransfer
+		wantErr string
+	}{
+		"should move fruit from source to target bucket": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {
+				mTime.EXPECT().Now().AnyTimes().Return(now)
+
+				fruitRows := sqlmock.NewRows([]string{"id", "expires_at", "state", "bucket_fk"}).
+					AddRow(int64(1), now.Add(time.Hour), "ripe", fromBucketID)
+				fromBucketRows := sqlmock.NewRows([]string{"id", "name", "capacity"}).
+					AddRow(fromBucketID, "A", 2)
+				toBucketRows := sqlmock.NewRows([]string{"id", "name", "capacity"}).
+					AddRow(toBucketID, "B", 2)
+				countTotalFruitsRows := sqlmock.NewRows([]string{"total"}).AddRow(int64(1))
+
+				db.ExpectBegin()
+				db.ExpectQuery("SELECT").WillReturnRows(fruitRows)                                        // find fruit
+				db.ExpectQuery("SELECT").WillReturnRows(fromBucketRows)                                   // find source bucket
+				db.ExpectQuery("SELECT (.+) FROM `buckets` (.+) FOR UPDATE").WillReturnRows(toBucketRows) // lock target bucket
+				db.ExpectQuery("SELECT").WillReturnRows(countTotalFruitsRows)                             // count fruits per bucket
+				db.ExpectExec("UPDATE").WillReturnResult(sqlmock.NewResult(1, 1))                         // update fruit with bucketID
+				db.ExpectExec("INSERT INTO `movements`").WillReturnResult(sqlmock.NewResult(1, 1))        // record movements
+				db.ExpectCommit()
+			},
+			fruitID: 1,
+			data:    dtos.TransferFruitDto{ToBucketID: &toBucketID, FromBucketID: &fromBucketID},
+			want: &models.FruitTransfer{
+				FruitID:    1,
+				FromBucket: &models.Bucket{ID: fromBucketID, Name: "A", Capacity: 2},
+				ToBucket:   models.Bucket{ID: toBucketID, Name: "B", Capacity: 2},
+				Moved:      true,
+			},
+		},
+		"should throw error when fruit moves into a quarantine bucket": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {
+				mTime.EXPECT().Now().AnyTimes().Return(now)
+
+				fruitRows := sqlmock.NewRows([]string{"id", "expires_at", "state", "bucket_fk"}).
+					AddRow(int64(1), now.Add(time.Hour), "ripe", nil)
+				toBucketRows := sqlmock.NewRows([]string{"id", "name", "capacity", "quarantine"}).
+					AddRow(toBucketID, "B", 2, true)
+				countTotalFruitsRows := sqlmock.NewRows([]string{"total"}).AddRow(int64(0))
+
+				db.ExpectBegin()
+				db.ExpectQuery("SELECT").WillReturnRows(fruitRows)            // find fruit
+				db.ExpectQuery("SELECT").WillReturnRows(toBucketRows)         // find target bucket
+				db.ExpectQuery("SELECT").WillReturnRows(countTotalFruitsRows) // count fruits per bucket
+				db.ExpectRollback()
+
+				logger.EXPECT().Warn(gomock.Any())
+			},
+			fruitID: 1,
+			data:    dtos.TransferFruitDto{ToBucketID: &toBucketID},
+			wantErr: "Quarantine bucket only holds quarantined fruits",
+		},
+		"should do nothing when fruit is already in the target bucket": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {
+				mTime.EXPECT().Now().Return(now)
+
+				fruitRows := sqlmock.NewRows([]string{"id", "expires_at", "state", "bucket_fk"}).
+					AddRow(int64(1), now.Add(time.Hour), "ripe", toBucketID)
+				bucketRows := sqlmock.NewRows([]string{"id", "name", "capacity"}).
+					AddRow(toBucketID, "B", 1)
+
+				db.ExpectBegin()
+				db.ExpectQuery("SELECT").WillReturnRows(fruitRows)  // find fruit
+				db.ExpectQuery("SELECT").WillReturnRows(bucketRows) // find source bucket
+				db.ExpectCommit()
+			},
+			fruitID: 1,
+			data:    dtos.TransferFruitDto{ToBucketID: &toBucketID},
+			want: &models.FruitTransfer{
+				FruitID:    1,
+				FromBucket: &models.Bucket{ID: toBucketID, Name: "B", Capacity: 1},
+				ToBucket:   models.Bucket{ID: toBucketID, Name: "B", Capacity: 1},
+			},
+		},
+		"should throw error on validate when target bucket is empty": {
+			mock:    func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {},
+			fruitID: 1,
+			data:    dtos.TransferFruitDto{},
+			wantErr: "Key: 'TransferFruitDto.ToBucketID' Error:Field validation for 'ToBucketID' failed on the 'required' tag",
+		},
+		"should throw error when fruit is deleted": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {
+				mTime.EXPECT().Now().Return(now)
+
+				fruitRows := sqlmock.NewRows([]string{"id", "deleted_at", "expires_at", "state", "bucket_fk"}).
+					AddRow(int64(1), now, now.Add(time.Hour), "ripe", fromBucketID)
+
+				db.ExpectBegin()
+				db.ExpectQuery("SELECT").WillReturnRows(fruitRows)
+				db.ExpectRollback()
+
+				logger.EXPECT().Warn(gomock.Any())
+			},
+			fruitID: 1,
+			data:    dtos.TransferFruitDto{ToBucketID: &toBucketID},
+			wantErr: "Fruit is deleted",
+		},
+		"should throw error when fruit is expired": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {
+				mTime.EXPECT().Now().Return(now)
+
+				fruitRows := sqlmock.NewRows([]string{"id", "expires_at", "state", "bucket_fk"}).
+					AddRow(int64(1), now, "overripe", fromBucketID)
+
+				db.ExpectBegin()
+				db.ExpectQuery("SELECT").WillReturnRows(fruitRows)
+				db.ExpectRollback()
+
+				logger.EXPECT().Warn(gomock.Any())
+			},
+			fruitID: 1,
+			data:    dtos.TransferFruitDto{ToBucketID: &toBucketID},
+			wantErr: "Fruit is expired",
+		},
+		"should throw error when fruit is not in the expected bucket": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {
+				mTime.EXPECT().Now().Return(now)
+
+				fruitRows := sqlmock.NewRows([]string{"id", "expires_at", "state", "bucket_fk"}).
+					AddRow(int64(1), now.Add(time.Hour), "ripe", otherBucketID)
+
+				db.ExpectBegin()
+				db.ExpectQuery("SELECT").WillReturnRows(fruitRows)
+				db.ExpectRollback()
+
+				logger.EXPECT().Warn(gomock.Any())
+			},
+			fruitID: 1,
+			data:    dtos.TransferFruitDto{ToBucketID: &toBucketID, FromBucketID: &fromBucketID},
+			wantErr: "Fruit is not in the expected bucket",
+		},
+		"should throw error on find source bucket": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {
+				mTime.EXPECT().Now().Return(now)
+
+				fruitRows := sqlmock.NewRows([]string{"id", "expires_at", "state", "bucket_fk"}).
+					AddRow(int64(1), now.Add(time.Hour), "ripe", fromBucketID)
+
+				db.ExpectBegin()
+				db.ExpectQuery("SELECT").WillReturnRows(fruitRows)
+				db.ExpectQuery("SELECT").WillReturnError(fmt.Errorf("error"))
+				db.ExpectRollback()
+
+				logger.EXPECT().Error(gomock.Any())
+			},
+			fruitID: 1,
+			data:    dtos.TransferFruitDto{ToBucketID: &toBucketID},
+			wantErr: "error",
+		},
+		"should throw error on find target bucket": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {
+				mTime.EXPECT().Now().AnyTimes().Return(now)
+
+				fruitRows := sqlmock.NewRows([]string{"id", "expires_at", "state", "bucket_fk"}).
+					AddRow(int64(1), now.Add(time.Hour), "ripe", nil)
+
+				db.ExpectBegin()
+				db.ExpectQuery("SELECT").WillReturnRows(fruitRows)
+				db.ExpectQuery("SELECT").WillReturnError(fmt.Errorf("error"))
+				db.ExpectRollback()
+
+				logger.EXPECT().Error(gomock.Any())
+			},
+			fruitID: 1,
+			data:    dtos.TransferFruitDto{ToBucketID: &toBucketID},
+			wantErr: "error",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			//setup
+			ctx := context.Background()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			db, sqlMock, err := sqlmock.New()
+			require.Nil(t, err)
+			defer db.Close()
+
+			dialector := mysql.New(mysql.Config{
+				DSN:                       "sqlmock_db_0",
+				DriverName:                "mysql",
+				Conn:                      db,
+				SkipInitializeWithVersion: true,
+			})
+
+			gormDB, err := gorm.Open(dialector, &gorm.Config{})
+			require.Nil(t, err)
+			database := &infra.Database{DB: gormDB, SQL: db}
+
+			loggerMock := mocks.NewMockLogger(ctrl)
+			validate := infra.NewValidator()
+			timeMock := mocks.NewMockTime(ctrl)
+			_time = timeMock
+
+			tt.mock(sqlMock, loggerMock, timeMock)
+
+			// given
+			service := NewFruit(database, loggerMock, validate, nil, nil)
+
+			// when
+			got, err := service.Transfer(ctx, tt.fruitID, tt.data)
+
+			// then
+			assert.Equal(t, tt.want, got)
+			if err != nil || tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			}
+			assert.Nil(t, sqlMock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestFruitService_MoveMany(t *testing.T) {
+	now := time.Date(2000, 12, 31, 23, 59, 59, 0, time.Local)
+	fromBucketID := int64(1)
+	toBucketID := int64(2)
+
+	tests := map[string]struct {
+		mock    func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime)
+		data    dtos.MoveFruitsDto
+		want    *models.FruitBulk
+		wantErr string
+	}{
+		"should move fruits picked by id checking capacity once": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {
+				mTime.EXPECT().Now().AnyTimes().Return(now)
+
+				fruitRows := sqlmock.NewRows([]string{"id", "expires_at", "state", "bucket_fk"}).
+					AddRow(int64(1), now.Add(time.Hour), "ripe", fromBucketID).
+					AddRow(int64(2), now.Add(time.Hour), "unripe", nil).
+					AddRow(int64(3), now.Add(time.Hour), "ripe", toBucketID)
+				bucketRows := sqlmock.NewRows([]string{"id", "name", "capacity"}).
+					AddRow(toBucketID, "B", 3)
+				countTotalFruitsRows := sqlmock.NewRows([]string{"total"}).AddRow(int64(1))
+
+				db.ExpectBegin()
+				db.ExpectQuery("SELECT (.+) FROM `fruits` (.+) FOR UPDATE").WillReturnRows(fruitRows)
+				db.ExpectQuery("SELECT (.+) FROM `buckets` (.+) FOR UPDATE").WillReturnRows(bucketRows)
+				db.ExpectQuery("SELECT count").WillReturnRows(countTotalFruitsRows)
+				db.ExpectExec("UPDATE").WillReturnResult(sqlmock.NewResult(0, 2))
+				db.ExpectExec("INSERT INTO `movements`").WillReturnResult(sqlmock.NewResult(1, 1)) // record movements
+				db.ExpectCommit()
+			},
+			data: dtos.MoveFruitsDto{
+				SelectFruitsDto: dtos.SelectFruitsDto{IDs: []int64{1, 2, 3}},
+				ToBucketID:      &toBucketID,
+			},
+			want: &models.FruitBulk{FruitIDs: []int64{1, 2, 3}, Affected: 2},
+		},
+		"should skip expired fruits matched by filter": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {
+				mTime.EXPECT().Now().AnyTimes().Return(now)
+
+				fruitRows := sqlmock.NewRows([]string{"id", "expires_at", "state", "bucket_fk"}).
+					AddRow(int64(1), now.Add(time.Hour), "ripe", fromBucketID).
+					AddRow(int64(2), now, "expired", fromBucketID)
+				bucketRows := sqlmock.NewRows([]string{"id", "name", "capacity"}).
+					AddRow(toBucketID, "B", 1)
+				countTotalFruitsRows := sqlmock.NewRows([]string{"total"}).AddRow(int64(0))
+
+				db.ExpectBegin()
+				db.ExpectQuery("SELECT (.+) FROM `fruits` (.+) FOR UPDATE").WillReturnRows(fruitRows)
+				db.ExpectQuery("SELECT (.+) FROM `buckets` (.+) FOR UPDATE").WillReturnRows(bucketRows)
+				db.ExpectQuery("SELECT count").WillReturnRows(countTotalFruitsRows)
+				db.ExpectExec("UPDATE").WillReturnResult(sqlmock.NewResult(0, 1))
+				db.ExpectExec("INSERT INTO `movements`").WillReturnResult(sqlmock.NewResult(1, 1)) // record movements
+				db.ExpectCommit()
+			},
+			data: dtos.MoveFruitsDto{
+				SelectFruitsDto: dtos.SelectFruitsDto{Filter: &dtos.FilterFruitsDto{BucketID: &fromBucketID}},
+				ToBucketID:      &toBucketID,
+			},
+			want: &models.FruitBulk{FruitIDs: []int64{1}, Affected: 1},
+		},
+		"should throw error when bucket has not capacity for the whole set": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {
+				mTime.EXPECT().Now().AnyTimes().Return(now)
+
+				fruitRows := sqlmock.NewRows([]string{"id", "expires_at", "state", "bucket_fk"}).
+					AddRow(int64(1), now.Add(time.Hour), "ripe", nil).
+					AddRow(int64(2), now.Add(time.Hour), "ripe", nil)
+				bucketRows := sqlmock.NewRows([]string{"id", "name", "capacity"}).
+					AddRow(toBucketID, "B", 2)
+				countTotalFruitsRows := sqlmock.NewRows([]string{"total"}).AddRow(int64(1))
+
+				db.ExpectBegin()
+				db.ExpectQuery("SELECT (.+) FROM `fruits` (.+) FOR UPDATE").WillReturnRows(fruitRows)
+				db.ExpectQuery("SELECT (.+) FROM `buckets` (.+) FOR UPDATE").WillReturnRows(bucketRows)
+				db.ExpectQuery("SELECT count").WillReturnRows(countTotalFruitsRows)
+				db.ExpectRollback()
+
+				logger.EXPECT().Warn(gomock.Any())
+			},
+			data: dtos.MoveFruitsDto{
+				SelectFruitsDto: dtos.SelectFruitsDto{IDs: []int64{1, 2}},
+				ToBucketID:      &toBucketID,
+			},
+			wantErr: "Bucket is full",
+		},
+		"should throw error when moving quarantined fruits out of quarantine buckets": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {
+				mTime.EXPECT().Now().AnyTimes().Return(now)
+
+				fruitRows := sqlmock.NewRows([]string{"id", "expires_at", "state", "bucket_fk", "quarantined_at"}).
+					AddRow(int64(1), now.Add(time.Hour), "ripe", nil, now)
+				bucketRows := sqlmock.NewRows([]string{"id", "name", "capacity", "quarantine"}).
+					AddRow(toBucketID, "B", 2, false)
+				countTotalFruitsRows := sqlmock.NewRows([]string{"total"}).AddRow(int64(0))
+
+				db.ExpectBegin()
+				db.ExpectQuery("SELECT (.+) FROM `fruits` (.+) FOR UPDATE").WillReturnRows(fruitRows)
+				db.ExpectQuery("SELECT (.+) FROM `buckets` (.+) FOR UPDATE").WillReturnRows(bucketRows)
+				db.ExpectQuery("SELECT count").WillReturnRows(countTotalFruitsRows)
+				db.ExpectRollback()
+
+				logger.EXPECT().Warn(gomock.Any())
+			},
+			data: dtos.MoveFruitsDto{
+				SelectFruitsDto: dtos.SelectFruitsDto{IDs: []int64{1}},
+				ToBucketID:      &toBucketID,
+			},
+			wantErr: "Quarantined fruits can only go to a quarantine bucket",
+		},
+		"should throw error when some fruits are not found": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {
+				mTime.EXPECT().Now().Return(now)
+
+				fruitRows := sqlmock.NewRows([]string{"id", "expires_at", "state", "bucket_fk"}).
+					AddRow(int64(1), now.Add(time.Hour), "ripe", nil)
+
+				db.ExpectBegin()
+				db.ExpectQuery("SELECT (.+) FROM `fruits` (.+) FOR UPDATE").WillReturnRows(fruitRows)
+				db.ExpectRollback()
+
+				logger.EXPECT().Warn(gomock.Any())
+			},
+			data: dtos.MoveFruitsDto{
+				SelectFruitsDto: dtos.SelectFruitsDto{IDs: []int64{1, 2, 3}},
+				ToBucketID:      &toBucketID,
+			},
+			wantErr: "Fruits not found: 2, 3",
+		},
+		"should throw error when a fruit picked by id is expired": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {
+				mTime.EXPECT().Now().Return(now)
+
+				fruitRows := sqlmock.NewRows([]string{"id", "expires_at", "state", "bucket_fk"}).
+					AddRow(int64(1), now, "expired", nil)
+
+				db.ExpectBegin()
+				db.ExpectQuery("SELECT (.+) FROM `fruits` (.+) FOR UPDATE").WillReturnRows(fruitRows)
+				db.ExpectRollback()
+
+				logger.EXPECT().Warn(gomock.Any())
+			},
+			data: dtos.MoveFruitsDto{
+				SelectFruitsDto: dtos.SelectFruitsDto{IDs: []int64{1}},
+				ToBucketID:      &toBucketID,
+			},
+			wantErr: "Fruit 1 is expired",
+		},
+		"should throw error on validate when neither ids nor filter are given": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {},
+			data: dtos.MoveFruitsDto{ToBucketID: &toBucketID},
+			wantErr: strings.Join([]string{
+				"Key: 'MoveFruitsDto.SelectFruitsDto.IDs' Error:Field validation for 'IDs' failed on the 'required_without' tag",
+				"Key: 'MoveFruitsDto.SelectFruitsDto.Filter' Error:Field validation for 'Filter' failed on the 'required_without' tag",
+			}, ", "),
+		},
+		"should throw error on select fruits": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {
+				mTime.EXPECT().Now().Return(now)
+
+				db.ExpectBegin()
+				db.ExpectQuery("SELECT").WillReturnError(fmt.Errorf("error"))
+				db.ExpectRollback()
+
+				logger.EXPECT().Error(gomock.Any())
+			},
+			data: dtos.MoveFruitsDto{
+				SelectFruitsDto: dtos.SelectFruitsDto{IDs: []int64{1}},
+				ToBucketID:      &toBucketID,
+			},
+			wantErr: "error",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			//setup
+			ctx := context.Background()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			db, sqlMock, err := sqlmock.New()
+			require.Nil(t, err)
+			defer db.Close()
+
+			dialector := mysql.New(mysql.Config{
+				DSN:                       "sqlmock_db_0",
+				DriverName:                "mysql",
+				Conn:                      db,
+				SkipInitializeWithVersion: true,
+			})
+
+			gormDB, err := gorm.Open(dialector, &gorm.Config{})
+			require.Nil(t, err)
+			database := &infra.Database{DB: gormDB, SQL: db}
+
+			loggerMock := mocks.NewMockLogger(ctrl)
+			validate := infra.NewValidator()
+			timeMock := mocks.NewMockTime(ctrl)
+			_time = timeMock
+
+			tt.mock(sqlMock, loggerMock, timeMock)
+
+			// given
+			service := NewFruit(database, loggerMock, validate, nil, nil)
+
+			// when
+			got, err := service.MoveMany(ctx, tt.data)
+
+			// then
+			assert.Equal(t, tt.want, got)
+			if err != nil || tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			}
+			assert.Nil(t, sqlMock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestFruitService_UnassignMany(t *testing.T) {
+	now := time.Date(2000, 12, 31, 23, 59, 59, 0, time.Local)
+	bucketID := int64(1)
+
+	tests := map[string]struct {
+		mock    func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime)
+		data    dtos.SelectFruitsDto
+		want    *models.FruitBulk
+		wantErr string
+	}{
+		"should unassign every fruit matched by filter": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {
+				mTime.EXPECT().Now().Return(now)
+
+				fruitRows := sqlmock.NewRows([]string{"id", "expires_at", "state", "bucket_fk"}).
+					AddRow(int64(1), now, "ripe", bucketID).
+					AddRow(int64(2), now, "expired", bucketID)
+
+				db.ExpectBegin()
+				db.ExpectQuery("SELECT (.+) FROM `fruits` WHERE deleted_at IS NULL AND bucket_fk = (.+) FOR UPDATE").
+					WillReturnRows(fruitRows)
+				db.ExpectExec("UPDATE `fruits` SET `bucket_fk`").WillReturnResult(sqlmock.NewResult(0, 2))
+				db.ExpectExec("INSERT INTO `movements`").WillReturnResult(sqlmock.NewResult(1, 1)) // record movements
+				db.ExpectCommit()
+			},
+			data: dtos.SelectFruitsDto{Filter: &dtos.FilterFruitsDto{BucketID: &bucketID}},
+			want: &models.FruitBulk{FruitIDs: []int64{1, 2}, Affected: 2},
+		},
+		"should leave reserved fruits matched by filter in their bucket": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {
+				mTime.EXPECT().Now().Return(now)
+
+				fruitRows := sqlmock.NewRows([]string{"id", "expires_at", "state", "bucket_fk", "reserved_until"}).
+					AddRow(int64(1), now, "ripe", bucketID, now.Add(time.Hour)).
+					AddRow(int64(2), now, "ripe", bucketID, nil)
+
+				db.ExpectBegin()
+				db.ExpectQuery("SELECT").WillReturnRows(fruitRows)
+				db.ExpectExec("UPDATE `fruits` SET `bucket_fk`=(.+) WHERE id IN (.+)").
+					WithArgs(nil, int64(2)).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				db.ExpectExec("INSERT INTO `movements`").WillReturnResult(sqlmock.NewResult(1, 1)) // record movements
+				db.ExpectCommit()
+			},
+			data: dtos.SelectFruitsDto{Filter: &dtos.FilterFruitsDto{BucketID: &bucketID}},
+			want: &models.FruitBulk{FruitIDs: []int64{2}, Affected: 1},
+		},
+		"should throw error when a fruit picked by id is reserved": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {
+				mTime.EXPECT().Now().Return(now)
+
+				fruitRows := sqlmock.NewRows([]string{"id", "expires_at", "state", "bucket_fk", "reserved_until"}).
+					AddRow(int64(1), now, "ripe", bucketID, now.Add(time.Hour))
+
+				db.ExpectBegin()
+				db.ExpectQuery("SELECT").WillReturnRows(fruitRows)
+				db.ExpectRollback()
+
+				logger.EXPECT().Warn(gomock.Any())
+			},
+			data:    dtos.SelectFruitsDto{IDs: []int64{1}},
+			wantErr: "Fruit 1 is reserved",
+		},
+		"should do nothing when no fruit matches the filter": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {
+				mTime.EXPECT().Now().Return(now)
+				db.ExpectBegin()
+				db.ExpectQuery("SELECT").WillReturnRows(sqlmock.NewRows([]string{"id"}))
+				db.ExpectCommit()
+			},
+			data: dtos.SelectFruitsDto{Filter: &dtos.FilterFruitsDto{BucketID: &bucketID}},
+			want: &models.FruitBulk{FruitIDs: []int64{}},
+		},
+		"should throw error on validate when filter is empty": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {
+				mTime.EXPECT().Now().Return(now)
+			},
+			data:    dtos.SelectFruitsDto{Filter: &dtos.FilterFruitsDto{}},
+			wantErr: "Key: 'SelectFruitsDto.Filter.BucketID' Error:Field validation for 'BucketID' failed on the 'required_without_all' tag",
+		},
+		"should throw error on update": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {
+				mTime.EXPECT().Now().Return(now)
+
+				fruitRows := sqlmock.NewRows([]string{"id", "expires_at", "state", "bucket_fk"}).
+					AddRow(int64(1), now, "ripe", bucketID)
+
+				db.ExpectBegin()
+				db.ExpectQuery("SELECT").WillReturnRows(fruitRows)
+				db.ExpectExec("UPDATE").WillReturnError(fmt.Errorf("error"))
+				db.ExpectRollback()
+
+				logger.EXPECT().Error(gomock.Any())
+			},
+			data:    dtos.SelectFruitsDto{IDs: []int64{1}},
+			wantErr: "error",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			//setup
+			ctx := context.Background()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			db, sqlMock, err := sqlmock.New()
+			require.Nil(t, err)
+			defer db.Close()
+
+			dialector := mysql.New(mysql.Config{
+				DSN:                       "sqlmock_db_0",
+				DriverName:                "mysql",
+				Conn:                      db,
+				SkipInitializeWithVersion: true,
+			})
+
+			gormDB, err := gorm.Open(dialector, &gorm.Config{})
+			require.Nil(t, err)
+			database := &infra.Database{DB: gormDB, SQL: db}
+
+			loggerMock := mocks.NewMockLogger(ctrl)
+			validate := infra.NewValidator()
+			timeMock := mocks.NewMockTime(ctrl)
+			_time = timeMock
+
+			tt.mock(sqlMock, loggerMock, timeMock)
+
+			// given
+			service := NewFruit(database, loggerMock, validate, nil, nil)
+
+			// when
+			got, err := service.UnassignMany(ctx, tt.data)
+
+			// then
+			assert.Equal(t, tt.want, got)
+			if err != nil || tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			}
+			assert.Nil(t, sqlMock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestFruitService_DeleteMany(t *testing.T) {
+	now := time.Date(2000, 12, 31, 23, 59, 59, 0, time.Local)
+
+	tests := map[string]struct {
+		mock    func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime)
+		data    dtos.SelectFruitsDto
+		want    *models.FruitBulk
+		wantErr string
+	}{
+		"should soft delete every fruit picked by id": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {
+				mTime.EXPECT().Now().Return(now)
+
+				fruitRows := sqlmock.NewRows([]string{"id", "expires_at", "state", "bucket_fk"}).
+					AddRow(int64(1), now, "ripe", nil).
+					AddRow(int64(2), now, "ripe", nil)
+
+				db.ExpectBegin()
+				db.ExpectQuery("SELECT (.+) FROM `fruits` WHERE deleted_at IS NULL AND id IN (.+) FOR UPDATE").
+					WillReturnRows(fruitRows)
+				db.ExpectExec("UPDATE `fruits` SET `deleted_at`").WillReturnResult(sqlmock.NewResult(0, 2))
+				db.ExpectExec("INSERT INTO `movements`").WillReturnResult(sqlmock.NewResult(1, 1)) // record movements
+				db.ExpectCommit()
+			},
+			data: dtos.SelectFruitsDto{IDs: []int64{1, 2}},
+			want: &models.FruitBulk{FruitIDs: []int64{1, 2}, Affected: 2},
+		},
+		"should throw error when a fruit picked by id is reserved": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {
+				mTime.EXPECT().Now().Return(now)
+
+				fruitRows := sqlmock.NewRows([]string{"id", "expires_at", "state", "bucket_fk", "reserved_until"}).
+					AddRow(int64(1), now, "ripe", nil, nil).
+					AddRow(int64(2), now, "ripe", nil, now.Add(time.Hour))
+
+				db.ExpectBegin()
+				db.ExpectQuery("SELECT").WillReturnRows(fruitRows)
+				db.ExpectRollback()
+
+				logger.EXPECT().Warn(gomock.Any())
+			},
+			data:    dtos.SelectFruitsDto{IDs: []int64{1, 2}},
+			wantErr: "Fruit 2 is reserved",
+		},
+		"should raise stock alert when stock falls below reorder point": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {
+				mTime.EXPECT().Now().Return(now)
+
+				fruitRows := sqlmock.NewRows([]string{"id", "name", "expires_at", "state", "bucket_fk"}).
+					AddRow(int64(1), "Orange", now.Add(time.Hour), "ripe", nil).
+					AddRow(int64(2), "Apple", now.Add(time.Hour), "ripe", nil)
+
+				db.ExpectBegin()
+				db.ExpectQuery("SELECT").WillReturnRows(fruitRows)
+				db.ExpectExec("UPDATE `fruits` SET `deleted_at`").WillReturnResult(sqlmock.NewResult(0, 2))
+				db.ExpectQuery("SELECT (.+) FROM `reorder_points` WHERE name IN (.+) ORDER BY name").
+					WithArgs("Apple", "Orange").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "name", "min_quantity"}).
+						AddRow(int64(1), "Apple", 1).
+						AddRow(int64(2), "Orange", 1))
+				db.ExpectQuery("SELECT name, COUNT(.+) FROM `fruits`").
+					WillReturnRows(sqlmock.NewRows([]string{"name", "quantity"}).AddRow("Orange", int64(3)))
+				db.ExpectExec("INSERT INTO `stock_alerts`").
+					WithArgs(now, "Apple", int64(0), 1, models.StockAlertReasonDeleted).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				db.ExpectExec("INSERT INTO `movements`").WillReturnResult(sqlmock.NewResult(1, 1)) // record movements
+				db.ExpectCommit()
+			},
+			data: dtos.SelectFruitsDto{IDs: []int64{1, 2}},
+			want: &models.FruitBulk{FruitIDs: []int64{1, 2}, Affected: 2},
+		},
+		"should throw error when some fruits are not found": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {
+				mTime.EXPECT().Now().Return(now)
+
+				db.ExpectBegin()
+				db.ExpectQuery("SELECT").WillReturnRows(sqlmock.NewRows([]string{"id"}))
+				db.ExpectRollback()
+
+				logger.EXPECT().Warn(gomock.Any())
+			},
+			data:    dtos.SelectFruitsDto{IDs: []int64{1}},
+			wantErr: "Fruits not found: 1",
+		},
+		"should throw error on validate when ids are invalid": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {
+				mTime.EXPECT().Now().Return(now)
+			},
+			data:    dtos.SelectFruitsDto{IDs: []int64{0}},
+			wantErr: "Key: 'SelectFruitsDto.IDs[0]' Error:Field validation for 'IDs[0]' failed on the 'gt' tag",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			//setup
+			ctx := context.Background()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			db, sqlMock, err := sqlmock.New()
+			require.Nil(t, err)
+			defer db.Close()
+
+			dialector := mysql.New(mysql.Config{
+				DSN:                       "sqlmock_db_0",
+				DriverName:                "mysql",
+				Conn:                      db,
+				SkipInitializeWithVersion: true,
+			})
+
+			gormDB, err := gorm.Open(dialector, &gorm.Config{})
+			require.Nil(t, err)
+			database := &infra.Database{DB: gormDB, SQL: db}
+
+			loggerMock := mocks.NewMockLogger(ctrl)
+			validate := infra.NewValidator()
+			timeMock := mocks.NewMockTime(ctrl)
+			_time = timeMock
+
+			tt.mock(sqlMock, loggerMock, timeMock)
+
+			// given
+			service := NewFruit(database, loggerMock, validate, nil, nil)
+
+			// when
+			got, err := service.DeleteMany(ctx, tt.data)
+
+			// then
+			assert.Equal(t, tt.want, got)
+			if err != nil || tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			}
+			assert.Nil(t, sqlMock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestFruitService_RemoveFromBucket(t *testing.T) {
+	now := time.Date(2000, 12, 31, 23, 59, 59, 0, time.Local)
+	actor := "tester"
+
+	tests := map[string]struct {
+		mock    func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime)
+		actor   string
+		fruitID int64
+		wantErr string
+	}{
+		"should be success recording the movement and its actor": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {
+				mTime.EXPECT().Now().Return(now)
+				db.ExpectBegin()
+				db.ExpectQuery("SELECT `id`,`bucket_fk`,`reserved_until` FROM `fruits` WHERE (.+) FOR UPDATE").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "bucket_fk"}).AddRow(int64(1), int64(2))) // find fruit
+				db.ExpectExec("UPDATE").WillReturnResult(sqlmock.NewResult(1, 1)) // update fruit
+				db.ExpectExec("INSERT INTO `movements`").
+					WithArgs(now, int64(1), int64(2), nil, models.MovementTypeUnassigned, &actor).
+					WillReturnResult(sqlmock.NewResult(1, 1)) // record movement
+				db.ExpectCommit()
+			},
+			actor:   actor,
+			fruitID: 1,
+		},
+		"should do nothing when fruit is not in a bucket": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {
+				mTime.EXPECT().Now().Return(now)
+				db.ExpectBegin()
+				db.ExpectQuery("SELECT").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "bucket_fk"}).AddRow(int64(1), nil)) // find fruit
+				db.ExpectCommit()
+			},
+			fruitID: 1,
+		},
+		"should throw error when fruit not found": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {
+				mTime.EXPECT().Now().Return(now)
+				db.ExpectBegin()
+				db.ExpectQuery("SELECT").WillReturnError(fmt.Errorf(infra.MYSQL_ERROR_NOT_FOUND)) // find fruit
+				db.ExpectRollback()
+
+				logger.EXPECT().Warn(gomock.Any())
+			},
+			fruitID: 1,
+			wantErr: "Fruit not found",
+		},
+		"should throw error when fruit is reserved": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {
+				mTime.EXPECT().Now().Return(now)
+				db.ExpectBegin()
+				db.ExpectQuery("SELECT").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "bucket_fk", "reserved_until"}).AddRow(int64(1), int64(2), now.Add(time.Hour))) // find fruit
+				db.ExpectRollback()
+
+				logger.EXPECT().Warn(gomock.Any())
+			},
+			fruitID: 1,
+			wantErr: "Fruit is reserved",
+		},
+		"should throw error": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {
+				mTime.EXPECT().Now().Return(now)
+				db.ExpectBegin()
+				db.ExpectQuery("SELECT").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "bucket_fk"}).AddRow(int64(1), int64(2))) // find fruit
+				db.ExpectExec("UPDATE").WillReturnError(fmt.Errorf("error")) // update fruit
+				db.ExpectRollback()
+
+				logger.EXPECT().Error(gomock.Any())
+			},
+			fruitID: 1,
+			wantErr: "error",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			//setup
+			ctx := context.WithValue(context.Background(), infra.ActorKey, tt.actor)
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			db, sqlMock, err := sqlmock.New()
+			require.Nil(t, err)
+			defer db.Close()
+
+			dialector := mysql.New(mysql.Config{
+				DSN:                       "sqlmock_db_0",
+				DriverName:                "mysql",
+				Conn:                      db,
+				SkipInitializeWithVersion: true,
+			})
+
+			gormDB, err := gorm.Open(dialector, &gorm.Config{})
+			require.Nil(t, err)
+			database := &infra.Database{DB: gormDB, SQL: db}
+
+			loggerMock := mocks.NewMockLogger(ctrl)
+			timeMock := mocks.NewMockTime(ctrl)
+			_time = timeMock
+
+			tt.mock(sqlMock, loggerMock, timeMock)
+
+			// given
+			service := NewFruit(database, loggerMock, nil, nil, nil)
+
+			// when
+			err = service.RemoveFromBucket(ctx, tt.fruitID)
+
+			// then
+			if err != nil || tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			}
+			assert.Nil(t, sqlMock.ExpectationsWereMet())
+		})
+	}
+}
+func TestFruitService_Delete(t *testing.T) {
+	now := time.Date(2000, 12, 31, 23, 59, 59, 0, time.Local)
+
+	tests := map[string]struct {
+		mock    func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime)
+		fruitID int64
+		wantErr string
+	}{
+		"should be success when fruit exists": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {
+				mTime.EXPECT().Now().Return(now)
+				db.ExpectBegin()
+				db.ExpectQuery("SELECT (.+) FROM `fruits` WHERE (.+) FOR UPDATE").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "name", "expires_at", "state"}).
+						AddRow(int64(1), "Orange", now.Add(time.Hour), "ripe")) // find fruit
+				db.ExpectExec("UPDATE").WillReturnResult(sqlmock.NewResult(1, 1)) // update fruit
+				db.ExpectQuery("SELECT (.+) FROM `reorder_points`").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "name", "min_quantity"})) // find reorder points
+				db.ExpectExec("INSERT INTO `movements`").WillReturnResult(sqlmock.NewResult(1, 1)) // record movements
+				db.ExpectCommit()
+			},
+			fruitID: 1,
+		},
+		"should be success raising stock alert": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {
+				mTime.EXPECT().Now().Return(now)
+				db.ExpectBegin()
+				db.ExpectQuery("SELECT").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "name", "expires_at", "state"}).
+						AddRow(int64(1), "Orange", now.Add(time.Hour), "ripe")) // find fruit
+				db.ExpectExec("UPDATE").WillReturnResult(sqlmock.NewResult(1, 1)) // update fruit
+				db.ExpectQuery("SELECT (.+) FROM `reorder_points`").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "name", "min_quantity"}).AddRow(int64(1), "Orange", 3)) // find reorder points
+				db.ExpectQuery("SELECT name, COUNT(.+) FROM `fruits`").
+					WillReturnRows(sqlmock.NewRows([]string{"name", "quantity"}).AddRow("Orange", int64(2))) // count stock
+				db.ExpectExec("INSERT INTO `stock_alerts`").
+					WithArgs(now, "Orange", int64(2), 3, models.StockAlertReasonDeleted).
+					WillReturnResult(sqlmock.NewResult(1, 1)) // create alert
+				db.ExpectExec("INSERT INTO `movements`").WillReturnResult(sqlmock.NewResult(1, 1)) // record movements
+				db.ExpectCommit()
+			},
+			fruitID: 1,
+		},
+		"should be success when fruit not exists": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {
+				mTime.EXPECT().Now().Return(now)
+				db.ExpectBegin()
+				db.ExpectQuery("SELECT").WillReturnRows(sqlmock.NewRows([]string{"id"})) // find fruit
+				db.ExpectCommit()
+			},
+			fruitID: 1,
+		},
+		"should throw error when fruit is reserved": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {
+				mTime.EXPECT().Now().Return(now)
+				db.ExpectBegin()
+				db.ExpectQuery("SELECT").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "name", "expires_at", "state", "reserved_until"}).
+						AddRow(int64(1), "Orange", now.Add(time.Hour), "ripe", now.Add(time.Hour))) // find fruit
+				db.ExpectRollback()
+
+				logger.EXPECT().Warn(gomock.Any())
+			},
+			fruitID: 1,
+			wantErr: "Fruit is reserved",
+		},
+		"should throw error": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {
+				mTime.EXPECT().Now().Return(now)
+				db.ExpectBegin()
+				db.ExpectQuery("SELECT").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "name", "expires_at", "state"}).
+						AddRow(int64(1), "Orange", now.Add(time.Hour), "ripe")) // find fruit
+				db.ExpectExec("UPDATE").WillReturnError(fmt.Errorf("error")) // update fruit
+				db.ExpectRollback()
+
+				logger.EXPECT().Error(gomock.Any())
+			},
+			fruitID: 1,
+			wantErr: "error",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			//setup
+			ctx := context.Background()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			db, sqlMock, err := sqlmock.New()
+			require.Nil(t, err)
+			defer db.Close()
+
+			dialector := mysql.New(mysql.Config{
+				DSN:                       "sqlmock_db_0",
+				DriverName:                "mysql",
+				Conn:                      db,
+				SkipInitializeWithVersion: true,
+			})
+
+			gormDB, err := gorm.Open(dialector, &gorm.Config{})
+			require.Nil(t, err)
+			database := &infra.Database{DB: gormDB, SQL: db}
+
+			loggerMock := mocks.NewMockLogger(ctrl)
+			timeMock := mocks.NewMockTime(ctrl)
+			_time = timeMock
+
+			tt.mock(sqlMock, loggerMock, timeMock)
+
+			// given
+			service := NewFruit(database, loggerMock, nil, nil, nil)
+
+			// when
+			err = service.Delete(ctx, tt.fruitID)
+
+			// then
+			if err != nil || tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestFruitService_Recall(t *testing.T) {
+	now := time.Date(2000, 12, 31, 23, 59, 59, 0, time.Local)
+	from := now.AddDate(0, 0, -7)
+	supplierID := int64(1)
+	lotID := int64(2)
+	bucketID := int64(3)
+	bucketName := "A"
+
+	tests := map[string]struct {
+		mock    func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime)
+		data    dtos.RecallFruitsDto
+		want    *models.Recall
+		wantErr string
+	}{
+		"should quarantine fruits of a supplier and list where they are": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {
+				mTime.EXPECT().Now().Return(now)
+
+				fruitRows := sqlmock.NewRows([]string{"id", "name", "bucket_fk", "quarantined_at"}).
+					AddRow(int64(1), "Apple", bucketID, nil).
+					AddRow(int64(2), "Apple", nil, nil).
+					AddRow(int64(3), "Apple", bucketID, now.Add(-time.Hour))
+				bucketRows := sqlmock.NewRows([]string{"id", "name"}).AddRow(bucketID, bucketName)
+
+				db.ExpectBegin()
+				db.ExpectQuery("SELECT `id`,`name`,`bucket_fk`,`quarantined_at` FROM `fruits` WHERE (.+) AND supplier_fk = \\? ORDER BY id FOR UPDATE").
+					WithArgs(models.FruitStateSold, models.FruitStateDisposed, supplierID).
+					WillReturnRows(fruitRows)
+				db.ExpectExec("UPDATE `fruits` SET `quarantined_at`").WithArgs(now, 1, 2).WillReturnResult(sqlmock.NewResult(0, 2))
+				db.ExpectQuery("SELECT \\* FROM `buckets`").WithArgs(bucketID).WillReturnRows(bucketRows)
+				db.ExpectCommit()
+			},
+			data: dtos.RecallFruitsDto{SupplierID: &supplierID},
+			want: &models.Recall{
+				QuarantinedAt: now,
+				Affected:      2,
+				Fruits: []models.RecallFruit{
+					{FruitID: 1, Name: "Apple", BucketID: &bucketID, BucketName: &bucketName},
+					{FruitID: 2, Name: "Apple"},
+					{FruitID: 3, Name: "Apple", BucketID: &bucketID, BucketName: &bucketName},
+				},
+			},
+		},
+		"should narrow the recall by lot and created-at window": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {
+				mTime.EXPECT().Now().Return(now)
+
+				db.ExpectBegin()
+				db.ExpectQuery("SELECT (.+) FROM `fruits` WHERE (.+) AND purchase_order_line_fk = \\? AND \\(created_at >= \\? AND created_at < \\?\\)").
+					WithArgs(models.FruitStateSold, models.FruitStateDisposed, lotID, from, now).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "name", "bucket_fk", "quarantined_at"}))
+				db.ExpectCommit()
+			},
+			data: dtos.RecallFruitsDto{LotID: &lotID, CreatedFrom: &from, CreatedTo: &now},
+			want: &models.Recall{QuarantinedAt: now, Fruits: []models.RecallFruit{}},
+		},
+		"should throw error on validate when no criterion is given": {
+			mock:    func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {},
+			data:    dtos.RecallFruitsDto{},
+			wantErr: "Key: 'RecallFruitsDto.SupplierID' Error:Field validation for 'SupplierID' failed on the 'required_without_all' tag",
+		},
+		"should throw error on validate when the window is open": {
+			mock:    func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {},
+			data:    dtos.RecallFruitsDto{CreatedFrom: &from},
+			wantErr: "Key: 'RecallFruitsDto.CreatedTo' Error:Field validation for 'CreatedTo' failed on the 'required_with' tag",
+		},
+		"should throw error on quarantine": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {
+				mTime.EXPECT().Now().Return(now)
+
+				fruitRows := sqlmock.NewRows([]string{"id", "name", "bucket_fk", "quarantined_at"}).
+					AddRow(int64(1), "Apple", bucketID, nil)
+
+				db.ExpectBegin()
+				db.ExpectQuery("SELECT (.+) FROM `fruits`").WillReturnRows(fruitRows)
+				db.ExpectExec("UPDATE `fruits`").WillReturnError(fmt.Errorf("error"))
+				db.ExpectRollback()
+
+				logger.EXPECT().Error(gomock.Any())
+			},
+			data:    dtos.RecallFruitsDto{SupplierID: &supplierID},
+			wantErr: "error",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			//setup
+			ctx := context.Background()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			db, sqlMock, err := sqlmock.New()
+			require.Nil(t, err)
+			defer db.Close()
+
+			dialector := mysql.New(mysql.Config{
+				DSN:                       "sqlmock_db_0",
+				DriverName:                "mysql",
+				Conn:                      db,
+				SkipInitializeWithVersion: true,
+			})
+
+			gormDB, err := gorm.Open(dialector, &gorm.Config{})
+			require.Nil(t, err)
+			database := &infra.Database{DB: gormDB, SQL: db}
+
+			loggerMock := mocks.NewMockLogger(ctrl)
+			validate := infra.NewValidator()
+			timeMock := mocks.NewMockTime(ctrl)
+			_time = timeMock
+
+			tt.mock(sqlMock, loggerMock, timeMock)
+
+			// given
+			service := NewFruit(database, loggerMock, validate, nil, nil)
+
+			// when
+			got, err := service.Recall(ctx, tt.data)
+
+			// then
+			assert.Equal(t, tt.want, got)
 			if err != nil || tt.wantErr != "" {
 				assert.EqualError(t, err, tt.wantErr)
 			}
+			assert.Nil(t, sqlMock.ExpectationsWereMet())
 		})
 	}
 }
@@ -2,6 +2,7 @@ package services
 
 import (
 	"context"
+	"io"
 
 	"github.com/viniosilva/where-are-my-fruits/internal/helpers"
 )
@@ -27,3 +28,9 @@ type DB interface{}
 type SQL interface {
 	PingContext(ctx context.Context) error
 }
+
+type BlobStore interface {
+	Put(ctx context.Context, key string, content io.Reader) error
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+}
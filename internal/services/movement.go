@@ -0,0 +1,92 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/viniosilva/where-are-my-fruits/internal/dtos"
+	"github.com/viniosilva/where-are-my-fruits/internal/exceptions"
+	"github.com/viniosilva/where-are-my-fruits/internal/infra"
+	"github.com/viniosilva/where-are-my-fruits/internal/models"
+	"gorm.io/gorm"
+)
+
+type MovementService struct {
+	db       *infra.Database
+	logger   Logger
+	validate Validate
+}
+
+func NewMovement(db *infra.Database, logger Logger, validate Validate) *MovementService {
+	return &MovementService{
+		db:       db,
+		logger:   logger,
+		validate: validate,
+	}
+}
+
+// List returns the movements matching the filters, newest first
+func (impl *MovementService) List(ctx context.Context, data dtos.ListMovementsDto, page, pageSize int) ([]models.Movement, error) {
+	if err := impl.validate.Struct(data); err != nil {
+		return nil, exceptions.NewValidationException(err)
+	}
+
+	offset := (page - 1) * pageSize
+
+	query := impl.db.DB
+	if data.FruitID != nil {
+		query = query.Where("fruit_fk = ?", *data.FruitID)
+	}
+	if data.BucketID != nil {
+		query = query.Where("from_bucket_fk = ? OR to_bucket_fk = ?", *data.BucketID, *data.BucketID)
+	}
+	if data.Type != nil {
+		query = query.Where("type = ?", *data.Type)
+	}
+	if data.Actor != nil {
+		query = query.Where("actor = ?", *data.Actor)
+	}
+	if data.From != nil {
+		query = query.Where("created_at >= ?", *data.From)
+	}
+	if data.To != nil {
+		query = query.Where("created_at < ?", *data.To)
+	}
+
+	movements := make([]models.Movement, 0)
+	res := query.
+		Order("created_at DESC, id DESC").
+		Offset(offset).
+		Limit(pageSize).
+		Find(&movements)
+
+	if err := res.Error; err != nil {
+		impl.logger.Error(err.Error())
+		return nil, err
+	}
+
+	return movements, nil
+}
+
+// newMovement describes a fruit leaving a bucket, entering one or both, on
+// behalf of the actor of the context
+func newMovement(ctx context.Context, kind string, fruitID int64, from, to *int64, now time.Time) models.Movement {
+	return models.Movement{
+		CreatedAt:    now,
+		FruitID:      fruitID,
+		FromBucketID: from,
+		ToBucketID:   to,
+		Type:         kind,
+		Actor:        infra.Actor(ctx),
+	}
+}
+
+// recordMovements appends the movements to the ledger within the transaction
+// of the change they record
+func recordMovements(tx *gorm.DB, movements []models.Movement) error {
+	if len(movements) == 0 {
+		return nil
+	}
+
+	return tx.Session(&gorm.Session{NewDB: true}).Create(&movements).Error
+}
@@ -0,0 +1,131 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/viniosilva/where-are-my-fruits/internal/dtos"
+	"github.com/viniosilva/where-are-my-fruits/internal/infra"
+	"github.com/viniosilva/where-are-my-fruits/internal/models"
+	"github.com/viniosilva/where-are-my-fruits/mocks"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+func TestMovementService_NewMovement(t *testing.T) {
+	t.Run("should be success", func(t *testing.T) {
+		//setup
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		loggerMock := mocks.NewMockLogger(ctrl)
+		validate := infra.NewValidator()
+
+		// given
+		got := NewMovement(nil, loggerMock, validate)
+
+		// then
+		assert.NotNil(t, got)
+	})
+}
+
+func TestMovementService_List(t *testing.T) {
+	now := time.Date(2000, 12, 31, 23, 59, 59, 0, time.Local)
+	from := time.Date(2000, 12, 1, 0, 0, 0, 0, time.Local)
+	to := time.Date(2001, 1, 1, 0, 0, 0, 0, time.Local)
+	fruitID := int64(1)
+	bucketID := int64(1)
+	toBucketID := int64(2)
+	kind := models.MovementTypeMoved
+	invalidKind := "teleported"
+	actor := "jane"
+
+	tests := map[string]struct {
+		mock    func(db sqlmock.Sqlmock, logger *mocks.MockLogger)
+		data    dtos.ListMovementsDto
+		want    []models.Movement
+		wantErr string
+	}{
+		"should be success": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger) {
+				db.ExpectQuery("SELECT (.+) FROM `movements` ORDER BY created_at DESC, id DESC LIMIT 10").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "created_at", "fruit_fk", "from_bucket_fk", "to_bucket_fk", "type", "actor"}).
+						AddRow(int64(2), now, int64(1), bucketID, toBucketID, "moved", actor).
+						AddRow(int64(1), now, int64(1), nil, bucketID, "created", nil))
+			},
+			want: []models.Movement{
+				{ID: 2, CreatedAt: now, FruitID: 1, FromBucketID: &bucketID, ToBucketID: &toBucketID, Type: models.MovementTypeMoved, Actor: &actor},
+				{ID: 1, CreatedAt: now, FruitID: 1, ToBucketID: &bucketID, Type: models.MovementTypeCreated},
+			},
+		},
+		"should be success filtering movements": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger) {
+				db.ExpectQuery("SELECT (.+) FROM `movements` WHERE fruit_fk = (.+) AND \\(from_bucket_fk = (.+) OR to_bucket_fk = (.+)\\) AND type = (.+) AND actor = (.+) AND created_at >= (.+) AND created_at < (.+) ORDER BY").
+					WithArgs(fruitID, bucketID, bucketID, kind, actor, from, to).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}))
+			},
+			data: dtos.ListMovementsDto{FruitID: &fruitID, BucketID: &bucketID, Type: &kind, Actor: &actor, From: &from, To: &to},
+			want: []models.Movement{},
+		},
+		"should throw error on validate when type is unknown": {
+			mock:    func(db sqlmock.Sqlmock, logger *mocks.MockLogger) {},
+			data:    dtos.ListMovementsDto{Type: &invalidKind},
+			wantErr: "Key: 'ListMovementsDto.Type' Error:Field validation for 'Type' failed on the 'oneof' tag",
+		},
+		"should throw error": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger) {
+				db.ExpectQuery("SELECT").WillReturnError(fmt.Errorf("error"))
+				logger.EXPECT().Error(gomock.Any())
+			},
+			wantErr: "error",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			//setup
+			ctx := context.Background()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			db, sqlMock, err := sqlmock.New()
+			require.Nil(t, err)
+			defer db.Close()
+
+			dialector := mysql.New(mysql.Config{
+				DSN:                       "sqlmock_db_0",
+				DriverName:                "mysql",
+				Conn:                      db,
+				SkipInitializeWithVersion: true,
+			})
+
+			gormDB, err := gorm.Open(dialector, &gorm.Config{})
+			require.Nil(t, err)
+			database := &infra.Database{DB: gormDB, SQL: db}
+
+			loggerMock := mocks.NewMockLogger(ctrl)
+			validate := infra.NewValidator()
+
+			tt.mock(sqlMock, loggerMock)
+
+			// given
+			service := NewMovement(database, loggerMock, validate)
+
+			// when
+			got, err := service.List(ctx, tt.data, 1, 10)
+
+			// then
+			assert.Equal(t, tt.want, got)
+			if err != nil || tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			}
+			assert.Nil(t, sqlMock.ExpectationsWereMet())
+		})
+	}
+}
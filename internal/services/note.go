@@ -0,0 +1,94 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/viniosilva/where-are-my-fruits/internal/dtos"
+	"github.com/viniosilva/where-are-my-fruits/internal/exceptions"
+	"github.com/viniosilva/where-are-my-fruits/internal/infra"
+	"github.com/viniosilva/where-are-my-fruits/internal/models"
+)
+
+type NoteService struct {
+	db       *infra.Database
+	logger   Logger
+	validate Validate
+}
+
+func NewNote(db *infra.Database, logger Logger, validate Validate) *NoteService {
+	return &NoteService{
+		db:       db,
+		logger:   logger,
+		validate: validate,
+	}
+}
+
+func (impl *NoteService) Create(ctx context.Context, data dtos.CreateNoteDto) (*models.Note, error) {
+	if err := impl.validate.Struct(data); err != nil {
+		return nil, exceptions.NewValidationException(err)
+	}
+
+	note := models.Note{
+		CreatedAt: _time.Now(),
+		Author:    data.Author,
+		Text:      data.Text,
+	}
+
+	var owner interface{}
+	notFound := "Fruit not found"
+
+	switch data.OwnerType {
+	case models.NoteOwnerFruit:
+		note.FruitID = &data.OwnerID
+		owner = &models.Fruit{}
+	case models.NoteOwnerBucket:
+		note.BucketID = &data.OwnerID
+		owner = &models.Bucket{}
+		notFound = "Bucket not found"
+	}
+
+	res := impl.db.DB.Where("id = ? AND deleted_at IS NULL", data.OwnerID).First(owner)
+	if err := res.Error; err != nil {
+		if err.Error() == infra.MYSQL_ERROR_NOT_FOUND {
+			err := exceptions.NewNotFoundException(notFound)
+			impl.logger.Warn(err.Error())
+			return nil, err
+		}
+
+		impl.logger.Error(err.Error())
+		return nil, err
+	}
+
+	if err := impl.db.DB.Create(&note).Error; err != nil {
+		impl.logger.Error(err.Error())
+		return nil, err
+	}
+
+	return &note, nil
+}
+
+// List returns the notes of a fruit or bucket, newest first
+func (impl *NoteService) List(ctx context.Context, ownerType string, ownerID int64, page, pageSize int) ([]models.Note, error) {
+	offset := (page - 1) * pageSize
+
+	column := "fruit_fk"
+	if ownerType == models.NoteOwnerBucket {
+		column = "bucket_fk"
+	}
+
+	notes := make([]models.Note, 0)
+	res := impl.db.DB.
+		Where(fmt.Sprintf("%s = ?", column), ownerID).
+		Order("created_at DESC, id DESC").
+		Offset(offset).
+		Limit(pageSize).
+		Find(&notes)
+
+	if err := res.Error; err != nil {
+		impl.logger.Error(err.Error())
+		return nil, err
+	}
+
+	return notes, nil
+}
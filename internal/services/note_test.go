@@ -0,0 +1,213 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/viniosilva/where-are-my-fruits/internal/dtos"
+	"github.com/viniosilva/where-are-my-fruits/internal/infra"
+	"github.com/viniosilva/where-are-my-fruits/internal/models"
+	"github.com/viniosilva/where-are-my-fruits/mocks"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+func TestNoteService_NewNote(t *testing.T) {
+	t.Run("should be success", func(t *testing.T) {
+		//setup
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		loggerMock := mocks.NewMockLogger(ctrl)
+		validate := infra.NewValidator()
+
+		// given
+		got := NewNote(nil, loggerMock, validate)
+
+		// then
+		assert.NotNil(t, got)
+	})
+}
+
+func TestNoteService_Create(t *testing.T) {
+	now := time.Date(2000, 12, 31, 23, 59, 59, 0, time.Local)
+	bucketID := int64(1)
+
+	tests := map[string]struct {
+		mock    func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime)
+		data    dtos.CreateNoteDto
+		want    *models.Note
+		wantErr string
+	}{
+		"should be success": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {
+				mTime.EXPECT().Now().Return(now)
+
+				db.ExpectQuery("SELECT (.+) FROM `buckets` WHERE id = (.+) AND deleted_at IS NULL").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(bucketID))
+				db.ExpectBegin()
+				db.ExpectExec("INSERT INTO `notes`").WillReturnResult(sqlmock.NewResult(1, 1))
+				db.ExpectCommit()
+			},
+			data: dtos.CreateNoteDto{OwnerType: models.NoteOwnerBucket, OwnerID: bucketID, Author: "John", Text: "lid cracked"},
+			want: &models.Note{ID: 1, CreatedAt: now, BucketID: &bucketID, Author: "John", Text: "lid cracked"},
+		},
+		"should throw error on validate when author and text are empty": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {},
+			data: dtos.CreateNoteDto{OwnerType: models.NoteOwnerFruit, OwnerID: 1},
+			wantErr: "Key: 'CreateNoteDto.Author' Error:Field validation for 'Author' failed on the 'required' tag, " +
+				"Key: 'CreateNoteDto.Text' Error:Field validation for 'Text' failed on the 'required' tag",
+		},
+		"should throw error when fruit not found": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {
+				mTime.EXPECT().Now().Return(now)
+
+				db.ExpectQuery("SELECT (.+) FROM `fruits`").WillReturnError(fmt.Errorf(infra.MYSQL_ERROR_NOT_FOUND))
+				logger.EXPECT().Warn(gomock.Any())
+			},
+			data:    dtos.CreateNoteDto{OwnerType: models.NoteOwnerFruit, OwnerID: 1, Author: "John", Text: "bruised"},
+			wantErr: "Fruit not found",
+		},
+		"should throw error on insert": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {
+				mTime.EXPECT().Now().Return(now)
+
+				db.ExpectQuery("SELECT").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(int64(1)))
+				db.ExpectBegin()
+				db.ExpectExec("INSERT").WillReturnError(fmt.Errorf("error"))
+				db.ExpectRollback()
+				logger.EXPECT().Error(gomock.Any())
+			},
+			data:    dtos.CreateNoteDto{OwnerType: models.NoteOwnerFruit, OwnerID: 1, Author: "John", Text: "bruised"},
+			wantErr: "error",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			//setup
+			ctx := context.Background()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			db, sqlMock, err := sqlmock.New()
+			require.Nil(t, err)
+			defer db.Close()
+
+			dialector := mysql.New(mysql.Config{
+				DSN:                       "sqlmock_db_0",
+				DriverName:                "mysql",
+				Conn:                      db,
+				SkipInitializeWithVersion: true,
+			})
+
+			gormDB, err := gorm.Open(dialector, &gorm.Config{})
+			require.Nil(t, err)
+			database := &infra.Database{DB: gormDB, SQL: db}
+
+			loggerMock := mocks.NewMockLogger(ctrl)
+			validate := infra.NewValidator()
+			timeMock := mocks.NewMockTime(ctrl)
+			_time = timeMock
+
+			tt.mock(sqlMock, loggerMock, timeMock)
+
+			// given
+			service := NewNote(database, loggerMock, validate)
+
+			// when
+			got, err := service.Create(ctx, tt.data)
+
+			// then
+			assert.Equal(t, tt.want, got)
+			if err != nil || tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			}
+			assert.Nil(t, sqlMock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestNoteService_List(t *testing.T) {
+	now := time.Date(2000, 12, 31, 23, 59, 59, 0, time.Local)
+	fruitID := int64(1)
+
+	tests := map[string]struct {
+		mock      func(db sqlmock.Sqlmock, logger *mocks.MockLogger)
+		ownerType string
+		want      []models.Note
+		wantErr   string
+	}{
+		"should be success": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger) {
+				rows := sqlmock.NewRows([]string{"id", "created_at", "fruit_fk", "author", "text"}).
+					AddRow(int64(2), now, fruitID, "Mary", "moved to cold room").
+					AddRow(int64(1), now, fruitID, "John", "bruised")
+
+				db.ExpectQuery("SELECT (.+) FROM `notes` WHERE fruit_fk = (.+) ORDER BY created_at DESC, id DESC LIMIT 10 OFFSET 10").
+					WillReturnRows(rows)
+			},
+			ownerType: models.NoteOwnerFruit,
+			want: []models.Note{
+				{ID: 2, CreatedAt: now, FruitID: &fruitID, Author: "Mary", Text: "moved to cold room"},
+				{ID: 1, CreatedAt: now, FruitID: &fruitID, Author: "John", Text: "bruised"},
+			},
+		},
+		"should throw error": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger) {
+				db.ExpectQuery("SELECT (.+) FROM `notes` WHERE bucket_fk").WillReturnError(fmt.Errorf("error"))
+				logger.EXPECT().Error(gomock.Any())
+			},
+			ownerType: models.NoteOwnerBucket,
+			wantErr:   "error",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			//setup
+			ctx := context.Background()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			db, sqlMock, err := sqlmock.New()
+			require.Nil(t, err)
+			defer db.Close()
+
+			dialector := mysql.New(mysql.Config{
+				DSN:                       "sqlmock_db_0",
+				DriverName:                "mysql",
+				Conn:                      db,
+				SkipInitializeWithVersion: true,
+			})
+
+			gormDB, err := gorm.Open(dialector, &gorm.Config{})
+			require.Nil(t, err)
+			database := &infra.Database{DB: gormDB, SQL: db}
+
+			loggerMock := mocks.NewMockLogger(ctrl)
+			validate := infra.NewValidator()
+
+			tt.mock(sqlMock, loggerMock)
+
+			// given
+			service := NewNote(database, loggerMock, validate)
+
+			// when
+			got, err := service.List(ctx, tt.ownerType, 1, 2, 10)
+
+			// then
+			assert.Equal(t, tt.want, got)
+			if err != nil || tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			}
+			assert.Nil(t, sqlMock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,276 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/viniosilva/where-are-my-fruits/internal/dtos"
+	"github.com/viniosilva/where-are-my-fruits/internal/exceptions"
+	"github.com/viniosilva/where-are-my-fruits/internal/infra"
+	"github.com/viniosilva/where-are-my-fruits/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type OrderService struct {
+	db       *infra.Database
+	logger   Logger
+	validate Validate
+}
+
+func NewOrder(db *infra.Database, logger Logger, validate Validate) *OrderService {
+	return &OrderService{
+		db:       db,
+		logger:   logger,
+		validate: validate,
+	}
+}
+
+// Create places an open order, pricing each item from the current fruit price
+func (impl *OrderService) Create(ctx context.Context, data dtos.CreateOrderDto) (*models.Order, error) {
+	if err := impl.validate.Struct(data); err != nil {
+		return nil, exceptions.NewValidationException(err)
+	}
+
+	now := _time.Now()
+	fruitIDs := make([]int64, 0, len(data.Items))
+	for _, item := range data.Items {
+		fruitIDs = append(fruitIDs, item.FruitID)
+	}
+
+	order := models.Order{CreatedAt: now, Total: decimal.Zero, Items: []models.OrderItem{}}
+
+	err := impl.db.DB.Transaction(func(tx *gorm.DB) error {
+		fruits, err := impl.availableFruits(ctx, tx, fruitIDs, now)
+		if err != nil {
+			return err
+		}
+
+		for _, id := range fruitIDs {
+			fruit := fruits[id]
+			order.Items = append(order.Items, models.OrderItem{FruitID: fruit.ID, Name: fruit.Name, Price: fruit.Price})
+			order.Total = order.Total.Add(fruit.Price)
+		}
+
+		return tx.Create(&order).Error
+	}, &sql.TxOptions{Isolation: sql.LevelReadCommitted})
+
+	if err != nil {
+		impl.logError(err)
+		return nil, err
+	}
+
+	return &order, nil
+}
+
+func (impl *OrderService) Get(ctx context.Context, id int64) (*models.Order, error) {
+	var order models.Order
+	res := impl.db.DB.Preload("Items").Where("id = ?", id).First(&order)
+	if err := res.Error; err != nil {
+		if err.Error() == infra.MYSQL_ERROR_NOT_FOUND {
+			err := exceptions.NewNotFoundException("Order not found")
+			impl.logger.Warn(err.Error())
+			return nil, err
+		}
+
+		impl.logger.Error(err.Error())
+		return nil, err
+	}
+
+	return &order, nil
+}
+
+// Checkout sells every fruit of an open order in one transaction. The order
+// fails as a whole when any fruit is no longer available
+func (impl *OrderService) Checkout(ctx context.Context, id int64) (*models.Order, error) {
+	now := _time.Now()
+
+	var order models.Order
+	err := impl.db.DB.Transaction(func(tx *gorm.DB) error {
+		res := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", id).First(&order)
+		if err := res.Error; err != nil {
+			if err.Error() == infra.MYSQL_ERROR_NOT_FOUND {
+				return exceptions.NewNotFoundException("Order not found")
+			}
+
+			return err
+		}
+		if order.CheckedOutAt != nil {
+			return exceptions.NewForbiddenException("Order is already checked out")
+		}
+
+		order.Items = make([]models.OrderItem, 0)
+		res = tx.Session(&gorm.Session{NewDB: true}).Where("order_fk = ?", id).Order("id").Find(&order.Items)
+		if err := res.Error; err != nil {
+			return err
+		}
+
+		fruitIDs := make([]int64, 0, len(order.Items))
+		for _, item := range order.Items {
+			fruitIDs = append(fruitIDs, item.FruitID)
+		}
+
+		fruits, err := impl.availableFruits(ctx, tx, fruitIDs, now)
+		if err != nil {
+			return err
+		}
+
+		sold := make([]models.Fruit, 0, len(fruits))
+		for _, id := range fruitIDs {
+			sold = append(sold, fruits[id])
+		}
+		if err := sellFruits(tx, sold, now); err != nil {
+			return err
+		}
+
+		order.CheckedOutAt = &now
+		res = tx.Session(&gorm.Session{NewDB: true}).Model(&models.Order{}).
+			Where("id = ?", id).
+			Update("checked_out_at", now)
+
+		return res.Error
+	}, &sql.TxOptions{Isolation: sql.LevelReadCommitted})
+
+	if err != nil {
+		impl.logError(err)
+		return nil, err
+	}
+
+	return &order, nil
+}
+
+// Sales returns the quantity and revenue of checked out fruits by day and name
+// in the [From, To) period
+func (impl *OrderService) Sales(ctx context.Context, data dtos.SalesDto) ([]models.Sales, error) {
+	if err := impl.validate.Struct(data); err != nil {
+		return nil, exceptions.NewValidationException(err)
+	}
+
+	query := impl.db.DB.Model(&models.OrderItem{}).
+		Select(`DATE_FORMAT(orders.checked_out_at, '%Y-%m-%d') AS day,
+				order_items.name,
+				COUNT(order_items.id) AS quantity,
+				SUM(order_items.price) AS revenue`).
+		Joins("JOIN orders ON orders.id = order_items.order_fk").
+		Where("orders.checked_out_at >= ? AND orders.checked_out_at < ?", data.From, data.To)
+	if data.Name != nil {
+		query = query.Where("order_items.name = ?", *data.Name)
+	}
+
+	rows, err := query.
+		Group("day, order_items.name").
+		Order("day, order_items.name").
+		Rows()
+	if err != nil {
+		impl.logger.Error(err.Error())
+		return nil, err
+	}
+	defer rows.Close()
+
+	sales := make([]models.Sales, 0)
+	for rows.Next() {
+		sale := models.Sales{}
+		dest := []interface{}{
+			&sale.Day,
+			&sale.Name,
+			&sale.Quantity,
+			&sale.Revenue,
+		}
+
+		if err := rows.Scan(dest...); err != nil {
+			impl.logger.Error(err.Error())
+			return nil, err
+		}
+
+		sales = append(sales, sale)
+	}
+
+	return sales, nil
+}
+
+// availableFruits locks the given fruits, which must all exist and still be sellable
+func (impl *OrderService) availableFruits(ctx context.Context, tx *gorm.DB, fruitIDs []int64, now time.Time) (map[int64]models.Fruit, error) {
+	fruits := make([]models.Fruit, 0)
+	res := tx.Session(&gorm.Session{NewDB: true}).Clauses(clause.Locking{Strength: "UPDATE"}).
+		Select("id", "name", "price", "expires_at", "state", "reserved_until", "quarantined_at").
+		Where("id IN ? AND deleted_at IS NULL", fruitIDs).
+		Find(&fruits)
+	if err := res.Error; err != nil {
+		return nil, err
+	}
+
+	found := map[int64]models.Fruit{}
+	for _, fruit := range fruits {
+		found[fruit.ID] = fruit
+	}
+
+	missing := []string{}
+	for _, id := range fruitIDs {
+		fruit, ok := found[id]
+		if !ok {
+			missing = append(missing, strconv.FormatInt(id, 10))
+			continue
+		}
+		if !fruit.State.CanTransitionTo(models.FruitStateSold) || !fruit.ExpiresAt.After(now) {
+			return nil, exceptions.NewForbiddenException(fmt.Sprintf("Fruit %d is not available", id))
+		}
+		if fruit.IsReserved(now) {
+			return nil, exceptions.NewForbiddenException(fmt.Sprintf("Fruit %d is reserved", id))
+		}
+		if fruit.IsQuarantined() {
+			return nil, exceptions.NewForbiddenException(fmt.Sprintf("Fruit %d is quarantined", id))
+		}
+	}
+	if len(missing) > 0 {
+		return nil, exceptions.NewForeignNotFoundException(fmt.Sprintf("Fruits not found: %s", strings.Join(missing, ", ")))
+	}
+
+	return found, nil
+}
+
+// sellFruits moves the fruits to the sold state, recording their transitions
+// and alerting on the stock falling below its reorder point. Any hold on them
+// ends with the sale
+func sellFruits(tx *gorm.DB, fruits []models.Fruit, now time.Time) error {
+	fruitIDs := make([]int64, 0, len(fruits))
+	transitions := make([]models.FruitStateTransition, 0, len(fruits))
+	for _, fruit := range fruits {
+		fruitIDs = append(fruitIDs, fruit.ID)
+		transitions = append(transitions, models.FruitStateTransition{
+			CreatedAt: now,
+			FruitID:   fruit.ID,
+			FromState: fruit.State,
+			ToState:   models.FruitStateSold,
+		})
+	}
+
+	if err := tx.Session(&gorm.Session{NewDB: true}).Create(&transitions).Error; err != nil {
+		return err
+	}
+
+	res := tx.Session(&gorm.Session{NewDB: true}).Model(&models.Fruit{}).
+		Where("id IN ?", fruitIDs).
+		Updates(map[string]interface{}{"state": models.FruitStateSold, "reserved_until": nil})
+	if err := res.Error; err != nil {
+		return err
+	}
+
+	return emitStockAlerts(tx, removedStock(fruits, now), models.StockAlertReasonSold, now)
+}
+
+func (impl *OrderService) logError(err error) {
+	if _, ok := err.(*exceptions.NotFoundException); ok {
+		impl.logger.Warn(err.Error())
+	} else if _, ok := err.(*exceptions.ForeignNotFoundException); ok {
+		impl.logger.Warn(err.Error())
+	} else if _, ok := err.(*exceptions.ForbiddenException); ok {
+		impl.logger.Warn(err.Error())
+	} else {
+		impl.logger.Error(err.Error())
+	}
+}
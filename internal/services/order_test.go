@@ -0,0 +1,538 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/golang/mock/gomock"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/viniosilva/where-are-my-fruits/internal/dtos"
+	"github.com/viniosilva/where-are-my-fruits/internal/infra"
+	"github.com/viniosilva/where-are-my-fruits/internal/models"
+	"github.com/viniosilva/where-are-my-fruits/mocks"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+func TestOrderService_NewOrder(t *testing.T) {
+	t.Run("should be success", func(t *testing.T) {
+		//setup
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		loggerMock := mocks.NewMockLogger(ctrl)
+		validate := infra.NewValidator()
+
+		// given
+		got := NewOrder(nil, loggerMock, validate)
+
+		// then
+		assert.NotNil(t, got)
+	})
+}
+
+func TestOrderService_Create(t *testing.T) {
+	now := time.Date(2000, 12, 31, 23, 59, 59, 0, time.Local)
+	expiresAt := now.Add(time.Hour)
+
+	tests := map[string]struct {
+		mock    func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime)
+		data    dtos.CreateOrderDto
+		want    *models.Order
+		wantErr string
+	}{
+		"should be success": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {
+				mTime.EXPECT().Now().Return(now)
+
+				fruitRows := sqlmock.NewRows([]string{"id", "name", "price", "expires_at", "state"}).
+					AddRow(int64(1), "Orange", "1.99", expiresAt, models.FruitStateRipe).
+					AddRow(int64(2), "Apple", "0.99", expiresAt, models.FruitStateUnripe)
+
+				db.ExpectBegin()
+				db.ExpectQuery("SELECT (.+) FROM `fruits` WHERE (.+) FOR UPDATE").WillReturnRows(fruitRows)
+				db.ExpectExec("INSERT INTO `orders`").WillReturnResult(sqlmock.NewResult(1, 1))
+				db.ExpectExec("INSERT INTO `order_items`").WillReturnResult(sqlmock.NewResult(1, 2))
+				db.ExpectCommit()
+			},
+			data: dtos.CreateOrderDto{Items: []dtos.CreateOrderItemDto{{FruitID: 2}, {FruitID: 1}}},
+			want: &models.Order{
+				ID:        1,
+				CreatedAt: now,
+				Total:     decimal.RequireFromString("2.98"),
+				Items: []models.OrderItem{
+					{ID: 1, OrderID: 1, FruitID: 2, Name: "Apple", Price: decimal.RequireFromString("0.99")},
+					{ID: 2, OrderID: 1, FruitID: 1, Name: "Orange", Price: decimal.RequireFromString("1.99")},
+				},
+			},
+		},
+		"should throw error on validate when fruit is repeated": {
+			mock:    func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {},
+			data:    dtos.CreateOrderDto{Items: []dtos.CreateOrderItemDto{{FruitID: 1}, {FruitID: 1}}},
+			wantErr: "Key: 'CreateOrderDto.Items' Error:Field validation for 'Items' failed on the 'unique' tag",
+		},
+		"should throw error when fruit not found": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {
+				mTime.EXPECT().Now().Return(now)
+
+				fruitRows := sqlmock.NewRows([]string{"id", "name", "price", "expires_at", "state"}).
+					AddRow(int64(1), "Orange", "1.99", expiresAt, models.FruitStateRipe)
+
+				db.ExpectBegin()
+				db.ExpectQuery("SELECT").WillReturnRows(fruitRows)
+				db.ExpectRollback()
+
+				logger.EXPECT().Warn(gomock.Any())
+			},
+			data:    dtos.CreateOrderDto{Items: []dtos.CreateOrderItemDto{{FruitID: 1}, {FruitID: 2}}},
+			wantErr: "Fruits not found: 2",
+		},
+		"should throw error when fruit is expired": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {
+				mTime.EXPECT().Now().Return(now)
+
+				fruitRows := sqlmock.NewRows([]string{"id", "name", "price", "expires_at", "state"}).
+					AddRow(int64(1), "Orange", "1.99", now, models.FruitStateOverripe)
+
+				db.ExpectBegin()
+				db.ExpectQuery("SELECT").WillReturnRows(fruitRows)
+				db.ExpectRollback()
+
+				logger.EXPECT().Warn(gomock.Any())
+			},
+			data:    dtos.CreateOrderDto{Items: []dtos.CreateOrderItemDto{{FruitID: 1}}},
+			wantErr: "Fruit 1 is not available",
+		},
+		"should throw error when fruit is sold": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {
+				mTime.EXPECT().Now().Return(now)
+
+				fruitRows := sqlmock.NewRows([]string{"id", "name", "price", "expires_at", "state"}).
+					AddRow(int64(1), "Orange", "1.99", expiresAt, models.FruitStateSold)
+
+				db.ExpectBegin()
+				db.ExpectQuery("SELECT").WillReturnRows(fruitRows)
+				db.ExpectRollback()
+
+				logger.EXPECT().Warn(gomock.Any())
+			},
+			data:    dtos.CreateOrderDto{Items: []dtos.CreateOrderItemDto{{FruitID: 1}}},
+			wantErr: "Fruit 1 is not available",
+		},
+		"should throw error when fruit is reserved": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {
+				mTime.EXPECT().Now().Return(now)
+
+				fruitRows := sqlmock.NewRows([]string{"id", "name", "price", "expires_at", "state", "reserved_until"}).
+					AddRow(int64(1), "Orange", "1.99", expiresAt, models.FruitStateRipe, expiresAt)
+
+				db.ExpectBegin()
+				db.ExpectQuery("SELECT").WillReturnRows(fruitRows)
+				db.ExpectRollback()
+
+				logger.EXPECT().Warn(gomock.Any())
+			},
+			data:    dtos.CreateOrderDto{Items: []dtos.CreateOrderItemDto{{FruitID: 1}}},
+			wantErr: "Fruit 1 is reserved",
+		},
+		"should throw error on insert": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {
+				mTime.EXPECT().Now().Return(now)
+
+				fruitRows := sqlmock.NewRows([]string{"id", "name", "price", "expires_at", "state"}).
+					AddRow(int64(1), "Orange", "1.99", expiresAt, models.FruitStateRipe)
+
+				db.ExpectBegin()
+				db.ExpectQuery("SELECT").WillReturnRows(fruitRows)
+				db.ExpectExec("INSERT").WillReturnError(fmt.Errorf("error"))
+				db.ExpectRollback()
+
+				logger.EXPECT().Error(gomock.Any())
+			},
+			data:    dtos.CreateOrderDto{Items: []dtos.CreateOrderItemDto{{FruitID: 1}}},
+			wantErr: "error",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			//setup
+			ctx := context.Background()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			db, sqlMock, err := sqlmock.New()
+			require.Nil(t, err)
+			defer db.Close()
+
+			dialector := mysql.New(mysql.Config{
+				DSN:                       "sqlmock_db_0",
+				DriverName:                "mysql",
+				Conn:                      db,
+				SkipInitializeWithVersion: true,
+			})
+
+			gormDB, err := gorm.Open(dialector, &gorm.Config{})
+			require.Nil(t, err)
+			database := &infra.Database{DB: gormDB, SQL: db}
+
+			loggerMock := mocks.NewMockLogger(ctrl)
+			validate := infra.NewValidator()
+			timeMock := mocks.NewMockTime(ctrl)
+			_time = timeMock
+
+			tt.mock(sqlMock, loggerMock, timeMock)
+
+			// given
+			service := NewOrder(database, loggerMock, validate)
+
+			// when
+			got, err := service.Create(ctx, tt.data)
+
+			// then
+			assert.Equal(t, tt.want, got)
+			if err != nil || tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			}
+			assert.Nil(t, sqlMock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestOrderService_Get(t *testing.T) {
+	now := time.Date(2000, 12, 31, 23, 59, 59, 0, time.Local)
+
+	tests := map[string]struct {
+		mock    func(db sqlmock.Sqlmock, logger *mocks.MockLogger)
+		want    *models.Order
+		wantErr string
+	}{
+		"should be success": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger) {
+				orderRows := sqlmock.NewRows([]string{"id", "created_at", "checked_out_at", "total"}).
+					AddRow(int64(1), now, nil, "1.99")
+				itemRows := sqlmock.NewRows([]string{"id", "order_fk", "fruit_fk", "name", "price"}).
+					AddRow(int64(1), int64(1), int64(1), "Orange", "1.99")
+
+				db.ExpectQuery("SELECT (.+) FROM `orders`").WillReturnRows(orderRows)
+				db.ExpectQuery("SELECT (.+) FROM `order_items`").WillReturnRows(itemRows)
+			},
+			want: &models.Order{
+				ID:        1,
+				CreatedAt: now,
+				Total:     decimal.RequireFromString("1.99"),
+				Items: []models.OrderItem{
+					{ID: 1, OrderID: 1, FruitID: 1, Name: "Orange", Price: decimal.RequireFromString("1.99")},
+				},
+			},
+		},
+		"should throw error when order not found": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger) {
+				db.ExpectQuery("SELECT").WillReturnError(fmt.Errorf(infra.MYSQL_ERROR_NOT_FOUND))
+				logger.EXPECT().Warn(gomock.Any())
+			},
+			wantErr: "Order not found",
+		},
+		"should throw error": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger) {
+				db.ExpectQuery("SELECT").WillReturnError(fmt.Errorf("error"))
+				logger.EXPECT().Error(gomock.Any())
+			},
+			wantErr: "error",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			//setup
+			ctx := context.Background()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			db, sqlMock, err := sqlmock.New()
+			require.Nil(t, err)
+			defer db.Close()
+
+			dialector := mysql.New(mysql.Config{
+				DSN:                       "sqlmock_db_0",
+				DriverName:                "mysql",
+				Conn:                      db,
+				SkipInitializeWithVersion: true,
+			})
+
+			gormDB, err := gorm.Open(dialector, &gorm.Config{})
+			require.Nil(t, err)
+			database := &infra.Database{DB: gormDB, SQL: db}
+
+			loggerMock := mocks.NewMockLogger(ctrl)
+			validate := infra.NewValidator()
+
+			tt.mock(sqlMock, loggerMock)
+
+			// given
+			service := NewOrder(database, loggerMock, validate)
+
+			// when
+			got, err := service.Get(ctx, 1)
+
+			// then
+			assert.Equal(t, tt.want, got)
+			if err != nil || tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			}
+			assert.Nil(t, sqlMock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestOrderService_Checkout(t *testing.T) {
+	now := time.Date(2000, 12, 31, 23, 59, 59, 0, time.Local)
+	expiresAt := now.Add(time.Hour)
+
+	tests := map[string]struct {
+		mock    func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime)
+		want    *models.Order
+		wantErr string
+	}{
+		"should be success": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {
+				mTime.EXPECT().Now().Return(now)
+
+				orderRows := sqlmock.NewRows([]string{"id", "created_at", "checked_out_at", "total"}).
+					AddRow(int64(1), now, nil, "1.99")
+				itemRows := sqlmock.NewRows([]string{"id", "order_fk", "fruit_fk", "name", "price"}).
+					AddRow(int64(1), int64(1), int64(1), "Orange", "1.99")
+				fruitRows := sqlmock.NewRows([]string{"id", "name", "price", "expires_at", "state"}).
+					AddRow(int64(1), "Orange", "1.99", expiresAt, models.FruitStateRipe)
+
+				db.ExpectBegin()
+				db.ExpectQuery("SELECT (.+) FROM `orders` WHERE id = (.+) FOR UPDATE").WillReturnRows(orderRows)
+				db.ExpectQuery("SELECT (.+) FROM `order_items` WHERE order_fk = (.+)").WillReturnRows(itemRows)
+				db.ExpectQuery("SELECT (.+) FROM `fruits` WHERE (.+) FOR UPDATE").WillReturnRows(fruitRows)
+				db.ExpectExec("INSERT INTO `fruit_state_transitions`").WillReturnResult(sqlmock.NewResult(1, 1))
+				db.ExpectExec("UPDATE `fruits` SET `reserved_until`=(.+),`state`").WillReturnResult(sqlmock.NewResult(0, 1))
+				db.ExpectQuery("SELECT (.+) FROM `reorder_points`").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "name", "min_quantity"}).AddRow(int64(1), "Orange", 1))
+				db.ExpectQuery("SELECT name, COUNT(.+) FROM `fruits`").
+					WillReturnRows(sqlmock.NewRows([]string{"name", "quantity"}))
+				db.ExpectExec("INSERT INTO `stock_alerts`").
+					WithArgs(now, "Orange", int64(0), 1, models.StockAlertReasonSold).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				db.ExpectExec("UPDATE `orders` SET `checked_out_at`").WillReturnResult(sqlmock.NewResult(0, 1))
+				db.ExpectCommit()
+			},
+			want: &models.Order{
+				ID:           1,
+				CreatedAt:    now,
+				CheckedOutAt: &now,
+				Total:        decimal.RequireFromString("1.99"),
+				Items: []models.OrderItem{
+					{ID: 1, OrderID: 1, FruitID: 1, Name: "Orange", Price: decimal.RequireFromString("1.99")},
+				},
+			},
+		},
+		"should throw error when order not found": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {
+				mTime.EXPECT().Now().Return(now)
+
+				db.ExpectBegin()
+				db.ExpectQuery("SELECT").WillReturnError(fmt.Errorf(infra.MYSQL_ERROR_NOT_FOUND))
+				db.ExpectRollback()
+
+				logger.EXPECT().Warn(gomock.Any())
+			},
+			wantErr: "Order not found",
+		},
+		"should throw error when order is checked out": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {
+				mTime.EXPECT().Now().Return(now)
+
+				orderRows := sqlmock.NewRows([]string{"id", "created_at", "checked_out_at", "total"}).
+					AddRow(int64(1), now, now, "1.99")
+
+				db.ExpectBegin()
+				db.ExpectQuery("SELECT").WillReturnRows(orderRows)
+				db.ExpectRollback()
+
+				logger.EXPECT().Warn(gomock.Any())
+			},
+			wantErr: "Order is already checked out",
+		},
+		"should throw error when fruit is disposed": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {
+				mTime.EXPECT().Now().Return(now)
+
+				orderRows := sqlmock.NewRows([]string{"id", "created_at", "checked_out_at", "total"}).
+					AddRow(int64(1), now, nil, "1.99")
+				itemRows := sqlmock.NewRows([]string{"id", "order_fk", "fruit_fk", "name", "price"}).
+					AddRow(int64(1), int64(1), int64(1), "Orange", "1.99")
+				fruitRows := sqlmock.NewRows([]string{"id", "name", "price", "expires_at", "state"}).
+					AddRow(int64(1), "Orange", "1.99", expiresAt, models.FruitStateDisposed)
+
+				db.ExpectBegin()
+				db.ExpectQuery("SELECT").WillReturnRows(orderRows)
+				db.ExpectQuery("SELECT").WillReturnRows(itemRows)
+				db.ExpectQuery("SELECT").WillReturnRows(fruitRows)
+				db.ExpectRollback()
+
+				logger.EXPECT().Warn(gomock.Any())
+			},
+			wantErr: "Fruit 1 is not available",
+		},
+		"should throw error on update": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {
+				mTime.EXPECT().Now().Return(now)
+
+				orderRows := sqlmock.NewRows([]string{"id", "created_at", "checked_out_at", "total"}).
+					AddRow(int64(1), now, nil, "1.99")
+				itemRows := sqlmock.NewRows([]string{"id", "order_fk", "fruit_fk", "name", "price"}).
+					AddRow(int64(1), int64(1), int64(1), "Orange", "1.99")
+				fruitRows := sqlmock.NewRows([]string{"id", "name", "price", "expires_at", "state"}).
+					AddRow(int64(1), "Orange", "1.99", expiresAt, models.FruitStateRipe)
+
+				db.ExpectBegin()
+				db.ExpectQuery("SELECT").WillReturnRows(orderRows)
+				db.ExpectQuery("SELECT").WillReturnRows(itemRows)
+				db.ExpectQuery("SELECT").WillReturnRows(fruitRows)
+				db.ExpectExec("INSERT").WillReturnResult(sqlmock.NewResult(1, 1))
+				db.ExpectExec("UPDATE").WillReturnError(fmt.Errorf("error"))
+				db.ExpectRollback()
+
+				logger.EXPECT().Error(gomock.Any())
+			},
+			wantErr: "error",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			//setup
+			ctx := context.Background()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			db, sqlMock, err := sqlmock.New()
+			require.Nil(t, err)
+			defer db.Close()
+
+			dialector := mysql.New(mysql.Config{
+				DSN:                       "sqlmock_db_0",
+				DriverName:                "mysql",
+				Conn:                      db,
+				SkipInitializeWithVersion: true,
+			})
+
+			gormDB, err := gorm.Open(dialector, &gorm.Config{})
+			require.Nil(t, err)
+			database := &infra.Database{DB: gormDB, SQL: db}
+
+			loggerMock := mocks.NewMockLogger(ctrl)
+			validate := infra.NewValidator()
+			timeMock := mocks.NewMockTime(ctrl)
+			_time = timeMock
+
+			tt.mock(sqlMock, loggerMock, timeMock)
+
+			// given
+			service := NewOrder(database, loggerMock, validate)
+
+			// when
+			got, err := service.Checkout(ctx, 1)
+
+			// then
+			assert.Equal(t, tt.want, got)
+			if err != nil || tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			}
+			assert.Nil(t, sqlMock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestOrderService_Sales(t *testing.T) {
+	from := time.Date(2000, 12, 1, 0, 0, 0, 0, time.Local)
+	to := time.Date(2001, 1, 1, 0, 0, 0, 0, time.Local)
+
+	tests := map[string]struct {
+		mock    func(db sqlmock.Sqlmock, logger *mocks.MockLogger)
+		data    dtos.SalesDto
+		want    []models.Sales
+		wantErr string
+	}{
+		"should be success": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger) {
+				rows := sqlmock.NewRows([]string{"day", "name", "quantity", "revenue"}).
+					AddRow("2000-12-30", "Orange", int64(2), "3.98").
+					AddRow("2000-12-31", "Apple", int64(1), "0.99")
+
+				db.ExpectQuery("SELECT DATE_FORMAT(.+) FROM `order_items` JOIN orders (.+) GROUP BY day, order_items.name ORDER BY day, order_items.name").
+					WillReturnRows(rows)
+			},
+			data: dtos.SalesDto{From: from, To: to},
+			want: []models.Sales{
+				{Day: "2000-12-30", Name: "Orange", Quantity: 2, Revenue: decimal.RequireFromString("3.98")},
+				{Day: "2000-12-31", Name: "Apple", Quantity: 1, Revenue: decimal.RequireFromString("0.99")},
+			},
+		},
+		"should throw error on validate when period is inverted": {
+			mock:    func(db sqlmock.Sqlmock, logger *mocks.MockLogger) {},
+			data:    dtos.SalesDto{From: to, To: from},
+			wantErr: "Key: 'SalesDto.To' Error:Field validation for 'To' failed on the 'gtfield' tag",
+		},
+		"should throw error": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger) {
+				db.ExpectQuery("SELECT").WillReturnError(fmt.Errorf("error"))
+				logger.EXPECT().Error(gomock.Any())
+			},
+			data:    dtos.SalesDto{From: from, To: to},
+			wantErr: "error",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			//setup
+			ctx := context.Background()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			db, sqlMock, err := sqlmock.New()
+			require.Nil(t, err)
+			defer db.Close()
+
+			dialector := mysql.New(mysql.Config{
+				DSN:                       "sqlmock_db_0",
+				DriverName:                "mysql",
+				Conn:                      db,
+				SkipInitializeWithVersion: true,
+			})
+
+			gormDB, err := gorm.Open(dialector, &gorm.Config{})
+			require.Nil(t, err)
+			database := &infra.Database{DB: gormDB, SQL: db}
+
+			loggerMock := mocks.NewMockLogger(ctrl)
+			validate := infra.NewValidator()
+
+			tt.mock(sqlMock, loggerMock)
+
+			// given
+			service := NewOrder(database, loggerMock, validate)
+
+			// when
+			got, err := service.Sales(ctx, tt.data)
+
+			// then
+			assert.Equal(t, tt.want, got)
+			if err != nil || tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			}
+			assert.Nil(t, sqlMock.ExpectationsWereMet())
+		})
+	}
+}
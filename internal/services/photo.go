@@ -0,0 +1,295 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"io"
+	"net/http"
+
+	_ "image/gif"
+	_ "image/png"
+
+	"github.com/viniosilva/where-are-my-fruits/internal/dtos"
+	"github.com/viniosilva/where-are-my-fruits/internal/exceptions"
+	"github.com/viniosilva/where-are-my-fruits/internal/infra"
+	"github.com/viniosilva/where-are-my-fruits/internal/models"
+)
+
+// photoMaxPixels bounds the decoded image size, so a small file cannot expand
+// into a huge bitmap while the thumbnail is generated
+const photoMaxPixels = 50_000_000
+
+var photoExtensions = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/gif":  ".gif",
+}
+
+type PhotoService struct {
+	db            *infra.Database
+	logger        Logger
+	validate      Validate
+	store         BlobStore
+	maxSize       int64
+	thumbnailSize int
+}
+
+func NewPhoto(db *infra.Database, logger Logger, validate Validate, store BlobStore, maxSize int64, thumbnailSize int) *PhotoService {
+	return &PhotoService{
+		db:            db,
+		logger:        logger,
+		validate:      validate,
+		store:         store,
+		maxSize:       maxSize,
+		thumbnailSize: thumbnailSize,
+	}
+}
+
+func (impl *PhotoService) Upload(ctx context.Context, data dtos.UploadPhotoDto) (*models.Photo, error) {
+	if err := impl.validate.Struct(data); err != nil {
+		return nil, exceptions.NewValidationException(err)
+	}
+
+	photo, err := impl.upload(ctx, data)
+	if err != nil {
+		if _, ok := err.(*exceptions.NotFoundException); ok {
+			impl.logger.Warn(err.Error())
+		} else if _, ok := err.(*exceptions.ForbiddenException); ok {
+			impl.logger.Warn(err.Error())
+		} else {
+			impl.logger.Error(err.Error())
+		}
+
+		return nil, err
+	}
+
+	return photo, nil
+}
+
+func (impl *PhotoService) upload(ctx context.Context, data dtos.UploadPhotoDto) (*models.Photo, error) {
+	photo := models.Photo{CreatedAt: _time.Now(), Filename: data.Filename}
+	if err := impl.setOwner(ctx, &photo, data.OwnerType, data.OwnerID); err != nil {
+		return nil, err
+	}
+
+	if data.Size > impl.maxSize {
+		return nil, exceptions.NewForbiddenException(fmt.Sprintf("Photo is larger than %d bytes", impl.maxSize))
+	}
+
+	content, err := io.ReadAll(io.LimitReader(data.Content, impl.maxSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(content)) > impl.maxSize {
+		return nil, exceptions.NewForbiddenException(fmt.Sprintf("Photo is larger than %d bytes", impl.maxSize))
+	}
+
+	contentType := http.DetectContentType(content)
+	ext, ok := photoExtensions[contentType]
+	if !ok {
+		return nil, exceptions.NewForbiddenException("Photo must be a jpeg, png or gif image")
+	}
+
+	config, _, err := image.DecodeConfig(bytes.NewReader(content))
+	if err != nil {
+		return nil, exceptions.NewForbiddenException("Photo is not a valid image")
+	}
+	if config.Width*config.Height > photoMaxPixels {
+		return nil, exceptions.NewForbiddenException("Photo dimensions are too large")
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(content))
+	if err != nil {
+		return nil, exceptions.NewForbiddenException("Photo is not a valid image")
+	}
+
+	photo.ContentType = contentType
+	photo.Size = int64(len(content))
+	photo.Width = config.Width
+	photo.Height = config.Height
+
+	var thumb bytes.Buffer
+	if err := jpeg.Encode(&thumb, thumbnail(img, impl.thumbnailSize), &jpeg.Options{Quality: 80}); err != nil {
+		return nil, err
+	}
+
+	name, err := randomName()
+	if err != nil {
+		return nil, err
+	}
+	photo.BlobKey = fmt.Sprintf("photos/%s%s", name, ext)
+	photo.ThumbnailKey = fmt.Sprintf("photos/%s_thumb.jpg", name)
+
+	if err := impl.store.Put(ctx, photo.BlobKey, bytes.NewReader(content)); err != nil {
+		return nil, err
+	}
+	if err := impl.store.Put(ctx, photo.ThumbnailKey, &thumb); err != nil {
+		impl.removeBlobs(ctx, photo.BlobKey)
+		return nil, err
+	}
+
+	if err := impl.db.DB.Create(&photo).Error; err != nil {
+		impl.removeBlobs(ctx, photo.BlobKey, photo.ThumbnailKey)
+		return nil, err
+	}
+
+	return &photo, nil
+}
+
+func (impl *PhotoService) List(ctx context.Context, ownerType string, ownerID int64) ([]models.Photo, error) {
+	column := "fruit_fk"
+	if ownerType == models.PhotoOwnerBucket {
+		column = "bucket_fk"
+	}
+
+	photos := make([]models.Photo, 0)
+	res := impl.db.DB.
+		Where(fmt.Sprintf("%s = ?", column), ownerID).
+		Order("created_at, id").
+		Find(&photos)
+
+	if err := res.Error; err != nil {
+		impl.logger.Error(err.Error())
+		return nil, err
+	}
+
+	return photos, nil
+}
+
+// Open returns the photo with a reader over its image, or over its thumbnail
+// when asked. The caller must close the reader
+func (impl *PhotoService) Open(ctx context.Context, id int64, thumbnail bool) (*models.Photo, io.ReadCloser, error) {
+	var photo models.Photo
+	res := impl.db.DB.Where("id = ?", id).First(&photo)
+	if err := res.Error; err != nil {
+		if err.Error() == infra.MYSQL_ERROR_NOT_FOUND {
+			err := exceptions.NewNotFoundException("Photo not found")
+			impl.logger.Warn(err.Error())
+			return nil, nil, err
+		}
+
+		impl.logger.Error(err.Error())
+		return nil, nil, err
+	}
+
+	key := photo.BlobKey
+	if thumbnail {
+		key = photo.ThumbnailKey
+	}
+
+	reader, err := impl.store.Get(ctx, key)
+	if err != nil {
+		impl.logger.Error(err.Error())
+		if err == infra.ErrBlobNotFound {
+			return nil, nil, exceptions.NewNotFoundException("Photo file not found")
+		}
+
+		return nil, nil, err
+	}
+
+	return &photo, reader, nil
+}
+
+// setOwner attaches the photo to its fruit or bucket, which must exist
+func (impl *PhotoService) setOwner(ctx context.Context, photo *models.Photo, ownerType string, ownerID int64) error {
+	var owner interface{}
+	notFound := "Fruit not found"
+
+	switch ownerType {
+	case models.PhotoOwnerFruit:
+		photo.FruitID = &ownerID
+		owner = &models.Fruit{}
+	case models.PhotoOwnerBucket:
+		photo.BucketID = &ownerID
+		owner = &models.Bucket{}
+		notFound = "Bucket not found"
+	}
+
+	err := impl.db.DB.Where("id = ? AND deleted_at IS NULL", ownerID).First(owner).Error
+	if err != nil && err.Error() == infra.MYSQL_ERROR_NOT_FOUND {
+		return exceptions.NewNotFoundException(notFound)
+	}
+
+	return err
+}
+
+func (impl *PhotoService) removeBlobs(ctx context.Context, keys ...string) {
+	for _, key := range keys {
+		if err := impl.store.Delete(ctx, key); err != nil {
+			impl.logger.Error(err.Error())
+		}
+	}
+}
+
+func randomName() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(b), nil
+}
+
+// thumbnail scales the image down to fit a size x size square, averaging the
+// source pixels behind each thumbnail pixel and flattening transparency on white
+func thumbnail(src image.Image, size int) image.Image {
+	bounds := src.Bounds()
+	w, h := bounds.Dx(), bounds.Dy()
+
+	tw, th := w, h
+	if w > size || h > size {
+		if w >= h {
+			tw, th = size, h*size/w
+		} else {
+			tw, th = w*size/h, size
+		}
+	}
+	if tw < 1 {
+		tw = 1
+	}
+	if th < 1 {
+		th = 1
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, tw, th))
+	for y := 0; y < th; y++ {
+		y0 := bounds.Min.Y + y*h/th
+		y1 := bounds.Min.Y + (y+1)*h/th
+		if y1 == y0 {
+			y1++
+		}
+
+		for x := 0; x < tw; x++ {
+			x0 := bounds.Min.X + x*w/tw
+			x1 := bounds.Min.X + (x+1)*w/tw
+			if x1 == x0 {
+				x1++
+			}
+
+			var r, g, b, a, n uint64
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					cr, cg, cb, ca := src.At(sx, sy).RGBA()
+					r, g, b, a = r+uint64(cr), g+uint64(cg), b+uint64(cb), a+uint64(ca)
+					n++
+				}
+			}
+
+			white := 0xffff - a/n
+			dst.Set(x, y, color.RGBA64{
+				R: uint16(r/n + white),
+				G: uint16(g/n + white),
+				B: uint16(b/n + white),
+				A: 0xffff,
+			})
+		}
+	}
+
+	return dst
+}
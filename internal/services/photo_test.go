@@ -0,0 +1,477 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/viniosilva/where-are-my-fruits/internal/dtos"
+	"github.com/viniosilva/where-are-my-fruits/internal/infra"
+	"github.com/viniosilva/where-are-my-fruits/internal/models"
+	"github.com/viniosilva/where-are-my-fruits/mocks"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+func TestPhotoService_NewPhoto(t *testing.T) {
+	t.Run("should be success", func(t *testing.T) {
+		//setup
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		loggerMock := mocks.NewMockLogger(ctrl)
+		storeMock := mocks.NewMockBlobStore(ctrl)
+		validate := infra.NewValidator()
+
+		// given
+		got := NewPhoto(nil, loggerMock, validate, storeMock, 1024, 256)
+
+		// then
+		assert.NotNil(t, got)
+	})
+}
+
+func TestPhotoService_Upload(t *testing.T) {
+	now := time.Date(2000, 12, 31, 23, 59, 59, 0, time.Local)
+	content := newTestPNG(t, 4, 2)
+	fruitID := int64(1)
+	bucketID := int64(2)
+
+	tests := map[string]struct {
+		mock    func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime, store *mocks.MockBlobStore)
+		data    dtos.UploadPhotoDto
+		want    *models.Photo
+		wantErr string
+	}{
+		"should be success": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime, store *mocks.MockBlobStore) {
+				mTime.EXPECT().Now().Return(now)
+
+				db.ExpectQuery("SELECT (.+) FROM `fruits` WHERE id = (.+) AND deleted_at IS NULL").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(fruitID))
+				store.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).Times(2)
+				db.ExpectBegin()
+				db.ExpectExec("INSERT INTO `photos`").WillReturnResult(sqlmock.NewResult(1, 1))
+				db.ExpectCommit()
+			},
+			data: dtos.UploadPhotoDto{
+				OwnerType: models.PhotoOwnerFruit,
+				OwnerID:   fruitID,
+				Filename:  "mango.png",
+				Size:      int64(len(content)),
+				Content:   bytes.NewReader(content),
+			},
+			want: &models.Photo{
+				ID:          1,
+				CreatedAt:   now,
+				FruitID:     &fruitID,
+				Filename:    "mango.png",
+				ContentType: "image/png",
+				Size:        int64(len(content)),
+				Width:       4,
+				Height:      2,
+			},
+		},
+		"should throw error on validate when data is empty": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime, store *mocks.MockBlobStore) {
+			},
+			data: dtos.UploadPhotoDto{},
+			wantErr: "Key: 'UploadPhotoDto.OwnerType' Error:Field validation for 'OwnerType' failed on the 'required' tag, " +
+				"Key: 'UploadPhotoDto.OwnerID' Error:Field validation for 'OwnerID' failed on the 'required' tag, " +
+				"Key: 'UploadPhotoDto.Filename' Error:Field validation for 'Filename' failed on the 'required' tag, " +
+				"Key: 'UploadPhotoDto.Size' Error:Field validation for 'Size' failed on the 'required' tag, " +
+				"Key: 'UploadPhotoDto.Content' Error:Field validation for 'Content' failed on the 'required' tag",
+		},
+		"should throw error when bucket not found": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime, store *mocks.MockBlobStore) {
+				mTime.EXPECT().Now().Return(now)
+
+				db.ExpectQuery("SELECT (.+) FROM `buckets`").WillReturnError(fmt.Errorf(infra.MYSQL_ERROR_NOT_FOUND))
+				logger.EXPECT().Warn(gomock.Any())
+			},
+			data: dtos.UploadPhotoDto{
+				OwnerType: models.PhotoOwnerBucket,
+				OwnerID:   bucketID,
+				Filename:  "bucket.png",
+				Size:      int64(len(content)),
+				Content:   bytes.NewReader(content),
+			},
+			wantErr: "Bucket not found",
+		},
+		"should throw error when photo is too large": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime, store *mocks.MockBlobStore) {
+				mTime.EXPECT().Now().Return(now)
+
+				db.ExpectQuery("SELECT").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(fruitID))
+				logger.EXPECT().Warn(gomock.Any())
+			},
+			data: dtos.UploadPhotoDto{
+				OwnerType: models.PhotoOwnerFruit,
+				OwnerID:   fruitID,
+				Filename:  "mango.png",
+				Size:      1025,
+				Content:   bytes.NewReader(content),
+			},
+			wantErr: "Photo is larger than 1024 bytes",
+		},
+		"should throw error when photo is not an image": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime, store *mocks.MockBlobStore) {
+				mTime.EXPECT().Now().Return(now)
+
+				db.ExpectQuery("SELECT").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(fruitID))
+				logger.EXPECT().Warn(gomock.Any())
+			},
+			data: dtos.UploadPhotoDto{
+				OwnerType: models.PhotoOwnerFruit,
+				OwnerID:   fruitID,
+				Filename:  "mango.txt",
+				Size:      5,
+				Content:   strings.NewReader("mango"),
+			},
+			wantErr: "Photo must be a jpeg, png or gif image",
+		},
+		"should throw error when photo is corrupted": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime, store *mocks.MockBlobStore) {
+				mTime.EXPECT().Now().Return(now)
+
+				db.ExpectQuery("SELECT").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(fruitID))
+				logger.EXPECT().Warn(gomock.Any())
+			},
+			data: dtos.UploadPhotoDto{
+				OwnerType: models.PhotoOwnerFruit,
+				OwnerID:   fruitID,
+				Filename:  "mango.png",
+				Size:      20,
+				Content:   bytes.NewReader(content[:20]),
+			},
+			wantErr: "Photo is not a valid image",
+		},
+		"should throw error on store": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime, store *mocks.MockBlobStore) {
+				mTime.EXPECT().Now().Return(now)
+
+				db.ExpectQuery("SELECT").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(fruitID))
+				store.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Return(fmt.Errorf("error"))
+				logger.EXPECT().Error(gomock.Any())
+			},
+			data: dtos.UploadPhotoDto{
+				OwnerType: models.PhotoOwnerFruit,
+				OwnerID:   fruitID,
+				Filename:  "mango.png",
+				Size:      int64(len(content)),
+				Content:   bytes.NewReader(content),
+			},
+			wantErr: "error",
+		},
+		"should remove stored files on insert error": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime, store *mocks.MockBlobStore) {
+				mTime.EXPECT().Now().Return(now)
+
+				db.ExpectQuery("SELECT").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(fruitID))
+				store.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).Times(2)
+				db.ExpectBegin()
+				db.ExpectExec("INSERT").WillReturnError(fmt.Errorf("error"))
+				db.ExpectRollback()
+				store.EXPECT().Delete(gomock.Any(), gomock.Any()).Return(nil).Times(2)
+				logger.EXPECT().Error(gomock.Any())
+			},
+			data: dtos.UploadPhotoDto{
+				OwnerType: models.PhotoOwnerFruit,
+				OwnerID:   fruitID,
+				Filename:  "mango.png",
+				Size:      int64(len(content)),
+				Content:   bytes.NewReader(content),
+			},
+			wantErr: "error",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			//setup
+			ctx := context.Background()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			db, sqlMock, err := sqlmock.New()
+			require.Nil(t, err)
+			defer db.Close()
+
+			dialector := mysql.New(mysql.Config{
+				DSN:                       "sqlmock_db_0",
+				DriverName:                "mysql",
+				Conn:                      db,
+				SkipInitializeWithVersion: true,
+			})
+
+			gormDB, err := gorm.Open(dialector, &gorm.Config{})
+			require.Nil(t, err)
+			database := &infra.Database{DB: gormDB, SQL: db}
+
+			loggerMock := mocks.NewMockLogger(ctrl)
+			validate := infra.NewValidator()
+			timeMock := mocks.NewMockTime(ctrl)
+			storeMock := mocks.NewMockBlobStore(ctrl)
+			_time = timeMock
+
+			tt.mock(sqlMock, loggerMock, timeMock, storeMock)
+
+			// given
+			service := NewPhoto(database, loggerMock, validate, storeMock, 1024, 256)
+
+			// when
+			got, err := service.Upload(ctx, tt.data)
+
+			// then
+			if got != nil {
+				assert.Regexp(t, `^photos/[0-9a-f]{32}\.png$`, got.BlobKey)
+				assert.Regexp(t, `^photos/[0-9a-f]{32}_thumb\.jpg$`, got.ThumbnailKey)
+				got.BlobKey, got.ThumbnailKey = "", ""
+			}
+			assert.Equal(t, tt.want, got)
+			if err != nil || tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			}
+			assert.Nil(t, sqlMock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestPhotoService_List(t *testing.T) {
+	now := time.Date(2000, 12, 31, 23, 59, 59, 0, time.Local)
+	bucketID := int64(1)
+
+	tests := map[string]struct {
+		mock      func(db sqlmock.Sqlmock, logger *mocks.MockLogger)
+		ownerType string
+		ownerID   int64
+		want      []models.Photo
+		wantErr   string
+	}{
+		"should be success": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger) {
+				rows := sqlmock.NewRows([]string{"id", "created_at", "bucket_fk", "filename", "content_type", "size", "width", "height"}).
+					AddRow(int64(1), now, bucketID, "bucket.png", "image/png", int64(100), 4, 2)
+
+				db.ExpectQuery("SELECT (.+) FROM `photos` WHERE bucket_fk = (.+) ORDER BY created_at, id").
+					WillReturnRows(rows)
+			},
+			ownerType: models.PhotoOwnerBucket,
+			ownerID:   bucketID,
+			want: []models.Photo{
+				{ID: 1, CreatedAt: now, BucketID: &bucketID, Filename: "bucket.png", ContentType: "image/png", Size: 100, Width: 4, Height: 2},
+			},
+		},
+		"should throw error": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger) {
+				db.ExpectQuery("SELECT (.+) FROM `photos` WHERE fruit_fk").WillReturnError(fmt.Errorf("error"))
+				logger.EXPECT().Error(gomock.Any())
+			},
+			ownerType: models.PhotoOwnerFruit,
+			ownerID:   1,
+			wantErr:   "error",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			//setup
+			ctx := context.Background()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			db, sqlMock, err := sqlmock.New()
+			require.Nil(t, err)
+			defer db.Close()
+
+			dialector := mysql.New(mysql.Config{
+				DSN:                       "sqlmock_db_0",
+				DriverName:                "mysql",
+				Conn:                      db,
+				SkipInitializeWithVersion: true,
+			})
+
+			gormDB, err := gorm.Open(dialector, &gorm.Config{})
+			require.Nil(t, err)
+			database := &infra.Database{DB: gormDB, SQL: db}
+
+			loggerMock := mocks.NewMockLogger(ctrl)
+			validate := infra.NewValidator()
+			storeMock := mocks.NewMockBlobStore(ctrl)
+
+			tt.mock(sqlMock, loggerMock)
+
+			// given
+			service := NewPhoto(database, loggerMock, validate, storeMock, 1024, 256)
+
+			// when
+			got, err := service.List(ctx, tt.ownerType, tt.ownerID)
+
+			// then
+			assert.Equal(t, tt.want, got)
+			if err != nil || tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			}
+			assert.Nil(t, sqlMock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestPhotoService_Open(t *testing.T) {
+	now := time.Date(2000, 12, 31, 23, 59, 59, 0, time.Local)
+	fruitID := int64(1)
+	photo := &models.Photo{
+		ID:           1,
+		CreatedAt:    now,
+		FruitID:      &fruitID,
+		Filename:     "mango.png",
+		ContentType:  "image/png",
+		BlobKey:      "photos/a.png",
+		ThumbnailKey: "photos/a_thumb.jpg",
+	}
+	photoRows := func() *sqlmock.Rows {
+		return sqlmock.NewRows([]string{"id", "created_at", "fruit_fk", "filename", "content_type", "blob_key", "thumbnail_key"}).
+			AddRow(int64(1), now, fruitID, "mango.png", "image/png", "photos/a.png", "photos/a_thumb.jpg")
+	}
+
+	tests := map[string]struct {
+		mock      func(db sqlmock.Sqlmock, logger *mocks.MockLogger, store *mocks.MockBlobStore)
+		thumbnail bool
+		want      *models.Photo
+		wantBody  string
+		wantErr   string
+	}{
+		"should be success": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, store *mocks.MockBlobStore) {
+				db.ExpectQuery("SELECT (.+) FROM `photos`").WillReturnRows(photoRows())
+				store.EXPECT().Get(gomock.Any(), "photos/a.png").Return(io.NopCloser(strings.NewReader("image")), nil)
+			},
+			want:     photo,
+			wantBody: "image",
+		},
+		"should be success when thumbnail": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, store *mocks.MockBlobStore) {
+				db.ExpectQuery("SELECT (.+) FROM `photos`").WillReturnRows(photoRows())
+				store.EXPECT().Get(gomock.Any(), "photos/a_thumb.jpg").Return(io.NopCloser(strings.NewReader("thumb")), nil)
+			},
+			thumbnail: true,
+			want:      photo,
+			wantBody:  "thumb",
+		},
+		"should throw error when photo not found": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, store *mocks.MockBlobStore) {
+				db.ExpectQuery("SELECT").WillReturnError(fmt.Errorf(infra.MYSQL_ERROR_NOT_FOUND))
+				logger.EXPECT().Warn(gomock.Any())
+			},
+			wantErr: "Photo not found",
+		},
+		"should throw error when file not found": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, store *mocks.MockBlobStore) {
+				db.ExpectQuery("SELECT").WillReturnRows(photoRows())
+				store.EXPECT().Get(gomock.Any(), gomock.Any()).Return(nil, infra.ErrBlobNotFound)
+				logger.EXPECT().Error(gomock.Any())
+			},
+			wantErr: "Photo file not found",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			//setup
+			ctx := context.Background()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			db, sqlMock, err := sqlmock.New()
+			require.Nil(t, err)
+			defer db.Close()
+
+			dialector := mysql.New(mysql.Config{
+				DSN:                       "sqlmock_db_0",
+				DriverName:                "mysql",
+				Conn:                      db,
+				SkipInitializeWithVersion: true,
+			})
+
+			gormDB, err := gorm.Open(dialector, &gorm.Config{})
+			require.Nil(t, err)
+			database := &infra.Database{DB: gormDB, SQL: db}
+
+			loggerMock := mocks.NewMockLogger(ctrl)
+			validate := infra.NewValidator()
+			storeMock := mocks.NewMockBlobStore(ctrl)
+
+			tt.mock(sqlMock, loggerMock, storeMock)
+
+			// given
+			service := NewPhoto(database, loggerMock, validate, storeMock, 1024, 256)
+
+			// when
+			got, reader, err := service.Open(ctx, 1, tt.thumbnail)
+
+			// then
+			assert.Equal(t, tt.want, got)
+			if reader != nil {
+				body, _ := io.ReadAll(reader)
+				assert.Equal(t, tt.wantBody, string(body))
+			}
+			if err != nil || tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			}
+			assert.Nil(t, sqlMock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestPhotoService_thumbnail(t *testing.T) {
+	tests := map[string]struct {
+		width, height int
+		wantW, wantH  int
+	}{
+		"should fit landscape image":  {width: 600, height: 300, wantW: 256, wantH: 128},
+		"should fit portrait image":   {width: 300, height: 600, wantW: 128, wantH: 256},
+		"should keep small image":     {width: 4, height: 2, wantW: 4, wantH: 2},
+		"should keep thin image wide": {width: 1000, height: 1, wantW: 256, wantH: 1},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			// given
+			src := image.NewRGBA(image.Rect(0, 0, tt.width, tt.height))
+
+			// when
+			got := thumbnail(src, 256)
+
+			// then
+			assert.Equal(t, tt.wantW, got.Bounds().Dx())
+			assert.Equal(t, tt.wantH, got.Bounds().Dy())
+
+			r, g, b, _ := got.At(0, 0).RGBA()
+			assert.Equal(t, []uint32{0xffff, 0xffff, 0xffff}, []uint32{r, g, b}) // transparent becomes white
+		})
+	}
+}
+
+func newTestPNG(t *testing.T, width, height int) []byte {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.Set(x, y, color.RGBA{R: 255, G: 165, A: 255})
+		}
+	}
+
+	var buf bytes.Buffer
+	require.Nil(t, png.Encode(&buf, img))
+
+	return buf.Bytes()
+}
@@ -0,0 +1,125 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/viniosilva/where-are-my-fruits/internal/dtos"
+	"github.com/viniosilva/where-are-my-fruits/internal/exceptions"
+	"github.com/viniosilva/where-are-my-fruits/internal/infra"
+	"github.com/viniosilva/where-are-my-fruits/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type PickService struct {
+	db       *infra.Database
+	logger   Logger
+	validate Validate
+}
+
+func NewPick(db *infra.Database, logger Logger, validate Validate) *PickService {
+	return &PickService{
+		db:       db,
+		logger:   logger,
+		validate: validate,
+	}
+}
+
+// Create picks the requested quantity of fruits first-expired-first-out across
+// all buckets and removes them from stock. Either every fruit is picked or none.
+// Reserved fruits are left for their holders and quarantined ones are never picked
+func (impl *PickService) Create(ctx context.Context, data dtos.CreatePickDto) (*models.Pick, error) {
+	if err := impl.validate.Struct(data); err != nil {
+		return nil, exceptions.NewValidationException(err)
+	}
+
+	now := _time.Now()
+	pick := models.Pick{Name: data.Name, Quantity: data.Quantity, Items: []models.PickItem{}}
+
+	err := impl.db.DB.Transaction(func(tx *gorm.DB) error {
+		fruits := make([]models.Fruit, 0)
+		res := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Select("id", "name", "expires_at", "price", "state", "bucket_fk").
+			Where(`name = ?
+				AND deleted_at IS NULL
+				AND bucket_fk IS NOT NULL
+				AND expires_at > ?
+				AND state IN ?
+				AND (reserved_until IS NULL OR reserved_until <= ?)
+				AND quarantined_at IS NULL
+			`, data.Name, now, models.FruitActiveStates, now).
+			Order("expires_at, id").
+			Limit(data.Quantity).
+			Find(&fruits)
+		if err := res.Error; err != nil {
+			return err
+		}
+
+		if len(fruits) < data.Quantity {
+			return exceptions.NewForbiddenException(fmt.Sprintf("Not enough %s to pick: %d available", data.Name, len(fruits)))
+		}
+
+		fruitIDs := make([]int64, 0, len(fruits))
+		bucketIDs := make([]int64, 0)
+		movements := make([]models.Movement, 0, len(fruits))
+		seen := map[int64]bool{}
+		for _, fruit := range fruits {
+			fruitIDs = append(fruitIDs, fruit.ID)
+			movements = append(movements, newMovement(ctx, models.MovementTypePicked, fruit.ID, fruit.BucketID, nil, now))
+			if !seen[*fruit.BucketID] {
+				seen[*fruit.BucketID] = true
+				bucketIDs = append(bucketIDs, *fruit.BucketID)
+			}
+		}
+
+		res = tx.Session(&gorm.Session{NewDB: true}).Model(&models.Fruit{}).
+			Where("id IN ?", fruitIDs).
+			Update("deleted_at", now)
+		if err := res.Error; err != nil {
+			return err
+		}
+		if err := recordMovements(tx, movements); err != nil {
+			return err
+		}
+		if err := emitStockAlerts(tx, removedStock(fruits, now), models.StockAlertReasonPicked, now); err != nil {
+			return err
+		}
+
+		buckets := make([]models.Bucket, 0)
+		res = tx.Session(&gorm.Session{NewDB: true}).Where("id IN ?", bucketIDs).Find(&buckets)
+		if err := res.Error; err != nil {
+			return err
+		}
+
+		bucketNames := map[int64]string{}
+		for _, bucket := range buckets {
+			bucketNames[bucket.ID] = bucket.Name
+		}
+
+		for _, fruit := range fruits {
+			pick.Items = append(pick.Items, models.PickItem{
+				FruitID:    fruit.ID,
+				BucketID:   *fruit.BucketID,
+				BucketName: bucketNames[*fruit.BucketID],
+				ExpiresAt:  fruit.ExpiresAt,
+				Price:      fruit.Price,
+			})
+		}
+
+		return nil
+	}, &sql.TxOptions{Isolation: sql.LevelReadCommitted})
+
+	if err != nil {
+		if _, ok := err.(*exceptions.ForbiddenException); ok {
+			impl.logger.Warn(err.Error())
+		} else {
+			impl.logger.Error(err.Error())
+		}
+
+		return nil, err
+	}
+
+	return &pick, nil
+}
@@ -0,0 +1,174 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/golang/mock/gomock"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/viniosilva/where-are-my-fruits/internal/dtos"
+	"github.com/viniosilva/where-are-my-fruits/internal/infra"
+	"github.com/viniosilva/where-are-my-fruits/internal/models"
+	"github.com/viniosilva/where-are-my-fruits/mocks"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+func TestPickService_NewPick(t *testing.T) {
+	t.Run("should be success", func(t *testing.T) {
+		//setup
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		loggerMock := mocks.NewMockLogger(ctrl)
+		validate := infra.NewValidator()
+
+		// given
+		got := NewPick(nil, loggerMock, validate)
+
+		// then
+		assert.NotNil(t, got)
+	})
+}
+
+func TestPickService_Create(t *testing.T) {
+	now := time.Date(2000, 12, 31, 23, 59, 59, 0, time.Local)
+	soon := now.Add(time.Hour)
+	later := now.Add(2 * time.Hour)
+
+	tests := map[string]struct {
+		mock    func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime)
+		data    dtos.CreatePickDto
+		want    *models.Pick
+		wantErr string
+	}{
+		"should be success": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {
+				mTime.EXPECT().Now().Return(now)
+
+				fruitRows := sqlmock.NewRows([]string{"id", "name", "expires_at", "price", "state", "bucket_fk"}).
+					AddRow(int64(3), "Orange", soon, "1.99", models.FruitStateRipe, int64(2)).
+					AddRow(int64(1), "Orange", later, "1.99", models.FruitStateRipe, int64(1))
+				bucketRows := sqlmock.NewRows([]string{"id", "name"}).
+					AddRow(int64(1), "A").
+					AddRow(int64(2), "B")
+
+				db.ExpectBegin()
+				db.ExpectQuery("SELECT (.+) FROM `fruits` WHERE name = (.+) ORDER BY expires_at, id LIMIT 2 FOR UPDATE").
+					WillReturnRows(fruitRows)
+				db.ExpectExec("UPDATE `fruits` SET `deleted_at`=(.+) WHERE id IN").
+					WillReturnResult(sqlmock.NewResult(0, 2))
+				db.ExpectExec("INSERT INTO `movements`").
+					WithArgs(now, int64(3), int64(2), nil, models.MovementTypePicked, nil,
+						now, int64(1), int64(1), nil, models.MovementTypePicked, nil).
+					WillReturnResult(sqlmock.NewResult(1, 2))
+				db.ExpectQuery("SELECT (.+) FROM `reorder_points`").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "name", "min_quantity"}).AddRow(int64(1), "Orange", 4))
+				db.ExpectQuery("SELECT name, COUNT(.+) FROM `fruits`").
+					WillReturnRows(sqlmock.NewRows([]string{"name", "quantity"}).AddRow("Orange", int64(3)))
+				db.ExpectExec("INSERT INTO `stock_alerts`").
+					WithArgs(now, "Orange", int64(3), 4, models.StockAlertReasonPicked).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				db.ExpectQuery("SELECT (.+) FROM `buckets` WHERE id IN").WillReturnRows(bucketRows)
+				db.ExpectCommit()
+			},
+			data: dtos.CreatePickDto{Name: "Orange", Quantity: 2},
+			want: &models.Pick{
+				Name:     "Orange",
+				Quantity: 2,
+				Items: []models.PickItem{
+					{FruitID: 3, BucketID: 2, BucketName: "B", ExpiresAt: soon, Price: decimal.RequireFromString("1.99")},
+					{FruitID: 1, BucketID: 1, BucketName: "A", ExpiresAt: later, Price: decimal.RequireFromString("1.99")},
+				},
+			},
+		},
+		"should throw error on validate when name and quantity are empty": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {},
+			data: dtos.CreatePickDto{},
+			wantErr: "Key: 'CreatePickDto.Name' Error:Field validation for 'Name' failed on the 'required' tag, " +
+				"Key: 'CreatePickDto.Quantity' Error:Field validation for 'Quantity' failed on the 'required' tag",
+		},
+		"should throw error when there are not enough fruits": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {
+				mTime.EXPECT().Now().Return(now)
+
+				fruitRows := sqlmock.NewRows([]string{"id", "expires_at", "price", "bucket_fk"}).
+					AddRow(int64(1), soon, "1.99", int64(1))
+
+				db.ExpectBegin()
+				db.ExpectQuery("SELECT (.+) FOR UPDATE").WillReturnRows(fruitRows)
+				db.ExpectRollback()
+
+				logger.EXPECT().Warn(gomock.Any())
+			},
+			data:    dtos.CreatePickDto{Name: "Orange", Quantity: 2},
+			wantErr: "Not enough Orange to pick: 1 available",
+		},
+		"should throw error on update": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {
+				mTime.EXPECT().Now().Return(now)
+
+				fruitRows := sqlmock.NewRows([]string{"id", "expires_at", "price", "bucket_fk"}).
+					AddRow(int64(1), soon, "1.99", int64(1))
+
+				db.ExpectBegin()
+				db.ExpectQuery("SELECT (.+) FOR UPDATE").WillReturnRows(fruitRows)
+				db.ExpectExec("UPDATE").WillReturnError(fmt.Errorf("error"))
+				db.ExpectRollback()
+
+				logger.EXPECT().Error(gomock.Any())
+			},
+			data:    dtos.CreatePickDto{Name: "Orange", Quantity: 1},
+			wantErr: "error",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			//setup
+			ctx := context.Background()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			db, sqlMock, err := sqlmock.New()
+			require.Nil(t, err)
+			defer db.Close()
+
+			dialector := mysql.New(mysql.Config{
+				DSN:                       "sqlmock_db_0",
+				DriverName:                "mysql",
+				Conn:                      db,
+				SkipInitializeWithVersion: true,
+			})
+
+			gormDB, err := gorm.Open(dialector, &gorm.Config{})
+			require.Nil(t, err)
+			database := &infra.Database{DB: gormDB, SQL: db}
+
+			loggerMock := mocks.NewMockLogger(ctrl)
+			validate := infra.NewValidator()
+			timeMock := mocks.NewMockTime(ctrl)
+			_time = timeMock
+
+			tt.mock(sqlMock, loggerMock, timeMock)
+
+			// given
+			service := NewPick(database, loggerMock, validate)
+
+			// when
+			got, err := service.Create(ctx, tt.data)
+
+			// then
+			assert.Equal(t, tt.want, got)
+			if err != nil || tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			}
+			assert.Nil(t, sqlMock.ExpectationsWereMet())
+		})
+	}
+}
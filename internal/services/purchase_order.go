@@ -0,0 +1,239 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/viniosilva/where-are-my-fruits/internal/dtos"
+	"github.com/viniosilva/where-are-my-fruits/internal/exceptions"
+	"github.com/viniosilva/where-are-my-fruits/internal/infra"
+	"github.com/viniosilva/where-are-my-fruits/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type PurchaseOrderService struct {
+	db            *infra.Database
+	logger        Logger
+	validate      Validate
+	compatibility models.CompatibilityRules
+}
+
+func NewPurchaseOrder(db *infra.Database, logger Logger, validate Validate, compatibility models.CompatibilityRules) *PurchaseOrderService {
+	return &PurchaseOrderService{
+		db:            db,
+		logger:        logger,
+		validate:      validate,
+		compatibility: compatibility,
+	}
+}
+
+func (impl *PurchaseOrderService) Create(ctx context.Context, data dtos.CreatePurchaseOrderDto) (*models.PurchaseOrder, error) {
+	if err := impl.validate.Struct(data); err != nil {
+		return nil, exceptions.NewValidationException(err)
+	}
+
+	order := models.PurchaseOrder{
+		CreatedAt:  _time.Now(),
+		SupplierID: data.SupplierID,
+		Lines:      []models.PurchaseOrderLine{},
+	}
+	for _, line := range data.Lines {
+		order.Lines = append(order.Lines, models.PurchaseOrderLine{
+			Name:     line.Name,
+			Price:    line.Price,
+			Quantity: line.Quantity,
+		})
+	}
+
+	err := impl.db.DB.Transaction(func(tx *gorm.DB) error {
+		var supplier models.Supplier
+		res := tx.Where("id = ? AND deleted_at IS NULL", data.SupplierID).First(&supplier)
+		if err := res.Error; err != nil {
+			if err.Error() == infra.MYSQL_ERROR_NOT_FOUND {
+				return exceptions.NewForeignNotFoundException("Supplier not found")
+			}
+
+			return err
+		}
+
+		return tx.Session(&gorm.Session{NewDB: true}).Create(&order).Error
+	}, &sql.TxOptions{Isolation: sql.LevelReadCommitted})
+
+	if err != nil {
+		impl.logError(err)
+		return nil, err
+	}
+
+	return &order, nil
+}
+
+func (impl *PurchaseOrderService) Get(ctx context.Context, id int64) (*models.PurchaseOrder, error) {
+	var order models.PurchaseOrder
+	res := impl.db.DB.Preload("Lines").Where("id = ?", id).First(&order)
+	if err := res.Error; err != nil {
+		if err.Error() == infra.MYSQL_ERROR_NOT_FOUND {
+			err := exceptions.NewNotFoundException("Purchase order not found")
+			impl.logger.Warn(err.Error())
+			return nil, err
+		}
+
+		impl.logger.Error(err.Error())
+		return nil, err
+	}
+
+	return &order, nil
+}
+
+// Receive turns the received quantity of each line into fruits of the
+// supplier, placed in the given buckets, and closes the purchase order. The
+// whole delivery is refused if any bucket is a quarantine one, has no room
+// for it or would hold fruits the compatibility rules keep apart
+func (impl *PurchaseOrderService) Receive(ctx context.Context, id int64, data dtos.ReceivePurchaseOrderDto) (*models.PurchaseOrder, error) {
+	if err := impl.validate.Struct(data); err != nil {
+		return nil, exceptions.NewValidationException(err)
+	}
+
+	now := _time.Now()
+
+	var order models.PurchaseOrder
+	err := impl.db.DB.Transaction(func(tx *gorm.DB) error {
+		res := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", id).First(&order)
+		if err := res.Error; err != nil {
+			if err.Error() == infra.MYSQL_ERROR_NOT_FOUND {
+				return exceptions.NewNotFoundException("Purchase order not found")
+			}
+
+			return err
+		}
+		if order.ReceivedAt != nil {
+			return exceptions.NewForbiddenException("Purchase order is already received")
+		}
+
+		order.Lines = make([]models.PurchaseOrderLine, 0)
+		res = tx.Session(&gorm.Session{NewDB: true}).Where("purchase_order_fk = ?", id).Order("id").Find(&order.Lines)
+		if err := res.Error; err != nil {
+			return err
+		}
+
+		names := map[int64]string{}
+		for _, line := range order.Lines {
+			names[line.ID] = line.Name
+		}
+
+		received := map[int64]dtos.ReceivePurchaseOrderLineDto{}
+		missing := []string{}
+		bucketIDs := []int64{}
+		bucketNeeds := map[int64]int64{}
+		bucketNames := map[int64][]string{}
+		for _, line := range data.Lines {
+			if _, ok := names[line.LineID]; !ok {
+				missing = append(missing, strconv.FormatInt(line.LineID, 10))
+				continue
+			}
+			received[line.LineID] = line
+
+			if line.BucketID != nil && line.Quantity > 0 {
+				if _, ok := bucketNeeds[*line.BucketID]; !ok {
+					bucketIDs = append(bucketIDs, *line.BucketID)
+				}
+				bucketNeeds[*line.BucketID] += int64(line.Quantity)
+				bucketNames[*line.BucketID] = append(bucketNames[*line.BucketID], names[line.LineID])
+			}
+		}
+		if len(missing) > 0 {
+			return exceptions.NewForeignNotFoundException(fmt.Sprintf("Purchase order lines not found: %s", strings.Join(missing, ", ")))
+		}
+
+		for _, bucketID := range bucketIDs {
+			bucket, free, err := bucketFreeCapacity(ctx, tx.Session(&gorm.Session{NewDB: true}).Clauses(clause.Locking{Strength: "UPDATE"}), bucketID)
+			if err != nil {
+				return err
+			}
+			if err := validateQuarantine(bucket, models.Fruit{}); err != nil {
+				return err
+			}
+			if free < bucketNeeds[bucketID] {
+				return exceptions.NewForbiddenException(fmt.Sprintf("Not enough room in bucket %d: %d free", bucketID, free))
+			}
+			if err := validateCompatibility(tx, impl.compatibility, bucketID, bucketNames[bucketID], now); err != nil {
+				return err
+			}
+		}
+
+		fruits := []models.Fruit{}
+		for i := range order.Lines {
+			line := &order.Lines[i]
+			item := received[line.ID]
+			quantity := item.Quantity
+			line.ReceivedQuantity = &quantity
+
+			for n := 0; n < quantity; n++ {
+				fruit := newFruit(dtos.CreateFruitDto{
+					Name:       line.Name,
+					Price:      line.Price,
+					ExpiresIn:  item.ExpiresIn,
+					BucketID:   item.BucketID,
+					SupplierID: &order.SupplierID,
+				}, now)
+				fruit.PurchaseOrderLineID = &line.ID
+				fruits = append(fruits, fruit)
+			}
+		}
+
+		if len(fruits) > 0 {
+			if err := tx.Session(&gorm.Session{NewDB: true}).Create(&fruits).Error; err != nil {
+				return err
+			}
+
+			prices := make([]models.FruitPrice, 0, len(fruits))
+			movements := make([]models.Movement, 0, len(fruits))
+			for _, fruit := range fruits {
+				prices = append(prices, *newFruitPrice(fruit))
+				movements = append(movements, newMovement(ctx, models.MovementTypeCreated, fruit.ID, nil, fruit.BucketID, now))
+			}
+			if err := tx.Session(&gorm.Session{NewDB: true}).Create(&prices).Error; err != nil {
+				return err
+			}
+			if err := recordMovements(tx, movements); err != nil {
+				return err
+			}
+		}
+
+		for _, line := range order.Lines {
+			res := tx.Session(&gorm.Session{NewDB: true}).Model(&models.PurchaseOrderLine{}).
+				Where("id = ?", line.ID).
+				Update("received_quantity", *line.ReceivedQuantity)
+			if err := res.Error; err != nil {
+				return err
+			}
+		}
+
+		order.ReceivedAt = &now
+		return tx.Session(&gorm.Session{NewDB: true}).Model(&models.PurchaseOrder{}).
+			Where("id = ?", id).
+			Update("received_at", now).Error
+	}, &sql.TxOptions{Isolation: sql.LevelReadCommitted})
+
+	if err != nil {
+		impl.logError(err)
+		return nil, err
+	}
+
+	return &order, nil
+}
+
+func (impl *PurchaseOrderService) logError(err error) {
+	if _, ok := err.(*exceptions.NotFoundException); ok {
+		impl.logger.Warn(err.Error())
+	} else if _, ok := err.(*exceptions.ForeignNotFoundException); ok {
+		impl.logger.Warn(err.Error())
+	} else if _, ok := err.(*exceptions.ForbiddenException); ok {
+		impl.logger.Warn(err.Error())
+	} else {
+		impl.logger.Error(err.Error())
+	}
+}
@@ -0,0 +1,487 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/golang/mock/gomock"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/viniosilva/where-are-my-fruits/internal/dtos"
+	"github.com/viniosilva/where-are-my-fruits/internal/infra"
+	"github.com/viniosilva/where-are-my-fruits/internal/models"
+	"github.com/viniosilva/where-are-my-fruits/mocks"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+func TestPurchaseOrderService_NewPurchaseOrder(t *testing.T) {
+	t.Run("should be success", func(t *testing.T) {
+		//setup
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		loggerMock := mocks.NewMockLogger(ctrl)
+		validate := infra.NewValidator()
+
+		// given
+		got := NewPurchaseOrder(nil, loggerMock, validate, nil)
+
+		// then
+		assert.NotNil(t, got)
+	})
+}
+
+func TestPurchaseOrderService_Create(t *testing.T) {
+	now := time.Date(2000, 12, 31, 23, 59, 59, 0, time.Local)
+
+	tests := map[string]struct {
+		mock    func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime)
+		data    dtos.CreatePurchaseOrderDto
+		want    *models.PurchaseOrder
+		wantErr string
+	}{
+		"should be success": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {
+				mTime.EXPECT().Now().Return(now)
+
+				db.ExpectBegin()
+				db.ExpectQuery("SELECT (.+) FROM `suppliers` WHERE (.+)").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(int64(1), "Testing"))
+				db.ExpectExec("INSERT INTO `purchase_orders`").WillReturnResult(sqlmock.NewResult(1, 1))
+				db.ExpectExec("INSERT INTO `purchase_order_lines`").WillReturnResult(sqlmock.NewResult(1, 2))
+				db.ExpectCommit()
+			},
+			data: dtos.CreatePurchaseOrderDto{
+				SupplierID: 1,
+				Lines: []dtos.CreatePurchaseOrderLineDto{
+					{Name: "Orange", Price: decimal.RequireFromString("1.99"), Quantity: 10},
+					{Name: "Apple", Price: decimal.RequireFromString("0.99"), Quantity: 5},
+				},
+			},
+			want: &models.PurchaseOrder{
+				ID:         1,
+				CreatedAt:  now,
+				SupplierID: 1,
+				Lines: []models.PurchaseOrderLine{
+					{ID: 1, PurchaseOrderID: 1, Name: "Orange", Price: decimal.RequireFromString("1.99"), Quantity: 10},
+					{ID: 2, PurchaseOrderID: 1, Name: "Apple", Price: decimal.RequireFromString("0.99"), Quantity: 5},
+				},
+			},
+		},
+		"should throw error on validate": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {},
+			data: dtos.CreatePurchaseOrderDto{
+				SupplierID: 1,
+				Lines:      []dtos.CreatePurchaseOrderLineDto{{Name: "Orange", Price: decimal.RequireFromString("1.99")}},
+			},
+			wantErr: "Key: 'CreatePurchaseOrderDto.Lines[0].Quantity' Error:Field validation for 'Quantity' failed on the 'required' tag",
+		},
+		"should throw error when supplier not found": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {
+				mTime.EXPECT().Now().Return(now)
+
+				db.ExpectBegin()
+				db.ExpectQuery("SELECT").WillReturnError(fmt.Errorf(infra.MYSQL_ERROR_NOT_FOUND))
+				db.ExpectRollback()
+
+				logger.EXPECT().Warn(gomock.Any())
+			},
+			data: dtos.CreatePurchaseOrderDto{
+				SupplierID: 1,
+				Lines:      []dtos.CreatePurchaseOrderLineDto{{Name: "Orange", Price: decimal.RequireFromString("1.99"), Quantity: 10}},
+			},
+			wantErr: "Supplier not found",
+		},
+		"should throw error on insert": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {
+				mTime.EXPECT().Now().Return(now)
+
+				db.ExpectBegin()
+				db.ExpectQuery("SELECT").WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(int64(1), "Testing"))
+				db.ExpectExec("INSERT").WillReturnError(fmt.Errorf("error"))
+				db.ExpectRollback()
+
+				logger.EXPECT().Error(gomock.Any())
+			},
+			data: dtos.CreatePurchaseOrderDto{
+				SupplierID: 1,
+				Lines:      []dtos.CreatePurchaseOrderLineDto{{Name: "Orange", Price: decimal.RequireFromString("1.99"), Quantity: 10}},
+			},
+			wantErr: "error",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			//setup
+			ctx := context.Background()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			db, sqlMock, err := sqlmock.New()
+			require.Nil(t, err)
+			defer db.Close()
+
+			dialector := mysql.New(mysql.Config{
+				DSN:                       "sqlmock_db_0",
+				DriverName:                "mysql",
+				Conn:                      db,
+				SkipInitializeWithVersion: true,
+			})
+
+			gormDB, err := gorm.Open(dialector, &gorm.Config{})
+			require.Nil(t, err)
+			database := &infra.Database{DB: gormDB, SQL: db}
+
+			loggerMock := mocks.NewMockLogger(ctrl)
+			validate := infra.NewValidator()
+			timeMock := mocks.NewMockTime(ctrl)
+			_time = timeMock
+
+			tt.mock(sqlMock, loggerMock, timeMock)
+
+			// given
+			service := NewPurchaseOrder(database, loggerMock, validate, nil)
+
+			// when
+			got, err := service.Create(ctx, tt.data)
+
+			// then
+			assert.Equal(t, tt.want, got)
+			if err != nil || tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			}
+			assert.Nil(t, sqlMock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestPurchaseOrderService_Get(t *testing.T) {
+	now := time.Date(2000, 12, 31, 23, 59, 59, 0, time.Local)
+
+	tests := map[string]struct {
+		mock    func(db sqlmock.Sqlmock, logger *mocks.MockLogger)
+		want    *models.PurchaseOrder
+		wantErr string
+	}{
+		"should be success": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger) {
+				orderRows := sqlmock.NewRows([]string{"id", "created_at", "received_at", "supplier_fk"}).
+					AddRow(int64(1), now, nil, int64(1))
+				lineRows := sqlmock.NewRows([]string{"id", "purchase_order_fk", "name", "price", "quantity", "received_quantity"}).
+					AddRow(int64(1), int64(1), "Orange", "1.99", 10, nil)
+
+				db.ExpectQuery("SELECT (.+) FROM `purchase_orders` WHERE id = (.+)").WillReturnRows(orderRows)
+				db.ExpectQuery("SELECT (.+) FROM `purchase_order_lines` WHERE `purchase_order_lines`.`purchase_order_fk` = (.+)").
+					WillReturnRows(lineRows)
+			},
+			want: &models.PurchaseOrder{
+				ID:         1,
+				CreatedAt:  now,
+				SupplierID: 1,
+				Lines: []models.PurchaseOrderLine{
+					{ID: 1, PurchaseOrderID: 1, Name: "Orange", Price: decimal.RequireFromString("1.99"), Quantity: 10},
+				},
+			},
+		},
+		"should throw error when purchase order not found": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger) {
+				db.ExpectQuery("SELECT").WillReturnError(fmt.Errorf(infra.MYSQL_ERROR_NOT_FOUND))
+				logger.EXPECT().Warn(gomock.Any())
+			},
+			wantErr: "Purchase order not found",
+		},
+		"should throw error": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger) {
+				db.ExpectQuery("SELECT").WillReturnError(fmt.Errorf("error"))
+				logger.EXPECT().Error(gomock.Any())
+			},
+			wantErr: "error",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			//setup
+			ctx := context.Background()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			db, sqlMock, err := sqlmock.New()
+			require.Nil(t, err)
+			defer db.Close()
+
+			dialector := mysql.New(mysql.Config{
+				DSN:                       "sqlmock_db_0",
+				DriverName:                "mysql",
+				Conn:                      db,
+				SkipInitializeWithVersion: true,
+			})
+
+			gormDB, err := gorm.Open(dialector, &gorm.Config{})
+			require.Nil(t, err)
+			database := &infra.Database{DB: gormDB, SQL: db}
+
+			loggerMock := mocks.NewMockLogger(ctrl)
+			validate := infra.NewValidator()
+
+			tt.mock(sqlMock, loggerMock)
+
+			// given
+			service := NewPurchaseOrder(database, loggerMock, validate, nil)
+
+			// when
+			got, err := service.Get(ctx, 1)
+
+			// then
+			assert.Equal(t, tt.want, got)
+			if err != nil || tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			}
+			assert.Nil(t, sqlMock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestPurchaseOrderService_Receive(t *testing.T) {
+	now := time.Date(2000, 12, 31, 23, 59, 59, 0, time.Local)
+	expiresIn := 168 * time.Hour
+	negativeExpiresIn := -time.Hour
+	bucketID := int64(1)
+	nine := 9
+	zero := 0
+
+	orderColumns := []string{"id", "created_at", "received_at", "supplier_fk"}
+	lineColumns := []string{"id", "purchase_order_fk", "name", "price", "quantity", "received_quantity"}
+
+	tests := map[string]struct {
+		mock    func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime)
+		rules   models.CompatibilityRules
+		data    dtos.ReceivePurchaseOrderDto
+		want    *models.PurchaseOrder
+		wantErr string
+	}{
+		"should be success": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {
+				mTime.EXPECT().Now().Return(now).Times(2)
+
+				orderRows := sqlmock.NewRows(orderColumns).AddRow(int64(1), now, nil, int64(1))
+				lineRows := sqlmock.NewRows(lineColumns).
+					AddRow(int64(1), int64(1), "Orange", "1.99", 10, nil).
+					AddRow(int64(2), int64(1), "Apple", "0.99", 5, nil)
+				bucketRows := sqlmock.NewRows([]string{"id", "name", "capacity"}).AddRow(int64(1), "A", 20)
+
+				db.ExpectBegin()
+				db.ExpectQuery("SELECT (.+) FROM `purchase_orders` WHERE id = (.+) FOR UPDATE").WillReturnRows(orderRows)
+				db.ExpectQuery("SELECT (.+) FROM `purchase_order_lines` WHERE purchase_order_fk = (.+) ORDER BY id").WillReturnRows(lineRows)
+				db.ExpectQuery("SELECT (.+) FROM `buckets` WHERE (.+) FOR UPDATE").WillReturnRows(bucketRows)
+				db.ExpectQuery("SELECT count(.+) FROM `fruits`").WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(int64(5)))
+				db.ExpectExec("INSERT INTO `fruits`").WillReturnResult(sqlmock.NewResult(1, 9))
+				db.ExpectExec("INSERT INTO `fruit_prices`").WillReturnResult(sqlmock.NewResult(1, 9))
+				db.ExpectExec("INSERT INTO `movements`").WillReturnResult(sqlmock.NewResult(1, 9))
+				db.ExpectExec("UPDATE `purchase_order_lines` SET `received_quantity`=(.+) WHERE id = (.+)").
+					WithArgs(9, int64(1)).WillReturnResult(sqlmock.NewResult(0, 1))
+				db.ExpectExec("UPDATE `purchase_order_lines` SET `received_quantity`=(.+) WHERE id = (.+)").
+					WithArgs(0, int64(2)).WillReturnResult(sqlmock.NewResult(0, 1))
+				db.ExpectExec("UPDATE `purchase_orders` SET `received_at`").WillReturnResult(sqlmock.NewResult(0, 1))
+				db.ExpectCommit()
+			},
+			data: dtos.ReceivePurchaseOrderDto{Lines: []dtos.ReceivePurchaseOrderLineDto{
+				{LineID: 1, Quantity: 9, ExpiresIn: &expiresIn, BucketID: &bucketID},
+			}},
+			want: &models.PurchaseOrder{
+				ID:         1,
+				CreatedAt:  now,
+				ReceivedAt: &now,
+				SupplierID: 1,
+				Lines: []models.PurchaseOrderLine{
+					{ID: 1, PurchaseOrderID: 1, Name: "Orange", Price: decimal.RequireFromString("1.99"), Quantity: 10, ReceivedQuantity: &nine},
+					{ID: 2, PurchaseOrderID: 1, Name: "Apple", Price: decimal.RequireFromString("0.99"), Quantity: 5, ReceivedQuantity: &zero},
+				},
+			},
+		},
+		"should throw error on validate when expires in is missing": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {},
+			data: dtos.ReceivePurchaseOrderDto{Lines: []dtos.ReceivePurchaseOrderLineDto{
+				{LineID: 1, Quantity: 9},
+			}},
+			wantErr: "Key: 'ReceivePurchaseOrderDto.Lines[0].ExpiresIn' Error:Field validation for 'ExpiresIn' failed on the 'required_unless' tag",
+		},
+		"should throw error on validate when expires in is not positive": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {},
+			data: dtos.ReceivePurchaseOrderDto{Lines: []dtos.ReceivePurchaseOrderLineDto{
+				{LineID: 1, Quantity: 9, ExpiresIn: &negativeExpiresIn},
+			}},
+			wantErr: "Key: 'ReceivePurchaseOrderDto.Lines[0].ExpiresIn' Error:Field validation for 'ExpiresIn' failed on the 'gt' tag",
+		},
+		"should throw error when purchase order not found": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {
+				mTime.EXPECT().Now().Return(now)
+
+				db.ExpectBegin()
+				db.ExpectQuery("SELECT").WillReturnError(fmt.Errorf(infra.MYSQL_ERROR_NOT_FOUND))
+				db.ExpectRollback()
+
+				logger.EXPECT().Warn(gomock.Any())
+			},
+			data:    dtos.ReceivePurchaseOrderDto{Lines: []dtos.ReceivePurchaseOrderLineDto{{LineID: 1}}},
+			wantErr: "Purchase order not found",
+		},
+		"should throw error when purchase order is received": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {
+				mTime.EXPECT().Now().Return(now)
+
+				db.ExpectBegin()
+				db.ExpectQuery("SELECT").WillReturnRows(sqlmock.NewRows(orderColumns).AddRow(int64(1), now, now, int64(1)))
+				db.ExpectRollback()
+
+				logger.EXPECT().Warn(gomock.Any())
+			},
+			data:    dtos.ReceivePurchaseOrderDto{Lines: []dtos.ReceivePurchaseOrderLineDto{{LineID: 1}}},
+			wantErr: "Purchase order is already received",
+		},
+		"should throw error when line not found": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {
+				mTime.EXPECT().Now().Return(now)
+
+				db.ExpectBegin()
+				db.ExpectQuery("SELECT").WillReturnRows(sqlmock.NewRows(orderColumns).AddRow(int64(1), now, nil, int64(1)))
+				db.ExpectQuery("SELECT").WillReturnRows(sqlmock.NewRows(lineColumns).AddRow(int64(1), int64(1), "Orange", "1.99", 10, nil))
+				db.ExpectRollback()
+
+				logger.EXPECT().Warn(gomock.Any())
+			},
+			data:    dtos.ReceivePurchaseOrderDto{Lines: []dtos.ReceivePurchaseOrderLineDto{{LineID: 1}, {LineID: 3}}},
+			wantErr: "Purchase order lines not found: 3",
+		},
+		"should throw error when bucket has no room": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {
+				mTime.EXPECT().Now().Return(now).Times(2)
+
+				db.ExpectBegin()
+				db.ExpectQuery("SELECT").WillReturnRows(sqlmock.NewRows(orderColumns).AddRow(int64(1), now, nil, int64(1)))
+				db.ExpectQuery("SELECT").WillReturnRows(sqlmock.NewRows(lineColumns).AddRow(int64(1), int64(1), "Orange", "1.99", 10, nil))
+				db.ExpectQuery("SELECT").WillReturnRows(sqlmock.NewRows([]string{"id", "name", "capacity"}).AddRow(int64(1), "A", 10))
+				db.ExpectQuery("SELECT").WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(int64(5)))
+				db.ExpectRollback()
+
+				logger.EXPECT().Warn(gomock.Any())
+			},
+			data: dtos.ReceivePurchaseOrderDto{Lines: []dtos.ReceivePurchaseOrderLineDto{
+				{LineID: 1, Quantity: 9, ExpiresIn: &expiresIn, BucketID: &bucketID},
+			}},
+			wantErr: "Not enough room in bucket 1: 5 free",
+		},
+		"should throw error when bucket is a quarantine one": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {
+				mTime.EXPECT().Now().Return(now).Times(2)
+
+				db.ExpectBegin()
+				db.ExpectQuery("SELECT").WillReturnRows(sqlmock.NewRows(orderColumns).AddRow(int64(1), now, nil, int64(1)))
+				db.ExpectQuery("SELECT").WillReturnRows(sqlmock.NewRows(lineColumns).AddRow(int64(1), int64(1), "Orange", "1.99", 10, nil))
+				db.ExpectQuery("SELECT").WillReturnRows(sqlmock.NewRows([]string{"id", "name", "capacity", "quarantine"}).AddRow(int64(1), "A", 20, true))
+				db.ExpectQuery("SELECT").WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(int64(0)))
+				db.ExpectRollback()
+
+				logger.EXPECT().Warn(gomock.Any())
+			},
+			data: dtos.ReceivePurchaseOrderDto{Lines: []dtos.ReceivePurchaseOrderLineDto{
+				{LineID: 1, Quantity: 9, ExpiresIn: &expiresIn, BucketID: &bucketID},
+			}},
+			wantErr: "Quarantine bucket only holds quarantined fruits",
+		},
+		"should throw error when lines in the same bucket are incompatible": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {
+				mTime.EXPECT().Now().Return(now).Times(2)
+
+				db.ExpectBegin()
+				db.ExpectQuery("SELECT").WillReturnRows(sqlmock.NewRows(orderColumns).AddRow(int64(1), now, nil, int64(1)))
+				db.ExpectQuery("SELECT").WillReturnRows(sqlmock.NewRows(lineColumns).
+					AddRow(int64(1), int64(1), "Orange", "1.99", 10, nil).
+					AddRow(int64(2), int64(1), "Apple", "0.99", 5, nil))
+				db.ExpectQuery("SELECT").WillReturnRows(sqlmock.NewRows([]string{"id", "name", "capacity"}).AddRow(int64(1), "A", 20))
+				db.ExpectQuery("SELECT").WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(int64(0)))
+				db.ExpectRollback()
+
+				logger.EXPECT().Warn(gomock.Any())
+			},
+			rules: models.CompatibilityRules{
+				{
+					A: models.CompatibilityGroup{Name: "ethylene_producers", Fruits: []string{"apple"}},
+					B: models.CompatibilityGroup{Name: "ethylene_sensitive", Fruits: []string{"orange"}},
+				},
+			},
+			data: dtos.ReceivePurchaseOrderDto{Lines: []dtos.ReceivePurchaseOrderLineDto{
+				{LineID: 1, Quantity: 9, ExpiresIn: &expiresIn, BucketID: &bucketID},
+				{LineID: 2, Quantity: 5, ExpiresIn: &expiresIn, BucketID: &bucketID},
+			}},
+			wantErr: "Fruit Orange cannot share a bucket with Apple",
+		},
+		"should throw error on insert": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {
+				mTime.EXPECT().Now().Return(now)
+
+				db.ExpectBegin()
+				db.ExpectQuery("SELECT").WillReturnRows(sqlmock.NewRows(orderColumns).AddRow(int64(1), now, nil, int64(1)))
+				db.ExpectQuery("SELECT").WillReturnRows(sqlmock.NewRows(lineColumns).AddRow(int64(1), int64(1), "Orange", "1.99", 10, nil))
+				db.ExpectExec("INSERT").WillReturnError(fmt.Errorf("error"))
+				db.ExpectRollback()
+
+				logger.EXPECT().Error(gomock.Any())
+			},
+			data: dtos.ReceivePurchaseOrderDto{Lines: []dtos.ReceivePurchaseOrderLineDto{
+				{LineID: 1, Quantity: 9, ExpiresIn: &expiresIn},
+			}},
+			wantErr: "error",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			//setup
+			ctx := context.Background()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			db, sqlMock, err := sqlmock.New()
+			require.Nil(t, err)
+			defer db.Close()
+
+			dialector := mysql.New(mysql.Config{
+				DSN:                       "sqlmock_db_0",
+				DriverName:                "mysql",
+				Conn:                      db,
+				SkipInitializeWithVersion: true,
+			})
+
+			gormDB, err := gorm.Open(dialector, &gorm.Config{})
+			require.Nil(t, err)
+			database := &infra.Database{DB: gormDB, SQL: db}
+
+			loggerMock := mocks.NewMockLogger(ctrl)
+			validate := infra.NewValidator()
+			timeMock := mocks.NewMockTime(ctrl)
+			_time = timeMock
+
+			tt.mock(sqlMock, loggerMock, timeMock)
+
+			// given
+			service := NewPurchaseOrder(database, loggerMock, validate, tt.rules)
+
+			// when
+			got, err := service.Receive(ctx, 1, tt.data)
+
+			// then
+			assert.Equal(t, tt.want, got)
+			if err != nil || tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			}
+			assert.Nil(t, sqlMock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,323 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/viniosilva/where-are-my-fruits/internal/dtos"
+	"github.com/viniosilva/where-are-my-fruits/internal/exceptions"
+	"github.com/viniosilva/where-are-my-fruits/internal/infra"
+	"github.com/viniosilva/where-are-my-fruits/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ReservationService struct {
+	db       *infra.Database
+	logger   Logger
+	validate Validate
+}
+
+func NewReservation(db *infra.Database, logger Logger, validate Validate) *ReservationService {
+	return &ReservationService{
+		db:       db,
+		logger:   logger,
+		validate: validate,
+	}
+}
+
+// Create holds the given fruits, or the requested quantity of a fruit picked
+// first-expired-first-out, until the hold lapses
+func (impl *ReservationService) Create(ctx context.Context, data dtos.CreateReservationDto) (*models.Reservation, error) {
+	if err := impl.validate.Struct(data); err != nil {
+		return nil, exceptions.NewValidationException(err)
+	}
+
+	now := _time.Now()
+	reservation := models.Reservation{
+		CreatedAt: now,
+		ExpiresAt: now.Add(data.HoldFor),
+		Holder:    data.Holder,
+		FruitIDs:  []int64{},
+	}
+
+	err := impl.db.DB.Transaction(func(tx *gorm.DB) error {
+		var fruitIDs []int64
+		var err error
+		if len(data.FruitIDs) > 0 {
+			fruitIDs, err = impl.holdFruits(ctx, tx, data.FruitIDs, now)
+		} else {
+			fruitIDs, err = impl.holdQuantity(ctx, tx, *data.Name, data.Quantity, now)
+		}
+		if err != nil {
+			return err
+		}
+
+		if err := tx.Session(&gorm.Session{NewDB: true}).Create(&reservation).Error; err != nil {
+			return err
+		}
+		reservation.FruitIDs = fruitIDs
+
+		return tx.Session(&gorm.Session{NewDB: true}).Model(&models.Fruit{}).
+			Where("id IN ?", fruitIDs).
+			Updates(map[string]interface{}{"reservation_fk": reservation.ID, "reserved_until": reservation.ExpiresAt}).Error
+	}, &sql.TxOptions{Isolation: sql.LevelReadCommitted})
+
+	if err != nil {
+		impl.logError(err)
+		return nil, err
+	}
+
+	return &reservation, nil
+}
+
+func (impl *ReservationService) Get(ctx context.Context, id int64) (*models.Reservation, error) {
+	var reservation models.Reservation
+	res := impl.db.DB.Where("id = ?", id).First(&reservation)
+	if err := res.Error; err != nil {
+		if err.Error() == infra.MYSQL_ERROR_NOT_FOUND {
+			err := exceptions.NewNotFoundException("Reservation not found")
+			impl.logger.Warn(err.Error())
+			return nil, err
+		}
+
+		impl.logger.Error(err.Error())
+		return nil, err
+	}
+
+	reservation.FruitIDs = make([]int64, 0)
+	res = impl.db.DB.Model(&models.Fruit{}).
+		Where("reservation_fk = ?", id).
+		Order("id").
+		Pluck("id", &reservation.FruitIDs)
+	if err := res.Error; err != nil {
+		impl.logger.Error(err.Error())
+		return nil, err
+	}
+
+	return &reservation, nil
+}
+
+// Confirm sells the held fruits to the holder through a checked out order
+func (impl *ReservationService) Confirm(ctx context.Context, id int64) (*models.Reservation, error) {
+	now := _time.Now()
+
+	var reservation models.Reservation
+	err := impl.db.DB.Transaction(func(tx *gorm.DB) error {
+		if err := impl.lockActive(ctx, tx, id, now, &reservation); err != nil {
+			return err
+		}
+
+		fruits := make([]models.Fruit, 0)
+		res := tx.Session(&gorm.Session{NewDB: true}).Clauses(clause.Locking{Strength: "UPDATE"}).
+			Select("id", "name", "price", "expires_at", "state", "quarantined_at").
+			Where("reservation_fk = ? AND deleted_at IS NULL", id).
+			Order("id").
+			Find(&fruits)
+		if err := res.Error; err != nil {
+			return err
+		}
+
+		order := models.Order{CreatedAt: now, CheckedOutAt: &now, Total: decimal.Zero, Items: []models.OrderItem{}}
+		for _, fruit := range fruits {
+			if !fruit.State.CanTransitionTo(models.FruitStateSold) || !fruit.ExpiresAt.After(now) || fruit.IsQuarantined() {
+				return exceptions.NewForbiddenException(fmt.Sprintf("Fruit %d is not available", fruit.ID))
+			}
+
+			order.Items = append(order.Items, models.OrderItem{FruitID: fruit.ID, Name: fruit.Name, Price: fruit.Price})
+			order.Total = order.Total.Add(fruit.Price)
+			reservation.FruitIDs = append(reservation.FruitIDs, fruit.ID)
+		}
+		if len(fruits) == 0 {
+			return exceptions.NewForbiddenException("Reservation has no fruits left")
+		}
+
+		if err := tx.Session(&gorm.Session{NewDB: true}).Create(&order).Error; err != nil {
+			return err
+		}
+		if err := sellFruits(tx, fruits, now); err != nil {
+			return err
+		}
+
+		reservation.ConfirmedAt = &now
+		reservation.OrderID = &order.ID
+
+		return tx.Session(&gorm.Session{NewDB: true}).Model(&models.Reservation{}).
+			Where("id = ?", id).
+			Updates(map[string]interface{}{"confirmed_at": now, "order_fk": order.ID}).Error
+	}, &sql.TxOptions{Isolation: sql.LevelReadCommitted})
+
+	if err != nil {
+		impl.logError(err)
+		return nil, err
+	}
+
+	return &reservation, nil
+}
+
+// Release ends an active hold before it lapses
+func (impl *ReservationService) Release(ctx context.Context, id int64) error {
+	now := _time.Now()
+
+	err := impl.db.DB.Transaction(func(tx *gorm.DB) error {
+		var reservation models.Reservation
+		if err := impl.lockActive(ctx, tx, id, now, &reservation); err != nil {
+			return err
+		}
+
+		return impl.release(tx, []int64{id}, now)
+	}, &sql.TxOptions{Isolation: sql.LevelReadCommitted})
+
+	if err != nil {
+		impl.logError(err)
+		return err
+	}
+
+	return nil
+}
+
+// ReleaseLapsed closes every reservation whose hold has lapsed. The fruits are
+// free again as soon as the hold lapses, this only tidies up the records
+func (impl *ReservationService) ReleaseLapsed(ctx context.Context) (int64, error) {
+	now := _time.Now()
+	released := int64(0)
+
+	err := impl.db.DB.Transaction(func(tx *gorm.DB) error {
+		ids := make([]int64, 0)
+		res := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Model(&models.Reservation{}).
+			Where("confirmed_at IS NULL AND released_at IS NULL AND expires_at <= ?", now).
+			Pluck("id", &ids)
+		if err := res.Error; err != nil {
+			return err
+		}
+		if len(ids) == 0 {
+			return nil
+		}
+
+		released = int64(len(ids))
+		return impl.release(tx, ids, now)
+	}, &sql.TxOptions{Isolation: sql.LevelReadCommitted})
+
+	if err != nil {
+		impl.logger.Error(err.Error())
+		return 0, err
+	}
+
+	return released, nil
+}
+
+// lockActive locks the reservation, which must be neither confirmed, released nor lapsed
+func (impl *ReservationService) lockActive(ctx context.Context, tx *gorm.DB, id int64, now time.Time, reservation *models.Reservation) error {
+	res := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", id).First(reservation)
+	if err := res.Error; err != nil {
+		if err.Error() == infra.MYSQL_ERROR_NOT_FOUND {
+			return exceptions.NewNotFoundException("Reservation not found")
+		}
+
+		return err
+	}
+
+	if status := reservation.Status(now); status != models.ReservationStatusActive {
+		return exceptions.NewForbiddenException(fmt.Sprintf("Reservation is %s", status))
+	}
+
+	reservation.FruitIDs = make([]int64, 0)
+	return nil
+}
+
+func (impl *ReservationService) release(tx *gorm.DB, ids []int64, now time.Time) error {
+	res := tx.Session(&gorm.Session{NewDB: true}).Model(&models.Fruit{}).
+		Where("reservation_fk IN ?", ids).
+		Update("reserved_until", nil)
+	if err := res.Error; err != nil {
+		return err
+	}
+
+	return tx.Session(&gorm.Session{NewDB: true}).Model(&models.Reservation{}).
+		Where("id IN ?", ids).
+		Update("released_at", now).Error
+}
+
+// holdFruits locks the given fruits, which must all exist and be free to hold
+func (impl *ReservationService) holdFruits(ctx context.Context, tx *gorm.DB, fruitIDs []int64, now time.Time) ([]int64, error) {
+	fruits := make([]models.Fruit, 0)
+	res := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Select("id", "expires_at", "state", "reserved_until", "quarantined_at").
+		Where("id IN ? AND deleted_at IS NULL", fruitIDs).
+		Find(&fruits)
+	if err := res.Error; err != nil {
+		return nil, err
+	}
+
+	found := map[int64]models.Fruit{}
+	for _, fruit := range fruits {
+		found[fruit.ID] = fruit
+	}
+
+	missing := []string{}
+	for _, id := range fruitIDs {
+		fruit, ok := found[id]
+		if !ok {
+			missing = append(missing, strconv.FormatInt(id, 10))
+			continue
+		}
+		if !fruit.State.IsActive() || !fruit.ExpiresAt.After(now) {
+			return nil, exceptions.NewForbiddenException(fmt.Sprintf("Fruit %d is not available", id))
+		}
+		if fruit.IsReserved(now) {
+			return nil, exceptions.NewForbiddenException(fmt.Sprintf("Fruit %d is reserved", id))
+		}
+		if fruit.IsQuarantined() {
+			return nil, exceptions.NewForbiddenException(fmt.Sprintf("Fruit %d is quarantined", id))
+		}
+	}
+	if len(missing) > 0 {
+		return nil, exceptions.NewForeignNotFoundException(fmt.Sprintf("Fruits not found: %s", strings.Join(missing, ", ")))
+	}
+
+	return fruitIDs, nil
+}
+
+// holdQuantity locks the first expiring fruits of the given name free to hold
+func (impl *ReservationService) holdQuantity(ctx context.Context, tx *gorm.DB, name string, quantity int, now time.Time) ([]int64, error) {
+	fruitIDs := make([]int64, 0)
+	res := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Model(&models.Fruit{}).
+		Where(`name = ?
+			AND deleted_at IS NULL
+			AND expires_at > ?
+			AND state IN ?
+			AND (reserved_until IS NULL OR reserved_until <= ?)
+			AND quarantined_at IS NULL
+		`, name, now, models.FruitActiveStates, now).
+		Order("expires_at, id").
+		Limit(quantity).
+		Pluck("id", &fruitIDs)
+	if err := res.Error; err != nil {
+		return nil, err
+	}
+
+	if len(fruitIDs) < quantity {
+		return nil, exceptions.NewForbiddenException(fmt.Sprintf("Not enough %s to reserve: %d available", name, len(fruitIDs)))
+	}
+
+	return fruitIDs, nil
+}
+
+func (impl *ReservationService) logError(err error) {
+	if _, ok := err.(*exceptions.NotFoundException); ok {
+		impl.logger.Warn(err.Error())
+	} else if _, ok := err.(*exceptions.ForeignNotFoundException); ok {
+		impl.logger.Warn(err.Error())
+	} else if _, ok := err.(*exceptions.ForbiddenException); ok {
+		impl.logger.Warn(err.Error())
+	} else {
+		impl.logger.Error(err.Error())
+	}
+}
@@ -0,0 +1,700 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/viniosilva/where-are-my-fruits/internal/dtos"
+	"github.com/viniosilva/where-are-my-fruits/internal/infra"
+	"github.com/viniosilva/where-are-my-fruits/internal/models"
+	"github.com/viniosilva/where-are-my-fruits/mocks"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+func TestReservationService_NewReservation(t *testing.T) {
+	t.Run("should be success", func(t *testing.T) {
+		//setup
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		loggerMock := mocks.NewMockLogger(ctrl)
+		validate := infra.NewValidator()
+
+		// given
+		got := NewReservation(nil, loggerMock, validate)
+
+		// then
+		assert.NotNil(t, got)
+	})
+}
+
+func TestReservationService_Create(t *testing.T) {
+	now := time.Date(2000, 12, 31, 23, 44, 59, 0, time.Local)
+	expiresAt := now.Add(time.Hour)
+	holdUntil := now.Add(15 * time.Minute)
+	name := "Orange"
+
+	tests := map[string]struct {
+		mock    func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime)
+		data    dtos.CreateReservationDto
+		want    *models.Reservation
+		wantErr string
+	}{
+		"should be success when fruits are given": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {
+				mTime.EXPECT().Now().Return(now)
+
+				fruitRows := sqlmock.NewRows([]string{"id", "expires_at", "state", "reserved_until"}).
+					AddRow(int64(1), expiresAt, models.FruitStateRipe, nil).
+					AddRow(int64(2), expiresAt, models.FruitStateUnripe, now)
+
+				db.ExpectBegin()
+				db.ExpectQuery("SELECT (.+) FROM `fruits` WHERE (.+) FOR UPDATE").WillReturnRows(fruitRows)
+				db.ExpectExec("INSERT INTO `reservations`").WillReturnResult(sqlmock.NewResult(1, 1))
+				db.ExpectExec("UPDATE `fruits` SET `reservation_fk`=(.+),`reserved_until`=(.+) WHERE id IN").
+					WillReturnResult(sqlmock.NewResult(0, 2))
+				db.ExpectCommit()
+			},
+			data: dtos.CreateReservationDto{Holder: "Store 1", HoldFor: 15 * time.Minute, FruitIDs: []int64{2, 1}},
+			want: &models.Reservation{
+				ID:        1,
+				CreatedAt: now,
+				ExpiresAt: holdUntil,
+				Holder:    "Store 1",
+				FruitIDs:  []int64{2, 1},
+			},
+		},
+		"should be success when quantity is given": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {
+				mTime.EXPECT().Now().Return(now)
+
+				fruitRows := sqlmock.NewRows([]string{"id"}).AddRow(int64(3)).AddRow(int64(1))
+
+				db.ExpectBegin()
+				db.ExpectQuery("SELECT `id` FROM `fruits` WHERE (.+) ORDER BY expires_at, id LIMIT 2 FOR UPDATE").
+					WillReturnRows(fruitRows)
+				db.ExpectExec("INSERT INTO `reservations`").WillReturnResult(sqlmock.NewResult(1, 1))
+				db.ExpectExec("UPDATE `fruits`").WillReturnResult(sqlmock.NewResult(0, 2))
+				db.ExpectCommit()
+			},
+			data: dtos.CreateReservationDto{Holder: "Store 1", HoldFor: 15 * time.Minute, Name: &name, Quantity: 2},
+			want: &models.Reservation{
+				ID:        1,
+				CreatedAt: now,
+				ExpiresAt: holdUntil,
+				Holder:    "Store 1",
+				FruitIDs:  []int64{3, 1},
+			},
+		},
+		"should throw error on validate when fruits and name are given": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {},
+			data: dtos.CreateReservationDto{Holder: "Store 1", HoldFor: time.Minute, FruitIDs: []int64{1}, Name: &name, Quantity: 1},
+			wantErr: "Key: 'CreateReservationDto.Name' Error:Field validation for 'Name' failed on the 'excluded_with' tag, " +
+				"Key: 'CreateReservationDto.Quantity' Error:Field validation for 'Quantity' failed on the 'excluded_with' tag",
+		},
+		"should throw error on validate when hold is too long": {
+			mock:    func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {},
+			data:    dtos.CreateReservationDto{Holder: "Store 1", HoldFor: 25 * time.Hour, FruitIDs: []int64{1}},
+			wantErr: "Key: 'CreateReservationDto.HoldFor' Error:Field validation for 'HoldFor' failed on the 'lte' tag",
+		},
+		"should throw error when fruit not found": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {
+				mTime.EXPECT().Now().Return(now)
+
+				fruitRows := sqlmock.NewRows([]string{"id", "expires_at", "state", "reserved_until"}).
+					AddRow(int64(1), expiresAt, models.FruitStateRipe, nil)
+
+				db.ExpectBegin()
+				db.ExpectQuery("SELECT").WillReturnRows(fruitRows)
+				db.ExpectRollback()
+
+				logger.EXPECT().Warn(gomock.Any())
+			},
+			data:    dtos.CreateReservationDto{Holder: "Store 1", HoldFor: time.Minute, FruitIDs: []int64{1, 2}},
+			wantErr: "Fruits not found: 2",
+		},
+		"should throw error when fruit is expired": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {
+				mTime.EXPECT().Now().Return(now)
+
+				fruitRows := sqlmock.NewRows([]string{"id", "expires_at", "state", "reserved_until"}).
+					AddRow(int64(1), now, models.FruitStateOverripe, nil)
+
+				db.ExpectBegin()
+				db.ExpectQuery("SELECT").WillReturnRows(fruitRows)
+				db.ExpectRollback()
+
+				logger.EXPECT().Warn(gomock.Any())
+			},
+			data:    dtos.CreateReservationDto{Holder: "Store 1", HoldFor: time.Minute, FruitIDs: []int64{1}},
+			wantErr: "Fruit 1 is not available",
+		},
+		"should throw error when fruit is reserved": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {
+				mTime.EXPECT().Now().Return(now)
+
+				fruitRows := sqlmock.NewRows([]string{"id", "expires_at", "state", "reserved_until"}).
+					AddRow(int64(1), expiresAt, models.FruitStateRipe, holdUntil)
+
+				db.ExpectBegin()
+				db.ExpectQuery("SELECT").WillReturnRows(fruitRows)
+				db.ExpectRollback()
+
+				logger.EXPECT().Warn(gomock.Any())
+			},
+			data:    dtos.CreateReservationDto{Holder: "Store 1", HoldFor: time.Minute, FruitIDs: []int64{1}},
+			wantErr: "Fruit 1 is reserved",
+		},
+		"should throw error when quantity is not available": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {
+				mTime.EXPECT().Now().Return(now)
+
+				fruitRows := sqlmock.NewRows([]string{"id"}).AddRow(int64(1))
+
+				db.ExpectBegin()
+				db.ExpectQuery("SELECT").WillReturnRows(fruitRows)
+				db.ExpectRollback()
+
+				logger.EXPECT().Warn(gomock.Any())
+			},
+			data:    dtos.CreateReservationDto{Holder: "Store 1", HoldFor: time.Minute, Name: &name, Quantity: 2},
+			wantErr: "Not enough Orange to reserve: 1 available",
+		},
+		"should throw error on insert": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {
+				mTime.EXPECT().Now().Return(now)
+
+				fruitRows := sqlmock.NewRows([]string{"id"}).AddRow(int64(1))
+
+				db.ExpectBegin()
+				db.ExpectQuery("SELECT").WillReturnRows(fruitRows)
+				db.ExpectExec("INSERT").WillReturnError(fmt.Errorf("error"))
+				db.ExpectRollback()
+
+				logger.EXPECT().Error(gomock.Any())
+			},
+			data:    dtos.CreateReservationDto{Holder: "Store 1", HoldFor: time.Minute, Name: &name, Quantity: 1},
+			wantErr: "error",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			//setup
+			ctx := context.Background()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			db, sqlMock, err := sqlmock.New()
+			require.Nil(t, err)
+			defer db.Close()
+
+			dialector := mysql.New(mysql.Config{
+				DSN:                       "sqlmock_db_0",
+				DriverName:                "mysql",
+				Conn:                      db,
+				SkipInitializeWithVersion: true,
+			})
+
+			gormDB, err := gorm.Open(dialector, &gorm.Config{})
+			require.Nil(t, err)
+			database := &infra.Database{DB: gormDB, SQL: db}
+
+			loggerMock := mocks.NewMockLogger(ctrl)
+			validate := infra.NewValidator()
+			timeMock := mocks.NewMockTime(ctrl)
+			_time = timeMock
+
+			tt.mock(sqlMock, loggerMock, timeMock)
+
+			// given
+			service := NewReservation(database, loggerMock, validate)
+
+			// when
+			got, err := service.Create(ctx, tt.data)
+
+			// then
+			assert.Equal(t, tt.want, got)
+			if err != nil || tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			}
+			assert.Nil(t, sqlMock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestReservationService_Get(t *testing.T) {
+	now := time.Date(2000, 12, 31, 23, 44, 59, 0, time.Local)
+	holdUntil := now.Add(15 * time.Minute)
+
+	tests := map[string]struct {
+		mock    func(db sqlmock.Sqlmock, logger *mocks.MockLogger)
+		want    *models.Reservation
+		wantErr string
+	}{
+		"should be success": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger) {
+				reservationRows := sqlmock.NewRows([]string{"id", "created_at", "expires_at", "confirmed_at", "released_at", "holder", "order_fk"}).
+					AddRow(int64(1), now, holdUntil, nil, nil, "Store 1", nil)
+				fruitRows := sqlmock.NewRows([]string{"id"}).AddRow(int64(1)).AddRow(int64(2))
+
+				db.ExpectQuery("SELECT (.+) FROM `reservations` WHERE id = (.+)").WillReturnRows(reservationRows)
+				db.ExpectQuery("SELECT `id` FROM `fruits` WHERE reservation_fk = (.+) ORDER BY id").WillReturnRows(fruitRows)
+			},
+			want: &models.Reservation{
+				ID:        1,
+				CreatedAt: now,
+				ExpiresAt: holdUntil,
+				Holder:    "Store 1",
+				FruitIDs:  []int64{1, 2},
+			},
+		},
+		"should throw error when reservation not found": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger) {
+				db.ExpectQuery("SELECT").WillReturnError(fmt.Errorf(infra.MYSQL_ERROR_NOT_FOUND))
+				logger.EXPECT().Warn(gomock.Any())
+			},
+			wantErr: "Reservation not found",
+		},
+		"should throw error on select fruits": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger) {
+				reservationRows := sqlmock.NewRows([]string{"id", "created_at", "expires_at", "confirmed_at", "released_at", "holder", "order_fk"}).
+					AddRow(int64(1), now, holdUntil, nil, nil, "Store 1", nil)
+
+				db.ExpectQuery("SELECT").WillReturnRows(reservationRows)
+				db.ExpectQuery("SELECT").WillReturnError(fmt.Errorf("error"))
+				logger.EXPECT().Error(gomock.Any())
+			},
+			wantErr: "error",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			//setup
+			ctx := context.Background()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			db, sqlMock, err := sqlmock.New()
+			require.Nil(t, err)
+			defer db.Close()
+
+			dialector := mysql.New(mysql.Config{
+				DSN:                       "sqlmock_db_0",
+				DriverName:                "mysql",
+				Conn:                      db,
+				SkipInitializeWithVersion: true,
+			})
+
+			gormDB, err := gorm.Open(dialector, &gorm.Config{})
+			require.Nil(t, err)
+			database := &infra.Database{DB: gormDB, SQL: db}
+
+			loggerMock := mocks.NewMockLogger(ctrl)
+			validate := infra.NewValidator()
+
+			tt.mock(sqlMock, loggerMock)
+
+			// given
+			service := NewReservation(database, loggerMock, validate)
+
+			// when
+			got, err := service.Get(ctx, 1)
+
+			// then
+			assert.Equal(t, tt.want, got)
+			if err != nil || tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			}
+			assert.Nil(t, sqlMock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestReservationService_Confirm(t *testing.T) {
+	now := time.Date(2000, 12, 31, 23, 50, 59, 0, time.Local)
+	createdAt := time.Date(2000, 12, 31, 23, 44, 59, 0, time.Local)
+	holdUntil := createdAt.Add(15 * time.Minute)
+	expiresAt := now.Add(time.Hour)
+	orderID := int64(1)
+
+	tests := map[string]struct {
+		mock    func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime)
+		want    *models.Reservation
+		wantErr string
+	}{
+		"should be success": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {
+				mTime.EXPECT().Now().Return(now)
+
+				reservationRows := sqlmock.NewRows([]string{"id", "created_at", "expires_at", "confirmed_at", "released_at", "holder", "order_fk"}).
+					AddRow(int64(1), createdAt, holdUntil, nil, nil, "Store 1", nil)
+				fruitRows := sqlmock.NewRows([]string{"id", "name", "price", "expires_at", "state"}).
+					AddRow(int64(1), "Orange", "1.99", expiresAt, models.FruitStateRipe)
+
+				db.ExpectBegin()
+				db.ExpectQuery("SELECT (.+) FROM `reservations` WHERE id = (.+) FOR UPDATE").WillReturnRows(reservationRows)
+				db.ExpectQuery("SELECT (.+) FROM `fruits` WHERE reservation_fk = (.+) FOR UPDATE").WillReturnRows(fruitRows)
+				db.ExpectExec("INSERT INTO `orders`").WillReturnResult(sqlmock.NewResult(1, 1))
+				db.ExpectExec("INSERT INTO `order_items`").WillReturnResult(sqlmock.NewResult(1, 1))
+				db.ExpectExec("INSERT INTO `fruit_state_transitions`").WillReturnResult(sqlmock.NewResult(1, 1))
+				db.ExpectExec("UPDATE `fruits` SET `reserved_until`=(.+),`state`").WillReturnResult(sqlmock.NewResult(0, 1))
+				db.ExpectQuery("SELECT (.+) FROM `reorder_points`").WillReturnRows(sqlmock.NewRows([]string{"id", "name", "min_quantity"}))
+				db.ExpectExec("UPDATE `reservations` SET `confirmed_at`=(.+),`order_fk`").WillReturnResult(sqlmock.NewResult(0, 1))
+				db.ExpectCommit()
+			},
+			want: &models.Reservation{
+				ID:          1,
+				CreatedAt:   createdAt,
+				ExpiresAt:   holdUntil,
+				ConfirmedAt: &now,
+				Holder:      "Store 1",
+				OrderID:     &orderID,
+				FruitIDs:    []int64{1},
+			},
+		},
+		"should throw error when reservation not found": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {
+				mTime.EXPECT().Now().Return(now)
+
+				db.ExpectBegin()
+				db.ExpectQuery("SELECT").WillReturnError(fmt.Errorf(infra.MYSQL_ERROR_NOT_FOUND))
+				db.ExpectRollback()
+
+				logger.EXPECT().Warn(gomock.Any())
+			},
+			wantErr: "Reservation not found",
+		},
+		"should throw error when reservation is lapsed": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {
+				mTime.EXPECT().Now().Return(now)
+
+				reservationRows := sqlmock.NewRows([]string{"id", "created_at", "expires_at", "confirmed_at", "released_at", "holder", "order_fk"}).
+					AddRow(int64(1), createdAt, now, nil, nil, "Store 1", nil)
+
+				db.ExpectBegin()
+				db.ExpectQuery("SELECT").WillReturnRows(reservationRows)
+				db.ExpectRollback()
+
+				logger.EXPECT().Warn(gomock.Any())
+			},
+			wantErr: "Reservation is lapsed",
+		},
+		"should throw error when reservation is confirmed": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {
+				mTime.EXPECT().Now().Return(now)
+
+				reservationRows := sqlmock.NewRows([]string{"id", "created_at", "expires_at", "confirmed_at", "released_at", "holder", "order_fk"}).
+					AddRow(int64(1), createdAt, holdUntil, now, nil, "Store 1", int64(1))
+
+				db.ExpectBegin()
+				db.ExpectQuery("SELECT").WillReturnRows(reservationRows)
+				db.ExpectRollback()
+
+				logger.EXPECT().Warn(gomock.Any())
+			},
+			wantErr: "Reservation is confirmed",
+		},
+		"should throw error when fruit is disposed": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {
+				mTime.EXPECT().Now().Return(now)
+
+				reservationRows := sqlmock.NewRows([]string{"id", "created_at", "expires_at", "confirmed_at", "released_at", "holder", "order_fk"}).
+					AddRow(int64(1), createdAt, holdUntil, nil, nil, "Store 1", nil)
+				fruitRows := sqlmock.NewRows([]string{"id", "name", "price", "expires_at", "state"}).
+					AddRow(int64(1), "Orange", "1.99", expiresAt, models.FruitStateDisposed)
+
+				db.ExpectBegin()
+				db.ExpectQuery("SELECT").WillReturnRows(reservationRows)
+				db.ExpectQuery("SELECT").WillReturnRows(fruitRows)
+				db.ExpectRollback()
+
+				logger.EXPECT().Warn(gomock.Any())
+			},
+			wantErr: "Fruit 1 is not available",
+		},
+		"should throw error when fruit is quarantined": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {
+				mTime.EXPECT().Now().Return(now)
+
+				reservationRows := sqlmock.NewRows([]string{"id", "created_at", "expires_at", "confirmed_at", "released_at", "holder", "order_fk"}).
+					AddRow(int64(1), createdAt, holdUntil, nil, nil, "Store 1", nil)
+				fruitRows := sqlmock.NewRows([]string{"id", "name", "price", "expires_at", "state", "quarantined_at"}).
+					AddRow(int64(1), "Orange", "1.99", expiresAt, models.FruitStateRipe, createdAt)
+
+				db.ExpectBegin()
+				db.ExpectQuery("SELECT").WillReturnRows(reservationRows)
+				db.ExpectQuery("SELECT").WillReturnRows(fruitRows)
+				db.ExpectRollback()
+
+				logger.EXPECT().Warn(gomock.Any())
+			},
+			wantErr: "Fruit 1 is not available",
+		},
+		"should throw error on insert": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {
+				mTime.EXPECT().Now().Return(now)
+
+				reservationRows := sqlmock.NewRows([]string{"id", "created_at", "expires_at", "confirmed_at", "released_at", "holder", "order_fk"}).
+					AddRow(int64(1), createdAt, holdUntil, nil, nil, "Store 1", nil)
+				fruitRows := sqlmock.NewRows([]string{"id", "name", "price", "expires_at", "state"}).
+					AddRow(int64(1), "Orange", "1.99", expiresAt, models.FruitStateRipe)
+
+				db.ExpectBegin()
+				db.ExpectQuery("SELECT").WillReturnRows(reservationRows)
+				db.ExpectQuery("SELECT").WillReturnRows(fruitRows)
+				db.ExpectExec("INSERT").WillReturnError(fmt.Errorf("error"))
+				db.ExpectRollback()
+
+				logger.EXPECT().Error(gomock.Any())
+			},
+			wantErr: "error",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			//setup
+			ctx := context.Background()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			db, sqlMock, err := sqlmock.New()
+			require.Nil(t, err)
+			defer db.Close()
+
+			dialector := mysql.New(mysql.Config{
+				DSN:                       "sqlmock_db_0",
+				DriverName:                "mysql",
+				Conn:                      db,
+				SkipInitializeWithVersion: true,
+			})
+
+			gormDB, err := gorm.Open(dialector, &gorm.Config{})
+			require.Nil(t, err)
+			database := &infra.Database{DB: gormDB, SQL: db}
+
+			loggerMock := mocks.NewMockLogger(ctrl)
+			validate := infra.NewValidator()
+			timeMock := mocks.NewMockTime(ctrl)
+			_time = timeMock
+
+			tt.mock(sqlMock, loggerMock, timeMock)
+
+			// given
+			service := NewReservation(database, loggerMock, validate)
+
+			// when
+			got, err := service.Confirm(ctx, 1)
+
+			// then
+			assert.Equal(t, tt.want, got)
+			if err != nil || tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			}
+			assert.Nil(t, sqlMock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestReservationService_Release(t *testing.T) {
+	now := time.Date(2000, 12, 31, 23, 50, 59, 0, time.Local)
+	createdAt := time.Date(2000, 12, 31, 23, 44, 59, 0, time.Local)
+	holdUntil := createdAt.Add(15 * time.Minute)
+
+	tests := map[string]struct {
+		mock    func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime)
+		wantErr string
+	}{
+		"should be success": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {
+				mTime.EXPECT().Now().Return(now)
+
+				reservationRows := sqlmock.NewRows([]string{"id", "created_at", "expires_at", "confirmed_at", "released_at", "holder", "order_fk"}).
+					AddRow(int64(1), createdAt, holdUntil, nil, nil, "Store 1", nil)
+
+				db.ExpectBegin()
+				db.ExpectQuery("SELECT (.+) FROM `reservations` WHERE id = (.+) FOR UPDATE").WillReturnRows(reservationRows)
+				db.ExpectExec("UPDATE `fruits` SET `reserved_until`=(.+) WHERE reservation_fk IN").WillReturnResult(sqlmock.NewResult(0, 2))
+				db.ExpectExec("UPDATE `reservations` SET `released_at`=(.+) WHERE id IN").WillReturnResult(sqlmock.NewResult(0, 1))
+				db.ExpectCommit()
+			},
+		},
+		"should throw error when reservation is released": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {
+				mTime.EXPECT().Now().Return(now)
+
+				reservationRows := sqlmock.NewRows([]string{"id", "created_at", "expires_at", "confirmed_at", "released_at", "holder", "order_fk"}).
+					AddRow(int64(1), createdAt, holdUntil, nil, now, "Store 1", nil)
+
+				db.ExpectBegin()
+				db.ExpectQuery("SELECT").WillReturnRows(reservationRows)
+				db.ExpectRollback()
+
+				logger.EXPECT().Warn(gomock.Any())
+			},
+			wantErr: "Reservation is released",
+		},
+		"should throw error on update": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {
+				mTime.EXPECT().Now().Return(now)
+
+				reservationRows := sqlmock.NewRows([]string{"id", "created_at", "expires_at", "confirmed_at", "released_at", "holder", "order_fk"}).
+					AddRow(int64(1), createdAt, holdUntil, nil, nil, "Store 1", nil)
+
+				db.ExpectBegin()
+				db.ExpectQuery("SELECT").WillReturnRows(reservationRows)
+				db.ExpectExec("UPDATE").WillReturnError(fmt.Errorf("error"))
+				db.ExpectRollback()
+
+				logger.EXPECT().Error(gomock.Any())
+			},
+			wantErr: "error",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			//setup
+			ctx := context.Background()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			db, sqlMock, err := sqlmock.New()
+			require.Nil(t, err)
+			defer db.Close()
+
+			dialector := mysql.New(mysql.Config{
+				DSN:                       "sqlmock_db_0",
+				DriverName:                "mysql",
+				Conn:                      db,
+				SkipInitializeWithVersion: true,
+			})
+
+			gormDB, err := gorm.Open(dialector, &gorm.Config{})
+			require.Nil(t, err)
+			database := &infra.Database{DB: gormDB, SQL: db}
+
+			loggerMock := mocks.NewMockLogger(ctrl)
+			validate := infra.NewValidator()
+			timeMock := mocks.NewMockTime(ctrl)
+			_time = timeMock
+
+			tt.mock(sqlMock, loggerMock, timeMock)
+
+			// given
+			service := NewReservation(database, loggerMock, validate)
+
+			// when
+			err = service.Release(ctx, 1)
+
+			// then
+			if err != nil || tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			}
+			assert.Nil(t, sqlMock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestReservationService_ReleaseLapsed(t *testing.T) {
+	now := time.Date(2000, 12, 31, 23, 59, 59, 0, time.Local)
+
+	tests := map[string]struct {
+		mock    func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime)
+		want    int64
+		wantErr string
+	}{
+		"should be success": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {
+				mTime.EXPECT().Now().Return(now)
+
+				reservationRows := sqlmock.NewRows([]string{"id"}).AddRow(int64(1)).AddRow(int64(2))
+
+				db.ExpectBegin()
+				db.ExpectQuery("SELECT `id` FROM `reservations` WHERE (.+) FOR UPDATE").WillReturnRows(reservationRows)
+				db.ExpectExec("UPDATE `fruits` SET `reserved_until`").WillReturnResult(sqlmock.NewResult(0, 3))
+				db.ExpectExec("UPDATE `reservations` SET `released_at`").WillReturnResult(sqlmock.NewResult(0, 2))
+				db.ExpectCommit()
+			},
+			want: 2,
+		},
+		"should be success when nothing lapsed": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {
+				mTime.EXPECT().Now().Return(now)
+
+				db.ExpectBegin()
+				db.ExpectQuery("SELECT").WillReturnRows(sqlmock.NewRows([]string{"id"}))
+				db.ExpectCommit()
+			},
+			want: 0,
+		},
+		"should throw error on update": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {
+				mTime.EXPECT().Now().Return(now)
+
+				reservationRows := sqlmock.NewRows([]string{"id"}).AddRow(int64(1))
+
+				db.ExpectBegin()
+				db.ExpectQuery("SELECT").WillReturnRows(reservationRows)
+				db.ExpectExec("UPDATE").WillReturnError(fmt.Errorf("error"))
+				db.ExpectRollback()
+
+				logger.EXPECT().Error(gomock.Any())
+			},
+			wantErr: "error",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			//setup
+			ctx := context.Background()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			db, sqlMock, err := sqlmock.New()
+			require.Nil(t, err)
+			defer db.Close()
+
+			dialector := mysql.New(mysql.Config{
+				DSN:                       "sqlmock_db_0",
+				DriverName:                "mysql",
+				Conn:                      db,
+				SkipInitializeWithVersion: true,
+			})
+
+			gormDB, err := gorm.Open(dialector, &gorm.Config{})
+			require.Nil(t, err)
+			database := &infra.Database{DB: gormDB, SQL: db}
+
+			loggerMock := mocks.NewMockLogger(ctrl)
+			validate := infra.NewValidator()
+			timeMock := mocks.NewMockTime(ctrl)
+			_time = timeMock
+
+			tt.mock(sqlMock, loggerMock, timeMock)
+
+			// given
+			service := NewReservation(database, loggerMock, validate)
+
+			// when
+			got, err := service.ReleaseLapsed(ctx)
+
+			// then
+			assert.Equal(t, tt.want, got)
+			if err != nil || tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			}
+			assert.Nil(t, sqlMock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,365 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"sort"
+	"time"
+
+	"github.com/viniosilva/where-are-my-fruits/internal/dtos"
+	"github.com/viniosilva/where-are-my-fruits/internal/exceptions"
+	"github.com/viniosilva/where-are-my-fruits/internal/infra"
+	"github.com/viniosilva/where-are-my-fruits/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type StockService struct {
+	db       *infra.Database
+	logger   Logger
+	validate Validate
+}
+
+func NewStock(db *infra.Database, logger Logger, validate Validate) *StockService {
+	return &StockService{
+		db:       db,
+		logger:   logger,
+		validate: validate,
+	}
+}
+
+// SetReorderPoint creates or updates the reorder point of a fruit name
+func (impl *StockService) SetReorderPoint(ctx context.Context, data dtos.SetReorderPointDto) (*models.ReorderPoint, error) {
+	if err := impl.validate.Struct(data); err != nil {
+		return nil, exceptions.NewValidationException(err)
+	}
+
+	now := _time.Now()
+
+	var point models.ReorderPoint
+	err := impl.db.DB.Transaction(func(tx *gorm.DB) error {
+		res := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("name = ?", data.Name).Find(&point)
+		if err := res.Error; err != nil {
+			return err
+		}
+
+		if res.RowsAffected == 0 {
+			point = models.ReorderPoint{CreatedAt: now, UpdatedAt: now, Name: data.Name, MinQuantity: data.MinQuantity}
+			return tx.Session(&gorm.Session{NewDB: true}).Create(&point).Error
+		}
+
+		point.UpdatedAt = now
+		point.MinQuantity = data.MinQuantity
+		return tx.Session(&gorm.Session{NewDB: true}).Model(&models.ReorderPoint{}).
+			Where("id = ?", point.ID).
+			Updates(map[string]interface{}{"updated_at": now, "min_quantity": data.MinQuantity}).Error
+	}, &sql.TxOptions{Isolation: sql.LevelReadCommitted})
+
+	if err != nil {
+		impl.logger.Error(err.Error())
+		return nil, err
+	}
+
+	return &point, nil
+}
+
+func (impl *StockService) ListReorderPoints(ctx context.Context, page, pageSize int) ([]models.ReorderPoint, error) {
+	offset := (page - 1) * pageSize
+
+	points := make([]models.ReorderPoint, 0)
+	res := impl.db.DB.
+		Order("name").
+		Offset(offset).
+		Limit(pageSize).
+		Find(&points)
+
+	if err := res.Error; err != nil {
+		impl.logger.Error(err.Error())
+		return nil, err
+	}
+
+	return points, nil
+}
+
+// Low lists the fruit names whose stock, the fruits neither deleted, expired
+// nor out of the active states, is below their reorder point
+func (impl *StockService) Low(ctx context.Context) ([]models.Stock, error) {
+	now := _time.Now()
+
+	rows, err := impl.db.DB.Model(&models.ReorderPoint{}).
+		Select(`reorder_points.name,
+				COUNT(fruits.id) AS quantity,
+				reorder_points.min_quantity`).
+		Joins(`LEFT JOIN fruits ON fruits.name = reorder_points.name
+				AND fruits.deleted_at IS NULL
+				AND fruits.expires_at > ?
+				AND fruits.state IN ?`, now, models.FruitActiveStates).
+		Group("reorder_points.id").
+		Having("quantity < reorder_points.min_quantity").
+		Order("reorder_points.name").
+		Rows()
+	if err != nil {
+		impl.logger.Error(err.Error())
+		return nil, err
+	}
+	defer rows.Close()
+
+	stocks := make([]models.Stock, 0)
+	for rows.Next() {
+		stock := models.Stock{}
+		dest := []interface{}{
+			&stock.Name,
+			&stock.Quantity,
+			&stock.MinQuantity,
+		}
+
+		if err := rows.Scan(dest...); err != nil {
+			impl.logger.Error(err.Error())
+			return nil, err
+		}
+
+		stocks = append(stocks, stock)
+	}
+
+	return stocks, nil
+}
+
+func (impl *StockService) ListAlerts(ctx context.Context, page, pageSize int) ([]models.StockAlert, error) {
+	offset := (page - 1) * pageSize
+
+	alerts := make([]models.StockAlert, 0)
+	res := impl.db.DB.
+		Order("created_at DESC, id DESC").
+		Offset(offset).
+		Limit(pageSize).
+		Find(&alerts)
+
+	if err := res.Error; err != nil {
+		impl.logger.Error(err.Error())
+		return nil, err
+	}
+
+	return alerts, nil
+}
+
+// Waste totals by fruit name, bucket and supplier the fruits that expired or
+// were discarded (deleted before expiring) in the period, along with the fruits
+// received in it. Sold and picked fruits are never waste, and fruits unassigned
+// from their bucket count for the bucket they last left
+func (impl *StockService) Waste(ctx context.Context, data dtos.WasteDto) ([]models.Waste, error) {
+	if err := impl.validate.Struct(data); err != nil {
+		return nil, exceptions.NewValidationException(err)
+	}
+
+	from, to := data.From, data.To
+	rows, err := impl.db.DB.Model(&models.Fruit{}).
+		Select(`fruits.name,
+				COALESCE(fruits.bucket_fk, (SELECT movements.from_bucket_fk FROM movements
+					WHERE movements.fruit_fk = fruits.id AND movements.type = ?
+					ORDER BY movements.id DESC LIMIT 1)) AS last_bucket_fk,
+				fruits.supplier_fk,
+				IFNULL(SUM(fruits.created_at >= ? AND fruits.created_at < ?), 0) AS received_fruits,
+				IFNULL(SUM(fruits.state <> ? AND fruits.expires_at >= ? AND fruits.expires_at < ?
+					AND (fruits.deleted_at IS NULL OR fruits.deleted_at >= fruits.expires_at)), 0) AS expired_fruits,
+				IFNULL(SUM(CASE WHEN fruits.state <> ? AND fruits.expires_at >= ? AND fruits.expires_at < ?
+					AND (fruits.deleted_at IS NULL OR fruits.deleted_at >= fruits.expires_at)
+					THEN fruits.price END), 0) AS expired_price,
+				IFNULL(SUM(fruits.state <> ? AND fruits.deleted_at >= ? AND fruits.deleted_at < ?
+					AND fruits.deleted_at < fruits.expires_at
+					AND NOT EXISTS (SELECT 1 FROM movements
+						WHERE movements.fruit_fk = fruits.id AND movements.type = ?)), 0) AS discarded_fruits,
+				IFNULL(SUM(CASE WHEN fruits.state <> ? AND fruits.deleted_at >= ? AND fruits.deleted_at < ?
+					AND fruits.deleted_at < fruits.expires_at
+					AND NOT EXISTS (SELECT 1 FROM movements
+						WHERE movements.fruit_fk = fruits.id AND movements.type = ?)
+					THEN fruits.price END), 0) AS discarded_price`,
+			models.MovementTypeUnassigned, from, to,
+			models.FruitStateSold, from, to, models.FruitStateSold, from, to,
+			models.FruitStateSold, from, to, models.MovementTypePicked,
+			models.FruitStateSold, from, to, models.MovementTypePicked).
+		Where(`(fruits.created_at >= ? AND fruits.created_at < ?)
+			OR (fruits.expires_at >= ? AND fruits.expires_at < ?)
+			OR (fruits.deleted_at >= ? AND fruits.deleted_at < ?)`, from, to, from, to, from, to).
+		Group("fruits.name, last_bucket_fk, fruits.supplier_fk").
+		Order("fruits.name, last_bucket_fk, fruits.supplier_fk").
+		Rows()
+	if err != nil {
+		impl.logger.Error(err.Error())
+		return nil, err
+	}
+	defer rows.Close()
+
+	wastes := make([]models.Waste, 0)
+	for rows.Next() {
+		waste := models.Waste{}
+		dest := []interface{}{
+			&waste.Name,
+			&waste.BucketID,
+			&waste.SupplierID,
+			&waste.ReceivedFruits,
+			&waste.ExpiredFruits,
+			&waste.ExpiredPrice,
+			&waste.DiscardedFruits,
+			&waste.DiscardedPrice,
+		}
+
+		if err := rows.Scan(dest...); err != nil {
+			impl.logger.Error(err.Error())
+			return nil, err
+		}
+
+		wastes = append(wastes, waste)
+	}
+
+	return wastes, nil
+}
+
+// Valuation values the stock as it was at the given moment by bucket and fruit
+// name. A fruit was in stock when it had been created, was neither deleted,
+// expired, sold, disposed nor quarantined yet, and it was in the bucket the movements
+// ledger places it at the moment, its current one when it never moved. It is
+// valued at the price it had at the moment
+func (impl *StockService) Valuation(ctx context.Context, data dtos.ValuationDto) ([]models.Valuation, error) {
+	if err := impl.validate.Struct(data); err != nil {
+		return nil, exceptions.NewValidationException(err)
+	}
+
+	at := data.At
+	stock := impl.db.DB.Model(&models.Fruit{}).
+		Select(`fruits.name,
+				CASE WHEN before_at.id IS NOT NULL THEN before_at.to_bucket_fk
+					WHEN after_at.id IS NOT NULL THEN after_at.from_bucket_fk
+					ELSE fruits.bucket_fk END AS bucket_fk,
+				IFNULL((SELECT fruit_prices.price FROM fruit_prices
+					WHERE fruit_prices.fruit_fk = fruits.id AND fruit_prices.created_at <= ?
+					ORDER BY fruit_prices.created_at DESC, fruit_prices.id DESC LIMIT 1), fruits.price) AS price`, at).
+		Joins(`LEFT JOIN movements AS before_at ON before_at.id = (SELECT movements.id FROM movements
+				WHERE movements.fruit_fk = fruits.id AND movements.created_at <= ?
+				ORDER BY movements.created_at DESC, movements.id DESC LIMIT 1)`, at).
+		Joins(`LEFT JOIN movements AS after_at ON after_at.id = (SELECT movements.id FROM movements
+				WHERE movements.fruit_fk = fruits.id AND movements.created_at > ?
+				ORDER BY movements.created_at, movements.id LIMIT 1)`, at).
+		Where("fruits.created_at <= ? AND (fruits.deleted_at IS NULL OR fruits.deleted_at > ?) AND fruits.expires_at > ?", at, at, at).
+		Where("fruits.quarantined_at IS NULL OR fruits.quarantined_at > ?", at).
+		Where(`NOT EXISTS (SELECT 1 FROM fruit_state_transitions
+				WHERE fruit_state_transitions.fruit_fk = fruits.id
+				AND fruit_state_transitions.to_state IN ?
+				AND fruit_state_transitions.created_at <= ?)`, []models.FruitState{models.FruitStateSold, models.FruitStateDisposed}, at)
+
+	rows, err := impl.db.DB.Table("(?) AS stock", stock).
+		Select("stock.bucket_fk, buckets.name, stock.name, COUNT(*) AS quantity, SUM(stock.price) AS value").
+		Joins("LEFT JOIN buckets ON buckets.id = stock.bucket_fk").
+		Group("stock.bucket_fk, buckets.name, stock.name").
+		Order("stock.bucket_fk, stock.name").
+		Rows()
+	if err != nil {
+		impl.logger.Error(err.Error())
+		return nil, err
+	}
+	defer rows.Close()
+
+	valuations := make([]models.Valuation, 0)
+	for rows.Next() {
+		valuation := models.Valuation{}
+		dest := []interface{}{
+			&valuation.BucketID,
+			&valuation.BucketName,
+			&valuation.Name,
+			&valuation.Quantity,
+			&valuation.Value,
+		}
+
+		if err := rows.Scan(dest...); err != nil {
+			impl.logger.Error(err.Error())
+			return nil, err
+		}
+
+		valuations = append(valuations, valuation)
+	}
+
+	return valuations, nil
+}
+
+// emitStockAlerts records an alert for every fruit name whose stock fell below
+// its reorder point once the given quantities left the stock
+func emitStockAlerts(tx *gorm.DB, removed map[string]int64, reason string, now time.Time) error {
+	if len(removed) == 0 {
+		return nil
+	}
+
+	names := make([]string, 0, len(removed))
+	for name := range removed {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	points := make([]models.ReorderPoint, 0)
+	res := tx.Session(&gorm.Session{NewDB: true}).Where("name IN ?", names).Order("name").Find(&points)
+	if err := res.Error; err != nil {
+		return err
+	}
+	if len(points) == 0 {
+		return nil
+	}
+
+	rows, err := tx.Session(&gorm.Session{NewDB: true}).Model(&models.Fruit{}).
+		Select("name, COUNT(id) AS quantity").
+		Where(`name IN ?
+			AND deleted_at IS NULL
+			AND expires_at > ?
+			AND state IN ?
+		`, names, now, models.FruitActiveStates).
+		Group("name").
+		Rows()
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	quantities := map[string]int64{}
+	for rows.Next() {
+		var name string
+		var quantity int64
+		if err := rows.Scan(&name, &quantity); err != nil {
+			return err
+		}
+		quantities[name] = quantity
+	}
+
+	alerts := []models.StockAlert{}
+	for _, point := range points {
+		quantity := quantities[point.Name]
+		before := quantity + removed[point.Name]
+		if quantity < int64(point.MinQuantity) && before >= int64(point.MinQuantity) {
+			alerts = append(alerts, models.StockAlert{
+				CreatedAt:   now,
+				Name:        point.Name,
+				Quantity:    quantity,
+				MinQuantity: point.MinQuantity,
+				Reason:      reason,
+			})
+		}
+	}
+	if len(alerts) == 0 {
+		return nil
+	}
+
+	return tx.Session(&gorm.Session{NewDB: true}).Create(&alerts).Error
+}
+
+// inStock tells whether the fruit still counts as stock at the given moment
+func inStock(fruit models.Fruit, now time.Time) bool {
+	return fruit.DeletedAt == nil && fruit.State.IsActive() && fruit.ExpiresAt.After(now)
+}
+
+// removedStock counts by name the fruits that still count as stock
+func removedStock(fruits []models.Fruit, now time.Time) map[string]int64 {
+	removed := map[string]int64{}
+	for _, fruit := range fruits {
+		if inStock(fruit, now) {
+			removed[fruit.Name]++
+		}
+	}
+
+	return removed
+}
@@ -0,0 +1,551 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/golang/mock/gomock"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/viniosilva/where-are-my-fruits/internal/dtos"
+	"github.com/viniosilva/where-are-my-fruits/internal/infra"
+	"github.com/viniosilva/where-are-my-fruits/internal/models"
+	"github.com/viniosilva/where-are-my-fruits/mocks"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+func TestStockService_NewStock(t *testing.T) {
+	t.Run("should be success", func(t *testing.T) {
+		//setup
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		loggerMock := mocks.NewMockLogger(ctrl)
+		validate := infra.NewValidator()
+
+		// given
+		got := NewStock(nil, loggerMock, validate)
+
+		// then
+		assert.NotNil(t, got)
+	})
+}
+
+func TestStockService_SetReorderPoint(t *testing.T) {
+	now := time.Date(2000, 12, 31, 23, 59, 59, 0, time.Local)
+	yesterday := now.Add(-24 * time.Hour)
+
+	tests := map[string]struct {
+		mock    func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime)
+		data    dtos.SetReorderPointDto
+		want    *models.ReorderPoint
+		wantErr string
+	}{
+		"should create reorder point": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {
+				mTime.EXPECT().Now().Return(now)
+				db.ExpectBegin()
+				db.ExpectQuery("SELECT (.+) FROM `reorder_points` WHERE name = (.+) FOR UPDATE").
+					WithArgs("Orange").
+					WillReturnRows(sqlmock.NewRows([]string{"id"})) // find reorder point
+				db.ExpectExec("INSERT INTO `reorder_points`").WillReturnResult(sqlmock.NewResult(1, 1))
+				db.ExpectCommit()
+			},
+			data: dtos.SetReorderPointDto{Name: "Orange", MinQuantity: 10},
+			want: &models.ReorderPoint{ID: 1, CreatedAt: now, UpdatedAt: now, Name: "Orange", MinQuantity: 10},
+		},
+		"should update reorder point": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {
+				mTime.EXPECT().Now().Return(now)
+				db.ExpectBegin()
+				db.ExpectQuery("SELECT (.+) FROM `reorder_points`").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "created_at", "updated_at", "name", "min_quantity"}).
+						AddRow(int64(1), yesterday, yesterday, "Orange", 5)) // find reorder point
+				db.ExpectExec("UPDATE `reorder_points`").WillReturnResult(sqlmock.NewResult(1, 1))
+				db.ExpectCommit()
+			},
+			data: dtos.SetReorderPointDto{Name: "Orange", MinQuantity: 10},
+			want: &models.ReorderPoint{ID: 1, CreatedAt: yesterday, UpdatedAt: now, Name: "Orange", MinQuantity: 10},
+		},
+		"should throw error on validate": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {},
+			data: dtos.SetReorderPointDto{Name: "", MinQuantity: -1},
+			wantErr: strings.Join([]string{
+				"Key: 'SetReorderPointDto.Name' Error:Field validation for 'Name' failed on the 'required' tag",
+				"Key: 'SetReorderPointDto.MinQuantity' Error:Field validation for 'MinQuantity' failed on the 'gte' tag",
+			}, ", "),
+		},
+		"should throw error": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {
+				mTime.EXPECT().Now().Return(now)
+				db.ExpectBegin()
+				db.ExpectQuery("SELECT").WillReturnError(fmt.Errorf("error"))
+				db.ExpectRollback()
+
+				logger.EXPECT().Error(gomock.Any())
+			},
+			data:    dtos.SetReorderPointDto{Name: "Orange", MinQuantity: 10},
+			wantErr: "error",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			//setup
+			ctx := context.Background()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			db, sqlMock, err := sqlmock.New()
+			require.Nil(t, err)
+			defer db.Close()
+
+			dialector := mysql.New(mysql.Config{
+				DSN:                       "sqlmock_db_0",
+				DriverName:                "mysql",
+				Conn:                      db,
+				SkipInitializeWithVersion: true,
+			})
+
+			gormDB, err := gorm.Open(dialector, &gorm.Config{})
+			require.Nil(t, err)
+			database := &infra.Database{DB: gormDB, SQL: db}
+
+			loggerMock := mocks.NewMockLogger(ctrl)
+			validate := infra.NewValidator()
+			timeMock := mocks.NewMockTime(ctrl)
+			_time = timeMock
+
+			tt.mock(sqlMock, loggerMock, timeMock)
+
+			// given
+			service := NewStock(database, loggerMock, validate)
+
+			// when
+			got, err := service.SetReorderPoint(ctx, tt.data)
+
+			// then
+			assert.Equal(t, tt.want, got)
+			if err != nil || tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			}
+			assert.Nil(t, sqlMock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestStockService_ListReorderPoints(t *testing.T) {
+	now := time.Date(2000, 12, 31, 23, 59, 59, 0, time.Local)
+
+	tests := map[string]struct {
+		mock    func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime)
+		want    []models.ReorderPoint
+		wantErr string
+	}{
+		"should be success": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {
+				db.ExpectQuery("SELECT (.+) FROM `reorder_points` ORDER BY name LIMIT 10").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "created_at", "updated_at", "name", "min_quantity"}).
+						AddRow(int64(1), now, now, "Orange", 10))
+			},
+			want: []models.ReorderPoint{{ID: 1, CreatedAt: now, UpdatedAt: now, Name: "Orange", MinQuantity: 10}},
+		},
+		"should be empty": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {
+				db.ExpectQuery("SELECT").WillReturnRows(sqlmock.NewRows([]string{"id"}))
+			},
+			want: []models.ReorderPoint{},
+		},
+		"should throw error": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {
+				db.ExpectQuery("SELECT").WillReturnError(fmt.Errorf("error"))
+				logger.EXPECT().Error(gomock.Any())
+			},
+			wantErr: "error",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			//setup
+			ctx := context.Background()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			db, sqlMock, err := sqlmock.New()
+			require.Nil(t, err)
+			defer db.Close()
+
+			dialector := mysql.New(mysql.Config{
+				DSN:                       "sqlmock_db_0",
+				DriverName:                "mysql",
+				Conn:                      db,
+				SkipInitializeWithVersion: true,
+			})
+
+			gormDB, err := gorm.Open(dialector, &gorm.Config{})
+			require.Nil(t, err)
+			database := &infra.Database{DB: gormDB, SQL: db}
+
+			loggerMock := mocks.NewMockLogger(ctrl)
+			validate := infra.NewValidator()
+			timeMock := mocks.NewMockTime(ctrl)
+			_time = timeMock
+
+			tt.mock(sqlMock, loggerMock, timeMock)
+
+			// given
+			service := NewStock(database, loggerMock, validate)
+
+			// when
+			got, err := service.ListReorderPoints(ctx, 1, 10)
+
+			// then
+			assert.Equal(t, tt.want, got)
+			if err != nil || tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			}
+			assert.Nil(t, sqlMock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestStockService_Low(t *testing.T) {
+	now := time.Date(2000, 12, 31, 23, 59, 59, 0, time.Local)
+
+	tests := map[string]struct {
+		mock    func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime)
+		want    []models.Stock
+		wantErr string
+	}{
+		"should be success": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {
+				mTime.EXPECT().Now().Return(now)
+				db.ExpectQuery("SELECT (.+) FROM `reorder_points` LEFT JOIN fruits (.+) GROUP BY (.+) HAVING").
+					WillReturnRows(sqlmock.NewRows([]string{"name", "quantity", "min_quantity"}).
+						AddRow("Apple", int64(0), 5).
+						AddRow("Orange", int64(4), 10))
+			},
+			want: []models.Stock{
+				{Name: "Apple", Quantity: 0, MinQuantity: 5},
+				{Name: "Orange", Quantity: 4, MinQuantity: 10},
+			},
+		},
+		"should be empty": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {
+				mTime.EXPECT().Now().Return(now)
+				db.ExpectQuery("SELECT").WillReturnRows(sqlmock.NewRows([]string{"name", "quantity", "min_quantity"}))
+			},
+			want: []models.Stock{},
+		},
+		"should throw error": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {
+				mTime.EXPECT().Now().Return(now)
+				db.ExpectQuery("SELECT").WillReturnError(fmt.Errorf("error"))
+				logger.EXPECT().Error(gomock.Any())
+			},
+			wantErr: "error",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			//setup
+			ctx := context.Background()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			db, sqlMock, err := sqlmock.New()
+			require.Nil(t, err)
+			defer db.Close()
+
+			dialector := mysql.New(mysql.Config{
+				DSN:                       "sqlmock_db_0",
+				DriverName:                "mysql",
+				Conn:                      db,
+				SkipInitializeWithVersion: true,
+			})
+
+			gormDB, err := gorm.Open(dialector, &gorm.Config{})
+			require.Nil(t, err)
+			database := &infra.Database{DB: gormDB, SQL: db}
+
+			loggerMock := mocks.NewMockLogger(ctrl)
+			validate := infra.NewValidator()
+			timeMock := mocks.NewMockTime(ctrl)
+			_time = timeMock
+
+			tt.mock(sqlMock, loggerMock, timeMock)
+
+			// given
+			service := NewStock(database, loggerMock, validate)
+
+			// when
+			got, err := service.Low(ctx)
+
+			// then
+			assert.Equal(t, tt.want, got)
+			if err != nil || tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			}
+			assert.Nil(t, sqlMock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestStockService_ListAlerts(t *testing.T) {
+	now := time.Date(2000, 12, 31, 23, 59, 59, 0, time.Local)
+
+	tests := map[string]struct {
+		mock    func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime)
+		want    []models.StockAlert
+		wantErr string
+	}{
+		"should be success": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {
+				db.ExpectQuery("SELECT (.+) FROM `stock_alerts` ORDER BY created_at DESC, id DESC LIMIT 10").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "created_at", "name", "quantity", "min_quantity", "reason"}).
+						AddRow(int64(1), now, "Orange", int64(9), 10, "expired"))
+			},
+			want: []models.StockAlert{
+				{ID: 1, CreatedAt: now, Name: "Orange", Quantity: 9, MinQuantity: 10, Reason: models.StockAlertReasonExpired},
+			},
+		},
+		"should throw error": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {
+				db.ExpectQuery("SELECT").WillReturnError(fmt.Errorf("error"))
+				logger.EXPECT().Error(gomock.Any())
+			},
+			wantErr: "error",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			//setup
+			ctx := context.Background()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			db, sqlMock, err := sqlmock.New()
+			require.Nil(t, err)
+			defer db.Close()
+
+			dialector := mysql.New(mysql.Config{
+				DSN:                       "sqlmock_db_0",
+				DriverName:                "mysql",
+				Conn:                      db,
+				SkipInitializeWithVersion: true,
+			})
+
+			gormDB, err := gorm.Open(dialector, &gorm.Config{})
+			require.Nil(t, err)
+			database := &infra.Database{DB: gormDB, SQL: db}
+
+			loggerMock := mocks.NewMockLogger(ctrl)
+			validate := infra.NewValidator()
+			timeMock := mocks.NewMockTime(ctrl)
+			_time = timeMock
+
+			tt.mock(sqlMock, loggerMock, timeMock)
+
+			// given
+			service := NewStock(database, loggerMock, validate)
+
+			// when
+			got, err := service.ListAlerts(ctx, 1, 10)
+
+			// then
+			assert.Equal(t, tt.want, got)
+			if err != nil || tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			}
+			assert.Nil(t, sqlMock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestStockService_Waste(t *testing.T) {
+	from := time.Date(2000, 12, 1, 0, 0, 0, 0, time.Local)
+	to := time.Date(2001, 1, 1, 0, 0, 0, 0, time.Local)
+	bucketID := int64(1)
+	supplierID := int64(1)
+
+	tests := map[string]struct {
+		mock    func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime)
+		data    dtos.WasteDto
+		want    []models.Waste
+		wantErr string
+	}{
+		"should be success": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {
+				rows := sqlmock.NewRows([]string{"name", "last_bucket_fk", "supplier_fk", "received_fruits", "expired_fruits", "expired_price", "discarded_fruits", "discarded_price"}).
+					AddRow("Apple", nil, nil, int64(0), int64(1), "0.99", int64(0), "0").
+					AddRow("Orange", bucketID, supplierID, int64(10), int64(2), "3.98", int64(1), "1.99")
+
+				db.ExpectQuery("SELECT fruits.name, COALESCE(.+) FROM `fruits` WHERE (.+) GROUP BY fruits.name, last_bucket_fk, fruits.supplier_fk ORDER BY fruits.name, last_bucket_fk, fruits.supplier_fk").
+					WithArgs(models.MovementTypeUnassigned, from, to,
+						models.FruitStateSold, from, to, models.FruitStateSold, from, to,
+						models.FruitStateSold, from, to, models.MovementTypePicked,
+						models.FruitStateSold, from, to, models.MovementTypePicked,
+						from, to, from, to, from, to).
+					WillReturnRows(rows)
+			},
+			data: dtos.WasteDto{From: from, To: to},
+			want: []models.Waste{
+				{Name: "Apple", ReceivedFruits: 0, ExpiredFruits: 1, ExpiredPrice: decimal.RequireFromString("0.99"), DiscardedPrice: decimal.RequireFromString("0")},
+				{Name: "Orange", BucketID: &bucketID, SupplierID: &supplierID, ReceivedFruits: 10, ExpiredFruits: 2, ExpiredPrice: decimal.RequireFromString("3.98"), DiscardedFruits: 1, DiscardedPrice: decimal.RequireFromString("1.99")},
+			},
+		},
+		"should throw error on validate when period is inverted": {
+			mock:    func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {},
+			data:    dtos.WasteDto{From: to, To: from},
+			wantErr: "Key: 'WasteDto.To' Error:Field validation for 'To' failed on the 'gtfield' tag",
+		},
+		"should throw error": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {
+				db.ExpectQuery("SELECT").WillReturnError(fmt.Errorf("error"))
+				logger.EXPECT().Error(gomock.Any())
+			},
+			data:    dtos.WasteDto{From: from, To: to},
+			wantErr: "error",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			//setup
+			ctx := context.Background()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			db, sqlMock, err := sqlmock.New()
+			require.Nil(t, err)
+			defer db.Close()
+
+			dialector := mysql.New(mysql.Config{
+				DSN:                       "sqlmock_db_0",
+				DriverName:                "mysql",
+				Conn:                      db,
+				SkipInitializeWithVersion: true,
+			})
+
+			gormDB, err := gorm.Open(dialector, &gorm.Config{})
+			require.Nil(t, err)
+			database := &infra.Database{DB: gormDB, SQL: db}
+
+			loggerMock := mocks.NewMockLogger(ctrl)
+			validate := infra.NewValidator()
+			timeMock := mocks.NewMockTime(ctrl)
+			_time = timeMock
+
+			tt.mock(sqlMock, loggerMock, timeMock)
+
+			// given
+			service := NewStock(database, loggerMock, validate)
+
+			// when
+			got, err := service.Waste(ctx, tt.data)
+
+			// then
+			assert.Equal(t, tt.want, got)
+			if err != nil || tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			}
+			assert.Nil(t, sqlMock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestStockService_Valuation(t *testing.T) {
+	at := time.Date(2000, 12, 31, 23, 59, 59, 0, time.Local)
+	bucketID := int64(1)
+	bucketName := "A"
+
+	tests := map[string]struct {
+		mock    func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime)
+		data    dtos.ValuationDto
+		want    []models.Valuation
+		wantErr string
+	}{
+		"should be success": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {
+				rows := sqlmock.NewRows([]string{"bucket_fk", "name", "name", "quantity", "value"}).
+					AddRow(nil, nil, "Apple", int64(1), "0.99").
+					AddRow(bucketID, bucketName, "Orange", int64(2), "3.98")
+
+				db.ExpectQuery("SELECT stock.bucket_fk, buckets.name, stock.name, COUNT(.+) FROM \\(SELECT fruits.name, (.+) FROM `fruits` LEFT JOIN movements AS before_at (.+) LEFT JOIN movements AS after_at (.+) WHERE (.+)\\) AS stock LEFT JOIN buckets ON buckets.id = stock.bucket_fk GROUP BY stock.bucket_fk, buckets.name, stock.name ORDER BY stock.bucket_fk, stock.name").
+					WithArgs(at, at, at, at, at, at, at, models.FruitStateSold, models.FruitStateDisposed, at).
+					WillReturnRows(rows)
+			},
+			data: dtos.ValuationDto{At: at},
+			want: []models.Valuation{
+				{Name: "Apple", Quantity: 1, Value: decimal.RequireFromString("0.99")},
+				{BucketID: &bucketID, BucketName: &bucketName, Name: "Orange", Quantity: 2, Value: decimal.RequireFromString("3.98")},
+			},
+		},
+		"should throw error on validate when at is empty": {
+			mock:    func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {},
+			data:    dtos.ValuationDto{},
+			wantErr: "Key: 'ValuationDto.At' Error:Field validation for 'At' failed on the 'required' tag",
+		},
+		"should throw error": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {
+				db.ExpectQuery("SELECT").WillReturnError(fmt.Errorf("error"))
+				logger.EXPECT().Error(gomock.Any())
+			},
+			data:    dtos.ValuationDto{At: at},
+			wantErr: "error",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			//setup
+			ctx := context.Background()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			db, sqlMock, err := sqlmock.New()
+			require.Nil(t, err)
+			defer db.Close()
+
+			dialector := mysql.New(mysql.Config{
+				DSN:                       "sqlmock_db_0",
+				DriverName:                "mysql",
+				Conn:                      db,
+				SkipInitializeWithVersion: true,
+			})
+
+			gormDB, err := gorm.Open(dialector, &gorm.Config{})
+			require.Nil(t, err)
+			database := &infra.Database{DB: gormDB, SQL: db}
+
+			loggerMock := mocks.NewMockLogger(ctrl)
+			validate := infra.NewValidator()
+			timeMock := mocks.NewMockTime(ctrl)
+			_time = timeMock
+
+			tt.mock(sqlMock, loggerMock, timeMock)
+
+			// given
+			service := NewStock(database, loggerMock, validate)
+
+			// when
+			got, err := service.Valuation(ctx, tt.data)
+
+			// then
+			assert.Equal(t, tt.want, got)
+			if err != nil || tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			}
+			assert.Nil(t, sqlMock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,176 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/viniosilva/where-are-my-fruits/internal/dtos"
+	"github.com/viniosilva/where-are-my-fruits/internal/exceptions"
+	"github.com/viniosilva/where-are-my-fruits/internal/infra"
+	"github.com/viniosilva/where-are-my-fruits/internal/models"
+	"gorm.io/gorm"
+)
+
+type SupplierService struct {
+	db       *infra.Database
+	logger   Logger
+	validate Validate
+}
+
+func NewSupplier(db *infra.Database, logger Logger, validate Validate) *SupplierService {
+	return &SupplierService{
+		db:       db,
+		logger:   logger,
+		validate: validate,
+	}
+}
+
+func (impl *SupplierService) Create(ctx context.Context, data dtos.CreateSupplierDto) (*models.Supplier, error) {
+	if err := impl.validate.Struct(data); err != nil {
+		return nil, exceptions.NewValidationException(err)
+	}
+
+	supplier := models.Supplier{
+		CreatedAt: _time.Now(),
+		Name:      data.Name,
+		Country:   data.Country,
+		Contact:   data.Contact,
+	}
+
+	res := impl.db.DB.Create(&supplier)
+	if err := res.Error; err != nil {
+		impl.logger.Error(err.Error())
+		return nil, err
+	}
+
+	return &supplier, nil
+}
+
+func (impl *SupplierService) List(ctx context.Context, page, pageSize int) ([]models.Supplier, error) {
+	offset := (page - 1) * pageSize
+
+	suppliers := make([]models.Supplier, 0)
+	res := impl.db.DB.
+		Where("deleted_at IS NULL").
+		Order("name, id").
+		Offset(offset).
+		Limit(pageSize).
+		Find(&suppliers)
+
+	if err := res.Error; err != nil {
+		impl.logger.Error(err.Error())
+		return nil, err
+	}
+
+	return suppliers, nil
+}
+
+func (impl *SupplierService) Get(ctx context.Context, id int64) (*models.Supplier, error) {
+	var supplier models.Supplier
+	res := impl.db.DB.Where("id = ? AND deleted_at IS NULL", id).First(&supplier)
+	if err := res.Error; err != nil {
+		if err.Error() == infra.MYSQL_ERROR_NOT_FOUND {
+			err := exceptions.NewNotFoundException("Supplier not found")
+			impl.logger.Warn(err.Error())
+			return nil, err
+		}
+
+		impl.logger.Error(err.Error())
+		return nil, err
+	}
+
+	return &supplier, nil
+}
+
+func (impl *SupplierService) Update(ctx context.Context, id int64, data dtos.UpdateSupplierDto) (*models.Supplier, error) {
+	if err := impl.validate.Struct(data); err != nil {
+		return nil, exceptions.NewValidationException(err)
+	}
+
+	var supplier models.Supplier
+	err := impl.db.DB.Transaction(func(tx *gorm.DB) error {
+		res := tx.Where("id = ? AND deleted_at IS NULL", id).First(&supplier)
+		if err := res.Error; err != nil {
+			if err.Error() == infra.MYSQL_ERROR_NOT_FOUND {
+				return exceptions.NewNotFoundException("Supplier not found")
+			}
+
+			return err
+		}
+
+		supplier.Name = data.Name
+		supplier.Country = data.Country
+		supplier.Contact = data.Contact
+
+		return tx.Save(&supplier).Error
+	}, &sql.TxOptions{Isolation: sql.LevelReadCommitted})
+
+	if err != nil {
+		if _, ok := err.(*exceptions.NotFoundException); ok {
+			impl.logger.Warn(err.Error())
+		} else {
+			impl.logger.Error(err.Error())
+		}
+
+		return nil, err
+	}
+
+	return &supplier, nil
+}
+
+func (impl *SupplierService) Delete(ctx context.Context, id int64) error {
+	res := impl.db.DB.Model(&models.Supplier{}).
+		Where("id = ? AND deleted_at IS NULL", id).
+		Update("deleted_at", _time.Now())
+
+	if err := res.Error; err != nil {
+		impl.logger.Error(err.Error())
+		return err
+	}
+
+	return nil
+}
+
+func (impl *SupplierService) Report(ctx context.Context, id int64) (*models.SupplierFruits, error) {
+	supplier, err := impl.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	now := _time.Now()
+	row := impl.db.DB.Model(&models.Fruit{}).
+		Select(`COUNT(fruits.id) AS received_fruits,
+				IFNULL(SUM(fruits.price), 0) AS received_price,
+				IFNULL(SUM(fruits.state <> ? AND fruits.expires_at <= ?
+					AND (fruits.deleted_at IS NULL OR fruits.deleted_at >= fruits.expires_at)), 0) AS expired_fruits,
+				IFNULL(SUM(CASE WHEN fruits.state <> ? AND fruits.expires_at <= ?
+					AND (fruits.deleted_at IS NULL OR fruits.deleted_at >= fruits.expires_at)
+					THEN fruits.price END), 0) AS expired_price,
+				IFNULL(SUM(fruits.deleted_at IS NULL AND fruits.expires_at > ? AND fruits.state IN ?), 0) AS current_fruits,
+				IFNULL(SUM(CASE WHEN fruits.deleted_at IS NULL AND fruits.expires_at > ? AND fruits.state IN ?
+					THEN fruits.price END), 0) AS current_price`,
+			models.FruitStateSold, now, models.FruitStateSold, now,
+			now, models.FruitActiveStates, now, models.FruitActiveStates).
+		Where("fruits.supplier_fk = ?", id).
+		Row()
+
+	supplierFruits := models.SupplierFruits{
+		ID:   supplier.ID,
+		Name: supplier.Name,
+	}
+	dest := []interface{}{
+		&supplierFruits.ReceivedFruits,
+		&supplierFruits.ReceivedPrice,
+		&supplierFruits.ExpiredFruits,
+		&supplierFruits.ExpiredPrice,
+		&supplierFruits.CurrentFruits,
+		&supplierFruits.CurrentPrice,
+	}
+
+	if err := row.Scan(dest...); err != nil {
+		impl.logger.Error(err.Error())
+		return nil, err
+	}
+
+	return &supplierFruits, nil
+}
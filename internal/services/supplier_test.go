@@ -0,0 +1,542 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/golang/mock/gomock"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/viniosilva/where-are-my-fruits/internal/dtos"
+	"github.com/viniosilva/where-are-my-fruits/internal/infra"
+	"github.com/viniosilva/where-are-my-fruits/internal/models"
+	"github.com/viniosilva/where-are-my-fruits/mocks"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+func TestSupplierService_NewSupplier(t *testing.T) {
+	t.Run("should be success", func(t *testing.T) {
+		//setup
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		loggerMock := mocks.NewMockLogger(ctrl)
+		validate := infra.NewValidator()
+
+		// given
+		got := NewSupplier(nil, loggerMock, validate)
+
+		// then
+		assert.NotNil(t, got)
+	})
+}
+
+func TestSupplierService_Create(t *testing.T) {
+	now := time.Date(2000, 12, 31, 23, 59, 59, 0, time.Local)
+	contact := "contact@testing.com"
+
+	tests := map[string]struct {
+		mock    func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime)
+		data    dtos.CreateSupplierDto
+		want    *models.Supplier
+		wantErr string
+	}{
+		"should be success": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {
+				mTime.EXPECT().Now().Return(now)
+				db.ExpectBegin()
+				db.ExpectExec("INSERT").WillReturnResult(sqlmock.NewResult(1, 1))
+				db.ExpectCommit()
+			},
+			data: dtos.CreateSupplierDto{
+				Name:    "Testing",
+				Country: "BR",
+				Contact: &contact,
+			},
+			want: &models.Supplier{
+				ID:        1,
+				CreatedAt: now,
+				Name:      "Testing",
+				Country:   "BR",
+				Contact:   &contact,
+			},
+		},
+		"should throw error on validate when name is empty and country is invalid": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {},
+			data: dtos.CreateSupplierDto{
+				Name:    "",
+				Country: "XX",
+			},
+			wantErr: strings.Join([]string{
+				"Key: 'CreateSupplierDto.Name' Error:Field validation for 'Name' failed on the 'required' tag",
+				"Key: 'CreateSupplierDto.Country' Error:Field validation for 'Country' failed on the 'iso3166_1_alpha2' tag",
+			}, ", "),
+		},
+		"should throw error": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {
+				mTime.EXPECT().Now().Return(now)
+				db.ExpectBegin()
+				db.ExpectExec("INSERT").WillReturnError(fmt.Errorf("error"))
+				db.ExpectRollback()
+
+				logger.EXPECT().Error(gomock.Any())
+			},
+			data: dtos.CreateSupplierDto{
+				Name:    "Testing",
+				Country: "BR",
+			},
+			wantErr: "error",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			//setup
+			ctx := context.Background()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			db, sqlMock, err := sqlmock.New()
+			require.Nil(t, err)
+			defer db.Close()
+
+			dialector := mysql.New(mysql.Config{
+				DSN:                       "sqlmock_db_0",
+				DriverName:                "mysql",
+				Conn:                      db,
+				SkipInitializeWithVersion: true,
+			})
+
+			gormDB, err := gorm.Open(dialector, &gorm.Config{})
+			require.Nil(t, err)
+			database := &infra.Database{DB: gormDB, SQL: db}
+
+			loggerMock := mocks.NewMockLogger(ctrl)
+			validate := infra.NewValidator()
+			timeMock := mocks.NewMockTime(ctrl)
+			_time = timeMock
+
+			tt.mock(sqlMock, loggerMock, timeMock)
+
+			// given
+			service := NewSupplier(database, loggerMock, validate)
+
+			// when
+			got, err := service.Create(ctx, tt.data)
+
+			// then
+			assert.Equal(t, tt.want, got)
+			if err != nil || tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSupplierService_List(t *testing.T) {
+	now := time.Date(2000, 12, 31, 23, 59, 59, 0, time.Local)
+
+	tests := map[string]struct {
+		mock     func(db sqlmock.Sqlmock, logger *mocks.MockLogger)
+		page     int
+		pageSize int
+		want     []models.Supplier
+		wantErr  string
+	}{
+		"should be success": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger) {
+				rows := sqlmock.NewRows([]string{"id", "created_at", "name", "country"}).
+					AddRow(int64(1), now, "Testing", "BR").
+					AddRow(int64(2), now, "Testing_2", "PT")
+
+				db.ExpectQuery("SELECT").WillReturnRows(rows)
+			},
+			page:     1,
+			pageSize: 10,
+			want: []models.Supplier{
+				{ID: 1, CreatedAt: now, Name: "Testing", Country: "BR"},
+				{ID: 2, CreatedAt: now, Name: "Testing_2", Country: "PT"},
+			},
+		},
+		"should throw error": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger) {
+				db.ExpectQuery("SELECT").WillReturnError(fmt.Errorf("error"))
+				logger.EXPECT().Error(gomock.Any())
+			},
+			page:     1,
+			pageSize: 10,
+			wantErr:  "error",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			//setup
+			ctx := context.Background()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			db, sqlMock, err := sqlmock.New()
+			require.Nil(t, err)
+			defer db.Close()
+
+			dialector := mysql.New(mysql.Config{
+				DSN:                       "sqlmock_db_0",
+				DriverName:                "mysql",
+				Conn:                      db,
+				SkipInitializeWithVersion: true,
+			})
+
+			gormDB, err := gorm.Open(dialector, &gorm.Config{})
+			require.Nil(t, err)
+			database := &infra.Database{DB: gormDB, SQL: db}
+
+			loggerMock := mocks.NewMockLogger(ctrl)
+
+			tt.mock(sqlMock, loggerMock)
+
+			// given
+			service := NewSupplier(database, loggerMock, nil)
+
+			// when
+			got, err := service.List(ctx, tt.page, tt.pageSize)
+
+			// then
+			assert.Equal(t, tt.want, got)
+			if err != nil || tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSupplierService_Update(t *testing.T) {
+	now := time.Date(2000, 12, 31, 23, 59, 59, 0, time.Local)
+
+	tests := map[string]struct {
+		mock    func(db sqlmock.Sqlmock, logger *mocks.MockLogger)
+		id      int64
+		data    dtos.UpdateSupplierDto
+		want    *models.Supplier
+		wantErr string
+	}{
+		"should be success": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger) {
+				rows := sqlmock.NewRows([]string{"id", "created_at", "name", "country"}).
+					AddRow(int64(1), now, "Testing", "BR")
+
+				db.ExpectBegin()
+				db.ExpectQuery("SELECT").WillReturnRows(rows)                     // find supplier
+				db.ExpectExec("UPDATE").WillReturnResult(sqlmock.NewResult(1, 1)) // update supplier
+				db.ExpectCommit()
+			},
+			id: 1,
+			data: dtos.UpdateSupplierDto{
+				Name:    "Updated",
+				Country: "PT",
+			},
+			want: &models.Supplier{
+				ID:        1,
+				CreatedAt: now,
+				Name:      "Updated",
+				Country:   "PT",
+			},
+		},
+		"should throw error when supplier not found": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger) {
+				db.ExpectBegin()
+				db.ExpectQuery("SELECT").WillReturnError(fmt.Errorf(infra.MYSQL_ERROR_NOT_FOUND))
+				db.ExpectRollback()
+
+				logger.EXPECT().Warn(gomock.Any())
+			},
+			id: 1,
+			data: dtos.UpdateSupplierDto{
+				Name:    "Updated",
+				Country: "PT",
+			},
+			wantErr: "Supplier not found",
+		},
+		"should throw error on validate when country is invalid": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger) {},
+			id:   1,
+			data: dtos.UpdateSupplierDto{
+				Name:    "Updated",
+				Country: "Portugal",
+			},
+			wantErr: "Key: 'UpdateSupplierDto.Country' Error:Field validation for 'Country' failed on the 'iso3166_1_alpha2' tag",
+		},
+		"should throw error on update": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger) {
+				rows := sqlmock.NewRows([]string{"id", "created_at", "name", "country"}).
+					AddRow(int64(1), now, "Testing", "BR")
+
+				db.ExpectBegin()
+				db.ExpectQuery("SELECT").WillReturnRows(rows)
+				db.ExpectExec("UPDATE").WillReturnError(fmt.Errorf("error"))
+				db.ExpectRollback()
+
+				logger.EXPECT().Error(gomock.Any())
+			},
+			id: 1,
+			data: dtos.UpdateSupplierDto{
+				Name:    "Updated",
+				Country: "PT",
+			},
+			wantErr: "error",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			//setup
+			ctx := context.Background()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			db, sqlMock, err := sqlmock.New()
+			require.Nil(t, err)
+			defer db.Close()
+
+			dialector := mysql.New(mysql.Config{
+				DSN:                       "sqlmock_db_0",
+				DriverName:                "mysql",
+				Conn:                      db,
+				SkipInitializeWithVersion: true,
+			})
+
+			gormDB, err := gorm.Open(dialector, &gorm.Config{})
+			require.Nil(t, err)
+			database := &infra.Database{DB: gormDB, SQL: db}
+
+			loggerMock := mocks.NewMockLogger(ctrl)
+			validate := infra.NewValidator()
+
+			tt.mock(sqlMock, loggerMock)
+
+			// given
+			service := NewSupplier(database, loggerMock, validate)
+
+			// when
+			got, err := service.Update(ctx, tt.id, tt.data)
+
+			// then
+			assert.Equal(t, tt.want, got)
+			if err != nil || tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSupplierService_Delete(t *testing.T) {
+	now := time.Date(2000, 12, 31, 23, 59, 59, 0, time.Local)
+
+	tests := map[string]struct {
+		mock    func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime)
+		id      int64
+		wantErr string
+	}{
+		"should be success": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {
+				mTime.EXPECT().Now().Return(now)
+				db.ExpectBegin()
+				db.ExpectExec("UPDATE").WillReturnResult(sqlmock.NewResult(1, 1))
+				db.ExpectCommit()
+			},
+			id: 1,
+		},
+		"should throw error": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {
+				mTime.EXPECT().Now().Return(now)
+				db.ExpectBegin()
+				db.ExpectExec("UPDATE").WillReturnError(fmt.Errorf("error"))
+				db.ExpectRollback()
+
+				logger.EXPECT().Error(gomock.Any())
+			},
+			id:      1,
+			wantErr: "error",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			//setup
+			ctx := context.Background()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			db, sqlMock, err := sqlmock.New()
+			require.Nil(t, err)
+			defer db.Close()
+
+			dialector := mysql.New(mysql.Config{
+				DSN:                       "sqlmock_db_0",
+				DriverName:                "mysql",
+				Conn:                      db,
+				SkipInitializeWithVersion: true,
+			})
+
+			gormDB, err := gorm.Open(dialector, &gorm.Config{})
+			require.Nil(t, err)
+			database := &infra.Database{DB: gormDB, SQL: db}
+
+			loggerMock := mocks.NewMockLogger(ctrl)
+			timeMock := mocks.NewMockTime(ctrl)
+			_time = timeMock
+
+			tt.mock(sqlMock, loggerMock, timeMock)
+
+			// given
+			service := NewSupplier(database, loggerMock, nil)
+
+			// when
+			err = service.Delete(ctx, tt.id)
+
+			// then
+			if err != nil || tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSupplierService_Report(t *testing.T) {
+	now := time.Date(2000, 12, 31, 23, 59, 59, 0, time.Local)
+
+	tests := map[string]struct {
+		mock    func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime)
+		id      int64
+		want    *models.SupplierFruits
+		wantErr string
+	}{
+		"should be success": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {
+				mTime.EXPECT().Now().Return(now)
+
+				supplierRows := sqlmock.NewRows([]string{"id", "created_at", "name", "country"}).
+					AddRow(int64(1), now, "Testing", "BR")
+				reportRows := sqlmock.NewRows([]string{"received_fruits", "received_price", "expired_fruits",
+					"expired_price", "current_fruits", "current_price"}).
+					AddRow(int64(3), decimal.NewFromFloat32(5.97), int64(1), decimal.NewFromFloat32(1.99),
+						int64(1), decimal.NewFromFloat32(1.99))
+
+				db.ExpectQuery("SELECT").WillReturnRows(supplierRows) // find supplier
+				db.ExpectQuery("SELECT").WillReturnRows(reportRows)   // aggregate fruits
+			},
+			id: 1,
+			want: &models.SupplierFruits{
+				ID:             1,
+				Name:           "Testing",
+				ReceivedFruits: 3,
+				ReceivedPrice:  decimal.NewFromFloat32(5.97),
+				ExpiredFruits:  1,
+				ExpiredPrice:   decimal.NewFromFloat32(1.99),
+				CurrentFruits:  1,
+				CurrentPrice:   decimal.NewFromFloat32(1.99),
+			},
+		},
+		"should leave sold and disposed fruits out of current stock": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {
+				mTime.EXPECT().Now().Return(now)
+
+				supplierRows := sqlmock.NewRows([]string{"id", "created_at", "name", "country"}).
+					AddRow(int64(1), now, "Testing", "BR")
+				// one fruit in stock, one sold and one disposed
+				reportRows := sqlmock.NewRows([]string{"received_fruits", "received_price", "expired_fruits",
+					"expired_price", "current_fruits", "current_price"}).
+					AddRow(int64(3), decimal.NewFromFloat32(5.97), int64(1), decimal.NewFromFloat32(1.99),
+						int64(1), decimal.NewFromFloat32(1.99))
+
+				db.ExpectQuery("SELECT").WillReturnRows(supplierRows)
+				db.ExpectQuery("SELECT (.+) fruits.state <> (.+) fruits.state IN (.+) FROM `fruits` WHERE fruits.supplier_fk = ?").
+					WithArgs(models.FruitStateSold, now, models.FruitStateSold, now,
+						now, models.FruitStateUnripe, models.FruitStateRipe, models.FruitStateOverripe,
+						now, models.FruitStateUnripe, models.FruitStateRipe, models.FruitStateOverripe, int64(1)).
+					WillReturnRows(reportRows)
+			},
+			id: 1,
+			want: &models.SupplierFruits{
+				ID:             1,
+				Name:           "Testing",
+				ReceivedFruits: 3,
+				ReceivedPrice:  decimal.NewFromFloat32(5.97),
+				ExpiredFruits:  1,
+				ExpiredPrice:   decimal.NewFromFloat32(1.99),
+				CurrentFruits:  1,
+				CurrentPrice:   decimal.NewFromFloat32(1.99),
+			},
+		},
+		"should throw error when supplier not found": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {
+				db.ExpectQuery("SELECT").WillReturnError(fmt.Errorf(infra.MYSQL_ERROR_NOT_FOUND))
+				logger.EXPECT().Warn(gomock.Any())
+			},
+			id:      1,
+			wantErr: "Supplier not found",
+		},
+		"should throw error when aggregate": {
+			mock: func(db sqlmock.Sqlmock, logger *mocks.MockLogger, mTime *mocks.MockTime) {
+				mTime.EXPECT().Now().Return(now)
+
+				supplierRows := sqlmock.NewRows([]string{"id", "created_at", "name", "country"}).
+					AddRow(int64(1), now, "Testing", "BR")
+
+				db.ExpectQuery("SELECT").WillReturnRows(supplierRows)
+				db.ExpectQuery("SELECT").WillReturnError(fmt.Errorf("error"))
+				logger.EXPECT().Error(gomock.Any())
+			},
+			id:      1,
+			wantErr: "error",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			//setup
+			ctx := context.Background()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			db, sqlMock, err := sqlmock.New()
+			require.Nil(t, err)
+			defer db.Close()
+
+			dialector := mysql.New(mysql.Config{
+				DSN:                       "sqlmock_db_0",
+				DriverName:                "mysql",
+				Conn:                      db,
+				SkipInitializeWithVersion: true,
+			})
+
+			gormDB, err := gorm.Open(dialector, &gorm.Config{})
+			require.Nil(t, err)
+			database := &infra.Database{DB: gormDB, SQL: db}
+
+			loggerMock := mocks.NewMockLogger(ctrl)
+			timeMock := mocks.NewMockTime(ctrl)
+			_time = timeMock
+
+			tt.mock(sqlMock, loggerMock, timeMock)
+
+			// given
+			service := NewSupplier(database, loggerMock, nil)
+
+			// when
+			got, err := service.Report(ctx, tt.id)
+
+			// then
+			assert.Equal(t, tt.want, got)
+			if err != nil || tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,25 @@
+package workers
+
+import (
+	"context"
+
+	"github.com/viniosilva/where-are-my-fruits/internal/models"
+)
+
+//go:generate mockgen -source=./interfaces.go -destination=../../mocks/workers_mocks.go -package=mocks -mock_names=Logger=MockWorkerLogger
+type Logger interface {
+	Infow(msg string, keysAndValues ...interface{})
+	Errorw(msg string, keysAndValues ...interface{})
+}
+
+type RipenessService interface {
+	Progress(ctx context.Context) (int64, error)
+}
+
+type SweeperService interface {
+	Sweep(ctx context.Context, unassign, dispose bool) (*models.FruitSweep, error)
+}
+
+type ReleaserService interface {
+	ReleaseLapsed(ctx context.Context) (int64, error)
+}
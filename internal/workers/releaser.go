@@ -0,0 +1,18 @@
+package workers
+
+import (
+	"context"
+	"time"
+)
+
+func NewReleaser(service ReleaserService, logger Logger, interval time.Duration) *Worker {
+	return newWorker(interval, func(ctx context.Context) {
+		total, err := service.ReleaseLapsed(ctx)
+		if err != nil {
+			logger.Errorw("releaser worker", "error", err.Error())
+			return
+		}
+
+		logger.Infow("releaser worker", "released_reservations", total)
+	})
+}
@@ -0,0 +1,47 @@
+package workers
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/viniosilva/where-are-my-fruits/mocks"
+)
+
+func TestReleaserWorker(t *testing.T) {
+	tests := map[string]struct {
+		mock func(service *mocks.MockReleaserService, logger *mocks.MockWorkerLogger)
+	}{
+		"should log released reservations": {
+			mock: func(service *mocks.MockReleaserService, logger *mocks.MockWorkerLogger) {
+				service.EXPECT().ReleaseLapsed(gomock.Any()).Return(int64(2), nil)
+				logger.EXPECT().Infow("releaser worker", "released_reservations", int64(2))
+			},
+		},
+		"should log error": {
+			mock: func(service *mocks.MockReleaserService, logger *mocks.MockWorkerLogger) {
+				service.EXPECT().ReleaseLapsed(gomock.Any()).Return(int64(0), fmt.Errorf("error"))
+				logger.EXPECT().Errorw("releaser worker", "error", "error")
+			},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			// setup
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			serviceMock := mocks.NewMockReleaserService(ctrl)
+			loggerMock := mocks.NewMockWorkerLogger(ctrl)
+			tt.mock(serviceMock, loggerMock)
+
+			// given
+			worker := NewReleaser(serviceMock, loggerMock, time.Hour)
+
+			// when
+			worker.job(context.Background())
+		})
+	}
+}
@@ -0,0 +1,18 @@
+package workers
+
+import (
+	"context"
+	"time"
+)
+
+func NewRipeness(service RipenessService, logger Logger, interval time.Duration) *Worker {
+	return newWorker(interval, func(ctx context.Context) {
+		total, err := service.Progress(ctx)
+		if err != nil {
+			logger.Errorw("ripeness worker", "error", err.Error())
+			return
+		}
+
+		logger.Infow("ripeness worker", "progressed_fruits", total)
+	})
+}
@@ -0,0 +1,47 @@
+package workers
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/viniosilva/where-are-my-fruits/mocks"
+)
+
+func TestRipenessWorker(t *testing.T) {
+	tests := map[string]struct {
+		mock func(service *mocks.MockRipenessService, logger *mocks.MockWorkerLogger)
+	}{
+		"should log progressed fruits": {
+			mock: func(service *mocks.MockRipenessService, logger *mocks.MockWorkerLogger) {
+				service.EXPECT().Progress(gomock.Any()).Return(int64(2), nil)
+				logger.EXPECT().Infow("ripeness worker", "progressed_fruits", int64(2))
+			},
+		},
+		"should log error": {
+			mock: func(service *mocks.MockRipenessService, logger *mocks.MockWorkerLogger) {
+				service.EXPECT().Progress(gomock.Any()).Return(int64(0), fmt.Errorf("error"))
+				logger.EXPECT().Errorw("ripeness worker", "error", "error")
+			},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			// setup
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			serviceMock := mocks.NewMockRipenessService(ctrl)
+			loggerMock := mocks.NewMockWorkerLogger(ctrl)
+			tt.mock(serviceMock, loggerMock)
+
+			// given
+			worker := NewRipeness(serviceMock, loggerMock, time.Hour)
+
+			// when
+			worker.job(context.Background())
+		})
+	}
+}
@@ -0,0 +1,21 @@
+package workers
+
+import (
+	"context"
+	"time"
+)
+
+func NewSweeper(service SweeperService, logger Logger, interval time.Duration, unassign, dispose bool) *Worker {
+	return newWorker(interval, func(ctx context.Context) {
+		sweep, err := service.Sweep(ctx, unassign, dispose)
+		if err != nil {
+			logger.Errorw("sweeper worker", "error", err.Error())
+			return
+		}
+
+		logger.Infow("sweeper worker",
+			"expired_fruits", sweep.ExpiredFruits,
+			"disposed_fruits", sweep.DisposedFruits,
+			"unassigned_fruits", sweep.UnassignedFruits)
+	})
+}
@@ -0,0 +1,54 @@
+package workers
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/viniosilva/where-are-my-fruits/internal/models"
+	"github.com/viniosilva/where-are-my-fruits/mocks"
+)
+
+func TestSweeperWorker(t *testing.T) {
+	tests := map[string]struct {
+		mock func(service *mocks.MockSweeperService, logger *mocks.MockWorkerLogger)
+	}{
+		"should log sweep summary": {
+			mock: func(service *mocks.MockSweeperService, logger *mocks.MockWorkerLogger) {
+				service.EXPECT().Sweep(gomock.Any(), true, false).Return(&models.FruitSweep{
+					ExpiredFruits:    2,
+					UnassignedFruits: 1,
+				}, nil)
+				logger.EXPECT().Infow("sweeper worker",
+					"expired_fruits", int64(2),
+					"disposed_fruits", int64(0),
+					"unassigned_fruits", int64(1))
+			},
+		},
+		"should log error": {
+			mock: func(service *mocks.MockSweeperService, logger *mocks.MockWorkerLogger) {
+				service.EXPECT().Sweep(gomock.Any(), true, false).Return(nil, fmt.Errorf("error"))
+				logger.EXPECT().Errorw("sweeper worker", "error", "error")
+			},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			// setup
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			serviceMock := mocks.NewMockSweeperService(ctrl)
+			loggerMock := mocks.NewMockWorkerLogger(ctrl)
+			tt.mock(serviceMock, loggerMock)
+
+			// given
+			worker := NewSweeper(serviceMock, loggerMock, time.Hour, true, false)
+
+			// when
+			worker.job(context.Background())
+		})
+	}
+}
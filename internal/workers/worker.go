@@ -0,0 +1,61 @@
+package workers
+
+import (
+	"context"
+	"time"
+)
+
+type Worker struct {
+	interval time.Duration
+	job      func(ctx context.Context)
+	cancel   context.CancelFunc
+	done     chan struct{}
+}
+
+func newWorker(interval time.Duration, job func(ctx context.Context)) *Worker {
+	return &Worker{
+		interval: interval,
+		job:      job,
+	}
+}
+
+// Start runs the job on every interval tick until Stop is called. A worker
+// without a positive interval is disabled
+func (impl *Worker) Start() {
+	if impl.interval <= 0 || impl.cancel != nil {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	impl.cancel = cancel
+	impl.done = make(chan struct{})
+
+	go func() {
+		defer close(impl.done)
+
+		ticker := time.NewTicker(impl.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				impl.job(ctx)
+			}
+		}
+	}()
+}
+
+// Stop cancels the running job and waits for it to finish
+func (impl *Worker) Stop() {
+	if impl.cancel == nil {
+		return
+	}
+
+	impl.cancel()
+	<-impl.done
+	impl.cancel = nil
+}
+
+// Refers: https://gobyexample.com/tickers
@@ -0,0 +1,64 @@
+package workers
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWorker_StartStop(t *testing.T) {
+	t.Run("should run job until stopped", func(t *testing.T) {
+		// given
+		var calls int64
+		worker := newWorker(time.Millisecond, func(ctx context.Context) {
+			atomic.AddInt64(&calls, 1)
+		})
+
+		// when
+		worker.Start()
+		time.Sleep(20 * time.Millisecond)
+		worker.Stop()
+		got := atomic.LoadInt64(&calls)
+		time.Sleep(5 * time.Millisecond)
+
+		// then
+		assert.Greater(t, got, int64(0))
+		assert.Equal(t, got, atomic.LoadInt64(&calls))
+	})
+
+	t.Run("should ignore a second start and stop", func(t *testing.T) {
+		// given
+		var calls int64
+		worker := newWorker(time.Millisecond, func(ctx context.Context) {
+			atomic.AddInt64(&calls, 1)
+		})
+
+		// when
+		worker.Start()
+		worker.Start()
+		time.Sleep(20 * time.Millisecond)
+		worker.Stop()
+		worker.Stop()
+
+		// then
+		assert.Greater(t, atomic.LoadInt64(&calls), int64(0))
+		assert.Nil(t, worker.cancel)
+	})
+
+	t.Run("should not run when interval is not positive", func(t *testing.T) {
+		// given
+		worker := newWorker(0, func(ctx context.Context) {
+			t.Fatal("job should not run")
+		})
+
+		// when
+		worker.Start()
+		worker.Stop()
+
+		// then
+		assert.Nil(t, worker.cancel)
+	})
+}
@@ -30,12 +30,13 @@ func main() {
 
 	validate := infra.NewValidator()
 
-	factory, err := factories.Build(db, logger, validate)
+	factory, err := factories.Build(db, logger, validate, config)
 	if err != nil {
 		log.Fatalf("factory.Build: %s\n", err)
 	}
 
-	server := api.ConfigServer(config.Api.Host, config.Api.Port, logger, factory.HealthController, factory.BucketController, factory.FruitController)
+	server := api.ConfigServer(config.Api.Host, config.Api.Port, logger, factory.HealthController, factory.BucketController, factory.FruitController,
+		factory.FruitStateController, factory.FruitPriceController, factory.PhotoController, factory.NoteController, factory.PickController, factory.OrderController, factory.ReservationController, factory.PurchaseOrderController, factory.CycleCountController, factory.StockController, factory.MovementController, factory.SupplierController)
 
 	go func() {
 		if err = server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
@@ -43,12 +44,20 @@ func main() {
 		}
 	}()
 
-	quit := make(chan os.Signal)
+	factory.RipenessWorker.Start()
+	factory.SweeperWorker.Start()
+	factory.ReleaserWorker.Start()
+
+	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 	<-quit
 
 	logger.Info("Shutdown server...")
 
+	factory.RipenessWorker.Stop()
+	factory.SweeperWorker.Stop()
+	factory.ReleaserWorker.Stop()
+
 	db.SQL.Close()
 
 	ctx := context.Background()
@@ -6,7 +6,9 @@ package mocks
 
 import (
 	context "context"
+	io "io"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 	dtos "github.com/viniosilva/where-are-my-fruits/internal/dtos"
@@ -73,6 +75,21 @@ func (m *MockBucketService) EXPECT() *MockBucketServiceMockRecorder {
 	return m.recorder
 }
 
+// Consolidate mocks base method.
+func (m *MockBucketService) Consolidate(ctx context.Context, apply bool) (*models.BucketConsolidation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Consolidate", ctx, apply)
+	ret0, _ := ret[0].(*models.BucketConsolidation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Consolidate indicates an expected call of Consolidate.
+func (mr *MockBucketServiceMockRecorder) Consolidate(ctx, apply interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Consolidate", reflect.TypeOf((*MockBucketService)(nil).Consolidate), ctx, apply)
+}
+
 // Create mocks base method.
 func (m *MockBucketService) Create(ctx context.Context, data dtos.CreateBucketDto) (*models.Bucket, error) {
 	m.ctrl.T.Helper()
@@ -117,6 +134,21 @@ func (mr *MockBucketServiceMockRecorder) List(ctx, page, pageSize interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockBucketService)(nil).List), ctx, page, pageSize)
 }
 
+// ListViolations mocks base method.
+func (m *MockBucketService) ListViolations(ctx context.Context) ([]models.BucketViolations, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListViolations", ctx)
+	ret0, _ := ret[0].([]models.BucketViolations)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListViolations indicates an expected call of ListViolations.
+func (mr *MockBucketServiceMockRecorder) ListViolations(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListViolations", reflect.TypeOf((*MockBucketService)(nil).ListViolations), ctx)
+}
+
 // MockFruitService is a mock of FruitService interface.
 type MockFruitService struct {
 	ctrl     *gomock.Controller
@@ -169,6 +201,21 @@ func (mr *MockFruitServiceMockRecorder) Create(ctx, data interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockFruitService)(nil).Create), ctx, data)
 }
 
+// CreateBatch mocks base method.
+func (m *MockFruitService) CreateBatch(ctx context.Context, data dtos.CreateFruitsBatchDto) (*models.FruitBatch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateBatch", ctx, data)
+	ret0, _ := ret[0].(*models.FruitBatch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateBatch indicates an expected call of CreateBatch.
+func (mr *MockFruitServiceMockRecorder) CreateBatch(ctx, data interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBatch", reflect.TypeOf((*MockFruitService)(nil).CreateBatch), ctx, data)
+}
+
 // Delete mocks base method.
 func (m *MockFruitService) Delete(ctx context.Context, id int64) error {
 	m.ctrl.T.Helper()
@@ -183,6 +230,81 @@ func (mr *MockFruitServiceMockRecorder) Delete(ctx, id interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockFruitService)(nil).Delete), ctx, id)
 }
 
+// DeleteMany mocks base method.
+func (m *MockFruitService) DeleteMany(ctx context.Context, data dtos.SelectFruitsDto) (*models.FruitBulk, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteMany", ctx, data)
+	ret0, _ := ret[0].(*models.FruitBulk)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteMany indicates an expected call of DeleteMany.
+func (mr *MockFruitServiceMockRecorder) DeleteMany(ctx, data interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMany", reflect.TypeOf((*MockFruitService)(nil).DeleteMany), ctx, data)
+}
+
+// GetByBarcode mocks base method.
+func (m *MockFruitService) GetByBarcode(ctx context.Context, barcode string) (*models.Fruit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByBarcode", ctx, barcode)
+	ret0, _ := ret[0].(*models.Fruit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByBarcode indicates an expected call of GetByBarcode.
+func (mr *MockFruitServiceMockRecorder) GetByBarcode(ctx, barcode interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByBarcode", reflect.TypeOf((*MockFruitService)(nil).GetByBarcode), ctx, barcode)
+}
+
+// ListExpiring mocks base method.
+func (m *MockFruitService) ListExpiring(ctx context.Context, within time.Duration) (*models.ExpiringFruits, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListExpiring", ctx, within)
+	ret0, _ := ret[0].(*models.ExpiringFruits)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListExpiring indicates an expected call of ListExpiring.
+func (mr *MockFruitServiceMockRecorder) ListExpiring(ctx, within interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListExpiring", reflect.TypeOf((*MockFruitService)(nil).ListExpiring), ctx, within)
+}
+
+// MoveMany mocks base method.
+func (m *MockFruitService) MoveMany(ctx context.Context, data dtos.MoveFruitsDto) (*models.FruitBulk, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MoveMany", ctx, data)
+	ret0, _ := ret[0].(*models.FruitBulk)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MoveMany indicates an expected call of MoveMany.
+func (mr *MockFruitServiceMockRecorder) MoveMany(ctx, data interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveMany", reflect.TypeOf((*MockFruitService)(nil).MoveMany), ctx, data)
+}
+
+// Recall mocks base method.
+func (m *MockFruitService) Recall(ctx context.Context, data dtos.RecallFruitsDto) (*models.Recall, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Recall", ctx, data)
+	ret0, _ := ret[0].(*models.Recall)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Recall indicates an expected call of Recall.
+func (mr *MockFruitServiceMockRecorder) Recall(ctx, data interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Recall", reflect.TypeOf((*MockFruitService)(nil).Recall), ctx, data)
+}
+
 // RemoveFromBucket mocks base method.
 func (m *MockFruitService) RemoveFromBucket(ctx context.Context, fruitID int64) error {
 	m.ctrl.T.Helper()
@@ -196,3 +318,893 @@ func (mr *MockFruitServiceMockRecorder) RemoveFromBucket(ctx, fruitID interface{
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveFromBucket", reflect.TypeOf((*MockFruitService)(nil).RemoveFromBucket), ctx, fruitID)
 }
+
+// Transfer mocks base method.
+func (m *MockFruitService) Transfer(ctx context.Context, fruitID int64, data dtos.TransferFruitDto) (*models.FruitTransfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Transfer", ctx, fruitID, data)
+	ret0, _ := ret[0].(*models.FruitTransfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Transfer indicates an expected call of Transfer.
+func (mr *MockFruitServiceMockRecorder) Transfer(ctx, fruitID, data interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Transfer", reflect.TypeOf((*MockFruitService)(nil).Transfer), ctx, fruitID, data)
+}
+
+// UnassignMany mocks base method.
+func (m *MockFruitService) UnassignMany(ctx context.Context, data dtos.SelectFruitsDto) (*models.FruitBulk, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnassignMany", ctx, data)
+	ret0, _ := ret[0].(*models.FruitBulk)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UnassignMany indicates an expected call of UnassignMany.
+func (mr *MockFruitServiceMockRecorder) UnassignMany(ctx, data interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnassignMany", reflect.TypeOf((*MockFruitService)(nil).UnassignMany), ctx, data)
+}
+
+// MockFruitStateService is a mock of FruitStateService interface.
+type MockFruitStateService struct {
+	ctrl     *gomock.Controller
+	recorder *MockFruitStateServiceMockRecorder
+}
+
+// MockFruitStateServiceMockRecorder is the mock recorder for MockFruitStateService.
+type MockFruitStateServiceMockRecorder struct {
+	mock *MockFruitStateService
+}
+
+// NewMockFruitStateService creates a new mock instance.
+func NewMockFruitStateService(ctrl *gomock.Controller) *MockFruitStateService {
+	mock := &MockFruitStateService{ctrl: ctrl}
+	mock.recorder = &MockFruitStateServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFruitStateService) EXPECT() *MockFruitStateServiceMockRecorder {
+	return m.recorder
+}
+
+// Change mocks base method.
+func (m *MockFruitStateService) Change(ctx context.Context, fruitID int64, data dtos.ChangeFruitStateDto) (*models.FruitStateTransition, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Change", ctx, fruitID, data)
+	ret0, _ := ret[0].(*models.FruitStateTransition)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Change indicates an expected call of Change.
+func (mr *MockFruitStateServiceMockRecorder) Change(ctx, fruitID, data interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Change", reflect.TypeOf((*MockFruitStateService)(nil).Change), ctx, fruitID, data)
+}
+
+// ListTransitions mocks base method.
+func (m *MockFruitStateService) ListTransitions(ctx context.Context, fruitID int64) ([]models.FruitStateTransition, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTransitions", ctx, fruitID)
+	ret0, _ := ret[0].([]models.FruitStateTransition)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTransitions indicates an expected call of ListTransitions.
+func (mr *MockFruitStateServiceMockRecorder) ListTransitions(ctx, fruitID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTransitions", reflect.TypeOf((*MockFruitStateService)(nil).ListTransitions), ctx, fruitID)
+}
+
+// MockFruitPriceService is a mock of FruitPriceService interface.
+type MockFruitPriceService struct {
+	ctrl     *gomock.Controller
+	recorder *MockFruitPriceServiceMockRecorder
+}
+
+// MockFruitPriceServiceMockRecorder is the mock recorder for MockFruitPriceService.
+type MockFruitPriceServiceMockRecorder struct {
+	mock *MockFruitPriceService
+}
+
+// NewMockFruitPriceService creates a new mock instance.
+func NewMockFruitPriceService(ctrl *gomock.Controller) *MockFruitPriceService {
+	mock := &MockFruitPriceService{ctrl: ctrl}
+	mock.recorder = &MockFruitPriceServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFruitPriceService) EXPECT() *MockFruitPriceServiceMockRecorder {
+	return m.recorder
+}
+
+// Change mocks base method.
+func (m *MockFruitPriceService) Change(ctx context.Context, fruitID int64, data dtos.ChangeFruitPriceDto) (*models.FruitPrice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Change", ctx, fruitID, data)
+	ret0, _ := ret[0].(*models.FruitPrice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Change indicates an expected call of Change.
+func (mr *MockFruitPriceServiceMockRecorder) Change(ctx, fruitID, data interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Change", reflect.TypeOf((*MockFruitPriceService)(nil).Change), ctx, fruitID, data)
+}
+
+// List mocks base method.
+func (m *MockFruitPriceService) List(ctx context.Context, fruitID int64) ([]models.FruitPrice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, fruitID)
+	ret0, _ := ret[0].([]models.FruitPrice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockFruitPriceServiceMockRecorder) List(ctx, fruitID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockFruitPriceService)(nil).List), ctx, fruitID)
+}
+
+// Stats mocks base method.
+func (m *MockFruitPriceService) Stats(ctx context.Context, data dtos.FruitPriceStatsDto) ([]models.FruitPriceStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Stats", ctx, data)
+	ret0, _ := ret[0].([]models.FruitPriceStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Stats indicates an expected call of Stats.
+func (mr *MockFruitPriceServiceMockRecorder) Stats(ctx, data interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stats", reflect.TypeOf((*MockFruitPriceService)(nil).Stats), ctx, data)
+}
+
+// MockPhotoService is a mock of PhotoService interface.
+type MockPhotoService struct {
+	ctrl     *gomock.Controller
+	recorder *MockPhotoServiceMockRecorder
+}
+
+// MockPhotoServiceMockRecorder is the mock recorder for MockPhotoService.
+type MockPhotoServiceMockRecorder struct {
+	mock *MockPhotoService
+}
+
+// NewMockPhotoService creates a new mock instance.
+func NewMockPhotoService(ctrl *gomock.Controller) *MockPhotoService {
+	mock := &MockPhotoService{ctrl: ctrl}
+	mock.recorder = &MockPhotoServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPhotoService) EXPECT() *MockPhotoServiceMockRecorder {
+	return m.recorder
+}
+
+// List mocks base method.
+func (m *MockPhotoService) List(ctx context.Context, ownerType string, ownerID int64) ([]models.Photo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, ownerType, ownerID)
+	ret0, _ := ret[0].([]models.Photo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockPhotoServiceMockRecorder) List(ctx, ownerType, ownerID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockPhotoService)(nil).List), ctx, ownerType, ownerID)
+}
+
+// Open mocks base method.
+func (m *MockPhotoService) Open(ctx context.Context, id int64, thumbnail bool) (*models.Photo, io.ReadCloser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Open", ctx, id, thumbnail)
+	ret0, _ := ret[0].(*models.Photo)
+	ret1, _ := ret[1].(io.ReadCloser)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Open indicates an expected call of Open.
+func (mr *MockPhotoServiceMockRecorder) Open(ctx, id, thumbnail interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Open", reflect.TypeOf((*MockPhotoService)(nil).Open), ctx, id, thumbnail)
+}
+
+// Upload mocks base method.
+func (m *MockPhotoService) Upload(ctx context.Context, data dtos.UploadPhotoDto) (*models.Photo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Upload", ctx, data)
+	ret0, _ := ret[0].(*models.Photo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Upload indicates an expected call of Upload.
+func (mr *MockPhotoServiceMockRecorder) Upload(ctx, data interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Upload", reflect.TypeOf((*MockPhotoService)(nil).Upload), ctx, data)
+}
+
+// MockNoteService is a mock of NoteService interface.
+type MockNoteService struct {
+	ctrl     *gomock.Controller
+	recorder *MockNoteServiceMockRecorder
+}
+
+// MockNoteServiceMockRecorder is the mock recorder for MockNoteService.
+type MockNoteServiceMockRecorder struct {
+	mock *MockNoteService
+}
+
+// NewMockNoteService creates a new mock instance.
+func NewMockNoteService(ctrl *gomock.Controller) *MockNoteService {
+	mock := &MockNoteService{ctrl: ctrl}
+	mock.recorder = &MockNoteServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockNoteService) EXPECT() *MockNoteServiceMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockNoteService) Create(ctx context.Context, data dtos.CreateNoteDto) (*models.Note, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, data)
+	ret0, _ := ret[0].(*models.Note)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockNoteServiceMockRecorder) Create(ctx, data interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockNoteService)(nil).Create), ctx, data)
+}
+
+// List mocks base method.
+func (m *MockNoteService) List(ctx context.Context, ownerType string, ownerID int64, page, pageSize int) ([]models.Note, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, ownerType, ownerID, page, pageSize)
+	ret0, _ := ret[0].([]models.Note)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockNoteServiceMockRecorder) List(ctx, ownerType, ownerID, page, pageSize interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockNoteService)(nil).List), ctx, ownerType, ownerID, page, pageSize)
+}
+
+// MockPickService is a mock of PickService interface.
+type MockPickService struct {
+	ctrl     *gomock.Controller
+	recorder *MockPickServiceMockRecorder
+}
+
+// MockPickServiceMockRecorder is the mock recorder for MockPickService.
+type MockPickServiceMockRecorder struct {
+	mock *MockPickService
+}
+
+// NewMockPickService creates a new mock instance.
+func NewMockPickService(ctrl *gomock.Controller) *MockPickService {
+	mock := &MockPickService{ctrl: ctrl}
+	mock.recorder = &MockPickServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPickService) EXPECT() *MockPickServiceMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockPickService) Create(ctx context.Context, data dtos.CreatePickDto) (*models.Pick, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, data)
+	ret0, _ := ret[0].(*models.Pick)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockPickServiceMockRecorder) Create(ctx, data interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockPickService)(nil).Create), ctx, data)
+}
+
+// MockOrderService is a mock of OrderService interface.
+type MockOrderService struct {
+	ctrl     *gomock.Controller
+	recorder *MockOrderServiceMockRecorder
+}
+
+// MockOrderServiceMockRecorder is the mock recorder for MockOrderService.
+type MockOrderServiceMockRecorder struct {
+	mock *MockOrderService
+}
+
+// NewMockOrderService creates a new mock instance.
+func NewMockOrderService(ctrl *gomock.Controller) *MockOrderService {
+	mock := &MockOrderService{ctrl: ctrl}
+	mock.recorder = &MockOrderServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOrderService) EXPECT() *MockOrderServiceMockRecorder {
+	return m.recorder
+}
+
+// Checkout mocks base method.
+func (m *MockOrderService) Checkout(ctx context.Context, id int64) (*models.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Checkout", ctx, id)
+	ret0, _ := ret[0].(*models.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Checkout indicates an expected call of Checkout.
+func (mr *MockOrderServiceMockRecorder) Checkout(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Checkout", reflect.TypeOf((*MockOrderService)(nil).Checkout), ctx, id)
+}
+
+// Create mocks base method.
+func (m *MockOrderService) Create(ctx context.Context, data dtos.CreateOrderDto) (*models.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, data)
+	ret0, _ := ret[0].(*models.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockOrderServiceMockRecorder) Create(ctx, data interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockOrderService)(nil).Create), ctx, data)
+}
+
+// Get mocks base method.
+func (m *MockOrderService) Get(ctx context.Context, id int64) (*models.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, id)
+	ret0, _ := ret[0].(*models.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockOrderServiceMockRecorder) Get(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockOrderService)(nil).Get), ctx, id)
+}
+
+// Sales mocks base method.
+func (m *MockOrderService) Sales(ctx context.Context, data dtos.SalesDto) ([]models.Sales, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Sales", ctx, data)
+	ret0, _ := ret[0].([]models.Sales)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Sales indicates an expected call of Sales.
+func (mr *MockOrderServiceMockRecorder) Sales(ctx, data interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Sales", reflect.TypeOf((*MockOrderService)(nil).Sales), ctx, data)
+}
+
+// MockReservationService is a mock of ReservationService interface.
+type MockReservationService struct {
+	ctrl     *gomock.Controller
+	recorder *MockReservationServiceMockRecorder
+}
+
+// MockReservationServiceMockRecorder is the mock recorder for MockReservationService.
+type MockReservationServiceMockRecorder struct {
+	mock *MockReservationService
+}
+
+// NewMockReservationService creates a new mock instance.
+func NewMockReservationService(ctrl *gomock.Controller) *MockReservationService {
+	mock := &MockReservationService{ctrl: ctrl}
+	mock.recorder = &MockReservationServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReservationService) EXPECT() *MockReservationServiceMockRecorder {
+	return m.recorder
+}
+
+// Confirm mocks base method.
+func (m *MockReservationService) Confirm(ctx context.Context, id int64) (*models.Reservation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Confirm", ctx, id)
+	ret0, _ := ret[0].(*models.Reservation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Confirm indicates an expected call of Confirm.
+func (mr *MockReservationServiceMockRecorder) Confirm(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Confirm", reflect.TypeOf((*MockReservationService)(nil).Confirm), ctx, id)
+}
+
+// Create mocks base method.
+func (m *MockReservationService) Create(ctx context.Context, data dtos.CreateReservationDto) (*models.Reservation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, data)
+	ret0, _ := ret[0].(*models.Reservation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockReservationServiceMockRecorder) Create(ctx, data interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockReservationService)(nil).Create), ctx, data)
+}
+
+// Get mocks base method.
+func (m *MockReservationService) Get(ctx context.Context, id int64) (*models.Reservation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, id)
+	ret0, _ := ret[0].(*models.Reservation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockReservationServiceMockRecorder) Get(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockReservationService)(nil).Get), ctx, id)
+}
+
+// Release mocks base method.
+func (m *MockReservationService) Release(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Release", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Release indicates an expected call of Release.
+func (mr *MockReservationServiceMockRecorder) Release(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Release", reflect.TypeOf((*MockReservationService)(nil).Release), ctx, id)
+}
+
+// MockPurchaseOrderService is a mock of PurchaseOrderService interface.
+type MockPurchaseOrderService struct {
+	ctrl     *gomock.Controller
+	recorder *MockPurchaseOrderServiceMockRecorder
+}
+
+// MockPurchaseOrderServiceMockRecorder is the mock recorder for MockPurchaseOrderService.
+type MockPurchaseOrderServiceMockRecorder struct {
+	mock *MockPurchaseOrderService
+}
+
+// NewMockPurchaseOrderService creates a new mock instance.
+func NewMockPurchaseOrderService(ctrl *gomock.Controller) *MockPurchaseOrderService {
+	mock := &MockPurchaseOrderService{ctrl: ctrl}
+	mock.recorder = &MockPurchaseOrderServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPurchaseOrderService) EXPECT() *MockPurchaseOrderServiceMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockPurchaseOrderService) Create(ctx context.Context, data dtos.CreatePurchaseOrderDto) (*models.PurchaseOrder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, data)
+	ret0, _ := ret[0].(*models.PurchaseOrder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockPurchaseOrderServiceMockRecorder) Create(ctx, data interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockPurchaseOrderService)(nil).Create), ctx, data)
+}
+
+// Get mocks base method.
+func (m *MockPurchaseOrderService) Get(ctx context.Context, id int64) (*models.PurchaseOrder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, id)
+	ret0, _ := ret[0].(*models.PurchaseOrder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockPurchaseOrderServiceMockRecorder) Get(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockPurchaseOrderService)(nil).Get), ctx, id)
+}
+
+// Receive mocks base method.
+func (m *MockPurchaseOrderService) Receive(ctx context.Context, id int64, data dtos.ReceivePurchaseOrderDto) (*models.PurchaseOrder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Receive", ctx, id, data)
+	ret0, _ := ret[0].(*models.PurchaseOrder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Receive indicates an expected call of Receive.
+func (mr *MockPurchaseOrderServiceMockRecorder) Receive(ctx, id, data interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Receive", reflect.TypeOf((*MockPurchaseOrderService)(nil).Receive), ctx, id, data)
+}
+
+// MockCycleCountService is a mock of CycleCountService interface.
+type MockCycleCountService struct {
+	ctrl     *gomock.Controller
+	recorder *MockCycleCountServiceMockRecorder
+}
+
+// MockCycleCountServiceMockRecorder is the mock recorder for MockCycleCountService.
+type MockCycleCountServiceMockRecorder struct {
+	mock *MockCycleCountService
+}
+
+// NewMockCycleCountService creates a new mock instance.
+func NewMockCycleCountService(ctrl *gomock.Controller) *MockCycleCountService {
+	mock := &MockCycleCountService{ctrl: ctrl}
+	mock.recorder = &MockCycleCountServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCycleCountService) EXPECT() *MockCycleCountServiceMockRecorder {
+	return m.recorder
+}
+
+// Approve mocks base method.
+func (m *MockCycleCountService) Approve(ctx context.Context, id int64) (*models.CycleCount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Approve", ctx, id)
+	ret0, _ := ret[0].(*models.CycleCount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Approve indicates an expected call of Approve.
+func (mr *MockCycleCountServiceMockRecorder) Approve(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Approve", reflect.TypeOf((*MockCycleCountService)(nil).Approve), ctx, id)
+}
+
+// Get mocks base method.
+func (m *MockCycleCountService) Get(ctx context.Context, id int64) (*models.CycleCount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, id)
+	ret0, _ := ret[0].(*models.CycleCount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockCycleCountServiceMockRecorder) Get(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockCycleCountService)(nil).Get), ctx, id)
+}
+
+// Open mocks base method.
+func (m *MockCycleCountService) Open(ctx context.Context, bucketID int64) (*models.CycleCount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Open", ctx, bucketID)
+	ret0, _ := ret[0].(*models.CycleCount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Open indicates an expected call of Open.
+func (mr *MockCycleCountServiceMockRecorder) Open(ctx, bucketID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Open", reflect.TypeOf((*MockCycleCountService)(nil).Open), ctx, bucketID)
+}
+
+// Submit mocks base method.
+func (m *MockCycleCountService) Submit(ctx context.Context, id int64, data dtos.SubmitCycleCountDto) (*models.CycleCount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Submit", ctx, id, data)
+	ret0, _ := ret[0].(*models.CycleCount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Submit indicates an expected call of Submit.
+func (mr *MockCycleCountServiceMockRecorder) Submit(ctx, id, data interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Submit", reflect.TypeOf((*MockCycleCountService)(nil).Submit), ctx, id, data)
+}
+
+// MockStockService is a mock of StockService interface.
+type MockStockService struct {
+	ctrl     *gomock.Controller
+	recorder *MockStockServiceMockRecorder
+}
+
+// MockStockServiceMockRecorder is the mock recorder for MockStockService.
+type MockStockServiceMockRecorder struct {
+	mock *MockStockService
+}
+
+// NewMockStockService creates a new mock instance.
+func NewMockStockService(ctrl *gomock.Controller) *MockStockService {
+	mock := &MockStockService{ctrl: ctrl}
+	mock.recorder = &MockStockServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStockService) EXPECT() *MockStockServiceMockRecorder {
+	return m.recorder
+}
+
+// ListAlerts mocks base method.
+func (m *MockStockService) ListAlerts(ctx context.Context, page, pageSize int) ([]models.StockAlert, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAlerts", ctx, page, pageSize)
+	ret0, _ := ret[0].([]models.StockAlert)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAlerts indicates an expected call of ListAlerts.
+func (mr *MockStockServiceMockRecorder) ListAlerts(ctx, page, pageSize interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAlerts", reflect.TypeOf((*MockStockService)(nil).ListAlerts), ctx, page, pageSize)
+}
+
+// ListReorderPoints mocks base method.
+func (m *MockStockService) ListReorderPoints(ctx context.Context, page, pageSize int) ([]models.ReorderPoint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListReorderPoints", ctx, page, pageSize)
+	ret0, _ := ret[0].([]models.ReorderPoint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListReorderPoints indicates an expected call of ListReorderPoints.
+func (mr *MockStockServiceMockRecorder) ListReorderPoints(ctx, page, pageSize interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListReorderPoints", reflect.TypeOf((*MockStockService)(nil).ListReorderPoints), ctx, page, pageSize)
+}
+
+// Low mocks base method.
+func (m *MockStockService) Low(ctx context.Context) ([]models.Stock, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Low", ctx)
+	ret0, _ := ret[0].([]models.Stock)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Low indicates an expected call of Low.
+func (mr *MockStockServiceMockRecorder) Low(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Low", reflect.TypeOf((*MockStockService)(nil).Low), ctx)
+}
+
+// SetReorderPoint mocks base method.
+func (m *MockStockService) SetReorderPoint(ctx context.Context, data dtos.SetReorderPointDto) (*models.ReorderPoint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetReorderPoint", ctx, data)
+	ret0, _ := ret[0].(*models.ReorderPoint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetReorderPoint indicates an expected call of SetReorderPoint.
+func (mr *MockStockServiceMockRecorder) SetReorderPoint(ctx, data interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetReorderPoint", reflect.TypeOf((*MockStockService)(nil).SetReorderPoint), ctx, data)
+}
+
+// Valuation mocks base method.
+func (m *MockStockService) Valuation(ctx context.Context, data dtos.ValuationDto) ([]models.Valuation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Valuation", ctx, data)
+	ret0, _ := ret[0].([]models.Valuation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Valuation indicates an expected call of Valuation.
+func (mr *MockStockServiceMockRecorder) Valuation(ctx, data interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Valuation", reflect.TypeOf((*MockStockService)(nil).Valuation), ctx, data)
+}
+
+// Waste mocks base method.
+func (m *MockStockService) Waste(ctx context.Context, data dtos.WasteDto) ([]models.Waste, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Waste", ctx, data)
+	ret0, _ := ret[0].([]models.Waste)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Waste indicates an expected call of Waste.
+func (mr *MockStockServiceMockRecorder) Waste(ctx, data interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Waste", reflect.TypeOf((*MockStockService)(nil).Waste), ctx, data)
+}
+
+// MockMovementService is a mock of MovementService interface.
+type MockMovementService struct {
+	ctrl     *gomock.Controller
+	recorder *MockMovementServiceMockRecorder
+}
+
+// MockMovementServiceMockRecorder is the mock recorder for MockMovementService.
+type MockMovementServiceMockRecorder struct {
+	mock *MockMovementService
+}
+
+// NewMockMovementService creates a new mock instance.
+func NewMockMovementService(ctrl *gomock.Controller) *MockMovementService {
+	mock := &MockMovementService{ctrl: ctrl}
+	mock.recorder = &MockMovementServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMovementService) EXPECT() *MockMovementServiceMockRecorder {
+	return m.recorder
+}
+
+// List mocks base method.
+func (m *MockMovementService) List(ctx context.Context, data dtos.ListMovementsDto, page, pageSize int) ([]models.Movement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, data, page, pageSize)
+	ret0, _ := ret[0].([]models.Movement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockMovementServiceMockRecorder) List(ctx, data, page, pageSize interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockMovementService)(nil).List), ctx, data, page, pageSize)
+}
+
+// MockSupplierService is a mock of SupplierService interface.
+type MockSupplierService struct {
+	ctrl     *gomock.Controller
+	recorder *MockSupplierServiceMockRecorder
+}
+
+// MockSupplierServiceMockRecorder is the mock recorder for MockSupplierService.
+type MockSupplierServiceMockRecorder struct {
+	mock *MockSupplierService
+}
+
+// NewMockSupplierService creates a new mock instance.
+func NewMockSupplierService(ctrl *gomock.Controller) *MockSupplierService {
+	mock := &MockSupplierService{ctrl: ctrl}
+	mock.recorder = &MockSupplierServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSupplierService) EXPECT() *MockSupplierServiceMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockSupplierService) Create(ctx context.Context, data dtos.CreateSupplierDto) (*models.Supplier, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, data)
+	ret0, _ := ret[0].(*models.Supplier)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockSupplierServiceMockRecorder) Create(ctx, data interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockSupplierService)(nil).Create), ctx, data)
+}
+
+// Delete mocks base method.
+func (m *MockSupplierService) Delete(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockSupplierServiceMockRecorder) Delete(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockSupplierService)(nil).Delete), ctx, id)
+}
+
+// Get mocks base method.
+func (m *MockSupplierService) Get(ctx context.Context, id int64) (*models.Supplier, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, id)
+	ret0, _ := ret[0].(*models.Supplier)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockSupplierServiceMockRecorder) Get(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockSupplierService)(nil).Get), ctx, id)
+}
+
+// List mocks base method.
+func (m *MockSupplierService) List(ctx context.Context, page, pageSize int) ([]models.Supplier, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, page, pageSize)
+	ret0, _ := ret[0].([]models.Supplier)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockSupplierServiceMockRecorder) List(ctx, page, pageSize interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockSupplierService)(nil).List), ctx, page, pageSize)
+}
+
+// Report mocks base method.
+func (m *MockSupplierService) Report(ctx context.Context, id int64) (*models.SupplierFruits, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Report", ctx, id)
+	ret0, _ := ret[0].(*models.SupplierFruits)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Report indicates an expected call of Report.
+func (mr *MockSupplierServiceMockRecorder) Report(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Report", reflect.TypeOf((*MockSupplierService)(nil).Report), ctx, id)
+}
+
+// Update mocks base method.
+func (m *MockSupplierService) Update(ctx context.Context, id int64, data dtos.UpdateSupplierDto) (*models.Supplier, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, id, data)
+	ret0, _ := ret[0].(*models.Supplier)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockSupplierServiceMockRecorder) Update(ctx, id, data interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockSupplierService)(nil).Update), ctx, id, data)
+}
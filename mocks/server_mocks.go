@@ -69,6 +69,18 @@ func (m *MockBucketController) EXPECT() *MockBucketControllerMockRecorder {
 	return m.recorder
 }
 
+// Consolidate mocks base method.
+func (m *MockBucketController) Consolidate(ctx *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Consolidate", ctx)
+}
+
+// Consolidate indicates an expected call of Consolidate.
+func (mr *MockBucketControllerMockRecorder) Consolidate(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Consolidate", reflect.TypeOf((*MockBucketController)(nil).Consolidate), ctx)
+}
+
 // Create mocks base method.
 func (m *MockBucketController) Create(ctx *gin.Context) {
 	m.ctrl.T.Helper()
@@ -105,6 +117,18 @@ func (mr *MockBucketControllerMockRecorder) List(ctx interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockBucketController)(nil).List), ctx)
 }
 
+// ListViolations mocks base method.
+func (m *MockBucketController) ListViolations(ctx *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "ListViolations", ctx)
+}
+
+// ListViolations indicates an expected call of ListViolations.
+func (mr *MockBucketControllerMockRecorder) ListViolations(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListViolations", reflect.TypeOf((*MockBucketController)(nil).ListViolations), ctx)
+}
+
 // MockFruitController is a mock of FruitController interface.
 type MockFruitController struct {
 	ctrl     *gomock.Controller
@@ -152,6 +176,18 @@ func (mr *MockFruitControllerMockRecorder) Create(ctx interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockFruitController)(nil).Create), ctx)
 }
 
+// CreateBatch mocks base method.
+func (m *MockFruitController) CreateBatch(ctx *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "CreateBatch", ctx)
+}
+
+// CreateBatch indicates an expected call of CreateBatch.
+func (mr *MockFruitControllerMockRecorder) CreateBatch(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBatch", reflect.TypeOf((*MockFruitController)(nil).CreateBatch), ctx)
+}
+
 // Delete mocks base method.
 func (m *MockFruitController) Delete(ctx *gin.Context) {
 	m.ctrl.T.Helper()
@@ -164,6 +200,66 @@ func (mr *MockFruitControllerMockRecorder) Delete(ctx interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockFruitController)(nil).Delete), ctx)
 }
 
+// DeleteMany mocks base method.
+func (m *MockFruitController) DeleteMany(ctx *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "DeleteMany", ctx)
+}
+
+// DeleteMany indicates an expected call of DeleteMany.
+func (mr *MockFruitControllerMockRecorder) DeleteMany(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMany", reflect.TypeOf((*MockFruitController)(nil).DeleteMany), ctx)
+}
+
+// GetByBarcode mocks base method.
+func (m *MockFruitController) GetByBarcode(ctx *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "GetByBarcode", ctx)
+}
+
+// GetByBarcode indicates an expected call of GetByBarcode.
+func (mr *MockFruitControllerMockRecorder) GetByBarcode(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByBarcode", reflect.TypeOf((*MockFruitController)(nil).GetByBarcode), ctx)
+}
+
+// ListExpiring mocks base method.
+func (m *MockFruitController) ListExpiring(ctx *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "ListExpiring", ctx)
+}
+
+// ListExpiring indicates an expected call of ListExpiring.
+func (mr *MockFruitControllerMockRecorder) ListExpiring(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListExpiring", reflect.TypeOf((*MockFruitController)(nil).ListExpiring), ctx)
+}
+
+// MoveMany mocks base method.
+func (m *MockFruitController) MoveMany(ctx *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "MoveMany", ctx)
+}
+
+// MoveMany indicates an expected call of MoveMany.
+func (mr *MockFruitControllerMockRecorder) MoveMany(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveMany", reflect.TypeOf((*MockFruitController)(nil).MoveMany), ctx)
+}
+
+// Recall mocks base method.
+func (m *MockFruitController) Recall(ctx *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Recall", ctx)
+}
+
+// Recall indicates an expected call of Recall.
+func (mr *MockFruitControllerMockRecorder) Recall(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Recall", reflect.TypeOf((*MockFruitController)(nil).Recall), ctx)
+}
+
 // RemoveFromBucket mocks base method.
 func (m *MockFruitController) RemoveFromBucket(ctx *gin.Context) {
 	m.ctrl.T.Helper()
@@ -175,3 +271,831 @@ func (mr *MockFruitControllerMockRecorder) RemoveFromBucket(ctx interface{}) *go
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveFromBucket", reflect.TypeOf((*MockFruitController)(nil).RemoveFromBucket), ctx)
 }
+
+// Transfer mocks base method.
+func (m *MockFruitController) Transfer(ctx *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Transfer", ctx)
+}
+
+// Transfer indicates an expected call of Transfer.
+func (mr *MockFruitControllerMockRecorder) Transfer(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Transfer", reflect.TypeOf((*MockFruitController)(nil).Transfer), ctx)
+}
+
+// UnassignMany mocks base method.
+func (m *MockFruitController) UnassignMany(ctx *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "UnassignMany", ctx)
+}
+
+// UnassignMany indicates an expected call of UnassignMany.
+func (mr *MockFruitControllerMockRecorder) UnassignMany(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnassignMany", reflect.TypeOf((*MockFruitController)(nil).UnassignMany), ctx)
+}
+
+// MockFruitStateController is a mock of FruitStateController interface.
+type MockFruitStateController struct {
+	ctrl     *gomock.Controller
+	recorder *MockFruitStateControllerMockRecorder
+}
+
+// MockFruitStateControllerMockRecorder is the mock recorder for MockFruitStateController.
+type MockFruitStateControllerMockRecorder struct {
+	mock *MockFruitStateController
+}
+
+// NewMockFruitStateController creates a new mock instance.
+func NewMockFruitStateController(ctrl *gomock.Controller) *MockFruitStateController {
+	mock := &MockFruitStateController{ctrl: ctrl}
+	mock.recorder = &MockFruitStateControllerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFruitStateController) EXPECT() *MockFruitStateControllerMockRecorder {
+	return m.recorder
+}
+
+// Change mocks base method.
+func (m *MockFruitStateController) Change(ctx *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Change", ctx)
+}
+
+// Change indicates an expected call of Change.
+func (mr *MockFruitStateControllerMockRecorder) Change(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Change", reflect.TypeOf((*MockFruitStateController)(nil).Change), ctx)
+}
+
+// ListTransitions mocks base method.
+func (m *MockFruitStateController) ListTransitions(ctx *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "ListTransitions", ctx)
+}
+
+// ListTransitions indicates an expected call of ListTransitions.
+func (mr *MockFruitStateControllerMockRecorder) ListTransitions(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTransitions", reflect.TypeOf((*MockFruitStateController)(nil).ListTransitions), ctx)
+}
+
+// MockFruitPriceController is a mock of FruitPriceController interface.
+type MockFruitPriceController struct {
+	ctrl     *gomock.Controller
+	recorder *MockFruitPriceControllerMockRecorder
+}
+
+// MockFruitPriceControllerMockRecorder is the mock recorder for MockFruitPriceController.
+type MockFruitPriceControllerMockRecorder struct {
+	mock *MockFruitPriceController
+}
+
+// NewMockFruitPriceController creates a new mock instance.
+func NewMockFruitPriceController(ctrl *gomock.Controller) *MockFruitPriceController {
+	mock := &MockFruitPriceController{ctrl: ctrl}
+	mock.recorder = &MockFruitPriceControllerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFruitPriceController) EXPECT() *MockFruitPriceControllerMockRecorder {
+	return m.recorder
+}
+
+// Change mocks base method.
+func (m *MockFruitPriceController) Change(ctx *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Change", ctx)
+}
+
+// Change indicates an expected call of Change.
+func (mr *MockFruitPriceControllerMockRecorder) Change(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Change", reflect.TypeOf((*MockFruitPriceController)(nil).Change), ctx)
+}
+
+// List mocks base method.
+func (m *MockFruitPriceController) List(ctx *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "List", ctx)
+}
+
+// List indicates an expected call of List.
+func (mr *MockFruitPriceControllerMockRecorder) List(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockFruitPriceController)(nil).List), ctx)
+}
+
+// Stats mocks base method.
+func (m *MockFruitPriceController) Stats(ctx *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Stats", ctx)
+}
+
+// Stats indicates an expected call of Stats.
+func (mr *MockFruitPriceControllerMockRecorder) Stats(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stats", reflect.TypeOf((*MockFruitPriceController)(nil).Stats), ctx)
+}
+
+// MockPhotoController is a mock of PhotoController interface.
+type MockPhotoController struct {
+	ctrl     *gomock.Controller
+	recorder *MockPhotoControllerMockRecorder
+}
+
+// MockPhotoControllerMockRecorder is the mock recorder for MockPhotoController.
+type MockPhotoControllerMockRecorder struct {
+	mock *MockPhotoController
+}
+
+// NewMockPhotoController creates a new mock instance.
+func NewMockPhotoController(ctrl *gomock.Controller) *MockPhotoController {
+	mock := &MockPhotoController{ctrl: ctrl}
+	mock.recorder = &MockPhotoControllerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPhotoController) EXPECT() *MockPhotoControllerMockRecorder {
+	return m.recorder
+}
+
+// Download mocks base method.
+func (m *MockPhotoController) Download(ctx *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Download", ctx)
+}
+
+// Download indicates an expected call of Download.
+func (mr *MockPhotoControllerMockRecorder) Download(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Download", reflect.TypeOf((*MockPhotoController)(nil).Download), ctx)
+}
+
+// DownloadThumbnail mocks base method.
+func (m *MockPhotoController) DownloadThumbnail(ctx *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "DownloadThumbnail", ctx)
+}
+
+// DownloadThumbnail indicates an expected call of DownloadThumbnail.
+func (mr *MockPhotoControllerMockRecorder) DownloadThumbnail(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DownloadThumbnail", reflect.TypeOf((*MockPhotoController)(nil).DownloadThumbnail), ctx)
+}
+
+// ListBucketPhotos mocks base method.
+func (m *MockPhotoController) ListBucketPhotos(ctx *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "ListBucketPhotos", ctx)
+}
+
+// ListBucketPhotos indicates an expected call of ListBucketPhotos.
+func (mr *MockPhotoControllerMockRecorder) ListBucketPhotos(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListBucketPhotos", reflect.TypeOf((*MockPhotoController)(nil).ListBucketPhotos), ctx)
+}
+
+// ListFruitPhotos mocks base method.
+func (m *MockPhotoController) ListFruitPhotos(ctx *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "ListFruitPhotos", ctx)
+}
+
+// ListFruitPhotos indicates an expected call of ListFruitPhotos.
+func (mr *MockPhotoControllerMockRecorder) ListFruitPhotos(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFruitPhotos", reflect.TypeOf((*MockPhotoController)(nil).ListFruitPhotos), ctx)
+}
+
+// UploadBucketPhoto mocks base method.
+func (m *MockPhotoController) UploadBucketPhoto(ctx *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "UploadBucketPhoto", ctx)
+}
+
+// UploadBucketPhoto indicates an expected call of UploadBucketPhoto.
+func (mr *MockPhotoControllerMockRecorder) UploadBucketPhoto(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UploadBucketPhoto", reflect.TypeOf((*MockPhotoController)(nil).UploadBucketPhoto), ctx)
+}
+
+// UploadFruitPhoto mocks base method.
+func (m *MockPhotoController) UploadFruitPhoto(ctx *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "UploadFruitPhoto", ctx)
+}
+
+// UploadFruitPhoto indicates an expected call of UploadFruitPhoto.
+func (mr *MockPhotoControllerMockRecorder) UploadFruitPhoto(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UploadFruitPhoto", reflect.TypeOf((*MockPhotoController)(nil).UploadFruitPhoto), ctx)
+}
+
+// MockNoteController is a mock of NoteController interface.
+type MockNoteController struct {
+	ctrl     *gomock.Controller
+	recorder *MockNoteControllerMockRecorder
+}
+
+// MockNoteControllerMockRecorder is the mock recorder for MockNoteController.
+type MockNoteControllerMockRecorder struct {
+	mock *MockNoteController
+}
+
+// NewMockNoteController creates a new mock instance.
+func NewMockNoteController(ctrl *gomock.Controller) *MockNoteController {
+	mock := &MockNoteController{ctrl: ctrl}
+	mock.recorder = &MockNoteControllerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockNoteController) EXPECT() *MockNoteControllerMockRecorder {
+	return m.recorder
+}
+
+// CreateBucketNote mocks base method.
+func (m *MockNoteController) CreateBucketNote(ctx *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "CreateBucketNote", ctx)
+}
+
+// CreateBucketNote indicates an expected call of CreateBucketNote.
+func (mr *MockNoteControllerMockRecorder) CreateBucketNote(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBucketNote", reflect.TypeOf((*MockNoteController)(nil).CreateBucketNote), ctx)
+}
+
+// CreateFruitNote mocks base method.
+func (m *MockNoteController) CreateFruitNote(ctx *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "CreateFruitNote", ctx)
+}
+
+// CreateFruitNote indicates an expected call of CreateFruitNote.
+func (mr *MockNoteControllerMockRecorder) CreateFruitNote(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateFruitNote", reflect.TypeOf((*MockNoteController)(nil).CreateFruitNote), ctx)
+}
+
+// ListBucketNotes mocks base method.
+func (m *MockNoteController) ListBucketNotes(ctx *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "ListBucketNotes", ctx)
+}
+
+// ListBucketNotes indicates an expected call of ListBucketNotes.
+func (mr *MockNoteControllerMockRecorder) ListBucketNotes(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListBucketNotes", reflect.TypeOf((*MockNoteController)(nil).ListBucketNotes), ctx)
+}
+
+// ListFruitNotes mocks base method.
+func (m *MockNoteController) ListFruitNotes(ctx *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "ListFruitNotes", ctx)
+}
+
+// ListFruitNotes indicates an expected call of ListFruitNotes.
+func (mr *MockNoteControllerMockRecorder) ListFruitNotes(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFruitNotes", reflect.TypeOf((*MockNoteController)(nil).ListFruitNotes), ctx)
+}
+
+// MockPickController is a mock of PickController interface.
+type MockPickController struct {
+	ctrl     *gomock.Controller
+	recorder *MockPickControllerMockRecorder
+}
+
+// MockPickControllerMockRecorder is the mock recorder for MockPickController.
+type MockPickControllerMockRecorder struct {
+	mock *MockPickController
+}
+
+// NewMockPickController creates a new mock instance.
+func NewMockPickController(ctrl *gomock.Controller) *MockPickController {
+	mock := &MockPickController{ctrl: ctrl}
+	mock.recorder = &MockPickControllerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPickController) EXPECT() *MockPickControllerMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockPickController) Create(ctx *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Create", ctx)
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockPickControllerMockRecorder) Create(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockPickController)(nil).Create), ctx)
+}
+
+// MockOrderController is a mock of OrderController interface.
+type MockOrderController struct {
+	ctrl     *gomock.Controller
+	recorder *MockOrderControllerMockRecorder
+}
+
+// MockOrderControllerMockRecorder is the mock recorder for MockOrderController.
+type MockOrderControllerMockRecorder struct {
+	mock *MockOrderController
+}
+
+// NewMockOrderController creates a new mock instance.
+func NewMockOrderController(ctrl *gomock.Controller) *MockOrderController {
+	mock := &MockOrderController{ctrl: ctrl}
+	mock.recorder = &MockOrderControllerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOrderController) EXPECT() *MockOrderControllerMockRecorder {
+	return m.recorder
+}
+
+// Checkout mocks base method.
+func (m *MockOrderController) Checkout(ctx *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Checkout", ctx)
+}
+
+// Checkout indicates an expected call of Checkout.
+func (mr *MockOrderControllerMockRecorder) Checkout(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Checkout", reflect.TypeOf((*MockOrderController)(nil).Checkout), ctx)
+}
+
+// Create mocks base method.
+func (m *MockOrderController) Create(ctx *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Create", ctx)
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockOrderControllerMockRecorder) Create(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockOrderController)(nil).Create), ctx)
+}
+
+// Get mocks base method.
+func (m *MockOrderController) Get(ctx *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Get", ctx)
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockOrderControllerMockRecorder) Get(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockOrderController)(nil).Get), ctx)
+}
+
+// Sales mocks base method.
+func (m *MockOrderController) Sales(ctx *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Sales", ctx)
+}
+
+// Sales indicates an expected call of Sales.
+func (mr *MockOrderControllerMockRecorder) Sales(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Sales", reflect.TypeOf((*MockOrderController)(nil).Sales), ctx)
+}
+
+// MockReservationController is a mock of ReservationController interface.
+type MockReservationController struct {
+	ctrl     *gomock.Controller
+	recorder *MockReservationControllerMockRecorder
+}
+
+// MockReservationControllerMockRecorder is the mock recorder for MockReservationController.
+type MockReservationControllerMockRecorder struct {
+	mock *MockReservationController
+}
+
+// NewMockReservationController creates a new mock instance.
+func NewMockReservationController(ctrl *gomock.Controller) *MockReservationController {
+	mock := &MockReservationController{ctrl: ctrl}
+	mock.recorder = &MockReservationControllerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReservationController) EXPECT() *MockReservationControllerMockRecorder {
+	return m.recorder
+}
+
+// Confirm mocks base method.
+func (m *MockReservationController) Confirm(ctx *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Confirm", ctx)
+}
+
+// Confirm indicates an expected call of Confirm.
+func (mr *MockReservationControllerMockRecorder) Confirm(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Confirm", reflect.TypeOf((*MockReservationController)(nil).Confirm), ctx)
+}
+
+// Create mocks base method.
+func (m *MockReservationController) Create(ctx *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Create", ctx)
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockReservationControllerMockRecorder) Create(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockReservationController)(nil).Create), ctx)
+}
+
+// Get mocks base method.
+func (m *MockReservationController) Get(ctx *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Get", ctx)
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockReservationControllerMockRecorder) Get(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockReservationController)(nil).Get), ctx)
+}
+
+// Release mocks base method.
+func (m *MockReservationController) Release(ctx *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Release", ctx)
+}
+
+// Release indicates an expected call of Release.
+func (mr *MockReservationControllerMockRecorder) Release(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Release", reflect.TypeOf((*MockReservationController)(nil).Release), ctx)
+}
+
+// MockPurchaseOrderController is a mock of PurchaseOrderController interface.
+type MockPurchaseOrderController struct {
+	ctrl     *gomock.Controller
+	recorder *MockPurchaseOrderControllerMockRecorder
+}
+
+// MockPurchaseOrderControllerMockRecorder is the mock recorder for MockPurchaseOrderController.
+type MockPurchaseOrderControllerMockRecorder struct {
+	mock *MockPurchaseOrderController
+}
+
+// NewMockPurchaseOrderController creates a new mock instance.
+func NewMockPurchaseOrderController(ctrl *gomock.Controller) *MockPurchaseOrderController {
+	mock := &MockPurchaseOrderController{ctrl: ctrl}
+	mock.recorder = &MockPurchaseOrderControllerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPurchaseOrderController) EXPECT() *MockPurchaseOrderControllerMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockPurchaseOrderController) Create(ctx *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Create", ctx)
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockPurchaseOrderControllerMockRecorder) Create(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockPurchaseOrderController)(nil).Create), ctx)
+}
+
+// Get mocks base method.
+func (m *MockPurchaseOrderController) Get(ctx *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Get", ctx)
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockPurchaseOrderControllerMockRecorder) Get(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockPurchaseOrderController)(nil).Get), ctx)
+}
+
+// Receive mocks base method.
+func (m *MockPurchaseOrderController) Receive(ctx *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Receive", ctx)
+}
+
+// Receive indicates an expected call of Receive.
+func (mr *MockPurchaseOrderControllerMockRecorder) Receive(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Receive", reflect.TypeOf((*MockPurchaseOrderController)(nil).Receive), ctx)
+}
+
+// MockCycleCountController is a mock of CycleCountController interface.
+type MockCycleCountController struct {
+	ctrl     *gomock.Controller
+	recorder *MockCycleCountControllerMockRecorder
+}
+
+// MockCycleCountControllerMockRecorder is the mock recorder for MockCycleCountController.
+type MockCycleCountControllerMockRecorder struct {
+	mock *MockCycleCountController
+}
+
+// NewMockCycleCountController creates a new mock instance.
+func NewMockCycleCountController(ctrl *gomock.Controller) *MockCycleCountController {
+	mock := &MockCycleCountController{ctrl: ctrl}
+	mock.recorder = &MockCycleCountControllerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCycleCountController) EXPECT() *MockCycleCountControllerMockRecorder {
+	return m.recorder
+}
+
+// Approve mocks base method.
+func (m *MockCycleCountController) Approve(ctx *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Approve", ctx)
+}
+
+// Approve indicates an expected call of Approve.
+func (mr *MockCycleCountControllerMockRecorder) Approve(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Approve", reflect.TypeOf((*MockCycleCountController)(nil).Approve), ctx)
+}
+
+// Get mocks base method.
+func (m *MockCycleCountController) Get(ctx *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Get", ctx)
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockCycleCountControllerMockRecorder) Get(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockCycleCountController)(nil).Get), ctx)
+}
+
+// Open mocks base method.
+func (m *MockCycleCountController) Open(ctx *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Open", ctx)
+}
+
+// Open indicates an expected call of Open.
+func (mr *MockCycleCountControllerMockRecorder) Open(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Open", reflect.TypeOf((*MockCycleCountController)(nil).Open), ctx)
+}
+
+// Submit mocks base method.
+func (m *MockCycleCountController) Submit(ctx *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Submit", ctx)
+}
+
+// Submit indicates an expected call of Submit.
+func (mr *MockCycleCountControllerMockRecorder) Submit(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Submit", reflect.TypeOf((*MockCycleCountController)(nil).Submit), ctx)
+}
+
+// MockStockController is a mock of StockController interface.
+type MockStockController struct {
+	ctrl     *gomock.Controller
+	recorder *MockStockControllerMockRecorder
+}
+
+// MockStockControllerMockRecorder is the mock recorder for MockStockController.
+type MockStockControllerMockRecorder struct {
+	mock *MockStockController
+}
+
+// NewMockStockController creates a new mock instance.
+func NewMockStockController(ctrl *gomock.Controller) *MockStockController {
+	mock := &MockStockController{ctrl: ctrl}
+	mock.recorder = &MockStockControllerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStockController) EXPECT() *MockStockControllerMockRecorder {
+	return m.recorder
+}
+
+// ListAlerts mocks base method.
+func (m *MockStockController) ListAlerts(ctx *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "ListAlerts", ctx)
+}
+
+// ListAlerts indicates an expected call of ListAlerts.
+func (mr *MockStockControllerMockRecorder) ListAlerts(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAlerts", reflect.TypeOf((*MockStockController)(nil).ListAlerts), ctx)
+}
+
+// ListReorderPoints mocks base method.
+func (m *MockStockController) ListReorderPoints(ctx *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "ListReorderPoints", ctx)
+}
+
+// ListReorderPoints indicates an expected call of ListReorderPoints.
+func (mr *MockStockControllerMockRecorder) ListReorderPoints(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListReorderPoints", reflect.TypeOf((*MockStockController)(nil).ListReorderPoints), ctx)
+}
+
+// Low mocks base method.
+func (m *MockStockController) Low(ctx *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Low", ctx)
+}
+
+// Low indicates an expected call of Low.
+func (mr *MockStockControllerMockRecorder) Low(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Low", reflect.TypeOf((*MockStockController)(nil).Low), ctx)
+}
+
+// SetReorderPoint mocks base method.
+func (m *MockStockController) SetReorderPoint(ctx *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetReorderPoint", ctx)
+}
+
+// SetReorderPoint indicates an expected call of SetReorderPoint.
+func (mr *MockStockControllerMockRecorder) SetReorderPoint(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetReorderPoint", reflect.TypeOf((*MockStockController)(nil).SetReorderPoint), ctx)
+}
+
+// Valuation mocks base method.
+func (m *MockStockController) Valuation(ctx *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Valuation", ctx)
+}
+
+// Valuation indicates an expected call of Valuation.
+func (mr *MockStockControllerMockRecorder) Valuation(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Valuation", reflect.TypeOf((*MockStockController)(nil).Valuation), ctx)
+}
+
+// Waste mocks base method.
+func (m *MockStockController) Waste(ctx *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Waste", ctx)
+}
+
+// Waste indicates an expected call of Waste.
+func (mr *MockStockControllerMockRecorder) Waste(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Waste", reflect.TypeOf((*MockStockController)(nil).Waste), ctx)
+}
+
+// MockMovementController is a mock of MovementController interface.
+type MockMovementController struct {
+	ctrl     *gomock.Controller
+	recorder *MockMovementControllerMockRecorder
+}
+
+// MockMovementControllerMockRecorder is the mock recorder for MockMovementController.
+type MockMovementControllerMockRecorder struct {
+	mock *MockMovementController
+}
+
+// NewMockMovementController creates a new mock instance.
+func NewMockMovementController(ctrl *gomock.Controller) *MockMovementController {
+	mock := &MockMovementController{ctrl: ctrl}
+	mock.recorder = &MockMovementControllerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMovementController) EXPECT() *MockMovementControllerMockRecorder {
+	return m.recorder
+}
+
+// List mocks base method.
+func (m *MockMovementController) List(ctx *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "List", ctx)
+}
+
+// List indicates an expected call of List.
+func (mr *MockMovementControllerMockRecorder) List(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockMovementController)(nil).List), ctx)
+}
+
+// MockSupplierController is a mock of SupplierController interface.
+type MockSupplierController struct {
+	ctrl     *gomock.Controller
+	recorder *MockSupplierControllerMockRecorder
+}
+
+// MockSupplierControllerMockRecorder is the mock recorder for MockSupplierController.
+type MockSupplierControllerMockRecorder struct {
+	mock *MockSupplierController
+}
+
+// NewMockSupplierController creates a new mock instance.
+func NewMockSupplierController(ctrl *gomock.Controller) *MockSupplierController {
+	mock := &MockSupplierController{ctrl: ctrl}
+	mock.recorder = &MockSupplierControllerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSupplierController) EXPECT() *MockSupplierControllerMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockSupplierController) Create(ctx *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Create", ctx)
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockSupplierControllerMockRecorder) Create(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockSupplierController)(nil).Create), ctx)
+}
+
+// Delete mocks base method.
+func (m *MockSupplierController) Delete(ctx *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Delete", ctx)
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockSupplierControllerMockRecorder) Delete(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockSupplierController)(nil).Delete), ctx)
+}
+
+// Get mocks base method.
+func (m *MockSupplierController) Get(ctx *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Get", ctx)
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockSupplierControllerMockRecorder) Get(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockSupplierController)(nil).Get), ctx)
+}
+
+// List mocks base method.
+func (m *MockSupplierController) List(ctx *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "List", ctx)
+}
+
+// List indicates an expected call of List.
+func (mr *MockSupplierControllerMockRecorder) List(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockSupplierController)(nil).List), ctx)
+}
+
+// Report mocks base method.
+func (m *MockSupplierController) Report(ctx *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Report", ctx)
+}
+
+// Report indicates an expected call of Report.
+func (mr *MockSupplierControllerMockRecorder) Report(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Report", reflect.TypeOf((*MockSupplierController)(nil).Report), ctx)
+}
+
+// Update mocks base method.
+func (m *MockSupplierController) Update(ctx *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Update", ctx)
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockSupplierControllerMockRecorder) Update(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockSupplierController)(nil).Update), ctx)
+}
@@ -0,0 +1,184 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./interfaces.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	models "github.com/viniosilva/where-are-my-fruits/internal/models"
+)
+
+// MockWorkerLogger is a mock of Logger interface.
+type MockWorkerLogger struct {
+	ctrl     *gomock.Controller
+	recorder *MockWorkerLoggerMockRecorder
+}
+
+// MockWorkerLoggerMockRecorder is the mock recorder for MockWorkerLogger.
+type MockWorkerLoggerMockRecorder struct {
+	mock *MockWorkerLogger
+}
+
+// NewMockWorkerLogger creates a new mock instance.
+func NewMockWorkerLogger(ctrl *gomock.Controller) *MockWorkerLogger {
+	mock := &MockWorkerLogger{ctrl: ctrl}
+	mock.recorder = &MockWorkerLoggerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWorkerLogger) EXPECT() *MockWorkerLoggerMockRecorder {
+	return m.recorder
+}
+
+// Errorw mocks base method.
+func (m *MockWorkerLogger) Errorw(msg string, keysAndValues ...interface{}) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{msg}
+	for _, a := range keysAndValues {
+		varargs = append(varargs, a)
+	}
+	m.ctrl.Call(m, "Errorw", varargs...)
+}
+
+// Errorw indicates an expected call of Errorw.
+func (mr *MockWorkerLoggerMockRecorder) Errorw(msg interface{}, keysAndValues ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{msg}, keysAndValues...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Errorw", reflect.TypeOf((*MockWorkerLogger)(nil).Errorw), varargs...)
+}
+
+// Infow mocks base method.
+func (m *MockWorkerLogger) Infow(msg string, keysAndValues ...interface{}) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{msg}
+	for _, a := range keysAndValues {
+		varargs = append(varargs, a)
+	}
+	m.ctrl.Call(m, "Infow", varargs...)
+}
+
+// Infow indicates an expected call of Infow.
+func (mr *MockWorkerLoggerMockRecorder) Infow(msg interface{}, keysAndValues ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{msg}, keysAndValues...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Infow", reflect.TypeOf((*MockWorkerLogger)(nil).Infow), varargs...)
+}
+
+// MockRipenessService is a mock of RipenessService interface.
+type MockRipenessService struct {
+	ctrl     *gomock.Controller
+	recorder *MockRipenessServiceMockRecorder
+}
+
+// MockRipenessServiceMockRecorder is the mock recorder for MockRipenessService.
+type MockRipenessServiceMockRecorder struct {
+	mock *MockRipenessService
+}
+
+// NewMockRipenessService creates a new mock instance.
+func NewMockRipenessService(ctrl *gomock.Controller) *MockRipenessService {
+	mock := &MockRipenessService{ctrl: ctrl}
+	mock.recorder = &MockRipenessServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRipenessService) EXPECT() *MockRipenessServiceMockRecorder {
+	return m.recorder
+}
+
+// Progress mocks base method.
+func (m *MockRipenessService) Progress(ctx context.Context) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Progress", ctx)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Progress indicates an expected call of Progress.
+func (mr *MockRipenessServiceMockRecorder) Progress(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Progress", reflect.TypeOf((*MockRipenessService)(nil).Progress), ctx)
+}
+
+// MockSweeperService is a mock of SweeperService interface.
+type MockSweeperService struct {
+	ctrl     *gomock.Controller
+	recorder *MockSweeperServiceMockRecorder
+}
+
+// MockSweeperServiceMockRecorder is the mock recorder for MockSweeperService.
+type MockSweeperServiceMockRecorder struct {
+	mock *MockSweeperService
+}
+
+// NewMockSweeperService creates a new mock instance.
+func NewMockSweeperService(ctrl *gomock.Controller) *MockSweeperService {
+	mock := &MockSweeperService{ctrl: ctrl}
+	mock.recorder = &MockSweeperServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSweeperService) EXPECT() *MockSweeperServiceMockRecorder {
+	return m.recorder
+}
+
+// Sweep mocks base method.
+func (m *MockSweeperService) Sweep(ctx context.Context, unassign, dispose bool) (*models.FruitSweep, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Sweep", ctx, unassign, dispose)
+	ret0, _ := ret[0].(*models.FruitSweep)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Sweep indicates an expected call of Sweep.
+func (mr *MockSweeperServiceMockRecorder) Sweep(ctx, unassign, dispose interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Sweep", reflect.TypeOf((*MockSweeperService)(nil).Sweep), ctx, unassign, dispose)
+}
+
+// MockReleaserService is a mock of ReleaserService interface.
+type MockReleaserService struct {
+	ctrl     *gomock.Controller
+	recorder *MockReleaserServiceMockRecorder
+}
+
+// MockReleaserServiceMockRecorder is the mock recorder for MockReleaserService.
+type MockReleaserServiceMockRecorder struct {
+	mock *MockReleaserService
+}
+
+// NewMockReleaserService creates a new mock instance.
+func NewMockReleaserService(ctrl *gomock.Controller) *MockReleaserService {
+	mock := &MockReleaserService{ctrl: ctrl}
+	mock.recorder = &MockReleaserServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReleaserService) EXPECT() *MockReleaserServiceMockRecorder {
+	return m.recorder
+}
+
+// ReleaseLapsed mocks base method.
+func (m *MockReleaserService) ReleaseLapsed(ctx context.Context) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReleaseLapsed", ctx)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReleaseLapsed indicates an expected call of ReleaseLapsed.
+func (mr *MockReleaserServiceMockRecorder) ReleaseLapsed(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseLapsed", reflect.TypeOf((*MockReleaserService)(nil).ReleaseLapsed), ctx)
+}
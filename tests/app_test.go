@@ -34,16 +34,32 @@ func TestApp(t *testing.T) {
 
 		validate := infra.NewValidator()
 
-		factory, err := factories.Build(db, logger, validate)
+		factory, err := factories.Build(db, logger, validate, config)
 		require.Nil(t, err)
 
 		defer func() {
+			db.SQL.Exec("DELETE FROM movements")
+			db.SQL.Exec("DELETE FROM cycle_count_adjustments")
+			db.SQL.Exec("DELETE FROM cycle_count_items")
+			db.SQL.Exec("DELETE FROM order_items")
+			db.SQL.Exec("DELETE FROM notes")
+			db.SQL.Exec("DELETE FROM photos")
+			db.SQL.Exec("DELETE FROM fruit_prices")
+			db.SQL.Exec("DELETE FROM fruit_state_transitions")
 			db.SQL.Exec("DELETE FROM fruits")
+			db.SQL.Exec("DELETE FROM purchase_order_lines")
+			db.SQL.Exec("DELETE FROM purchase_orders")
+			db.SQL.Exec("DELETE FROM reservations")
+			db.SQL.Exec("DELETE FROM orders")
+			db.SQL.Exec("DELETE FROM cycle_counts")
 			db.SQL.Exec("DELETE FROM buckets")
+			db.SQL.Exec("DELETE FROM stock_alerts")
+			db.SQL.Exec("DELETE FROM reorder_points")
 		}()
 
 		// given
-		r := api.ConfigGin(config.Api.Host, config.Api.Port, logger, factory.HealthController, factory.BucketController, factory.FruitController)
+		r := api.ConfigGin(config.Api.Host, config.Api.Port, logger, factory.HealthController, factory.BucketController, factory.FruitController,
+			factory.FruitStateController, factory.FruitPriceController, factory.PhotoController, factory.NoteController, factory.PickController, factory.OrderController, factory.ReservationController, factory.PurchaseOrderController, factory.CycleCountController, factory.StockController, factory.MovementController, factory.SupplierController)
 
 		// cases
 		getHealth(t, r)
@@ -67,6 +83,9 @@ func TestApp(t *testing.T) {
 			&presenters.FruitRes{
 				Name:  "Apple",
 				Price: decimal.NewFromFloat32(1.99),
+				State: "unripe",
+
+				EffectivePrice: decimal.NewFromInt32(1),
 			})
 
 		// case: add apple to the bucket
@@ -76,13 +95,16 @@ func TestApp(t *testing.T) {
 		createFruit(t, r, presenters.CreateFruitReq{
 			Name:      "Melon",
 			Price:     decimal.NewFromFloat32(3.50),
-			BucketID:  &bucket.ID,
+			BucketID:  presenters.BucketIDReq{ID: &bucket.ID},
 			ExpiresIn: "1s",
 		}, http.StatusCreated,
 			&presenters.FruitRes{
 				Name:     "Melon",
 				Price:    decimal.NewFromFloat32(3.50),
+				State:    "unripe",
 				BucketID: &bucket.ID,
+
+				EffectivePrice: decimal.NewFromFloat32(1.75),
 			})
 
 		// case: list buckets with all fruits
@@ -95,6 +117,9 @@ func TestApp(t *testing.T) {
 					TotalFruits: 2,
 					TotalPrice:  decimal.NewFromFloat32(5.49),
 					Percent:     "100.00%",
+					States:      presenters.BucketFruitsStatesRes{Unripe: 2},
+
+					TotalEffectivePrice: decimal.NewFromFloat32(2.75),
 				},
 			},
 		})
@@ -112,6 +137,9 @@ func TestApp(t *testing.T) {
 					TotalFruits: 1,
 					TotalPrice:  decimal.NewFromFloat32(1.99),
 					Percent:     "50.00%",
+					States:      presenters.BucketFruitsStatesRes{Unripe: 1},
+
+					TotalEffectivePrice: decimal.NewFromInt32(1),
 				},
 			},
 		})
@@ -123,19 +151,22 @@ func TestApp(t *testing.T) {
 		createFruit(t, r, presenters.CreateFruitReq{
 			Name:      "Abacato",
 			Price:     decimal.NewFromFloat32(7.50),
-			BucketID:  &bucket.ID,
+			BucketID:  presenters.BucketIDReq{ID: &bucket.ID},
 			ExpiresIn: "1s",
 		}, http.StatusCreated, &presenters.FruitRes{
 			Name:     "Abacato",
 			Price:    decimal.NewFromFloat32(7.50),
+			State:    "unripe",
 			BucketID: &bucket.ID,
+
+			EffectivePrice: decimal.NewFromFloat32(3.75),
 		})
 
 		// case: try add another abacato to bucket, but fail for it be full
 		createFruit(t, r, presenters.CreateFruitReq{
 			Name:      "Abacato",
 			Price:     decimal.NewFromFloat32(7.50),
-			BucketID:  &bucket.ID,
+			BucketID:  presenters.BucketIDReq{ID: &bucket.ID},
 			ExpiresIn: "1s",
 		}, http.StatusBadRequest, nil)
 
@@ -155,6 +186,8 @@ func TestApp(t *testing.T) {
 					TotalFruits: 0,
 					TotalPrice:  decimal.NewFromInt32(0),
 					Percent:     "0.00%",
+
+					TotalEffectivePrice: decimal.NewFromInt32(0),
 				},
 			},
 		})